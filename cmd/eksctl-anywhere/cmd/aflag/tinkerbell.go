@@ -7,6 +7,13 @@ var TinkerbellBootstrapIP = Flag[string]{
 	Usage: "The IP used to expose the Tinkerbell stack from the bootstrap cluster",
 }
 
+// TinkerbellValidateBMCConnectivity enables a pre-flight check that opens a Redfish session with the BMC
+// of each hardware in the CSV.
+var TinkerbellValidateBMCConnectivity = Flag[bool]{
+	Name:  "validate-bmc-connectivity",
+	Usage: "Validate the BMC credentials of each hardware in the hardware CSV by opening a Redfish session before creating the cluster",
+}
+
 // TinkerbellBMCConsumerURL is a Rufio RPC provider option.
 // ConsumerURL is the URL where an rpc consumer/listener is running and to which we will send and receive all notifications.
 var TinkerbellBMCConsumerURL = Flag[string]{
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+var analyzeSupportBundleCmd = &cobra.Command{
+	Use:          "support-bundle <archive.tar.gz>",
+	Short:        "Analyze a support bundle archive",
+	Long:         "This command runs the EKS Anywhere analyzers against an existing support bundle archive, without access to the cluster",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return analyzeSupportBundle(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	analyzeCmd.AddCommand(analyzeSupportBundleCmd)
+}
+
+func analyzeSupportBundle(ctx context.Context, archive string) error {
+	if !validations.FileExists(archive) {
+		return fmt.Errorf("support bundle archive %s does not exist", archive)
+	}
+
+	archive, err := filepath.Abs(archive)
+	if err != nil {
+		return fmt.Errorf("getting absolute path for support bundle archive: %v", err)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(archive)).
+		WithWriter().
+		WithTroubleshoot().
+		WithAnalyzerFactory().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	analyzer := diagnostics.NewArchiveAnalyzer(deps.AnalyzerFactory, deps.Troubleshoot, deps.Writer)
+	analysis, err := analyzer.Analyze(ctx, archive)
+	if err != nil {
+		return fmt.Errorf("analyzing support bundle: %v", err)
+	}
+
+	return diagnostics.PrintAnalysis(analysis)
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+type applyHardwareOptions struct {
+	fileName string
+	// kubeConfig is an optional kubeconfig file for the management cluster.
+	kubeConfig string
+	dryRun     bool
+	prune      bool
+	bmcOptions *hardware.BMCOptions
+}
+
+var aho = &applyHardwareOptions{
+	bmcOptions: &hardware.BMCOptions{
+		RPC: &hardware.RPCOpts{},
+	},
+}
+
+var applyHardwareCmd = &cobra.Command{
+	Use:          "hardware",
+	Short:        "Apply Tinkerbell hardware",
+	Long:         "Reconcile the Tinkerbell Hardware, BMC Machines and Secrets in a management cluster with a hardware CSV file",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return aho.applyHardware(cmd.Context())
+	},
+}
+
+func init() {
+	applyCmd.AddCommand(applyHardwareCmd)
+
+	fset := applyHardwareCmd.Flags()
+	fset.StringVarP(&aho.fileName, "filename", "f", "", "Path to a CSV file containing hardware data.")
+	fset.StringVar(&aho.kubeConfig, "kubeconfig", "", "Path to an optional kubeconfig file to use.")
+	fset.BoolVar(&aho.dryRun, "dry-run", false, "Print the changes without applying them.")
+	fset.BoolVar(&aho.prune, "prune", false, "Remove the hardware, BMC machines and secrets that are not in the CSV file. Hardware in use by a cluster is never removed.")
+	tinkerbellFlags(fset, aho.bmcOptions.RPC)
+
+	if err := applyHardwareCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (aho *applyHardwareOptions) applyHardware(ctx context.Context) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(aho.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	catalogue, err := hardware.BuildCatalogueFromCSV(aho.fileName, aho.bmcOptions)
+	if err != nil {
+		return err
+	}
+
+	client, err := kubernetes.NewRuntimeClientFromFileName(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	var syncerOpts []hardware.SyncerOpt
+	if aho.prune {
+		syncerOpts = append(syncerOpts, hardware.WithPrune())
+	}
+	syncer := hardware.NewSyncer(client, syncerOpts...)
+	plan, err := syncer.Plan(ctx, catalogue)
+	if err != nil {
+		return fmt.Errorf("computing hardware changes: %v", err)
+	}
+
+	table, err := hardwareChangesToText(plan)
+	if err != nil {
+		return err
+	}
+	fmt.Print(table)
+
+	if plan.Empty() || aho.dryRun {
+		return nil
+	}
+
+	if err := syncer.Apply(ctx, plan); err != nil {
+		return err
+	}
+	logger.MarkSuccess("Hardware applied")
+
+	return nil
+}
+
+func hardwareChangesToText(plan *hardware.SyncPlan) (string, error) {
+	if plan.Empty() && len(plan.Refused) == 0 {
+		return "Hardware is up to date\n", nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tKIND\tNAME\tNOTE")
+	for _, c := range plan.Changes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", c.Type, c.Kind, c.Name)
+	}
+	for _, c := range plan.Refused {
+		fmt.Fprintf(w, "%s\t%s\t%s\trefused, %s\n", c.Type, c.Kind, c.Name, c.Reason)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type backupEtcdOptions struct {
+	configFile  string
+	destination string
+	s3Endpoint  string
+	s3Region    string
+}
+
+var beo = &backupEtcdOptions{}
+
+var backupEtcdCmd = &cobra.Command{
+	Use:          "etcd -f <config-file> [flags]",
+	Short:        "Backup etcd",
+	Long:         "Take a snapshot of the etcd cluster of a cluster over SSH and save it in a local folder or an S3-compatible bucket",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return beo.backupEtcd(cmd.Context())
+	},
+}
+
+func init() {
+	backupCmd.AddCommand(backupEtcdCmd)
+	backupEtcdCmd.Flags().StringVarP(&beo.configFile, "filename", "f", "", "Path to the node and SSH configuration file, same format as the renew certificates one")
+	backupEtcdCmd.Flags().StringVarP(&beo.destination, "destination", "d", ".", "Folder or s3://bucket/prefix to save the snapshot to")
+	backupEtcdCmd.Flags().StringVar(&beo.s3Endpoint, "s3-endpoint", "", "URL of an S3-compatible endpoint, when not using AWS S3")
+	backupEtcdCmd.Flags().StringVar(&beo.s3Region, "s3-region", "", "Region of the S3 bucket")
+
+	if err := backupEtcdCmd.MarkFlagRequired("filename"); err != nil {
+		logger.Fatal(err, "marking filename as required")
+	}
+}
+
+func (beo *backupEtcdOptions) backupEtcd(ctx context.Context) error {
+	cfg, _, err := readEtcdNodesConfig(ctx, beo.configFile)
+	if err != nil {
+		return err
+	}
+
+	store, err := etcdbackup.NewStore(beo.destination, etcdbackup.S3Options{Endpoint: beo.s3Endpoint, Region: beo.s3Region})
+	if err != nil {
+		return err
+	}
+
+	backuper, err := etcdbackup.NewBackuper(cfg)
+	if err != nil {
+		return err
+	}
+
+	location, err := backuper.Backup(ctx, store, etcdbackup.SnapshotName(cfg.ClusterName, time.Now()))
+	if err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Etcd snapshot saved", "location", location)
+	return nil
+}
+
+// readEtcdNodesConfig reads the nodes and SSH config file shared with renew certificates, filling the node IPs
+// from the management cluster when they aren't set. It returns a client for the management cluster.
+func readEtcdNodesConfig(ctx context.Context, configFile string) (*certificates.RenewalConfig, kubernetes.Client, error) {
+	cfg, err := certificates.ParseConfig(configFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableBuilder().
+		WithKubectl().
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kubeCfgPath := kubeconfig.FromClusterName(cfg.ClusterName)
+	if cfg.ManagementClusterName != "" {
+		kubeCfgPath, err = getManagementClusterKubeconfig(cfg.ManagementClusterName)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	kubeClient := deps.UnAuthKubeClient.KubeconfigClient(kubeCfgPath)
+
+	if err := certificates.PopulateConfig(ctx, cfg, kubeClient, &types.Cluster{Name: cfg.ClusterName}); err != nil {
+		return nil, nil, err
+	}
+
+	if err := certificates.ValidateConfig(cfg, ""); err != nil {
+		return nil, nil, fmt.Errorf("validating config: %v", err)
+	}
+
+	return cfg, kubeClient, nil
+}
@@ -7,6 +7,9 @@ const (
 	externalEtcdWaitTimeoutFlag = "external-etcd-wait-timeout"
 	perMachineWaitTimeoutFlag   = "per-machine-wait-timeout"
 	noTimeoutsFlag              = "no-timeouts"
+	outputEventsFlag            = "output-events"
+	eventsFileFlag              = "events-file"
+	eventsFormatJSONL           = "jsonl"
 )
 
 type Operation int
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type copyImagesOptions struct {
+	bundlesFile  string
+	dstRegistry  string
+	kubeVersions []string
+	concurrency  int
+	dstInsecure  bool
+	dstCertFile  string
+	reportFile   string
+	resumeFile   string
+	verifyKey    string
+	attestations []string
+}
+
+var cpio = &copyImagesOptions{}
+
+var copyImagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "Copy EKS Anywhere images and charts from source registries to a destination registry",
+	Long: `Copy all the images and helm charts referenced in a Bundles file, and in the EKS-D releases it references, to a destination registry.
+Images already present in the destination are skipped and an interrupted copy can be resumed with the same resume file.
+Registry credentials are fetched from docker config.`,
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cpio.copyImages(cmd.Context())
+	},
+}
+
+func init() {
+	copyCmd.AddCommand(copyImagesCmd)
+
+	copyImagesCmd.Flags().StringVarP(&cpio.bundlesFile, "bundles", "b", "", "Bundles file to read artifact dependencies from")
+	if err := copyImagesCmd.MarkFlagRequired("bundles"); err != nil {
+		log.Fatalf("Cannot mark 'bundles' as required: %s", err)
+	}
+	copyImagesCmd.Flags().StringVar(&cpio.dstRegistry, "dst-registry", "", "Registry where to copy images and charts, with an optional namespace (host:port/namespace)")
+	if err := copyImagesCmd.MarkFlagRequired("dst-registry"); err != nil {
+		log.Fatalf("Cannot mark 'dst-registry' as required: %s", err)
+	}
+	copyImagesCmd.Flags().StringSliceVar(&cpio.kubeVersions, "kube-versions", nil, "Kubernetes versions to copy images for, all versions in the Bundles if not set")
+	copyImagesCmd.Flags().IntVar(&cpio.concurrency, "concurrency", 4, "Number of images copied in parallel")
+	copyImagesCmd.Flags().BoolVar(&cpio.dstInsecure, "dst-insecure", false, "Skip TLS verification against the destination registry")
+	copyImagesCmd.Flags().StringVar(&cpio.dstCertFile, "dst-cert", "", "CA certificate file of the destination registry")
+	copyImagesCmd.Flags().StringVar(&cpio.reportFile, "report", "copy-images-report.json", "File where to write the copy verification report")
+	copyImagesCmd.Flags().StringVar(&cpio.resumeFile, "resume-file", "copy-images.state", "File recording the images already copied to each destination, used to resume an interrupted copy")
+	copyImagesCmd.Flags().StringVar(&cpio.verifyKey, "verify-key", "", "Public key file to verify the cosign signatures of the images in the destination registry. Signatures and attestations are copied along with the images")
+	copyImagesCmd.Flags().StringSliceVar(&cpio.attestations, "require-attestation", nil, "Attestation predicate types every image must have, like https://spdx.dev/Document. Requires --verify-key")
+}
+
+func (c *copyImagesOptions) copyImages(ctx context.Context) error {
+	if c.concurrency < 1 {
+		return fmt.Errorf("concurrency must be greater than 0")
+	}
+	if len(c.attestations) > 0 && c.verifyKey == "" {
+		return fmt.Errorf("--require-attestation requires --verify-key")
+	}
+
+	var verifier *registry.ImageVerifier
+	if c.verifyKey != "" {
+		key, err := os.ReadFile(c.verifyKey)
+		if err != nil {
+			return fmt.Errorf("reading verification key: %v", err)
+		}
+		if verifier, err = registry.NewImageVerifier(key, registry.WithRequiredAttestations(c.attestations...)); err != nil {
+			return err
+		}
+	}
+
+	deps, err := dependencies.NewFactory().WithManifestReader().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	b, err := bundles.Read(deps.ManifestReader, c.bundlesFile)
+	if err != nil {
+		return err
+	}
+
+	images, err := bundles.ReadImages(deps.ManifestReader, b, c.kubeVersions...)
+	if err != nil {
+		return err
+	}
+	images = append(images, bundles.Charts(b, c.kubeVersions...)...)
+	toCopy := bundleArtifacts(images)
+	if len(toCopy) == 0 {
+		return fmt.Errorf("no images found in bundles for kubernetes versions %v", c.kubeVersions)
+	}
+
+	certificates, err := registry.GetCertificates(c.dstCertFile)
+	if err != nil {
+		return err
+	}
+	host, namespace := splitRegistryHostNamespace(c.dstRegistry)
+	dstClient := registry.NewOCIRegistry(registry.NewStorageContext(host, cs, certificates, c.dstInsecure))
+	if err := dstClient.Init(); err != nil {
+		return err
+	}
+	dstClient.SetProject(namespace)
+
+	cache := registry.NewCache()
+	sourceClient := func(host string) (registry.StorageClient, error) {
+		return cache.Get(registry.NewStorageContext(host, cs, nil, false))
+	}
+
+	logger.Info("Copying images and charts", "count", len(toCopy), "destination", c.dstRegistry)
+	syncOpts := []registry.SyncerOpt{
+		registry.WithSyncConcurrency(c.concurrency),
+		registry.WithSyncStateFile(c.resumeFile),
+	}
+	if verifier != nil {
+		syncOpts = append(syncOpts, registry.WithSyncCosignArtifacts())
+	}
+	report, err := registry.NewSyncer(sourceClient, dstClient, syncOpts...).Sync(ctx, toCopy)
+	if err != nil {
+		return err
+	}
+
+	if verifier != nil {
+		logger.Info("Verifying image signatures in the destination registry")
+		verifySignatures(ctx, verifier, dstClient, toCopy, report)
+	}
+
+	if err := registry.WriteSyncReport(report, c.reportFile); err != nil {
+		return err
+	}
+
+	logger.Info("Copy finished",
+		"copied", report.Count(registry.SyncCopied),
+		"skipped", report.Count(registry.SyncSkipped),
+		"failed", report.Count(registry.SyncFailed),
+		"report", c.reportFile,
+	)
+	if unverified := report.Unverified(); len(unverified) > 0 {
+		return fmt.Errorf("%d images couldn't be verified in the destination registry, check %s for details", len(unverified), c.reportFile)
+	}
+
+	return nil
+}
+
+// verifySignatures verifies the signatures of the copied artifacts and marks the ones that fail as unverified in the report.
+func verifySignatures(ctx context.Context, verifier *registry.ImageVerifier, client registry.StorageClient, artifacts []registry.Artifact, report *registry.SyncReport) {
+	for i, a := range artifacts {
+		if report.Results[i].Status == registry.SyncFailed {
+			continue
+		}
+		if err := verifier.Verify(ctx, client, a); err != nil {
+			report.Results[i].Verified = false
+			report.Results[i].Error = err.Error()
+		}
+	}
+}
+
+// bundleArtifacts converts the bundle images to registry artifacts, removing duplicates
+// and the CSI component images that are referenced in EKS-D releases but not used by EKS Anywhere.
+func bundleArtifacts(images []releasev1.Image) []registry.Artifact {
+	seen := map[string]struct{}{}
+	artifacts := make([]registry.Artifact, 0, len(images))
+	for _, img := range images {
+		if img.URI == "" || strings.Contains(img.URI, "public.ecr.aws/csi-components/") {
+			continue
+		}
+		if _, ok := seen[img.URI]; ok {
+			continue
+		}
+		seen[img.URI] = struct{}{}
+		digest := img.ImageDigest
+		if digest == "" {
+			digest = img.Digest()
+		}
+		artifacts = append(artifacts, registry.NewArtifact(img.Registry(), img.Repository(), img.Version(), digest))
+	}
+
+	return artifacts
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func TestBundleArtifacts(t *testing.T) {
+	g := NewWithT(t)
+	images := []releasev1.Image{
+		{
+			URI:         "public.ecr.aws/eks-anywhere/kube-vip:v0.5.5-eks-a-1",
+			ImageDigest: "sha256:6efe21500abbfbb6b3e37b80dd5dea0b11a0d1b145e84298fee5d7784a77e967",
+		},
+		{
+			URI:         "public.ecr.aws/eks-anywhere/kube-vip:v0.5.5-eks-a-1",
+			ImageDigest: "sha256:6efe21500abbfbb6b3e37b80dd5dea0b11a0d1b145e84298fee5d7784a77e967",
+		},
+		{
+			URI: "public.ecr.aws/eks-distro/etcd-io/etcd@sha256:0a1b2c",
+		},
+		{
+			URI: "public.ecr.aws/csi-components/csi-snapshotter:v6.3.3-eks-1-29-1",
+		},
+		{},
+	}
+
+	g.Expect(bundleArtifacts(images)).To(Equal([]registry.Artifact{
+		{
+			Registry:   "public.ecr.aws",
+			Repository: "eks-anywhere/kube-vip",
+			Tag:        "v0.5.5-eks-a-1",
+			Digest:     "sha256:6efe21500abbfbb6b3e37b80dd5dea0b11a0d1b145e84298fee5d7784a77e967",
+		},
+		{
+			Registry:   "public.ecr.aws",
+			Repository: "eks-distro/etcd-io/etcd",
+			Digest:     "sha256:0a1b2c",
+		},
+	}))
+}
+
+func TestCopyImagesInvalidConcurrency(t *testing.T) {
+	g := NewWithT(t)
+	opts := &copyImagesOptions{concurrency: 0}
+	g.Expect(opts.copyImages(context.Background())).To(MatchError("concurrency must be greater than 0"))
+}
+
+func TestCopyImagesAttestationsWithoutKey(t *testing.T) {
+	g := NewWithT(t)
+	opts := &copyImagesOptions{concurrency: 1, attestations: []string{"https://spdx.dev/Document"}}
+	g.Expect(opts.copyImages(context.Background())).To(MatchError("--require-attestation requires --verify-key"))
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -12,12 +13,14 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
+	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/management"
 	"github.com/aws/eks-anywhere/pkg/workflows/workload"
 )
@@ -25,12 +28,14 @@ import (
 type createClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	eventOptions
 	forceClean            bool
 	skipIpCheck           bool
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
 	installPackages       string
 	skipValidations       []string
+	resume                bool
 	providerOptions       *dependencies.ProviderOptions
 }
 
@@ -50,21 +55,32 @@ var createClusterCmd = &cobra.Command{
 	Long:         "This command is used to create workload clusters",
 	PreRunE:      bindFlagsToViper,
 	SilenceUsage: true,
-	RunE:         cc.createCluster,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		endEvents, err := cc.startEvents()
+		if err != nil {
+			return err
+		}
+		defer func() { endEvents(err) }()
+
+		return cc.createCluster(cmd, args)
+	},
 }
 
 func init() {
 	createCmd.AddCommand(createClusterCmd)
 	applyClusterOptionFlags(createClusterCmd.Flags(), &cc.clusterOptions)
 	applyTimeoutFlags(createClusterCmd.Flags(), &cc.timeoutOptions)
+	applyEventFlags(createClusterCmd.Flags(), &cc.eventOptions)
 	applyTinkerbellHardwareFlag(createClusterCmd.Flags(), &cc.hardwareCSVPath)
 	aflag.String(aflag.TinkerbellBootstrapIP, &cc.tinkerbellBootstrapIP, createClusterCmd.Flags())
 	createClusterCmd.Flags().BoolVar(&cc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	hideForceCleanup(createClusterCmd.Flags())
 	createClusterCmd.Flags().BoolVar(&cc.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
 	createClusterCmd.Flags().StringVar(&cc.installPackages, "install-packages", "", "Location of curated packages configuration files to install to the cluster")
+	createClusterCmd.Flags().BoolVar(&cc.resume, "resume", false, "Resume a previously failed cluster creation from its last completed task")
 	createClusterCmd.Flags().StringArrayVar(&cc.skipValidations, "skip-validations", []string{}, fmt.Sprintf("Bypass create validations by name. Valid arguments you can pass are --skip-validations=%s", strings.Join(createvalidations.SkippableValidations[:], ",")))
 	tinkerbellFlags(createClusterCmd.Flags(), cc.providerOptions.Tinkerbell.BMCOptions.RPC)
+	aflag.Bool(aflag.TinkerbellValidateBMCConnectivity, &cc.providerOptions.Tinkerbell.ValidateBMCConnectivity, createClusterCmd.Flags())
 
 	aflag.MarkRequired(createClusterCmd.Flags(), aflag.ClusterConfig.Name)
 }
@@ -132,8 +148,13 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 
 	validations.CheckDockerAllocatedMemory(ctx, docker)
 
+	// Resuming a failed creation reuses the files it left behind, but only if it saved a checkpoint.
+	if cc.resume && !workflows.HasCheckpoint(filepath.Join(clusterConfig.Name, filewriter.DefaultTmpFolder), clusterConfig.Name) {
+		return fmt.Errorf("no previous failed creation of cluster %s found to resume", clusterConfig.Name)
+	}
+
 	kubeconfigPath := kubeconfig.FromClusterName(clusterConfig.Name)
-	if validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
+	if !cc.resume && validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
 		return fmt.Errorf(
 			"old cluster config file exists under %s, please use a different clusterName to proceed",
 			clusterConfig.Name,
@@ -235,7 +256,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 			deps.ClusterCreator,
 			deps.UnAuthKubectlClient,
 			deps.AwsIamAuth,
-		)
+		).WithResume(cc.resume)
 		err = createWorkloadCluster.Run(ctx, clusterSpec, createValidations)
 
 	} else if clusterSpec.Cluster.IsSelfManaged() {
@@ -253,7 +274,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 			deps.EksaInstaller,
 			deps.ClusterMover,
 			deps.AwsIamAuth,
-		)
+		).WithResume(cc.resume)
 
 		err = createMgmtCluster.Run(ctx, clusterSpec, createValidations)
 	}
@@ -19,6 +19,7 @@ import (
 
 type deleteClusterOptions struct {
 	clusterOptions
+	eventOptions
 	wConfig               string
 	forceCleanup          bool
 	hardwareFileName      string
@@ -42,7 +43,13 @@ var deleteClusterCmd = &cobra.Command{
 	Long:         "This command is used to delete workload clusters created by eksctl anywhere",
 	PreRunE:      bindFlagsToViper,
 	SilenceUsage: true,
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		endEvents, err := dc.startEvents()
+		if err != nil {
+			return err
+		}
+		defer func() { endEvents(err) }()
+
 		if err := dc.validate(cmd.Context(), args); err != nil {
 			return err
 		}
@@ -62,6 +69,7 @@ func init() {
 	deleteClusterCmd.Flags().StringVar(&dc.managementKubeconfig, "kubeconfig", "", "kubeconfig file pointing to a management cluster")
 	deleteClusterCmd.Flags().StringVar(&dc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	tinkerbellFlags(deleteClusterCmd.Flags(), dc.providerOptions.Tinkerbell.BMCOptions.RPC)
+	applyEventFlags(deleteClusterCmd.Flags(), &dc.eventOptions)
 }
 
 func (dc *deleteClusterOptions) validate(ctx context.Context, args []string) error {
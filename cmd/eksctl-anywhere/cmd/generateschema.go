@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+type generateSchemaOptions struct {
+	kinds  []string
+	strict bool
+}
+
+var gso = &generateSchemaOptions{}
+
+var generateSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Generate the JSON Schema of the cluster config",
+	Long: "Generate a JSON Schema for the Cluster and provider kinds of a cluster config, to validate cluster config files " +
+		"in editors and pre-commit hooks",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kinds := gso.kinds
+		if len(kinds) == 0 {
+			kinds = schema.ClusterConfigKinds
+		}
+		s, err := schema.Generate(kinds, schema.Options{Strict: gso.strict})
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(s))
+		return nil
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(generateSchemaCmd)
+	generateSchemaCmd.Flags().StringSliceVar(&gso.kinds, "kind", nil, "Kinds to generate the schema for, all the cluster config kinds by default")
+	generateSchemaCmd.Flags().BoolVar(&gso.strict, "strict", false, "Disallow the fields that are not part of the API, to catch typos")
+}
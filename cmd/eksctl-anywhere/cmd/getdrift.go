@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type getDriftOptions struct {
+	clusterOptions
+	output string
+}
+
+var gdo = &getDriftOptions{}
+
+var getDriftCmd = &cobra.Command{
+	Use:          "drift",
+	Short:        "Get the drift between a cluster and its GitOps repository",
+	Long:         "Compare the Cluster, datacenter config and machine configs in the GitOps repository with the objects in the cluster and print the fields that don't match",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return gdo.getDrift(cmd.Context())
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getDriftCmd)
+	getDriftCmd.Flags().StringVarP(&gdo.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	getDriftCmd.Flags().StringVar(&gdo.managementKubeconfig, "kubeconfig", "", "Kubeconfig file of the management cluster, or of the cluster itself if self-managed")
+	getDriftCmd.Flags().StringVarP(&gdo.output, "output", "o", outputText, "Output format: text|json")
+	if err := getDriftCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (gdo *getDriftOptions) getDrift(ctx context.Context) error {
+	if gdo.output != outputText && gdo.output != outputJson {
+		return fmt.Errorf("invalid output format [%s]", gdo.output)
+	}
+
+	clusterSpec, err := newClusterSpec(gdo.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.FluxConfig == nil {
+		return fmt.Errorf("cluster %s is not managed with GitOps, the cluster config doesn't have a FluxConfig", clusterSpec.Cluster.Name)
+	}
+
+	kubeConfig := getKubeconfigPath(clusterSpec.Cluster.Name, gdo.managementKubeconfig)
+	if clusterSpec.ManagementCluster != nil {
+		kubeConfig = clusterSpec.ManagementCluster.KubeconfigFile
+	}
+	if err := kubeconfig.ValidateFilename(kubeConfig); err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(clusterSpec).
+		WithGit(clusterSpec.Cluster, clusterSpec.FluxConfig).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	gitConfig, err := flux.NewFlux(nil, nil, deps.Git, nil).GetGitEksaSpec(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	client, err := kubernetes.NewRuntimeClientFromFileName(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	report, err := drift.Compare(ctx, client, gitConfig, clusterSpec.Cluster.Namespace)
+	if err != nil {
+		return err
+	}
+
+	out, err := serializeDrift(report, gdo.output)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+
+	return nil
+}
+
+func serializeDrift(report *drift.Report, outputFormat string) (string, error) {
+	if outputFormat == outputJson {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the drift report to json: %v", err)
+		}
+		return string(out) + "\n", nil
+	}
+
+	if !report.HasDrift() {
+		return "The cluster matches the GitOps repository\n", nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tFIELD\tGIT\tLIVE")
+	for _, o := range report.Objects {
+		if o.Missing {
+			fmt.Fprintf(w, "%s\t%s\t\t\tnot found\n", o.Kind, o.Name)
+			continue
+		}
+		for _, f := range o.Fields {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", o.Kind, o.Name, f.Path, driftValue(f.Git), driftValue(f.Live))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+func driftValue(v interface{}) string {
+	if v == nil {
+		return "<unset>"
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+)
+
+func TestSerializeDriftText(t *testing.T) {
+	g := NewWithT(t)
+	report := &drift.Report{
+		Objects: []drift.ObjectDrift{
+			{
+				Kind: "Cluster",
+				Name: "mgmt",
+				Fields: []drift.FieldDrift{
+					{Path: "spec.workerNodeGroupConfigurations[0].count", Git: float64(2), Live: float64(5)},
+					{Path: "spec.kubernetesVersion", Git: "1.30", Live: nil},
+				},
+			},
+			{
+				Kind:    "VSphereMachineConfig",
+				Name:    "mgmt-cp",
+				Missing: true,
+			},
+		},
+	}
+
+	out, err := serializeDrift(report, outputText)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out).To(Equal(`KIND                   NAME      FIELD                                         GIT       LIVE
+Cluster                mgmt      spec.workerNodeGroupConfigurations[0].count   2         5
+Cluster                mgmt      spec.kubernetesVersion                        1.30      <unset>
+VSphereMachineConfig   mgmt-cp                                                           not found
+`))
+}
+
+func TestSerializeDriftNoDrift(t *testing.T) {
+	g := NewWithT(t)
+	out, err := serializeDrift(&drift.Report{}, outputText)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out).To(Equal("The cluster matches the GitOps repository\n"))
+
+	out, err = serializeDrift(&drift.Report{Objects: []drift.ObjectDrift{}}, outputJson)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out).To(Equal("{\n  \"objects\": []\n}\n"))
+}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -16,9 +18,12 @@ import (
 	"github.com/aws/eks-anywhere/pkg/docker"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/helm"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/registry"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 // imagesCmd represents the images command.
@@ -52,6 +57,8 @@ func init() {
 	importImagesCmd.Flags().BoolVar(&importImagesCommand.includePackages, "include-packages", false, "Flag to indicate inclusion of curated packages in imported images")
 	importImagesCmd.Flag("include-packages").Deprecated = "use copy packages command"
 	importImagesCmd.Flags().BoolVar(&importImagesCommand.insecure, "insecure", false, "Flag to indicate skipping TLS verification while pushing helm charts and bundles")
+	importImagesCmd.Flags().StringVar(&importImagesCommand.verifyKey, "verify-key", "", "Public key file to verify the cosign signatures of the images in the registry once they are imported. The signatures must already be in the registry")
+	importImagesCmd.Flags().StringSliceVar(&importImagesCommand.attestations, "require-attestation", nil, "Attestation predicate types every image must have, like https://spdx.dev/Document. Requires --verify-key")
 }
 
 var importImagesCommand = ImportImagesCommand{}
@@ -62,9 +69,26 @@ type ImportImagesCommand struct {
 	BundlesFile      string
 	includePackages  bool
 	insecure         bool
+	verifyKey        string
+	attestations     []string
 }
 
 func (c ImportImagesCommand) Call(ctx context.Context) error {
+	if len(c.attestations) > 0 && c.verifyKey == "" {
+		return fmt.Errorf("--require-attestation requires --verify-key")
+	}
+
+	var verifier *registry.ImageVerifier
+	if c.verifyKey != "" {
+		key, err := os.ReadFile(c.verifyKey)
+		if err != nil {
+			return fmt.Errorf("reading verification key: %v", err)
+		}
+		if verifier, err = registry.NewImageVerifier(key, registry.WithRequiredAttestations(c.attestations...)); err != nil {
+			return err
+		}
+	}
+
 	username, password, err := config.ReadCredentials()
 	if err != nil {
 		return err
@@ -152,7 +176,44 @@ func (c ImportImagesCommand) Call(ctx context.Context) error {
 		FileImporter:       oras.NewFileRegistryImporter(c.RegistryEndpoint, username, password, artifactsFolder),
 	}
 
-	return importArtifacts.Run(context.WithValue(ctx, types.InsecureRegistry, c.insecure))
+	if err = importArtifacts.Run(context.WithValue(ctx, types.InsecureRegistry, c.insecure)); err != nil {
+		return err
+	}
+
+	if verifier == nil {
+		return nil
+	}
+
+	return c.verifyImportedImages(ctx, verifier, deps.ManifestReader, bundle)
+}
+
+// verifyImportedImages verifies the cosign signatures of the imported images and charts in the registry.
+// Docker doesn't keep the signatures when it moves the images, so they must have been pushed to the registry
+// separately, for example with cosign.
+func (c ImportImagesCommand) verifyImportedImages(ctx context.Context, verifier *registry.ImageVerifier, reader bundles.Reader, bundle *releasev1.Bundles) error {
+	images, err := bundles.ReadImages(reader, bundle)
+	if err != nil {
+		return err
+	}
+	images = append(images, bundles.Charts(bundle)...)
+
+	credentialStore := registry.NewCredentialStore()
+	if err := credentialStore.Init(); err != nil {
+		return fmt.Errorf("reading registry credentials: %v", err)
+	}
+	host, namespace := splitRegistryHostNamespace(c.RegistryEndpoint)
+	client := registry.NewOCIRegistry(registry.NewStorageContext(host, credentialStore, nil, c.insecure))
+	if err := client.Init(); err != nil {
+		return err
+	}
+	client.SetProject(namespace)
+
+	logger.Info("Verifying image signatures in the registry")
+	if err := verifier.VerifyAll(ctx, client, bundleArtifacts(images)); err != nil {
+		return fmt.Errorf("verifying imported images: %v", err)
+	}
+
+	return nil
 }
 
 // splitRegistryHostNamespace separates a registry endpoint like "host:port/namespace"
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestImportImagesAttestationsWithoutKey(t *testing.T) {
+	g := NewWithT(t)
+	c := ImportImagesCommand{attestations: []string{"https://spdx.dev/Document"}}
+	g.Expect(c.Call(context.Background())).To(MatchError("--require-attestation requires --verify-key"))
+}
+
+func TestImportImagesMissingVerifyKey(t *testing.T) {
+	g := NewWithT(t)
+	c := ImportImagesCommand{verifyKey: filepath.Join(t.TempDir(), "cosign.pub")}
+	g.Expect(c.Call(context.Background())).To(MatchError(ContainSubstring("reading verification key")))
+}
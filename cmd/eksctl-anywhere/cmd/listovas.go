@@ -79,9 +79,12 @@ func listOvas(context context.Context, clusterSpecPath, bundlesOverride string)
 func printOvas(bundle *cluster.VersionsBundle) error {
 	titler := cases.Title(language.English)
 	for _, ova := range bundle.Ovas() {
-		if strings.Contains(ova.URI, string(eksav1alpha1.Bottlerocket)) {
+		switch {
+		case strings.Contains(ova.URI, string(eksav1alpha1.Bottlerocket)):
 			fmt.Printf("%s:\n", titler.String(string(eksav1alpha1.Bottlerocket)))
-		} else {
+		case strings.Contains(ova.URI, string(eksav1alpha1.RedHat)):
+			fmt.Printf("%s:\n", titler.String(string(eksav1alpha1.RedHat)))
+		default:
 			fmt.Printf("%s:\n", titler.String(string(eksav1alpha1.Ubuntu)))
 		}
 		output := listOvasOutput{
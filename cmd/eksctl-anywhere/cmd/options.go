@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -18,6 +19,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/manifests"
@@ -73,6 +75,63 @@ func buildClusterManagerOpts(t timeoutOptions, datacenterKind string) (*dependen
 	}, nil
 }
 
+type eventOptions struct {
+	outputEvents string
+	eventsFile   string
+}
+
+func applyEventFlags(flagSet *pflag.FlagSet, e *eventOptions) {
+	flagSet.StringVar(&e.outputEvents, outputEventsFlag, "", fmt.Sprintf("Emit machine-readable events for task starts and ends, retries, validations and errors. Supported formats: %s", eventsFormatJSONL))
+	flagSet.StringVar(&e.eventsFile, eventsFileFlag, "", fmt.Sprintf("File to write the events enabled by --%s to (default stderr)", outputEventsFlag))
+}
+
+// startEvents configures the event stream requested by the event flags. The returned function
+// must be called with the command result to report it and close the stream.
+func (e eventOptions) startEvents() (func(error), error) {
+	switch e.outputEvents {
+	case "":
+		return func(error) {}, nil
+	case eventsFormatJSONL:
+	default:
+		return nil, fmt.Errorf("invalid --%s format %s, supported formats: %s", outputEventsFlag, e.outputEvents, eventsFormatJSONL)
+	}
+
+	out := os.Stderr
+	if e.eventsFile != "" {
+		f, err := os.Create(e.eventsFile)
+		if err != nil {
+			return nil, fmt.Errorf("creating events file: %v", err)
+		}
+		out = f
+	}
+
+	emitter := &errorTrackingEmitter{Emitter: events.NewJSONLEmitter(out)}
+	events.Set(emitter)
+	return func(err error) {
+		// A failed task already emitted the error the command returns.
+		if err != nil && !emitter.errorEmitted.Load() {
+			events.Emit(events.Event{Type: events.Error, Error: err.Error()})
+		}
+		events.Set(nil)
+		if out != os.Stderr {
+			out.Close()
+		}
+	}, nil
+}
+
+// errorTrackingEmitter forwards events to an Emitter and records if any of them is an Error event.
+type errorTrackingEmitter struct {
+	events.Emitter
+	errorEmitted atomic.Bool
+}
+
+func (e *errorTrackingEmitter) Emit(event events.Event) {
+	if event.Type == events.Error {
+		e.errorEmitted.Store(true)
+	}
+	e.Emitter.Emit(event)
+}
+
 type clusterOptions struct {
 	fileName             string
 	bundlesOverride      string
@@ -261,6 +320,9 @@ func buildCliConfig(clusterSpec *cluster.Spec) *config.CliConfig {
 		cliConfig.GitPrivateKeyFile = os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		cliConfig.GitKnownHostsFile = os.Getenv(config.EksaGitKnownHostsFileEnv)
 	}
+	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
+		cliConfig.ImageVerificationKeyFile = os.Getenv(config.EksaImageVerificationKeyEnv)
+	}
 
 	return cliConfig
 }
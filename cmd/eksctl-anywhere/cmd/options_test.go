@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/events"
+)
+
+func TestEventOptionsStartEventsCommandError(t *testing.T) {
+	g := NewWithT(t)
+	file := filepath.Join(t.TempDir(), "events.jsonl")
+	e := eventOptions{outputEvents: eventsFormatJSONL, eventsFile: file}
+
+	endEvents, err := e.startEvents()
+	g.Expect(err).NotTo(HaveOccurred())
+	endEvents(errors.New("validations failed"))
+
+	content, err := os.ReadFile(file)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(strings.Count(string(content), `"type":"error"`)).To(Equal(1))
+	g.Expect(string(content)).To(ContainSubstring(`"error":"validations failed"`))
+}
+
+func TestEventOptionsStartEventsTaskError(t *testing.T) {
+	g := NewWithT(t)
+	file := filepath.Join(t.TempDir(), "events.jsonl")
+	e := eventOptions{outputEvents: eventsFormatJSONL, eventsFile: file}
+
+	endEvents, err := e.startEvents()
+	g.Expect(err).NotTo(HaveOccurred())
+	taskErr := errors.New("creating workload cluster")
+	events.TaskFailed("workload-cluster-init", taskErr)
+	endEvents(taskErr)
+
+	content, err := os.ReadFile(file)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(strings.Count(string(content), `"type":"error"`)).To(Equal(1))
+	g.Expect(string(content)).To(ContainSubstring(`"task":"workload-cluster-init"`))
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type restoreEtcdOptions struct {
+	configFile string
+	snapshot   string
+	s3Endpoint string
+	s3Region   string
+}
+
+var reo = &restoreEtcdOptions{}
+
+var restoreEtcdCmd = &cobra.Command{
+	Use:   "etcd -f <config-file> --snapshot <snapshot> [flags]",
+	Short: "Restore etcd",
+	Long: "Restore an etcd snapshot in all the etcd members of a cluster over SSH. The control plane components are stopped " +
+		"and the cluster reconciliation is paused during the restore. The cluster can be a new cluster rebuilt with the same config.",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return reo.restoreEtcd(cmd.Context())
+	},
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreEtcdCmd)
+	restoreEtcdCmd.Flags().StringVarP(&reo.configFile, "filename", "f", "", "Path to the node and SSH configuration file, same format as the renew certificates one")
+	restoreEtcdCmd.Flags().StringVarP(&reo.snapshot, "snapshot", "s", "", "Path or s3://bucket/key of the snapshot to restore")
+	restoreEtcdCmd.Flags().StringVar(&reo.s3Endpoint, "s3-endpoint", "", "URL of an S3-compatible endpoint, when not using AWS S3")
+	restoreEtcdCmd.Flags().StringVar(&reo.s3Region, "s3-region", "", "Region of the S3 bucket")
+
+	for _, flag := range []string{"filename", "snapshot"} {
+		if err := restoreEtcdCmd.MarkFlagRequired(flag); err != nil {
+			logger.Fatal(err, fmt.Sprintf("marking %s as required", flag))
+		}
+	}
+}
+
+func (reo *restoreEtcdOptions) restoreEtcd(ctx context.Context) error {
+	cfg, kubeClient, err := readEtcdNodesConfig(ctx, reo.configFile)
+	if err != nil {
+		return err
+	}
+
+	restorer, err := etcdbackup.NewRestorer(kubeClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	snapshot := reo.snapshot
+	if etcdbackup.IsS3Location(reo.snapshot) {
+		store, err := etcdbackup.NewS3Store(reo.snapshot, etcdbackup.S3Options{Endpoint: reo.s3Endpoint, Region: reo.s3Region})
+		if err != nil {
+			return err
+		}
+		dir, err := os.MkdirTemp("", "etcd-restore-")
+		if err != nil {
+			return fmt.Errorf("creating temporary folder: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		snapshot = filepath.Join(dir, "snapshot.db")
+		logger.Info("Downloading etcd snapshot", "location", reo.snapshot)
+		if err := store.Fetch(ctx, reo.snapshot, snapshot); err != nil {
+			return err
+		}
+	}
+
+	return restorer.Restore(ctx, snapshot)
+}
@@ -4,19 +4,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/aflag"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterupgrade"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/management"
 	"github.com/aws/eks-anywhere/pkg/workflows/workload"
 )
@@ -24,14 +34,20 @@ import (
 type upgradeClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	eventOptions
 	wConfig               string
 	forceClean            bool
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
 	skipValidations       []string
+	resume                bool
+	targetVersion         string
 	providerOptions       *dependencies.ProviderOptions
 }
 
+// upgradeHopHealthCheckTimeout is how long a multi-hop upgrade waits for the cluster to be healthy after each hop.
+const upgradeHopHealthCheckTimeout = 30 * time.Minute
+
 var uc = &upgradeClusterOptions{
 	providerOptions: &dependencies.ProviderOptions{
 		Tinkerbell: &dependencies.TinkerbellOptions{
@@ -49,13 +65,24 @@ var upgradeClusterCmd = &cobra.Command{
 	PreRunE:      bindFlagsToViper,
 	SilenceUsage: true,
 	Args:         cobra.MaximumNArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		endEvents, err := uc.startEvents()
+		if err != nil {
+			return err
+		}
+		defer func() { endEvents(err) }()
+
 		if uc.forceClean {
 			logger.MarkFail(forceCleanupDeprecationMessageForUpgrade)
 			return errors.New("please remove the --force-cleanup flag")
 		}
 
-		if err := uc.upgradeCluster(cmd, args); err != nil {
+		if uc.targetVersion != "" {
+			err = uc.upgradeClusterToTargetVersion(cmd, args)
+		} else {
+			err = uc.upgradeCluster(cmd, args)
+		}
+		if err != nil {
 			return fmt.Errorf("failed to upgrade cluster: %v", err)
 		}
 		return nil
@@ -66,10 +93,13 @@ func init() {
 	upgradeCmd.AddCommand(upgradeClusterCmd)
 	applyClusterOptionFlags(upgradeClusterCmd.Flags(), &uc.clusterOptions)
 	applyTimeoutFlags(upgradeClusterCmd.Flags(), &uc.timeoutOptions)
+	applyEventFlags(upgradeClusterCmd.Flags(), &uc.eventOptions)
 	applyTinkerbellHardwareFlag(upgradeClusterCmd.Flags(), &uc.hardwareCSVPath)
 	upgradeClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file to use when upgrading a workload cluster")
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	hideForceCleanup(upgradeClusterCmd.Flags())
+	upgradeClusterCmd.Flags().BoolVar(&uc.resume, "resume", false, "Resume a previously failed cluster upgrade from its last completed task")
+	upgradeClusterCmd.Flags().StringVar(&uc.targetVersion, "target-version", "", "Kubernetes version to upgrade the cluster to, going through each minor version in between. Overrides the kubernetesVersion in the cluster config")
 	upgradeClusterCmd.Flags().StringArrayVar(&uc.skipValidations, "skip-validations", []string{}, fmt.Sprintf("Bypass upgrade validations by name. Valid arguments you can pass are --skip-validations=%s", strings.Join(upgradevalidations.SkippableValidations[:], ",")))
 	aflag.MarkRequired(createClusterCmd.Flags(), aflag.ClusterConfig.Name)
 	tinkerbellFlags(upgradeClusterCmd.Flags(), uc.providerOptions.Tinkerbell.BMCOptions.RPC)
@@ -218,7 +248,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 			deps.ClusterApplier,
 			deps.PackageManager,
 			deps.AwsIamAuth,
-		)
+		).WithResume(uc.resume)
 
 		err = upgrade.Run(ctx, clusterSpec, managementCluster, upgradeValidations)
 
@@ -233,7 +263,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 			deps.EksdInstaller,
 			deps.PackageManager,
 			deps.AwsIamAuth,
-		)
+		).WithResume(uc.resume)
 		err = upgradeWorkloadCluster.Run(ctx, workloadCluster, clusterSpec, upgradeValidations)
 	}
 
@@ -241,6 +271,118 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 	return err
 }
 
+// upgradeClusterToTargetVersion upgrades the cluster to the target Kubernetes version in several hops of one minor
+// version, running a full cluster upgrade for each of them. With --resume, it continues from the first hop that
+// didn't complete in a previous run.
+func (uc *upgradeClusterOptions) upgradeClusterToTargetVersion(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if !validations.FileExists(uc.fileName) {
+		return fmt.Errorf("the cluster config file %s does not exist", uc.fileName)
+	}
+
+	config, err := cluster.ParseConfigFromFile(uc.fileName)
+	if err != nil {
+		return fmt.Errorf("the cluster config file provided is invalid: %v", err)
+	}
+	if err := validations.ValidateClusterNameFromCommandAndConfig(args, config.Cluster.Name); err != nil {
+		return err
+	}
+	config.Cluster.Spec.KubernetesVersion = v1alpha1.KubernetesVersion(uc.targetVersion)
+
+	managementKubeconfig, err := uc.managementClusterKubeconfig(config.Cluster)
+	if err != nil {
+		return err
+	}
+	if err := kubeconfig.ValidateFilename(managementKubeconfig); err != nil {
+		return err
+	}
+	managementClient, err := kubernetes.NewRuntimeClientFromFileName(managementKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	writer, err := filewriter.NewWriter(config.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	clusterKey := client.ObjectKey{Name: config.Cluster.Name, Namespace: clusterNamespace(config.Cluster)}
+	plan, err := uc.upgradePlan(ctx, managementClient, clusterKey, writer, config)
+	if err != nil {
+		return err
+	}
+
+	timeout := upgradeHopHealthCheckTimeout
+	if uc.noTimeouts {
+		timeout = time.Duration(math.MaxInt64)
+	}
+	healthCheck := clusterupgrade.ClusterHealthCheck(managementClient, clusterKey, retrier.New(timeout, retrier.WithRetryPolicy(retrier.BackOffPolicy(10*time.Second))))
+
+	upgradeHop := func(ctx context.Context, configFile string, resume bool) error {
+		hop := *uc
+		hop.fileName = configFile
+		// A hop that failed its health check completed its upgrade and has no checkpoint left to resume.
+		hop.resume = resume && workflows.HasCheckpoint(writer.TempDir(), config.Cluster.Name)
+		hop.targetVersion = ""
+		return hop.upgradeCluster(cmd, args)
+	}
+
+	return clusterupgrade.NewRunner(writer, upgradeHop, healthCheck).Run(ctx, plan, uc.resume)
+}
+
+// upgradePlan returns the plan saved by a previous failed run when resuming the upgrade to the same
+// target version, or plans the hops from the current cluster otherwise.
+func (uc *upgradeClusterOptions) upgradePlan(ctx context.Context, managementClient client.Client, clusterKey client.ObjectKey, writer filewriter.FileWriter, config *cluster.Config) (*clusterupgrade.Plan, error) {
+	if uc.resume {
+		saved, err := clusterupgrade.LoadPlan(writer, config.Cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+		if saved != nil && saved.TargetVersion == config.Cluster.Spec.KubernetesVersion && !saved.Completed() {
+			logger.Info("Resuming upgrade plan", "targetVersion", saved.TargetVersion)
+			return saved, nil
+		}
+	}
+
+	current := &v1alpha1.Cluster{}
+	if err := managementClient.Get(ctx, clusterKey, current); err != nil {
+		return nil, fmt.Errorf("getting cluster %s: %v", config.Cluster.Name, err)
+	}
+
+	plan, err := clusterupgrade.NewPlan(current, config)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, hop := range plan.Hops {
+		logger.Info(fmt.Sprintf("Upgrade hop %d: Kubernetes version %s", i+1, hop.KubernetesVersion), "workerNodeGroupVersions", hop.WorkerNodeGroupVersions)
+	}
+
+	return plan, nil
+}
+
+func (uc *upgradeClusterOptions) managementClusterKubeconfig(cluster *v1alpha1.Cluster) (string, error) {
+	if cluster.IsSelfManaged() {
+		if uc.wConfig != "" {
+			return uc.wConfig, nil
+		}
+		return getKubeconfigPath(cluster.Name, uc.managementKubeconfig), nil
+	}
+
+	if uc.managementKubeconfig != "" {
+		return uc.managementKubeconfig, nil
+	}
+	return getManagementClusterKubeconfig(cluster.Spec.ManagementCluster.Name)
+}
+
+func clusterNamespace(cluster *v1alpha1.Cluster) string {
+	if cluster.Namespace == "" {
+		return constants.DefaultNamespace
+	}
+	return cluster.Namespace
+}
+
 func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster *v1alpha1.Cluster, err error) {
 	clusterConfig, err := commonValidation(ctx, uc.fileName)
 	if err != nil {
@@ -6,14 +6,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi/diff"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/eksd"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -22,9 +33,14 @@ const (
 	outputDefault  = outputText
 	outputText     = "text"
 	outputJson     = "json"
+
+	dryRunDiffFlagName = "dry-run-diff"
 )
 
-var output string
+var (
+	output     string
+	dryRunDiff bool
+)
 
 var upgradePlanClusterCmd = &cobra.Command{
 	Use:          "cluster",
@@ -46,6 +62,7 @@ func init() {
 	upgradePlanClusterCmd.Flags().StringVar(&uc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	upgradePlanClusterCmd.Flags().StringVarP(&output, outputFlagName, "o", outputDefault, "Output format: text|json")
 	upgradePlanClusterCmd.Flags().StringVar(&uc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	upgradePlanClusterCmd.Flags().BoolVar(&dryRunDiff, dryRunDiffFlagName, false, "Show the CAPI objects that would change with the upgrade and whether each change triggers a machine rollout")
 	err := upgradePlanClusterCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -106,9 +123,62 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 
 	logger.V(0).Info(serializedDiff)
 
+	if !dryRunDiff {
+		return nil
+	}
+
+	objectsDiff, err := capiObjectsDiff(currentSpec, newClusterSpec)
+	if err != nil {
+		return err
+	}
+
+	serializedObjectsDiff, err := serializeObjectsDiff(objectsDiff, output)
+	if err != nil {
+		return err
+	}
+
+	logger.V(0).Info(serializedObjectsDiff)
+
 	return nil
 }
 
+// capiObjectsDiff renders the CAPI objects for the current and new specs with the provider template builder and compares them.
+func capiObjectsDiff(currentSpec, newSpec *cluster.Spec) (*diff.Report, error) {
+	currentBuilder, err := templateBuilderForSpec(currentSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	newBuilder, err := templateBuilderForSpec(newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := diff.Specs(currentBuilder, newBuilder, currentSpec, newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("generating CAPI objects diff: %v", err)
+	}
+
+	return report, nil
+}
+
+func templateBuilderForSpec(spec *cluster.Spec) (providers.TemplateBuilder, error) {
+	switch spec.Cluster.Spec.DatacenterRef.Kind {
+	case v1alpha1.VSphereDatacenterKind:
+		return vsphere.NewVsphereTemplateBuilder(time.Now), nil
+	case v1alpha1.DockerDatacenterKind:
+		return docker.NewDockerTemplateBuilder(time.Now), nil
+	case v1alpha1.CloudStackDatacenterKind:
+		return cloudstack.NewTemplateBuilder(time.Now), nil
+	case v1alpha1.NutanixDatacenterKind:
+		return nutanix.TemplateBuilderForSpec(spec), nil
+	case v1alpha1.TinkerbellDatacenterKind:
+		return tinkerbell.TemplateBuilderForSpec(spec)
+	default:
+		return nil, fmt.Errorf("--%s is not supported for datacenter %s", dryRunDiffFlagName, spec.Cluster.Spec.DatacenterRef.Kind)
+	}
+}
+
 func serialize(componentChangeDiffs *types.ChangeDiff, outputFormat string) (string, error) {
 	switch outputFormat {
 	case outputText:
@@ -150,3 +220,64 @@ func serializeToJson(componentChangeDiffs *types.ChangeDiff) (string, error) {
 
 	return string(jsonDiff), nil
 }
+
+func serializeObjectsDiff(report *diff.Report, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeObjectsDiffToText(report), nil
+	case outputJson:
+		jsonDiff, err := json.Marshal(report)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the CAPI objects diff to json: %v", err)
+		}
+		return string(jsonDiff), nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeObjectsDiffToText(report *diff.Report) string {
+	if len(report.Objects) == 0 {
+		return "No changes in the cluster CAPI objects"
+	}
+
+	buffer := bytes.Buffer{}
+	for _, o := range report.Objects {
+		fmt.Fprintf(&buffer, "%s %s/%s: %s", o.Kind, o.Namespace, o.Name, o.Action)
+		if o.Impact == diff.Rollout {
+			buffer.WriteString(", triggers machine rollout")
+		}
+		buffer.WriteString("\n")
+		for _, f := range o.Fields {
+			fmt.Fprintf(&buffer, "  %s (%s)\n", f.Path, f.Impact)
+			fmt.Fprintf(&buffer, "    - %s\n", formatDiffValue(f.Old))
+			fmt.Fprintf(&buffer, "    + %s\n", formatDiffValue(f.New))
+		}
+	}
+
+	if report.RequiresRollout() {
+		buffer.WriteString("The upgrade will roll out new machines")
+	} else {
+		buffer.WriteString("All the changes will be applied in place")
+	}
+
+	return buffer.String()
+}
+
+func formatDiffValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "<unset>"
+	case string:
+		if !strings.Contains(value, "\n") {
+			return value
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(b)
+}
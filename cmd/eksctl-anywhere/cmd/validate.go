@@ -10,6 +10,14 @@ var validateCmd = &cobra.Command{
 	Long:  "Use eksctl anywhere validate to validate a resource or action",
 }
 
+// validateResourceCmd is the top level validate command. The experimental validate command stays under exp.
+var validateResourceCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate resource",
+	Long:  "Use eksctl anywhere validate to validate a resource",
+}
+
 func init() {
 	expCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(validateResourceCmd)
 }
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/internal/pkg/conformance"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+)
+
+type validateClusterOptions struct {
+	clusterOptions
+	kubeconfig  string
+	conformance bool
+	mode        string
+	outputDir   string
+	sonobuoy    string
+	timeout     time.Duration
+}
+
+var vco = &validateClusterOptions{}
+
+var validateClusterCmd = &cobra.Command{
+	Use:          "cluster -f <cluster-config-file> --conformance [flags]",
+	Short:        "Validate a cluster",
+	Long:         "Run the Kubernetes conformance tests in a cluster with sonobuoy and write the results with a JUnit report",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return vco.validateCluster(cmd.Context())
+	},
+}
+
+func init() {
+	validateResourceCmd.AddCommand(validateClusterCmd)
+	validateClusterCmd.Flags().StringVarP(&vco.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	validateClusterCmd.Flags().StringVar(&vco.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	validateClusterCmd.Flags().StringVar(&vco.kubeconfig, "kubeconfig", "", "Kubeconfig file of the cluster to validate")
+	validateClusterCmd.Flags().BoolVar(&vco.conformance, "conformance", false, "Run the Kubernetes conformance tests")
+	validateClusterCmd.Flags().StringVar(&vco.mode, "mode", string(conformance.ModeQuick), "Conformance tests to run: quick|certified")
+	validateClusterCmd.Flags().StringVar(&vco.outputDir, "output-dir", "conformance-results", "Folder to write the sonobuoy results and the JUnit report to")
+	validateClusterCmd.Flags().StringVar(&vco.sonobuoy, "sonobuoy", "sonobuoy", "Path to the sonobuoy binary")
+	validateClusterCmd.Flags().DurationVar(&vco.timeout, "timeout", 3*time.Hour, "Maximum time to wait for the conformance tests to complete")
+	if err := validateClusterCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (vco *validateClusterOptions) validateCluster(ctx context.Context) error {
+	if !vco.conformance {
+		return fmt.Errorf("no validation selected, use --conformance to run the conformance tests")
+	}
+	mode := conformance.Mode(vco.mode)
+	if mode != conformance.ModeQuick && mode != conformance.ModeCertified {
+		return fmt.Errorf("invalid mode %s, must be %s or %s", vco.mode, conformance.ModeQuick, conformance.ModeCertified)
+	}
+
+	clusterSpec, err := newClusterSpec(vco.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	kubeConfig := getKubeconfigPath(clusterSpec.Cluster.Name, vco.kubeconfig)
+	if err := kubeconfig.ValidateFilename(kubeConfig); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, vco.timeout)
+	defer cancel()
+
+	runner := conformance.NewRunner(executables.NewSonobuoy(executables.NewExecutable(vco.sonobuoy)))
+	result, err := runner.Run(ctx, conformance.Config{
+		Kubeconfig:     kubeConfig,
+		Mode:           mode,
+		KubeVersion:    clusterSpec.RootVersionsBundle().EksD.KubeVersion,
+		RegistryMirror: registrymirror.FromCluster(clusterSpec.Cluster),
+		OutputDir:      vco.outputDir,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Conformance tests: %d passed, %d failed, %d skipped\n", result.Passed, result.Failed, result.Skipped)
+	for _, name := range result.Failures {
+		logger.MarkFail(name)
+	}
+	fmt.Printf("Results written to %s and %s\n", result.Tarball, result.JUnitFile)
+
+	if !result.Succeeded() {
+		return fmt.Errorf("%d conformance tests failed", result.Failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/offlinevalidations"
+)
+
+type validateConfigOptions struct {
+	fileName string
+	offline  bool
+}
+
+var vcfo = &validateConfigOptions{}
+
+var validateConfigCmd = &cobra.Command{
+	Use:   "config -f <cluster-config-file> --offline [flags]",
+	Short: "Validate a cluster config",
+	Long: "Validate a cluster config without access to the infrastructure provider or to an existing cluster. " +
+		"It runs the static validations of the CLI and of the webhooks and reports all the errors at once",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return vcfo.validateConfig(cmd.Context())
+	},
+}
+
+func init() {
+	validateResourceCmd.AddCommand(validateConfigCmd)
+	validateConfigCmd.Flags().StringVarP(&vcfo.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	validateConfigCmd.Flags().BoolVar(&vcfo.offline, "offline", false, "Run only the validations that don't need access to the infrastructure provider or to a cluster")
+	if err := validateConfigCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (vcfo *validateConfigOptions) validateConfig(ctx context.Context) error {
+	if !vcfo.offline {
+		return fmt.Errorf("no validation selected, use --offline to run the static validations or 'anywhere exp validate create cluster' to validate the cluster creation")
+	}
+
+	config, err := cluster.ParseConfigFromFile(vcfo.fileName)
+	if err != nil {
+		return err
+	}
+	if err := offlinevalidations.SetDefaults(ctx, config); err != nil {
+		return err
+	}
+
+	runner := validations.NewRunner()
+	runner.Register(offlinevalidations.Validations(ctx, config)...)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Cluster config is valid")
+	return nil
+}
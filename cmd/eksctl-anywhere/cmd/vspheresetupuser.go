@@ -1,19 +1,24 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/setupuser"
 )
 
 type vSphereSetupUserOptions struct {
-	fileName string
-	force    bool
-	password string
+	fileName  string
+	force     bool
+	password  string
+	check     bool
+	reconcile bool
 }
 
 var setupUserOptions = &vSphereSetupUserOptions{}
@@ -33,6 +38,8 @@ func init() {
 	setupUserCmd.Flags().StringVarP(&setupUserOptions.fileName, "filename", "f", "", "Filename containing vsphere setup configuration")
 	setupUserCmd.Flags().StringVarP(&setupUserOptions.password, "password", "p", "", "Password for creating new user")
 	setupUserCmd.Flags().BoolVarP(&setupUserOptions.force, "force", "", false, "Force flag. When set, setup user will proceed even if the group and role objects already exist. Mutually exclusive with --password flag, as it expects the user to already exist. default: false")
+	setupUserCmd.Flags().BoolVar(&setupUserOptions.check, "check", false, "List the privileges EKS Anywhere needs that are missing from the roles, without changing anything. Mutually exclusive with --password, --force and --reconcile flags")
+	setupUserCmd.Flags().BoolVar(&setupUserOptions.reconcile, "reconcile", false, "Create the missing group and role objects and add the privileges EKS Anywhere needs to the existing roles. Mutually exclusive with --password, --force and --check flags, as it expects the user to already exist")
 
 	if err := setupUserCmd.MarkFlagRequired("filename"); err != nil {
 		log.Fatalf("error marking flag as required: %v", err)
@@ -46,6 +53,14 @@ func (setupUserOptions *vSphereSetupUserOptions) setupUser(cmd *cobra.Command, _
 		return fmt.Errorf("--password and --force are mutually exclusive. --force may only be run on an existing user")
 	}
 
+	if setupUserOptions.check && setupUserOptions.reconcile {
+		return fmt.Errorf("--check and --reconcile are mutually exclusive")
+	}
+
+	if (setupUserOptions.check || setupUserOptions.reconcile) && (setupUserOptions.force || setupUserOptions.password != "") {
+		return fmt.Errorf("--check and --reconcile are mutually exclusive with --password and --force. They may only be run on an existing user")
+	}
+
 	cfg, err := setupuser.GenerateConfig(ctx, setupUserOptions.fileName)
 	if err != nil {
 		return err
@@ -61,6 +76,17 @@ func (setupUserOptions *vSphereSetupUserOptions) setupUser(cmd *cobra.Command, _
 	}
 	defer close(ctx, deps)
 
+	if setupUserOptions.check {
+		return checkRoles(ctx, cfg, deps.Govc)
+	}
+
+	if setupUserOptions.reconcile {
+		if err = setupuser.ValidateVSphereObjects(ctx, cfg, deps.Govc); err != nil {
+			return err
+		}
+		return setupuser.Reconcile(ctx, cfg, deps.Govc)
+	}
+
 	// when using the force flag we assume the user already exists
 	if !setupUserOptions.force {
 		err = deps.Govc.CreateUser(ctx, cfg.Spec.Username, setupUserOptions.password)
@@ -80,3 +106,27 @@ func (setupUserOptions *vSphereSetupUserOptions) setupUser(cmd *cobra.Command, _
 
 	return nil
 }
+
+func checkRoles(ctx context.Context, cfg *setupuser.VSphereSetupUserConfig, govc setupuser.GovcClient) error {
+	drifts, err := setupuser.Check(ctx, cfg, govc)
+	if err != nil {
+		return err
+	}
+
+	if len(drifts) == 0 {
+		logger.MarkPass("All roles have the vSphere privileges EKS Anywhere needs")
+		return nil
+	}
+
+	roles := make([]string, 0, len(drifts))
+	for _, d := range drifts {
+		if d.Exists {
+			logger.MarkFail(fmt.Sprintf("Role %s is missing privileges: %s", d.Role, strings.Join(d.MissingPrivileges, ", ")))
+		} else {
+			logger.MarkFail(fmt.Sprintf("Role %s doesn't exist", d.Role))
+		}
+		roles = append(roles, d.Role)
+	}
+
+	return fmt.Errorf("roles %s are missing vSphere privileges, run with --reconcile to add them", strings.Join(roles, ", "))
+}
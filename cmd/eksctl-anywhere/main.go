@@ -5,6 +5,8 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	// The images don't ship the time zone database the cluster maintenance windows need, so it's embedded.
+	_ "time/tzdata"
 
 	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd"
 	"github.com/aws/eks-anywhere/pkg/eksctl"
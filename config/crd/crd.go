@@ -0,0 +1,9 @@
+// Package crd embeds the EKS Anywhere CRDs generated by controller-gen.
+package crd
+
+import "embed"
+
+// Bases contains the CRD manifests, one CRD per file.
+//
+//go:embed bases/*.yaml
+var Bases embed.FS
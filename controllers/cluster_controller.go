@@ -29,6 +29,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
 	"github.com/aws/eks-anywhere/pkg/controller/handlers"
+	"github.com/aws/eks-anywhere/pkg/controller/metrics"
 	"github.com/aws/eks-anywhere/pkg/controller/serverside"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
@@ -54,6 +55,7 @@ type ClusterReconciler struct {
 	packagesClient             PackagesClient
 	machineHealthCheck         MachineHealthCheckReconciler
 	vSpherefailureDomainMover  FailureDomainApplier
+	remoteClientRegistry       clusters.RemoteClientRegistry
 }
 
 // PackagesClient handles curated packages operations from within the cluster
@@ -89,6 +91,14 @@ type ClusterValidator interface {
 // ClusterReconcilerOption allows to configure the ClusterReconciler.
 type ClusterReconcilerOption func(*ClusterReconciler)
 
+// WithRemoteClientRegistry sets the registry of workload cluster clients, used to re-encrypt
+// the workload cluster resources when rotating the etcd encryption key.
+func WithRemoteClientRegistry(registry clusters.RemoteClientRegistry) ClusterReconcilerOption {
+	return func(r *ClusterReconciler) {
+		r.remoteClientRegistry = registry
+	}
+}
+
 // SpecBuilder builds a cluster specification from an EKS Anywhere Cluster object.
 type SpecBuilder interface {
 	BuildSpec(ctx context.Context, eksaCluster *anywherev1.Cluster) (*c.Spec, error)
@@ -306,10 +316,18 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	}
 
 	defer func() {
+		statusStart := time.Now()
 		err := r.updateStatus(ctx, log, cluster)
 		if err != nil {
 			reterr = kerrors.NewAggregate([]error{reterr, err})
 		}
+		metrics.ObserveReconcilePhase(metrics.PhaseStatus, statusStart)
+
+		if !cluster.DeletionTimestamp.IsZero() && len(cluster.GetFinalizers()) == 0 {
+			metrics.DeleteCluster(cluster)
+		} else {
+			metrics.RecordClusterStatus(cluster)
+		}
 
 		// Always attempt to patch the object and status after each reconciliation.
 		patchOpts := []v1beta1patch.Option{}
@@ -333,6 +351,19 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		if reterr == nil && !result.Requeue && result.RequeueAfter <= 0 && v1beta1conditions.IsFalse(cluster, anywherev1.ReadyCondition) {
 			result = ctrl.Result{RequeueAfter: 10 * time.Second}
 		}
+
+		// With automatic certificate renewal, we need to check the certificates periodically
+		// even if nothing changes in the cluster. A later requeue, like the one that holds changes until
+		// the next maintenance window, is shortened so the certificates are never left unchecked longer.
+		if reterr == nil && !result.Requeue && cluster.Spec.CertificateRenewal != nil {
+			result = requeueWithin(result, clusters.CertificateRenewalCheckInterval)
+		}
+
+		// The etcd encryption key rotation moves forward when the control plane is rolled out, which doesn't
+		// always trigger a reconciliation of the cluster.
+		if reterr == nil && !result.Requeue && cluster.EtcdEncryptionKeyRotationInProgress() {
+			result = requeueWithin(result, clusters.EtcdEncryptionKeyRotationCheckInterval)
+		}
 	}()
 
 	if !cluster.DeletionTimestamp.IsZero() {
@@ -364,11 +395,55 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	}
 
 	aggregatedGeneration := aggregatedGeneration(config)
+	specChanged := aggregatedGeneration != cluster.Status.ChildrenReconciledGeneration || cluster.Status.ReconciledGeneration != cluster.Generation
+
+	certificateRenewalPending, err := clusters.CertificateRenewalPending(ctx, r.client, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// Certificates about to expire are renewed even outside the maintenance window, since holding the renewal until
+	// the next window could let them expire. An upgrade pause still holds it, since it's an explicit user request.
+	urgentRenewal := certificateRenewalPending && !cluster.UpgradePaused() && clusters.CertificateRenewalUrgent(cluster)
+	pending := clusters.PendingRollout{
+		Changes:            specChanged || cluster.EtcdEncryptionKeyRotationInProgress(),
+		CertificateRenewal: certificateRenewalPending && !urgentRenewal,
+	}
+
+	// The upgrade schedule runs on every reconciliation, not only on spec changes, since pausing the upgrade
+	// also stops the rollouts already in progress. Outside the maintenance window, any change that rolls out
+	// machines is held, so it must run before the certificate renewal and the etcd encryption key rotation.
+	scheduleResult, err := clusters.ReconcileUpgradeSchedule(ctx, log, r.client, cluster, pending, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if scheduleResult.Return() && !urgentRenewal {
+		return scheduleResult.ToCtrlResult(), nil
+	}
+
+	// Certificate renewal runs on every reconciliation, not only on spec changes, since it's driven
+	// by the passage of time.
+	renewalResult, err := clusters.ReconcileCertificateRenewal(ctx, log, r.client, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if renewalResult.Return() {
+		return renewalResult.ToCtrlResult(), nil
+	}
+	if scheduleResult.Return() {
+		return scheduleResult.ToCtrlResult(), nil
+	}
+
+	// The etcd encryption key rotation is driven by the status, so it needs to run on every reconciliation
+	// and before the provider reconciler, which renders the encryption providers from the status.
+	if err := clusters.ReconcileEtcdEncryption(ctx, log, r.client, r.remoteClientRegistry, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	// If there is no difference between the aggregated generation and childrenReconciledGeneration,
 	// and there is no difference in the reconciled generation and .metadata.generation of the cluster,
-	// then return without any further processing.
-	if aggregatedGeneration == cluster.Status.ChildrenReconciledGeneration && cluster.Status.ReconciledGeneration == cluster.Generation {
+	// then return without any further processing. An etcd encryption key rotation changes the control plane
+	// without changing the spec, so we always reconcile while it's in progress.
+	if !specChanged && !cluster.EtcdEncryptionKeyRotationInProgress() {
 		log.Info("Generation and aggregated generation match reconciled generations for cluster and child objects, skipping reconciliation.")
 
 		// Failure messages are cleared in the reconciler loop after running validations. But sometimes,
@@ -395,7 +470,9 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 	var reconcileResult controller.Result
 	var err error
 
+	phaseStart := time.Now()
 	reconcileResult, err = r.preClusterProviderReconcile(ctx, log, cluster)
+	metrics.ObserveReconcilePhase(metrics.PhasePreProvider, phaseStart)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -404,7 +481,9 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 		return reconcileResult.ToCtrlResult(), nil
 	}
 
+	phaseStart = time.Now()
 	reconcileResult, err = clusterProviderReconciler.Reconcile(ctx, log, cluster)
+	metrics.ObserveReconcilePhase(metrics.PhaseProvider, phaseStart)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -413,7 +492,9 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 		return reconcileResult.ToCtrlResult(), nil
 	}
 
+	phaseStart = time.Now()
 	reconcileResult, err = r.postClusterProviderReconcile(ctx, log, cluster)
+	metrics.ObserveReconcilePhase(metrics.PhasePostProvider, phaseStart)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -433,7 +514,9 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 	// packages reconcile is still in progress.
 	// Moving the packages reconcile after the above two generation fields are set, so that packages reconcile error
 	// does not cause side effect of rolling out of workload cluster machines during management cluster upgrade.
+	phaseStart = time.Now()
 	reconcileResult, err = r.packagesReconcile(ctx, log, cluster)
+	metrics.ObserveReconcilePhase(metrics.PhasePackages, phaseStart)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -549,6 +632,10 @@ func (r *ClusterReconciler) updateStatus(ctx context.Context, log logr.Logger, c
 		return errors.Wrap(err, "updating cluster certificate status for cluster")
 	}
 
+	if err := clusters.UpdateClusterStatusForGitOpsDrift(ctx, r.client, cluster); err != nil {
+		return errors.Wrap(err, "updating status for gitops drift")
+	}
+
 	summarizedConditionTypes := []anywherev1.ConditionType{
 		anywherev1.ControlPlaneInitializedCondition,
 		anywherev1.ControlPlaneReadyCondition,
@@ -562,6 +649,11 @@ func (r *ClusterReconciler) updateStatus(ctx context.Context, log logr.Logger, c
 		summarizedConditionTypes = append(summarizedConditionTypes, anywherev1.DefaultCNIConfiguredCondition)
 	}
 
+	// The cluster is not ready until the etcd encryption key rotation completes.
+	if v1beta1conditions.IsFalse(cluster, anywherev1.EtcdEncryptionKeyRotatedCondition) {
+		summarizedConditionTypes = append(summarizedConditionTypes, anywherev1.EtcdEncryptionKeyRotatedCondition)
+	}
+
 	// Always update the readyCondition by summarizing the state of other conditions.
 	v1beta1conditions.SetSummary(cluster,
 		v1beta1conditions.WithConditions(summarizedConditionTypes...),
@@ -614,6 +706,15 @@ func (r *ClusterReconciler) isOwnedByCluster(obj client.Object, cluster *anywher
 	return false
 }
 
+// requeueWithin makes sure the cluster is reconciled again within the interval, keeping an earlier requeue if
+// the result already has one.
+func requeueWithin(result ctrl.Result, interval time.Duration) ctrl.Result {
+	if result.RequeueAfter <= 0 || result.RequeueAfter > interval {
+		result.RequeueAfter = interval
+	}
+	return result
+}
+
 func (r *ClusterReconciler) reconcileDelete(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (ctrl.Result, error) {
 	if cluster.IsSelfManaged() && !cluster.IsManagedByCLI() {
 		return ctrl.Result{}, errors.New("deleting self-managed clusters is not supported")
@@ -649,6 +750,12 @@ func (r *ClusterReconciler) reconcileDelete(ctx context.Context, log logr.Logger
 	case apierrors.IsNotFound(err):
 		log.Info("Deleting EKS Anywhere cluster", "name", capiCluster.Name, "cluster.DeletionTimestamp", cluster.DeletionTimestamp, "finalizer", cluster.Finalizers)
 
+		if providerReconciler, ok := r.providerReconcilerRegistry.Get(cluster.Spec.DatacenterRef.Kind).(clusters.ProviderClusterDeleteReconciler); ok {
+			if err := providerReconciler.ReconcileDelete(ctx, log, cluster); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
 		// TODO delete GitOps,Datacenter and MachineConfig objects
 		controllerutil.RemoveFinalizer(cluster, ClusterFinalizerName)
 	default:
@@ -741,6 +848,8 @@ func patchCluster(ctx context.Context, patchHelper *v1beta1patch.Helper, cluster
 			anywherev1.ControlPlaneReadyCondition,
 			anywherev1.WorkersReadyCondition,
 			anywherev1.DefaultCNIConfiguredCondition,
+			anywherev1.EtcdEncryptionKeyRotatedCondition,
+			anywherev1.UpgradeAllowedCondition,
 		}},
 	}, patchOpts...)
 
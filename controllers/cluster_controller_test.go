@@ -129,6 +129,7 @@ func newVsphereClusterReconcilerTest(t *testing.T, objs ...runtime.Object) *vsph
 		cniReconciler,
 		nil,
 		ipValidator,
+		vcb,
 	)
 	registry := clusters.NewProviderClusterReconcilerRegistryBuilder().
 		Add(anywherev1.VSphereDatacenterKind, reconciler).
@@ -170,6 +171,7 @@ func newVsphereClusterReconcilerWithFailureDomainsTest(t *testing.T, cl client.C
 		cniReconciler,
 		nil,
 		ipValidator,
+		vcb,
 	)
 	registry := clusters.NewProviderClusterReconcilerRegistryBuilder().
 		Add(anywherev1.VSphereDatacenterKind, reconciler).
@@ -896,9 +898,13 @@ func TestClusterReconcilerReconcileGenerations(t *testing.T) {
 		workerMachineConfigGeneration int64
 		oidcGeneration                int64
 		awsIAMGeneration              int64
+		etcdEncryptionKeyRotation     bool
+		certificateRenewal            bool
+		maintenanceWindowClosed       bool
 
 		wantReconciliation            bool
 		wantChildReconciledGeneration int64
+		wantResult                    ctrl.Result
 	}{
 		{
 			testName:                      "matching generation, matching aggregated generation",
@@ -952,6 +958,68 @@ func TestClusterReconcilerReconcileGenerations(t *testing.T) {
 			wantReconciliation:            true,
 			wantChildReconciledGeneration: 14,
 		},
+		{
+			testName:                      "matching generation, matching aggregated generation, etcd encryption key rotation in progress",
+			clusterGeneration:             2,
+			reconciledGeneration:          2,
+			childReconciledGeneration:     12,
+			datacenterGeneration:          1,
+			cpMachineConfigGeneration:     2,
+			workerMachineConfigGeneration: 5,
+			oidcGeneration:                3,
+			awsIAMGeneration:              1,
+			etcdEncryptionKeyRotation:     true,
+			wantReconciliation:            true,
+			wantChildReconciledGeneration: 12,
+			wantResult:                    ctrl.Result{RequeueAfter: clusters.EtcdEncryptionKeyRotationCheckInterval},
+		},
+		{
+			testName:                      "etcd encryption key rotation in progress with certificate renewal",
+			clusterGeneration:             2,
+			reconciledGeneration:          2,
+			childReconciledGeneration:     12,
+			datacenterGeneration:          1,
+			cpMachineConfigGeneration:     2,
+			workerMachineConfigGeneration: 5,
+			oidcGeneration:                3,
+			awsIAMGeneration:              1,
+			etcdEncryptionKeyRotation:     true,
+			certificateRenewal:            true,
+			wantReconciliation:            true,
+			wantChildReconciledGeneration: 12,
+			wantResult:                    ctrl.Result{RequeueAfter: clusters.EtcdEncryptionKeyRotationCheckInterval},
+		},
+		{
+			testName:                      "etcd encryption key rotation in progress outside maintenance window",
+			clusterGeneration:             2,
+			reconciledGeneration:          2,
+			childReconciledGeneration:     12,
+			datacenterGeneration:          1,
+			cpMachineConfigGeneration:     2,
+			workerMachineConfigGeneration: 5,
+			oidcGeneration:                3,
+			awsIAMGeneration:              1,
+			etcdEncryptionKeyRotation:     true,
+			maintenanceWindowClosed:       true,
+			wantReconciliation:            false,
+			wantChildReconciledGeneration: 12,
+			wantResult:                    ctrl.Result{RequeueAfter: clusters.EtcdEncryptionKeyRotationCheckInterval},
+		},
+		{
+			testName:                      "matching generation, matching aggregated generation, certificate renewal",
+			clusterGeneration:             2,
+			reconciledGeneration:          2,
+			childReconciledGeneration:     12,
+			datacenterGeneration:          1,
+			cpMachineConfigGeneration:     2,
+			workerMachineConfigGeneration: 5,
+			oidcGeneration:                3,
+			awsIAMGeneration:              1,
+			certificateRenewal:            true,
+			wantReconciliation:            false,
+			wantChildReconciledGeneration: 12,
+			wantResult:                    ctrl.Result{RequeueAfter: clusters.CertificateRenewalCheckInterval},
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -963,6 +1031,34 @@ func TestClusterReconcilerReconcileGenerations(t *testing.T) {
 			config.Cluster.Status.ReconciledGeneration = tt.reconciledGeneration
 			config.Cluster.Status.ReconciledGeneration = tt.reconciledGeneration
 			config.Cluster.Status.ChildrenReconciledGeneration = tt.childReconciledGeneration
+			if tt.certificateRenewal {
+				config.Cluster.Spec.CertificateRenewal = &anywherev1.CertificateRenewal{ThresholdDays: 30}
+			}
+			if tt.maintenanceWindowClosed {
+				// February 30th never comes, so there is no upcoming window.
+				config.Cluster.Spec.MaintenanceWindow = &anywherev1.MaintenanceWindowConfiguration{
+					Windows: []anywherev1.MaintenanceWindow{
+						{Schedule: "0 2 30 2 *", Duration: metav1.Duration{Duration: time.Hour}},
+					},
+				}
+			}
+			if tt.etcdEncryptionKeyRotation {
+				provider := anywherev1.EtcdEncryptionProvider{
+					KMS: &anywherev1.KMS{
+						Name:                "kms-2",
+						SocketListenAddress: "unix:///var/run/kmsplugin/kms-2.sock",
+						CacheSize:           anywherev1.DefaultKMSCacheSize,
+						Timeout:             &anywherev1.DefaultKMSTimeout,
+					},
+				}
+				config.Cluster.Spec.EtcdEncryption = &[]anywherev1.EtcdEncryption{
+					{Providers: []anywherev1.EtcdEncryptionProvider{provider}, Resources: []string{"secrets"}},
+				}
+				config.Cluster.Status.EtcdEncryption = &anywherev1.EtcdEncryptionStatus{
+					Providers:        []anywherev1.EtcdEncryptionProvider{provider},
+					KeyRotationPhase: anywherev1.EtcdEncryptionRemovingProvider,
+				}
+			}
 
 			config.VSphereDatacenter.Generation = tt.datacenterGeneration
 			cpMachine := config.VSphereMachineConfigs[config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
@@ -1020,11 +1116,17 @@ func TestClusterReconcilerReconcileGenerations(t *testing.T) {
 
 			result, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
 			g.Expect(err).ToNot(HaveOccurred())
-			g.Expect(result).To(Equal(ctrl.Result{}))
+			g.Expect(result).To(Equal(tt.wantResult))
 
 			api := envtest.NewAPIExpecter(t, client)
 			c := envtest.CloneNameNamespace(config.Cluster)
 			api.ShouldEventuallyMatch(ctx, c, func(g Gomega) {
+				if tt.maintenanceWindowClosed {
+					g.Expect(c.Status.EtcdEncryption.KeyRotationPhase).To(Equal(anywherev1.EtcdEncryptionRemovingProvider))
+					g.Expect(v1beta1conditions.GetReason(c, anywherev1.UpgradeAllowedCondition)).To(Equal(anywherev1.OutsideMaintenanceWindowReason))
+					// Held changes don't make the cluster not ready.
+					g.Expect(v1beta1conditions.GetReason(c, anywherev1.ReadyCondition)).NotTo(Equal(anywherev1.OutsideMaintenanceWindowReason))
+				}
 				g.Expect(c.Status.ReconciledGeneration).To(
 					Equal(c.Generation), "status generation should have been updated to the metadata generation's value",
 				)
@@ -1037,6 +1139,118 @@ func TestClusterReconcilerReconcileGenerations(t *testing.T) {
 	}
 }
 
+func TestClusterReconcilerReconcileUrgentCertificateRenewalOutsideMaintenanceWindow(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Generation = 2
+	config.Cluster.Status.ReconciledGeneration = 2
+	config.Cluster.Status.ChildrenReconciledGeneration = 12
+	config.VSphereDatacenter.Generation = 1
+	config.VSphereMachineConfigs[config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Generation = 2
+	config.VSphereMachineConfigs[config.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name].Generation = 5
+	for _, oidc := range config.OIDCConfigs {
+		oidc.Generation = 3
+	}
+	for _, awsIAM := range config.AWSIAMConfigs {
+		awsIAM.Generation = 1
+	}
+	config.Cluster.Spec.CertificateRenewal = &anywherev1.CertificateRenewal{ThresholdDays: 30}
+	// February 30th never comes, so there is no upcoming window.
+	config.Cluster.Spec.MaintenanceWindow = &anywherev1.MaintenanceWindowConfiguration{
+		Windows: []anywherev1.MaintenanceWindow{
+			{Schedule: "0 2 30 2 *", Duration: metav1.Duration{Duration: time.Hour}},
+		},
+	}
+	v1beta1conditions.MarkTrue(config.Cluster, anywherev1.ReadyCondition)
+	config.Cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "test-cluster-cp-1", ExpiresInDays: clusters.CertificateRenewalUrgentDays},
+	}
+
+	kcp := testKubeadmControlPlaneFromCluster(config.Cluster)
+	capiCluster := newCAPICluster(config.Cluster.Name, constants.EksaSystemNamespace)
+	machine := &clusterv1beta2.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster-cp-1",
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.MachineControlPlaneLabel: ""},
+		},
+	}
+
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), kcp, capiCluster, machine}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+	for _, md := range machineDeploymentsFromCluster(config.Cluster) {
+		objs = append(objs, md.DeepCopy())
+	}
+
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).WithStatusSubresource(config.Cluster).Build()
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	r := controllers.NewClusterReconciler(client, newRegistryMock(providerReconciler), mocks.NewMockAWSIamConfigReconciler(mockCtrl),
+		mocks.NewMockClusterValidator(mockCtrl), mocks.NewMockPackagesClient(mockCtrl), mocks.NewMockMachineHealthCheckReconciler(mockCtrl), nil)
+
+	_, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The certificates are renewed even if the maintenance window never opens.
+	api := envtest.NewAPIExpecter(t, client)
+	api.ShouldEventuallyMatch(ctx, kcp, func(g Gomega) {
+		g.Expect(kcp.Spec.Rollout.After.IsZero()).To(BeFalse())
+	})
+}
+
+func TestClusterReconcilerReconcileCertificateRenewalCheckOutsideMaintenanceWindow(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Generation = 3
+	config.Cluster.Status.ReconciledGeneration = 2
+	config.Cluster.Status.ChildrenReconciledGeneration = 12
+	config.Cluster.Spec.CertificateRenewal = &anywherev1.CertificateRenewal{ThresholdDays: 30}
+	nextWindow := time.Now().UTC().Add(10 * 24 * time.Hour)
+	config.Cluster.Spec.MaintenanceWindow = &anywherev1.MaintenanceWindowConfiguration{
+		Windows: []anywherev1.MaintenanceWindow{
+			{
+				Schedule: fmt.Sprintf("%d %d %d %d *", nextWindow.Minute(), nextWindow.Hour(), nextWindow.Day(), nextWindow.Month()),
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	v1beta1conditions.MarkTrue(config.Cluster, anywherev1.ReadyCondition)
+	config.Cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "test-cluster-cp-1", ExpiresInDays: 100},
+	}
+
+	kcp := testKubeadmControlPlaneFromCluster(config.Cluster)
+	capiCluster := newCAPICluster(config.Cluster.Name, constants.EksaSystemNamespace)
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), kcp, capiCluster}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+	for _, md := range machineDeploymentsFromCluster(config.Cluster) {
+		objs = append(objs, md.DeepCopy())
+	}
+
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).WithStatusSubresource(config.Cluster).Build()
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	r := controllers.NewClusterReconciler(client, newRegistryMock(providerReconciler), mocks.NewMockAWSIamConfigReconciler(mockCtrl),
+		mocks.NewMockClusterValidator(mockCtrl), mocks.NewMockPackagesClient(mockCtrl), mocks.NewMockMachineHealthCheckReconciler(mockCtrl), nil)
+
+	// The changes are held for days, but the certificates are still checked within the renewal check interval.
+	result, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: clusters.CertificateRenewalCheckInterval}))
+}
+
 func TestClusterReconcilerReconcilePausedCluster(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -1288,6 +1502,72 @@ func TestClusterReconcilerDeleteNoCAPIClusterSuccess(t *testing.T) {
 	g.Expect(apierrors.IsNotFound(tt.client.Get(context.TODO(), req.NamespacedName, &anywherev1.Cluster{}))).To(BeTrue())
 }
 
+type deleteProviderReconciler struct {
+	dummyProviderReconciler
+	err     error
+	deleted bool
+}
+
+func (d *deleteProviderReconciler) ReconcileDelete(_ context.Context, _ logr.Logger, _ *anywherev1.Cluster) error {
+	d.deleted = true
+	return d.err
+}
+
+func deletedWorkloadCluster() *anywherev1.Cluster {
+	deleteTimestamp := metav1.NewTime(time.Now())
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "my-cluster",
+			Namespace:         "default",
+			DeletionTimestamp: &deleteTimestamp,
+			Finalizers:        []string{controllers.ClusterFinalizerName},
+		},
+		Spec: anywherev1.ClusterSpec{
+			ManagementCluster: anywherev1.ManagementCluster{Name: "my-management-cluster"},
+			DatacenterRef:     anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: "my-datacenter"},
+		},
+	}
+}
+
+func TestClusterReconcilerDeleteProviderResources(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := deletedWorkloadCluster()
+
+	controller := gomock.NewController(t)
+	providerReconciler := &deleteProviderReconciler{}
+	mockPkgs := mocks.NewMockPackagesClient(controller)
+	mockPkgs.EXPECT().ReconcileDelete(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	c := fake.NewClientBuilder().WithRuntimeObjects(cluster).
+		WithStatusSubresource(cluster).
+		Build()
+
+	r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler), nil, nil, mockPkgs, nil, nil)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(providerReconciler.deleted).To(BeTrue())
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(cluster), &anywherev1.Cluster{}))).To(BeTrue())
+}
+
+func TestClusterReconcilerDeleteProviderResourcesError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := deletedWorkloadCluster()
+
+	providerReconciler := &deleteProviderReconciler{err: errors.New("deleting anti-affinity rules")}
+	c := fake.NewClientBuilder().WithRuntimeObjects(cluster).
+		WithStatusSubresource(cluster).
+		Build()
+
+	r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler), nil, nil, nil, nil, nil)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).To(MatchError(ContainSubstring("deleting anti-affinity rules")))
+
+	api := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), api)).To(Succeed())
+	g.Expect(api.Finalizers).To(ContainElement(controllers.ClusterFinalizerName))
+}
+
 func TestClusterReconcilerFailureDomainCreation(t *testing.T) {
 	g := NewWithT(t)
 	features.ClearCache()
@@ -44,6 +44,7 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/metrics"
 	eksasemver "github.com/aws/eks-anywhere/pkg/semver"
 )
 
@@ -97,12 +98,18 @@ func (r *ControlPlaneUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
+	alreadyReady := cpUpgrade.Status.Ready
 	defer func() {
 		err := r.updateStatus(ctx, log, cpUpgrade)
 		if err != nil {
 			reterr = kerrors.NewAggregate([]error{reterr, err})
 		}
 
+		// The KubeadmControlPlane has the same name as the cluster.
+		if !alreadyReady && cpUpgrade.Status.Ready {
+			metrics.ObserveUpgrade(cpUpgrade.Spec.ControlPlane.Namespace, cpUpgrade.Spec.ControlPlane.Name, metrics.UpgradeKindControlPlane, cpUpgrade.CreationTimestamp)
+		}
+
 		// Always attempt to patch the object and status after each reconciliation.
 		patchOpts := []patch.Option{}
 
@@ -145,6 +152,7 @@ func (r *ControlPlaneUpgradeReconciler) SetupWithManager(mgr ctrl.Manager) error
 
 func (r *ControlPlaneUpgradeReconciler) reconcile(ctx context.Context, log logr.Logger, cpUpgrade *anywherev1.ControlPlaneUpgrade) (ctrl.Result, error) {
 	var firstControlPlane bool
+	cpUpgrade.Status.Paused = false
 	rClient, err := r.remoteClientRegistry.GetClient(ctx, GetNamespacedNameType(cpUpgrade.Spec.ControlPlane.Name, cpUpgrade.Spec.ControlPlane.Namespace))
 	if err != nil {
 		return ctrl.Result{}, err
@@ -173,6 +181,16 @@ func (r *ControlPlaneUpgradeReconciler) reconcile(ctx context.Context, log logr.
 		nodeUpgrade := nodeUpgrader(machineRef, cpUpgrade.Spec.KubernetesVersion, cpUpgrade.Spec.EtcdVersion, firstControlPlane)
 		if err := r.client.Get(ctx, GetNamespacedNameType(nodeUpgraderName(machineRef.Name), constants.EksaSystemNamespace), nodeUpgrade); err != nil {
 			if apierrors.IsNotFound(err) {
+				// The KubeadmControlPlane has the same name as the cluster.
+				paused, err := nodeUpgradesPaused(ctx, r.client, GetNamespacedNameType(cpUpgrade.Spec.ControlPlane.Name, cpUpgrade.Spec.ControlPlane.Namespace))
+				if err != nil {
+					return ctrl.Result{}, err
+				}
+				if paused {
+					log.Info("Cluster upgrade is paused, holding upgrade of the next control plane node", "Machine", machineRef.Name)
+					cpUpgrade.Status.Paused = true
+					return ctrl.Result{}, nil
+				}
 				if err := r.client.Create(ctx, nodeUpgrade); client.IgnoreAlreadyExists(err) != nil {
 					return ctrl.Result{}, fmt.Errorf("failed to create node upgrader for machine %s:  %v", machineRef.Name, err)
 				}
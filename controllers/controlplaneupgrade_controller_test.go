@@ -12,6 +12,7 @@ import (
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -212,6 +213,47 @@ func TestCPUpgradeReconcileNodeUpgraderCreate(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestCPUpgradeReconcileNodeUpgraderPaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+	testObjs := getObjectsForCPUpgradeTest()
+	cluster := generateEKSAClusterFor(testObjs.cluster)
+	cluster.PauseUpgrade()
+	testObjs.cpUpgrade.Spec.ControlPlane.Name = testObjs.cluster.Name
+	testObjs.nodeUpgrades[0].Name = fmt.Sprintf("%s-node-upgrader", testObjs.machines[0].Name)
+	testObjs.nodeUpgrades[0].Status = anywherev1.NodeUpgradeStatus{
+		Completed: true,
+	}
+	objs := []runtime.Object{
+		testObjs.cluster, cluster, testObjs.machines[0], testObjs.machines[1], testObjs.nodes[0], testObjs.nodes[1], testObjs.cpUpgrade,
+		testObjs.nodeUpgrades[0], testObjs.kubeadmConfigs[0], testObjs.kubeadmConfigs[1], testObjs.infraMachines[0], testObjs.infraMachines[1],
+	}
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).WithObjects(tinkerbellMachineCRD()).
+		WithStatusSubresource(testObjs.cpUpgrade).
+		Build()
+	kcp := testObjs.cpUpgrade.Spec.ControlPlane
+	clientRegistry.EXPECT().GetClient(ctx, types.NamespacedName{Name: kcp.Name, Namespace: kcp.Namespace}).Return(client, nil)
+
+	r := controllers.NewControlPlaneUpgradeReconciler(client, clientRegistry)
+	req := cpUpgradeRequest(testObjs.cpUpgrade)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	n := &anywherev1.NodeUpgrade{}
+	nodeUpgradeName := fmt.Sprintf("%s-node-upgrader", testObjs.machines[1].Name)
+	err = client.Get(ctx, types.NamespacedName{Name: nodeUpgradeName, Namespace: constants.EksaSystemNamespace}, n)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	cpu := &anywherev1.ControlPlaneUpgrade{}
+	err = client.Get(ctx, types.NamespacedName{Name: testObjs.cpUpgrade.Name, Namespace: constants.EksaSystemNamespace}, cpu)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cpu.Status.Paused).To(BeTrue())
+	g.Expect(cpu.Status.Upgraded).To(BeEquivalentTo(1))
+	g.Expect(cpu.Status.RequireUpgrade).To(BeEquivalentTo(1))
+}
+
 func TestCPUpgradeReconcileNodeUpgraderInvalidKCPSpec(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
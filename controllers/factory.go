@@ -23,6 +23,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/executables/cmk"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/helm"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	ciliumreconciler "github.com/aws/eks-anywhere/pkg/networking/cilium/reconciler"
@@ -137,7 +138,7 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider, o
 			f.packageControllerClient,
 			f.machineHealthCheckReconciler,
 			NewFailureDomainMover(f.manager.GetClient()),
-			opts...,
+			append([]ClusterReconcilerOption{WithRemoteClientRegistry(f.tracker)}, opts...)...,
 		)
 
 		return nil
@@ -397,6 +398,7 @@ func (f *Factory) withVSphereClusterReconciler() *Factory {
 			f.cniReconciler,
 			f.tracker,
 			f.ipValidator,
+			govmomi.NewVMOMIClientBuilder(),
 		)
 		f.registryBuilder.Add(anywherev1.VSphereDatacenterKind, f.vsphereClusterReconciler)
 
@@ -154,6 +154,20 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, log logr.
 	}
 
 	if apierrors.IsNotFound(cpuGetErr) {
+		// The KubeadmControlPlane has the same name as the cluster.
+		cluster, err := eksaClusterForCAPICluster(ctx, r.client, GetNamespacedNameType(kcp.ObjectMeta.Name, kcp.ObjectMeta.Namespace))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		held, err := inPlaceUpgradeHeld(cluster, time.Now())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if held != "" {
+			log.Info("Holding control plane in place upgrade", "reason", held)
+			return ctrl.Result{}, nil
+		}
+
 		log.Info("Creating ControlPlaneUpgrade object")
 		machines, err := r.machinesToUpgrade(ctx, kcp)
 		if err != nil {
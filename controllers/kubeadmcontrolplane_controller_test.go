@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/aws/eks-anywhere/controllers"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
 )
 
@@ -200,6 +202,56 @@ func TestKCPReconcileFullFlow(t *testing.T) {
 	g.Expect(mhc.Annotations).ToNot(HaveKey(capiPausedAnnotation))
 }
 
+func TestKCPReconcileHeldUpgradePaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	kcpObjs := getObjectsForKCP()
+	capiCluster := generateCluster()
+	cluster := generateEKSAClusterFor(capiCluster)
+	cluster.PauseUpgrade()
+
+	runtimeObjs := []runtime.Object{kcpObjs.machines[0], kcpObjs.machines[1], kcpObjs.kcp, kcpObjs.mhc, capiCluster, cluster}
+	client := fake.NewClientBuilder().WithRuntimeObjects(runtimeObjs...).Build()
+	r := controllers.NewKubeadmControlPlaneReconciler(client, client)
+	req := kcpRequest(kcpObjs.kcp)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cpu := &anywherev1.ControlPlaneUpgrade{}
+	err = client.Get(ctx, types.NamespacedName{Name: kcpObjs.cpUpgrade.Name, Namespace: constants.EksaSystemNamespace}, cpu)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	mhc := &clusterv1beta2.MachineHealthCheck{}
+	err = client.Get(ctx, types.NamespacedName{Name: kcpObjs.mhc.Name, Namespace: constants.EksaSystemNamespace}, mhc)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mhc.Annotations).ToNot(HaveKey(capiPausedAnnotation))
+}
+
+func TestKCPReconcileHeldOutsideMaintenanceWindow(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	kcpObjs := getObjectsForKCP()
+	capiCluster := generateCluster()
+	cluster := generateEKSAClusterFor(capiCluster)
+	cluster.Spec.MaintenanceWindow = &anywherev1.MaintenanceWindowConfiguration{
+		Windows: []anywherev1.MaintenanceWindow{
+			// The 30th of February never comes.
+			{Schedule: "0 0 30 2 *", Duration: metav1.Duration{Duration: time.Hour}},
+		},
+	}
+
+	runtimeObjs := []runtime.Object{kcpObjs.machines[0], kcpObjs.machines[1], kcpObjs.kcp, kcpObjs.mhc, capiCluster, cluster}
+	client := fake.NewClientBuilder().WithRuntimeObjects(runtimeObjs...).Build()
+	r := controllers.NewKubeadmControlPlaneReconciler(client, client)
+	req := kcpRequest(kcpObjs.kcp)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cpu := &anywherev1.ControlPlaneUpgrade{}
+	err = client.Get(ctx, types.NamespacedName{Name: kcpObjs.cpUpgrade.Name, Namespace: constants.EksaSystemNamespace}, cpu)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
 func TestKCPReconcileNotFound(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -296,6 +348,21 @@ func getObjectsForKCP() kcpObjects {
 	}
 }
 
+// generateEKSAClusterFor returns the EKS-A Cluster for a CAPI cluster, labeling the CAPI cluster with its name and namespace.
+func generateEKSAClusterFor(capiCluster *clusterv1beta2.Cluster) *anywherev1.Cluster {
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capiCluster.Name,
+			Namespace: "default",
+		},
+	}
+	capiCluster.Labels = map[string]string{
+		clusterapi.EKSAClusterLabelName:      cluster.Name,
+		clusterapi.EKSAClusterLabelNamespace: cluster.Namespace,
+	}
+	return cluster
+}
+
 func kcpRequest(kcp *controlplanev1beta2.KubeadmControlPlane) reconcile.Request {
 	return reconcile.Request{
 		NamespacedName: types.NamespacedName{
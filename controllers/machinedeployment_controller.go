@@ -165,6 +165,19 @@ func (r *MachineDeploymentReconciler) reconcile(ctx context.Context, log logr.Lo
 	}
 
 	if apierrors.IsNotFound(mduGetErr) {
+		cluster, err := eksaClusterForCAPICluster(ctx, r.client, GetNamespacedNameType(md.Spec.ClusterName, md.ObjectMeta.Namespace))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		held, err := inPlaceUpgradeHeld(cluster, time.Now())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if held != "" {
+			log.Info("Holding machine deployment in place upgrade", "reason", held)
+			return ctrl.Result{}, nil
+		}
+
 		log.Info("Creating MachineDeploymentUpgrade object")
 		mdUpgrade, err := machineDeploymentUpgrade(md, machineRefList)
 		if err != nil {
@@ -198,6 +198,31 @@ func TestMDReconcileFullFlow(t *testing.T) {
 	g.Expect(mhc.Annotations).ToNot(HaveKey(capiPausedAnnotation))
 }
 
+func TestMDReconcileHeldUpgradePaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mdObjs := getObjectsForMD()
+	capiCluster := generateCluster()
+	cluster := generateEKSAClusterFor(capiCluster)
+	cluster.PauseUpgrade()
+
+	runtimeObjs := []runtime.Object{mdObjs.machine, mdObjs.md, mdObjs.mhc, capiCluster, cluster}
+	client := fake.NewClientBuilder().WithRuntimeObjects(runtimeObjs...).Build()
+	r := controllers.NewMachineDeploymentReconciler(client, client)
+	req := mdRequest(mdObjs.md)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	mdu := &anywherev1.MachineDeploymentUpgrade{}
+	err = client.Get(ctx, types.NamespacedName{Name: mdObjs.mdUpgrade.Name, Namespace: constants.EksaSystemNamespace}, mdu)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	mhc := &clusterv1beta2.MachineHealthCheck{}
+	err = client.Get(ctx, types.NamespacedName{Name: mdObjs.mhc.Name, Namespace: constants.EksaSystemNamespace}, mhc)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mhc.Annotations).ToNot(HaveKey(capiPausedAnnotation))
+}
+
 func TestMDReconcileNotFound(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
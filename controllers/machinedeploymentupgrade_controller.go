@@ -38,6 +38,7 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/metrics"
 )
 
 const (
@@ -96,12 +97,17 @@ func (r *MachineDeploymentUpgradeReconciler) Reconcile(ctx context.Context, req
 		return ctrl.Result{}, err
 	}
 
+	alreadyReady := mdUpgrade.Status.Ready
 	defer func() {
 		err := r.updateStatus(ctx, log, mdUpgrade, ms)
 		if err != nil {
 			reterr = kerrors.NewAggregate([]error{reterr, err})
 		}
 
+		if !alreadyReady && mdUpgrade.Status.Ready {
+			metrics.ObserveUpgrade(md.Namespace, md.Spec.ClusterName, metrics.UpgradeKindMachineDeployment, mdUpgrade.CreationTimestamp)
+		}
+
 		// Always attempt to patch the object and status after each reconciliation.
 		patchOpts := []patch.Option{}
 
@@ -134,7 +140,7 @@ func (r *MachineDeploymentUpgradeReconciler) Reconcile(ctx context.Context, req
 	// AddFinalizer	is idempotent
 	controllerutil.AddFinalizer(mdUpgrade, mdUpgradeFinalizerName)
 
-	return r.reconcile(ctx, log, mdUpgrade)
+	return r.reconcile(ctx, log, mdUpgrade, md)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -144,12 +150,22 @@ func (r *MachineDeploymentUpgradeReconciler) SetupWithManager(mgr ctrl.Manager)
 		Complete(r)
 }
 
-func (r *MachineDeploymentUpgradeReconciler) reconcile(ctx context.Context, log logr.Logger, mdUpgrade *anywherev1.MachineDeploymentUpgrade) (ctrl.Result, error) {
+func (r *MachineDeploymentUpgradeReconciler) reconcile(ctx context.Context, log logr.Logger, mdUpgrade *anywherev1.MachineDeploymentUpgrade, md *clusterv1beta2.MachineDeployment) (ctrl.Result, error) {
 	log.Info("Upgrading all worker nodes")
+	mdUpgrade.Status.Paused = false
 	for _, machineRef := range mdUpgrade.Spec.MachinesRequireUpgrade {
 		nodeUpgrade, err := getNodeUpgrade(ctx, r.client, nodeUpgraderName(machineRef.Name))
 		if err != nil {
 			if apierrors.IsNotFound(err) {
+				paused, err := nodeUpgradesPaused(ctx, r.client, GetNamespacedNameType(md.Spec.ClusterName, md.Namespace))
+				if err != nil {
+					return ctrl.Result{}, err
+				}
+				if paused {
+					log.Info("Cluster upgrade is paused, holding upgrade of the next worker node", "Machine", machineRef.Name)
+					mdUpgrade.Status.Paused = true
+					return ctrl.Result{}, nil
+				}
 				nodeUpgrade = mdNodeUpgrader(machineRef, mdUpgrade.Spec.KubernetesVersion)
 				if err := r.client.Create(ctx, nodeUpgrade); err != nil {
 					return ctrl.Result{}, fmt.Errorf("failed to create node upgrader for machine %s:  %v", machineRef.Name, err)
@@ -10,6 +10,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -168,6 +169,33 @@ func TestMDUpgradeReconcileNodeUpgraderCreate(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestMDUpgradeReconcileNodeUpgraderPaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	capiCluster, machines, nodes, mdUpgrade, _, md, ms := getObjectsForMDUpgradeTest()
+	cluster := generateEKSAClusterFor(capiCluster)
+	cluster.PauseUpgrade()
+	client := fake.NewClientBuilder().WithRuntimeObjects(capiCluster, cluster, machines[0], machines[1], nodes[0], nodes[1], mdUpgrade, md, ms).
+		WithStatusSubresource(mdUpgrade).
+		Build()
+
+	r := controllers.NewMachineDeploymentUpgradeReconciler(client)
+	req := mdUpgradeRequest(mdUpgrade)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	n := &anywherev1.NodeUpgrade{}
+	nodeUpgradeName := fmt.Sprintf("%s-node-upgrader", machines[0].Name)
+	err = client.Get(ctx, types.NamespacedName{Name: nodeUpgradeName, Namespace: constants.EksaSystemNamespace}, n)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	mdu := &anywherev1.MachineDeploymentUpgrade{}
+	err = client.Get(ctx, types.NamespacedName{Name: mdUpgrade.Name, Namespace: constants.EksaSystemNamespace}, mdu)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mdu.Status.Paused).To(BeTrue())
+	g.Expect(mdu.Status.Ready).To(BeFalse())
+}
+
 func TestMDUpgradeObjectDoesNotExist(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
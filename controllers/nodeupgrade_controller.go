@@ -23,6 +23,7 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/metrics"
 	upgrader "github.com/aws/eks-anywhere/pkg/nodeupgrader"
 )
 
@@ -106,12 +107,17 @@ func (r *NodeUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	alreadyCompleted := nodeUpgrade.Status.Completed
 	defer func() {
 		err := r.updateStatus(ctx, log, rClient, nodeUpgrade, machineToBeUpgraded.Status.NodeRef.Name)
 		if err != nil {
 			reterr = kerrors.NewAggregate([]error{reterr, err})
 		}
 
+		if !alreadyCompleted && nodeUpgrade.Status.Completed {
+			metrics.ObserveNodeUpgrade(machineToBeUpgraded.Namespace, machineToBeUpgraded.Spec.ClusterName, nodeRole(machineToBeUpgraded), nodeUpgrade.CreationTimestamp)
+		}
+
 		// Always attempt to patch the object and status after each reconciliation.
 		patchOpts := []v1beta1patch.Option{}
 
@@ -370,6 +376,13 @@ func isControlPlane(node *corev1.Node) bool {
 	return ok
 }
 
+func nodeRole(machine *clusterv1beta2.Machine) string {
+	if _, ok := machine.Labels[clusterv1beta2.MachineControlPlaneLabel]; ok {
+		return metrics.NodeRoleControlPlane
+	}
+	return metrics.NodeRoleWorker
+}
+
 // GetNamespacedNameType takes name and namespace and returns NamespacedName in namespace/name format.
 func GetNamespacedNameType(name, namespace string) types.NamespacedName {
 	return types.NamespacedName{
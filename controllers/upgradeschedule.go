@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+// eksaClusterForCAPICluster returns the EKS-A Cluster a CAPI cluster was created from. It returns nil if the CAPI
+// cluster doesn't exist or it's not managed by an EKS-A Cluster.
+func eksaClusterForCAPICluster(ctx context.Context, c client.Client, capiCluster types.NamespacedName) (*anywherev1.Cluster, error) {
+	capi := &clusterv1beta2.Cluster{}
+	if err := c.Get(ctx, capiCluster, capi); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting CAPI cluster %s: %v", capiCluster.Name, err)
+	}
+
+	name, ok := capi.Labels[clusterapi.EKSAClusterLabelName]
+	if !ok {
+		return nil, nil
+	}
+
+	cluster := &anywherev1.Cluster{}
+	key := types.NamespacedName{Name: name, Namespace: capi.Labels[clusterapi.EKSAClusterLabelNamespace]}
+	if err := c.Get(ctx, key, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting EKS-A cluster %s: %v", name, err)
+	}
+
+	return cluster, nil
+}
+
+// inPlaceUpgradeHeld returns why a new in place upgrade of the cluster machines can't start at time now, or an
+// empty string if it can. Upgrades are held while the cluster upgrade is paused and outside its maintenance window.
+func inPlaceUpgradeHeld(cluster *anywherev1.Cluster, now time.Time) (string, error) {
+	if cluster == nil {
+		return "", nil
+	}
+
+	if cluster.UpgradePaused() {
+		return "cluster upgrade is paused", nil
+	}
+
+	inWindow, next, err := cluster.InMaintenanceWindow(now)
+	if err != nil {
+		return "", err
+	}
+	if !inWindow && next.IsZero() {
+		return "outside maintenance window", nil
+	}
+	if !inWindow {
+		return fmt.Sprintf("outside maintenance window, next window starts at %s", next.Format(time.RFC3339)), nil
+	}
+
+	return "", nil
+}
+
+// nodeUpgradesPaused returns true if no more nodes should start upgrading because the cluster upgrade is paused.
+// The nodes already upgrading are left to complete.
+func nodeUpgradesPaused(ctx context.Context, c client.Client, capiCluster types.NamespacedName) (bool, error) {
+	cluster, err := eksaClusterForCAPICluster(ctx, c, capiCluster)
+	if err != nil {
+		return false, err
+	}
+
+	return cluster != nil && cluster.UpgradePaused(), nil
+}
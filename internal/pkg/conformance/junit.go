@@ -0,0 +1,118 @@
+package conformance
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+const e2eResultsDir = "plugins/e2e/results/"
+
+// Summary counts the test cases of a JUnit report.
+type Summary struct {
+	Total    int
+	Passed   int
+	Failed   int
+	Skipped  int
+	Failures []string
+}
+
+// Succeeded returns true if no test failed.
+func (s *Summary) Succeeded() bool {
+	return s.Failed == 0
+}
+
+type junitSuite struct {
+	Suites    []junitSuite    `xml:"testsuite"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string    `xml:"name,attr"`
+	Failure *struct{} `xml:"failure"`
+	Error   *struct{} `xml:"error"`
+	Skipped *struct{} `xml:"skipped"`
+}
+
+// extractJUnit writes the JUnit report of the e2e plugin in the sonobuoy results tarball to a file.
+func extractJUnit(tarball, dst string) error {
+	f, err := os.Open(tarball)
+	if err != nil {
+		return fmt.Errorf("opening sonobuoy results: %v", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("reading sonobuoy results: %v", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("no JUnit report found for the e2e plugin in %s", tarball)
+		}
+		if err != nil {
+			return fmt.Errorf("reading sonobuoy results: %v", err)
+		}
+
+		name := strings.TrimPrefix(header.Name, "./")
+		if header.Typeflag != tar.TypeReg || !strings.HasPrefix(name, e2eResultsDir) ||
+			!strings.HasPrefix(path.Base(name), "junit") || path.Ext(name) != ".xml" {
+			continue
+		}
+
+		out, err := os.Create(dst)
+		if err != nil {
+			return fmt.Errorf("creating JUnit report: %v", err)
+		}
+		defer out.Close()
+		// #nosec G110 the tarball is the output of sonobuoy retrieve
+		if _, err := io.Copy(out, tr); err != nil {
+			return fmt.Errorf("writing JUnit report: %v", err)
+		}
+		return nil
+	}
+}
+
+// summarizeJUnit counts the passed, failed and skipped test cases in a JUnit report.
+func summarizeJUnit(file string) (*Summary, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading JUnit report: %v", err)
+	}
+	suite := &junitSuite{}
+	if err := xml.Unmarshal(content, suite); err != nil {
+		return nil, fmt.Errorf("parsing JUnit report: %v", err)
+	}
+
+	summary := &Summary{}
+	summary.add(suite)
+	return summary, nil
+}
+
+func (s *Summary) add(suite *junitSuite) {
+	for i := range suite.Suites {
+		s.add(&suite.Suites[i])
+	}
+	for _, tc := range suite.TestCases {
+		s.Total++
+		switch {
+		case tc.Failure != nil || tc.Error != nil:
+			s.Failed++
+			s.Failures = append(s.Failures, tc.Name)
+		case tc.Skipped != nil:
+			s.Skipped++
+		default:
+			s.Passed++
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	versionutil "k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+)
+
+// Mode selects the conformance tests to run.
+type Mode string
+
+const (
+	// ModeQuick runs a single test to check sonobuoy can run in the cluster.
+	ModeQuick Mode = "quick"
+	// ModeCertified runs all the tests required to certify the cluster.
+	ModeCertified Mode = "certified"
+)
+
+const (
+	kubeConformanceImage  = "registry.k8s.io/conformance"
+	sonobuoyImage         = "docker.io/sonobuoy/sonobuoy"
+	systemdLogsImage      = "docker.io/sonobuoy/systemd-logs:v0.4"
+	e2eRepoConfigFileName = "e2e-repo-config.yaml"
+	junitFileName         = "junit.xml"
+	defaultPollInterval   = 30 * time.Second
+	defaultCleanupTimeout = 5 * time.Minute
+)
+
+// e2eRegistries are the registries the Kubernetes e2e tests pull images from, by the key used to override them.
+var e2eRegistries = map[string]string{
+	"dockerLibraryRegistry":    "docker.io/library",
+	"e2eRegistry":              "registry.k8s.io/e2e-test-images",
+	"promoterE2eRegistry":      "registry.k8s.io/e2e-test-images",
+	"buildImageRegistry":       "registry.k8s.io/build-image",
+	"gcEtcdRegistry":           "registry.k8s.io",
+	"gcRegistry":               "registry.k8s.io",
+	"sigStorageRegistry":       "registry.k8s.io/sig-storage",
+	"cloudProviderGcpRegistry": "registry.k8s.io/cloud-provider-gcp",
+}
+
+// SonobuoyClient runs sonobuoy against a cluster.
+type SonobuoyClient interface {
+	Version(ctx context.Context) (string, error)
+	Start(ctx context.Context, kubeconfig string, args ...string) error
+	Status(ctx context.Context, kubeconfig string) (*executables.SonobuoyStatus, error)
+	Retrieve(ctx context.Context, kubeconfig, dir string) (string, error)
+	Delete(ctx context.Context, kubeconfig string) error
+}
+
+// Config configures a conformance run.
+type Config struct {
+	Kubeconfig string
+	Mode       Mode
+	// KubeVersion is the EKS-D Kubernetes version of the cluster, like v1.30.2, used to pick the conformance image.
+	KubeVersion string
+	// RegistryMirror, when set, is where all the images used by the tests are pulled from.
+	RegistryMirror *registrymirror.RegistryMirror
+	// OutputDir is where the results tarball and the JUnit report are written.
+	OutputDir string
+}
+
+// Result is the result of a conformance run.
+type Result struct {
+	Tarball   string
+	JUnitFile string
+	Summary
+}
+
+// Runner runs the Kubernetes conformance tests in a cluster with sonobuoy.
+type Runner struct {
+	sonobuoy     SonobuoyClient
+	pollInterval time.Duration
+}
+
+// RunnerOpt configures a Runner.
+type RunnerOpt func(*Runner)
+
+// WithPollInterval sets how often the progress of the tests is checked.
+func WithPollInterval(interval time.Duration) RunnerOpt {
+	return func(r *Runner) {
+		r.pollInterval = interval
+	}
+}
+
+// NewRunner returns a new Runner.
+func NewRunner(sonobuoy SonobuoyClient, opts ...RunnerOpt) *Runner {
+	r := &Runner{
+		sonobuoy:     sonobuoy,
+		pollInterval: defaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run runs the conformance tests, logging their progress until they finish, and retrieves the results.
+// The sonobuoy resources are deleted from the cluster once the results are retrieved.
+func (r *Runner) Run(ctx context.Context, config Config) (*Result, error) {
+	if err := os.MkdirAll(config.OutputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating conformance output folder: %v", err)
+	}
+
+	args, err := r.runArgs(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Starting conformance tests", "mode", config.Mode)
+	if err := r.sonobuoy.Start(ctx, config.Kubeconfig, args...); err != nil {
+		return nil, err
+	}
+	defer func() {
+		// The run context is canceled when the tests time out, so the resources are deleted with a new context.
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), defaultCleanupTimeout)
+		defer cancel()
+		logger.V(3).Info("Deleting sonobuoy resources from the cluster")
+		if err := r.sonobuoy.Delete(cleanupCtx, config.Kubeconfig); err != nil {
+			logger.Info("Warning: failed to delete sonobuoy resources from the cluster", "error", err)
+		}
+	}()
+
+	if err := r.waitForCompletion(ctx, config.Kubeconfig); err != nil {
+		return nil, err
+	}
+
+	tarball, err := r.sonobuoy.Retrieve(ctx, config.Kubeconfig, config.OutputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	junitFile := filepath.Join(config.OutputDir, junitFileName)
+	if err := extractJUnit(tarball, junitFile); err != nil {
+		return nil, err
+	}
+	summary, err := summarizeJUnit(junitFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Tarball: tarball, JUnitFile: junitFile, Summary: *summary}, nil
+}
+
+func (r *Runner) runArgs(ctx context.Context, config Config) ([]string, error) {
+	var args []string
+	switch config.Mode {
+	case ModeQuick:
+		args = append(args, "--mode", "quick")
+	case ModeCertified:
+		skip, err := requiresCiliumTestSkip(config.KubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		// The mode and --e2e-skip can't be used together, so the certified mode focus is set explicitly.
+		if skip {
+			args = append(args, "--e2e-focus", `\[Conformance\]`, "--e2e-skip", ciliumSkippedTest)
+		} else {
+			args = append(args, "--mode", "certified-conformance")
+		}
+	default:
+		return nil, fmt.Errorf("invalid conformance mode %s, must be %s or %s", config.Mode, ModeQuick, ModeCertified)
+	}
+
+	conformanceImage := fmt.Sprintf("%s:%s", kubeConformanceImage, config.KubeVersion)
+	if config.RegistryMirror == nil {
+		return append(args, "--kube-conformance-image", conformanceImage), nil
+	}
+
+	version, err := r.sonobuoy.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repoConfig, err := writeE2ERepoConfig(config.RegistryMirror, config.OutputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(args,
+		"--kube-conformance-image", mirrorImage(config.RegistryMirror, conformanceImage),
+		"--sonobuoy-image", mirrorImage(config.RegistryMirror, fmt.Sprintf("%s:%s", sonobuoyImage, version)),
+		"--systemd-logs-image", mirrorImage(config.RegistryMirror, systemdLogsImage),
+		"--e2e-repo-config", repoConfig,
+	), nil
+}
+
+const (
+	minKubernetesVersionRequiringTestSkip = "v1.29.0"
+	// ciliumSkippedTest doesn't pass with the Cilium deployment of EKS Anywhere from Kubernetes 1.29.
+	// See https://github.com/cilium/cilium/issues/29913.
+	ciliumSkippedTest = "Services should serve endpoints on same port and different protocols"
+)
+
+func requiresCiliumTestSkip(kubeVersion string) (bool, error) {
+	v, err := versionutil.ParseSemantic(kubeVersion)
+	if err != nil {
+		return false, fmt.Errorf("parsing kubernetes version %s: %v", kubeVersion, err)
+	}
+	compare, err := v.Compare(minKubernetesVersionRequiringTestSkip)
+	if err != nil {
+		return false, err
+	}
+	return compare != -1, nil
+}
+
+// mirrorImage returns the location of an image in the registry mirror. Images from registries
+// without a namespace in the mirror are expected at the root of the mirror.
+func mirrorImage(mirror *registrymirror.RegistryMirror, image string) string {
+	if mirrored := mirror.ReplaceRegistry(image); mirrored != image {
+		return mirrored
+	}
+	_, repository, _ := strings.Cut(image, "/")
+	return path.Join(mirror.BaseRegistry, repository)
+}
+
+func writeE2ERepoConfig(mirror *registrymirror.RegistryMirror, dir string) (string, error) {
+	config := make(map[string]string, len(e2eRegistries))
+	for key, registry := range e2eRegistries {
+		config[key] = mirrorImage(mirror, registry)
+	}
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("marshalling e2e repo config: %v", err)
+	}
+
+	file := filepath.Join(dir, e2eRepoConfigFileName)
+	if err := os.WriteFile(file, content, 0o644); err != nil {
+		return "", fmt.Errorf("writing e2e repo config: %v", err)
+	}
+	return file, nil
+}
+
+func (r *Runner) waitForCompletion(ctx context.Context, kubeconfig string) error {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	lastProgress := ""
+	for {
+		status, err := r.sonobuoy.Status(ctx, kubeconfig)
+		if err != nil {
+			// sonobuoy status fails while the aggregator is starting, so it's retried until the context is done.
+			logger.V(4).Info("Failed getting sonobuoy status", "error", err)
+		} else {
+			if progress := formatProgress(status); progress != lastProgress {
+				logger.Info("Conformance tests progress", "status", status.Status, "plugins", progress)
+				lastProgress = progress
+			}
+			if status.Done() {
+				if status.Status == "failed" {
+					logger.Info("Warning: sonobuoy run failed, retrieving the results anyway")
+				}
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for conformance tests to complete: %v", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func formatProgress(status *executables.SonobuoyStatus) string {
+	plugins := make([]string, 0, len(status.Plugins))
+	for _, p := range status.Plugins {
+		s := fmt.Sprintf("%s:%s", p.Plugin, p.Status)
+		if p.Progress != nil && p.Progress.Total > 0 {
+			s += fmt.Sprintf(" %d/%d", p.Progress.Completed, p.Progress.Total)
+			if len(p.Progress.Failures) > 0 {
+				s += fmt.Sprintf(" (%d failed)", len(p.Progress.Failures))
+			}
+		}
+		plugins = append(plugins, s)
+	}
+	return strings.Join(plugins, ", ")
+}
@@ -0,0 +1,245 @@
+package conformance_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/pkg/conformance"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+)
+
+const junitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="4" failures="1">
+  <testsuite name="Kubernetes e2e suite" tests="4" failures="1" skipped="1">
+    <testcase name="[sig-node] Pods should run"></testcase>
+    <testcase name="[sig-network] DNS should resolve"></testcase>
+    <testcase name="[sig-storage] CSI should mount"><skipped></skipped></testcase>
+    <testcase name="[sig-apps] Deployment should roll"><failure>timed out</failure></testcase>
+  </testsuite>
+</testsuites>
+`
+
+type fakeSonobuoy struct {
+	t         *testing.T
+	startArgs []string
+	statuses  []string
+	tarball   string
+	deleted   bool
+	deleteErr error
+	startErr  error
+}
+
+func (f *fakeSonobuoy) Version(_ context.Context) (string, error) {
+	return "v0.57.1", nil
+}
+
+func (f *fakeSonobuoy) Start(_ context.Context, _ string, args ...string) error {
+	f.startArgs = args
+	return f.startErr
+}
+
+func (f *fakeSonobuoy) Status(_ context.Context, _ string) (*executables.SonobuoyStatus, error) {
+	if len(f.statuses) == 0 {
+		return nil, errors.New("no more statuses")
+	}
+	status := f.statuses[0]
+	f.statuses = f.statuses[1:]
+	return &executables.SonobuoyStatus{
+		Status: status,
+		Plugins: []executables.SonobuoyPluginStatus{
+			{Plugin: "e2e", Status: status, Progress: &executables.SonobuoyPluginProgress{Total: 4, Completed: 2}},
+		},
+	}, nil
+}
+
+func (f *fakeSonobuoy) Retrieve(_ context.Context, _, dir string) (string, error) {
+	f.tarball = filepath.Join(dir, "202410171200_sonobuoy.tar.gz")
+	writeTarball(f.t, f.tarball, map[string]string{
+		"plugins/e2e/results/global/e2e.log":      "log",
+		"plugins/e2e/results/global/junit_01.xml": junitReport,
+	})
+	return f.tarball, nil
+}
+
+func (f *fakeSonobuoy) Delete(ctx context.Context, _ string) error {
+	f.deleted = true
+	// Like the sonobuoy executable, it fails if the context is done.
+	f.deleteErr = ctx.Err()
+	return f.deleteErr
+}
+
+func writeTarball(t *testing.T, file string, files map[string]string) {
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func newConfig(t *testing.T, mode conformance.Mode, kubeVersion string) conformance.Config {
+	return conformance.Config{
+		Kubeconfig:  "mgmt/mgmt-eks-a-cluster.kubeconfig",
+		Mode:        mode,
+		KubeVersion: kubeVersion,
+		OutputDir:   filepath.Join(t.TempDir(), "results"),
+	}
+}
+
+func TestRunnerRun(t *testing.T) {
+	g := NewWithT(t)
+	sonobuoy := &fakeSonobuoy{t: t, statuses: []string{"running", "running", "complete"}}
+	runner := conformance.NewRunner(sonobuoy, conformance.WithPollInterval(time.Millisecond))
+	config := newConfig(t, conformance.ModeQuick, "v1.30.2")
+
+	result, err := runner.Run(context.Background(), config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sonobuoy.startArgs).To(Equal([]string{
+		"--mode", "quick", "--kube-conformance-image", "registry.k8s.io/conformance:v1.30.2",
+	}))
+	g.Expect(sonobuoy.deleted).To(BeTrue())
+	g.Expect(result.Tarball).To(Equal(sonobuoy.tarball))
+	g.Expect(result.JUnitFile).To(Equal(filepath.Join(config.OutputDir, "junit.xml")))
+	g.Expect(os.ReadFile(result.JUnitFile)).To(BeEquivalentTo(junitReport))
+	g.Expect(result.Summary).To(Equal(conformance.Summary{
+		Total:    4,
+		Passed:   2,
+		Failed:   1,
+		Skipped:  1,
+		Failures: []string{"[sig-apps] Deployment should roll"},
+	}))
+	g.Expect(result.Succeeded()).To(BeFalse())
+}
+
+func TestRunnerRunCertified(t *testing.T) {
+	tests := []struct {
+		name        string
+		kubeVersion string
+		wantArgs    []string
+	}{
+		{
+			name:        "before 1.29",
+			kubeVersion: "v1.28.11",
+			wantArgs:    []string{"--mode", "certified-conformance"},
+		},
+		{
+			name:        "1.29 and later skip the cilium test",
+			kubeVersion: "v1.30.2",
+			wantArgs:    []string{"--e2e-focus", `\[Conformance\]`, "--e2e-skip", "Services should serve endpoints on same port and different protocols"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			sonobuoy := &fakeSonobuoy{t: t, statuses: []string{"complete"}}
+			runner := conformance.NewRunner(sonobuoy, conformance.WithPollInterval(time.Millisecond))
+
+			_, err := runner.Run(context.Background(), newConfig(t, conformance.ModeCertified, tt.kubeVersion))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(sonobuoy.startArgs[:len(tt.wantArgs)]).To(Equal(tt.wantArgs))
+		})
+	}
+}
+
+func TestRunnerRunRegistryMirror(t *testing.T) {
+	g := NewWithT(t)
+	sonobuoy := &fakeSonobuoy{t: t, statuses: []string{"complete"}}
+	runner := conformance.NewRunner(sonobuoy, conformance.WithPollInterval(time.Millisecond))
+	config := newConfig(t, conformance.ModeQuick, "v1.30.2")
+	config.RegistryMirror = &registrymirror.RegistryMirror{
+		BaseRegistry: "harbor.local:443",
+		NamespacedRegistryMap: map[string]string{
+			"registry.k8s.io": "harbor.local:443/k8s",
+		},
+	}
+
+	_, err := runner.Run(context.Background(), config)
+	g.Expect(err).NotTo(HaveOccurred())
+	repoConfig := filepath.Join(config.OutputDir, "e2e-repo-config.yaml")
+	g.Expect(sonobuoy.startArgs).To(Equal([]string{
+		"--mode", "quick",
+		"--kube-conformance-image", "harbor.local:443/k8s/conformance:v1.30.2",
+		"--sonobuoy-image", "harbor.local:443/sonobuoy/sonobuoy:v0.57.1",
+		"--systemd-logs-image", "harbor.local:443/sonobuoy/systemd-logs:v0.4",
+		"--e2e-repo-config", repoConfig,
+	}))
+
+	content, err := os.ReadFile(repoConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	registries := map[string]string{}
+	g.Expect(yaml.Unmarshal(content, &registries)).To(Succeed())
+	g.Expect(registries).To(HaveKeyWithValue("promoterE2eRegistry", "harbor.local:443/k8s/e2e-test-images"))
+	g.Expect(registries).To(HaveKeyWithValue("dockerLibraryRegistry", "harbor.local:443/library"))
+}
+
+func TestRunnerRunInvalidMode(t *testing.T) {
+	g := NewWithT(t)
+	runner := conformance.NewRunner(&fakeSonobuoy{t: t})
+
+	_, err := runner.Run(context.Background(), newConfig(t, "full", "v1.30.2"))
+	g.Expect(err).To(MatchError("invalid conformance mode full, must be quick or certified"))
+}
+
+func TestRunnerRunStartError(t *testing.T) {
+	g := NewWithT(t)
+	sonobuoy := &fakeSonobuoy{t: t, startErr: errors.New("sonobuoy already running")}
+	runner := conformance.NewRunner(sonobuoy)
+
+	_, err := runner.Run(context.Background(), newConfig(t, conformance.ModeQuick, "v1.30.2"))
+	g.Expect(err).To(MatchError("sonobuoy already running"))
+	g.Expect(sonobuoy.deleted).To(BeFalse())
+}
+
+func TestRunnerRunContextCanceled(t *testing.T) {
+	g := NewWithT(t)
+	sonobuoy := &fakeSonobuoy{t: t}
+	runner := conformance.NewRunner(sonobuoy, conformance.WithPollInterval(time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := runner.Run(ctx, newConfig(t, conformance.ModeQuick, "v1.30.2"))
+	g.Expect(err).To(MatchError(ContainSubstring("waiting for conformance tests to complete")))
+	g.Expect(sonobuoy.deleted).To(BeTrue())
+	g.Expect(sonobuoy.deleteErr).NotTo(HaveOccurred())
+}
+
+func TestRunnerRunNoJUnitReport(t *testing.T) {
+	g := NewWithT(t)
+	sonobuoy := &noJUnitSonobuoy{fakeSonobuoy{t: t, statuses: []string{"failed"}}}
+	runner := conformance.NewRunner(sonobuoy, conformance.WithPollInterval(time.Millisecond))
+
+	_, err := runner.Run(context.Background(), newConfig(t, conformance.ModeQuick, "v1.30.2"))
+	g.Expect(err).To(MatchError(ContainSubstring("no JUnit report found for the e2e plugin")))
+}
+
+type noJUnitSonobuoy struct {
+	fakeSonobuoy
+}
+
+func (f *noJUnitSonobuoy) Retrieve(_ context.Context, _, dir string) (string, error) {
+	tarball := filepath.Join(dir, "results.tar.gz")
+	writeTarball(f.t, tarball, map[string]string{"plugins/e2e/results/global/e2e.log": "log"})
+	return tarball, nil
+}
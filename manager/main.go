@@ -4,6 +4,8 @@ import (
 	"context"
 	"flag"
 	"os"
+	// The images don't ship the time zone database the cluster maintenance windows need, so it's embedded.
+	_ "time/tzdata"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
@@ -197,6 +197,8 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateControlPlaneKubeletConfiguration,
 	validateWorkerNodeKubeletConfiguration,
 	validateAuditPolicyContent,
+	validateCertificateRenewal,
+	validateMaintenanceWindow,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -579,6 +581,31 @@ func validateAuditPolicyContent(c *Cluster) error {
 	return nil
 }
 
+// Certificates are issued for one year. Thresholds too close to expiration don't leave time to
+// recover from a failed rollout, and the ones too far away roll out the machines too often.
+const (
+	minCertificateRenewalThresholdDays = 7
+	maxCertificateRenewalThresholdDays = 180
+)
+
+func validateCertificateRenewal(c *Cluster) error {
+	if c.Spec.CertificateRenewal == nil {
+		return nil
+	}
+
+	threshold := c.Spec.CertificateRenewal.ThresholdDays
+	if threshold < minCertificateRenewalThresholdDays || threshold > maxCertificateRenewalThresholdDays {
+		return fmt.Errorf("certificateRenewal: thresholdDays must be between %d and %d, got %d",
+			minCertificateRenewalThresholdDays, maxCertificateRenewalThresholdDays, threshold)
+	}
+
+	if strategy := c.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy; strategy != nil && strategy.Type == InPlaceStrategyType {
+		return errors.New("certificateRenewal is not supported with the 'InPlace' control plane upgrade rollout strategy")
+	}
+
+	return nil
+}
+
 func validateKubeletConfiguration(kubeletConfig *unstructured.Unstructured) error {
 	if kubeletConfig == nil {
 		return nil
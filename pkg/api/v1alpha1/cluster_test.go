@@ -4269,3 +4269,59 @@ rules:
 		})
 	}
 }
+
+func TestValidateCertificateRenewal(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    ClusterSpec
+		wantErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid threshold",
+			spec: ClusterSpec{
+				CertificateRenewal: &CertificateRenewal{ThresholdDays: 30},
+			},
+		},
+		{
+			name: "threshold too low",
+			spec: ClusterSpec{
+				CertificateRenewal: &CertificateRenewal{ThresholdDays: 1},
+			},
+			wantErr: "certificateRenewal: thresholdDays must be between 7 and 180, got 1",
+		},
+		{
+			name: "threshold too high",
+			spec: ClusterSpec{
+				CertificateRenewal: &CertificateRenewal{ThresholdDays: 365},
+			},
+			wantErr: "certificateRenewal: thresholdDays must be between 7 and 180, got 365",
+		},
+		{
+			name: "in place upgrades",
+			spec: ClusterSpec{
+				CertificateRenewal: &CertificateRenewal{ThresholdDays: 30},
+				ControlPlaneConfiguration: ControlPlaneConfiguration{
+					UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{
+						Type: InPlaceStrategyType,
+					},
+				},
+			},
+			wantErr: "certificateRenewal is not supported with the 'InPlace' control plane upgrade rollout strategy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateCertificateRenewal(&Cluster{Spec: tt.spec})
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"reflect"
@@ -66,6 +67,15 @@ const (
 	// AllowDeleteWhenPausedAnnotation is an annotation applied to an EKS-A cluster that allows the deletion of the cluster
 	// when paused.
 	AllowDeleteWhenPausedAnnotation = "anywhere.eks.amazonaws.com/allow-delete-when-paused"
+
+	// UpgradePausedAnnotation is an annotation applied to an EKS-A cluster to pause the rollout of its machines
+	// between nodes. Unlike the paused annotation, the controller keeps reconciling the cluster status.
+	UpgradePausedAnnotation = "anywhere.eks.amazonaws.com/upgrade-paused"
+
+	// vsphereAntiAffinityRulesAnnotation lists the vSphere DRS anti-affinity rules the controller maintains for the cluster
+	// and their resource pools, so they can be deleted once they aren't needed anymore or the cluster is deleted.
+	// This is an internal EKS-A managed annotation, not meant to be updated manually.
+	vsphereAntiAffinityRulesAnnotation = "anywhere.eks.amazonaws.com/vsphere-anti-affinity-rules"
 )
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -93,6 +103,12 @@ type ClusterSpec struct {
 	MachineHealthCheck *MachineHealthCheck `json:"machineHealthCheck,omitempty"`
 	EtcdEncryption     *[]EtcdEncryption   `json:"etcdEncryption,omitempty"`
 	LicenseToken       string              `json:"licenseToken,omitempty"`
+	// CertificateRenewal enables the automatic renewal of the control plane and external etcd certificates.
+	// +optional
+	CertificateRenewal *CertificateRenewal `json:"certificateRenewal,omitempty"`
+	// MaintenanceWindow restricts when the controller rolls out spec changes to an existing cluster.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowConfiguration `json:"maintenanceWindow,omitempty"`
 }
 
 // EksaVersion is the semver identifying the release of eks-a used to populate the cluster components.
@@ -345,6 +361,35 @@ type MachineHealthCheck struct {
 	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
 }
 
+// CertificateRenewal configures the automatic renewal of the cluster certificates by the controller.
+// Certificates are renewed by rolling out the control plane and external etcd machines, so no
+// access to the machines is required.
+type CertificateRenewal struct {
+	// ThresholdDays is the number of days before the certificates of a machine expire at which the controller
+	// rolls out that machine to renew them. It must be between 7 and 180.
+	ThresholdDays int `json:"thresholdDays"`
+}
+
+// MaintenanceWindowConfiguration configures the windows in which the controller is allowed to roll out
+// spec changes to an existing cluster. Outside them, changes are held until the next window starts.
+type MaintenanceWindowConfiguration struct {
+	// TimeZone is the IANA time zone the window schedules are evaluated in, like "America/New_York".
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Windows is the list of allowed maintenance windows. Changes are rolled out if any of them is open.
+	Windows []MaintenanceWindow `json:"windows"`
+}
+
+// MaintenanceWindow is a recurring period of time in which rollouts are allowed.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression with 5 fields (minute, hour, day of month, month and day of week)
+	// for the start of the window, like "0 2 * * sat" for every Saturday at 2am.
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open after each start, like "4h".
+	Duration metav1.Duration `json:"duration"`
+}
+
 func TaintsSliceEqual(s1, s2 []corev1.Taint) bool {
 	if len(s1) != len(s2) {
 		return false
@@ -1090,6 +1135,11 @@ type ClusterStatus struct {
 	// +optional
 	ClusterCertificateInfo []ClusterCertificateInfo `json:"clusterCertificateInfo,omitempty"`
 
+	// EtcdEncryption contains the etcd encryption providers configured in the control plane and the
+	// progress of the encryption key rotation.
+	// +optional
+	EtcdEncryption *EtcdEncryptionStatus `json:"etcdEncryption,omitempty"`
+
 	// ReconciledGeneration represents the .metadata.generation the last time the
 	// cluster was successfully reconciled. It is the latest generation observed
 	// by the controller.
@@ -1480,6 +1530,36 @@ func (c *Cluster) SetManagementComponentsVersion(version string) {
 	c.Annotations[managementComponentsVersionAnnotation] = version
 }
 
+// VSphereAntiAffinityRules returns the vSphere DRS anti-affinity rules maintained for the Cluster, mapping each rule
+// name to the resource pool whose compute cluster holds the rule.
+func (c *Cluster) VSphereAntiAffinityRules() map[string]string {
+	rules := c.Annotations[vsphereAntiAffinityRulesAnnotation]
+	if rules == "" {
+		return nil
+	}
+
+	parsed := map[string]string{}
+	if err := json.Unmarshal([]byte(rules), &parsed); err != nil {
+		return nil
+	}
+	return parsed
+}
+
+// SetVSphereAntiAffinityRules sets the vSphere DRS anti-affinity rules maintained for the Cluster, mapping each rule
+// name to the resource pool whose compute cluster holds the rule.
+func (c *Cluster) SetVSphereAntiAffinityRules(rules map[string]string) {
+	if len(rules) == 0 {
+		delete(c.Annotations, vsphereAntiAffinityRulesAnnotation)
+		return
+	}
+	if c.Annotations == nil {
+		c.Annotations = make(map[string]string, 1)
+	}
+	// json.Marshal sorts the map keys, so the annotation doesn't change between reconciliations.
+	content, _ := json.Marshal(rules)
+	c.Annotations[vsphereAntiAffinityRulesAnnotation] = string(content)
+}
+
 // DisableControlPlaneIPCheck sets the `skip-ip-check` annotation on the Cluster object.
 func (c *Cluster) DisableControlPlaneIPCheck() {
 	if c.Annotations == nil {
@@ -1662,6 +1742,8 @@ func (c *Cluster) ConvertConfigToConfigGenerateStruct() *ClusterGenerate {
 			MachineHealthCheck:            c.Spec.MachineHealthCheck,
 			EtcdEncryption:                c.Spec.EtcdEncryption,
 			LicenseToken:                  c.Spec.LicenseToken,
+			CertificateRenewal:            c.Spec.CertificateRenewal,
+			MaintenanceWindow:             c.Spec.MaintenanceWindow,
 		},
 	}
 
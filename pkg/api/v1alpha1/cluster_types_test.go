@@ -3831,3 +3831,22 @@ func TestCluster_ManagementComponentsVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterVSphereAntiAffinityRules(t *testing.T) {
+	g := NewWithT(t)
+	cluster := baseCluster(func(c *v1alpha1.Cluster) {
+		c.Annotations = nil
+	})
+	g.Expect(cluster.VSphereAntiAffinityRules()).To(BeEmpty())
+
+	rules := map[string]string{
+		"my-cluster-control-plane-anti-affinity": "/datacenter/host/cluster/Resources",
+		"my-cluster-md-0-anti-affinity":          "/datacenter/host/cluster-2/Resources",
+	}
+	cluster.SetVSphereAntiAffinityRules(rules)
+	g.Expect(cluster.VSphereAntiAffinityRules()).To(Equal(rules))
+
+	cluster.SetVSphereAntiAffinityRules(nil)
+	g.Expect(cluster.VSphereAntiAffinityRules()).To(BeEmpty())
+	g.Expect(cluster.Annotations).To(BeEmpty())
+}
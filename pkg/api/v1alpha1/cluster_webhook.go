@@ -130,6 +130,8 @@ func (r *Cluster) ValidateUpdate(_ context.Context, old, obj runtime.Object) (ad
 
 	allErrs = append(allErrs, validateEtcdEncryptionSupport(newCluster)...)
 
+	allErrs = append(allErrs, validateEtcdEncryptionKeyRotation(newCluster, oldCluster)...)
+
 	if len(allErrs) != 0 {
 		return nil, apierrors.NewInvalid(GroupVersion.WithKind(ClusterKind).GroupKind(), newCluster.Name, allErrs)
 	}
@@ -642,3 +644,38 @@ func validateEtcdEncryptionSupport(cluster *Cluster) field.ErrorList {
 
 	return errs
 }
+
+// validateEtcdEncryptionKeyRotation validates the changes to the etcd encryption providers against the ones configured
+// in the control plane. The key is rotated by replacing the provider, so the new provider runs next to the old one.
+func validateEtcdEncryptionKeyRotation(newCluster, oldCluster *Cluster) field.ErrorList {
+	var errs field.ErrorList
+	path := field.NewPath("spec", "etcdEncryption")
+
+	if oldCluster.EtcdEncryptionKeyRotationInProgress() {
+		if !reflect.DeepEqual(newCluster.Spec.EtcdEncryption, oldCluster.Spec.EtcdEncryption) {
+			errs = append(errs, field.Forbidden(path, "etcdEncryption cannot be changed while the encryption key is being rotated"))
+		}
+		return errs
+	}
+
+	status := oldCluster.Status.EtcdEncryption
+	if status == nil || len(status.Providers) == 0 || newCluster.Spec.EtcdEncryption == nil || len(*newCluster.Spec.EtcdEncryption) == 0 {
+		return errs
+	}
+
+	current := status.Providers[0].KMS
+	for i, p := range (*newCluster.Spec.EtcdEncryption)[0].Providers {
+		if p.KMS == nil || current == nil || p.KMS.Name == current.Name {
+			continue
+		}
+		if p.KMS.SocketListenAddress == current.SocketListenAddress {
+			errs = append(errs, field.Invalid(
+				path.Index(0).Child("providers").Index(i).Child("kms", "socketListenAddress"),
+				p.KMS.SocketListenAddress,
+				fmt.Sprintf("the new provider must listen on a different socket than the provider %s it replaces, both run during the key rotation", current.Name),
+			))
+		}
+	}
+
+	return errs
+}
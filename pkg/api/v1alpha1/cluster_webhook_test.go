@@ -1338,6 +1338,81 @@ func TestClusterUpdateEtcdEncryption(t *testing.T) {
 				},
 			},
 		},
+		{
+			testName:    "kms_plugin_empty_image",
+			expectedErr: errors.New("etcdEncryption[0].providers[0] is invalid: kms.plugin.image cannot be empty"),
+			encryptionConfig: &[]v1alpha1.EtcdEncryption{
+				{
+					Providers: []v1alpha1.EtcdEncryptionProvider{
+						{
+							KMS: &v1alpha1.KMS{
+								Name:                "kms-1",
+								SocketListenAddress: "unix:///var/run/kmsplugin/kms-1.sock",
+								Plugin:              &v1alpha1.KMSPlugin{},
+							},
+						},
+					},
+					Resources: resources,
+				},
+			},
+		},
+		{
+			testName:    "kms_plugin_invalid_name",
+			expectedErr: errors.New("etcdEncryption[0].providers[0] is invalid: kms.name must be a valid DNS label to deploy the plugin"),
+			encryptionConfig: &[]v1alpha1.EtcdEncryption{
+				{
+					Providers: []v1alpha1.EtcdEncryptionProvider{
+						{
+							KMS: &v1alpha1.KMS{
+								Name:                "test_config",
+								SocketListenAddress: "unix:///var/run/kmsplugin/kms-1.sock",
+								Plugin:              &v1alpha1.KMSPlugin{Image: "public.ecr.aws/kms-plugin:v1"},
+							},
+						},
+					},
+					Resources: resources,
+				},
+			},
+		},
+		{
+			testName:    "kms_plugin_socket_outside_plugin_dir",
+			expectedErr: errors.New("etcdEncryption[0].providers[0] is invalid: kms.socketListenAddress must be in /var/run/kmsplugin/ to deploy the plugin"),
+			encryptionConfig: &[]v1alpha1.EtcdEncryption{
+				{
+					Providers: []v1alpha1.EtcdEncryptionProvider{
+						{
+							KMS: &v1alpha1.KMS{
+								Name:                "kms-1",
+								SocketListenAddress: "unix:///var/run/kms-1.sock",
+								Plugin:              &v1alpha1.KMSPlugin{Image: "public.ecr.aws/kms-plugin:v1"},
+							},
+						},
+					},
+					Resources: resources,
+				},
+			},
+		},
+		{
+			testName:    "valid_config_with_kms_plugin",
+			expectedErr: nil,
+			encryptionConfig: &[]v1alpha1.EtcdEncryption{
+				{
+					Providers: []v1alpha1.EtcdEncryptionProvider{
+						{
+							KMS: &v1alpha1.KMS{
+								Name:                "kms-1",
+								SocketListenAddress: "unix:///var/run/kmsplugin/kms-1.sock",
+								Plugin: &v1alpha1.KMSPlugin{
+									Image: "public.ecr.aws/kms-plugin:v1",
+									Args:  []string{"--listen=/var/run/kmsplugin/kms-1.sock"},
+								},
+							},
+						},
+					},
+					Resources: resources,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1358,6 +1433,74 @@ func TestClusterUpdateEtcdEncryption(t *testing.T) {
 	}
 }
 
+func kmsEncryptionConfig(name, socket string) *[]v1alpha1.EtcdEncryption {
+	return &[]v1alpha1.EtcdEncryption{
+		{
+			Providers: []v1alpha1.EtcdEncryptionProvider{
+				{
+					KMS: &v1alpha1.KMS{Name: name, SocketListenAddress: socket},
+				},
+			},
+			Resources: []string{"secrets"},
+		},
+	}
+}
+
+func TestClusterUpdateEtcdEncryptionKeyRotation(t *testing.T) {
+	tests := []struct {
+		testName    string
+		phase       v1alpha1.EtcdEncryptionKeyRotationPhase
+		newConfig   *[]v1alpha1.EtcdEncryption
+		expectedErr string
+	}{
+		{
+			testName:  "rotate key",
+			newConfig: kmsEncryptionConfig("kms-2", "unix:///var/run/kmsplugin/kms-2.sock"),
+		},
+		{
+			testName:    "rotate key same socket",
+			newConfig:   kmsEncryptionConfig("kms-2", "unix:///var/run/kmsplugin/kms-1.sock"),
+			expectedErr: "the new provider must listen on a different socket than the provider kms-1 it replaces",
+		},
+		{
+			testName:  "update provider same socket",
+			newConfig: kmsEncryptionConfig("kms-1", "unix:///var/run/kmsplugin/kms-1.sock"),
+		},
+		{
+			testName:    "change during rotation",
+			phase:       v1alpha1.EtcdEncryptionAddingProvider,
+			newConfig:   kmsEncryptionConfig("kms-3", "unix:///var/run/kmsplugin/kms-3.sock"),
+			expectedErr: "etcdEncryption cannot be changed while the encryption key is being rotated",
+		},
+		{
+			testName:    "disable during rotation",
+			phase:       v1alpha1.EtcdEncryptionReencryptingResources,
+			expectedErr: "etcdEncryption cannot be changed while the encryption key is being rotated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			oldCluster := baseCluster()
+			oldCluster.Spec.EtcdEncryption = kmsEncryptionConfig("kms-1", "unix:///var/run/kmsplugin/kms-1.sock")
+			oldCluster.Status.EtcdEncryption = &v1alpha1.EtcdEncryptionStatus{
+				Providers:        (*oldCluster.Spec.EtcdEncryption)[0].Providers,
+				KeyRotationPhase: tt.phase,
+			}
+			newCluster := oldCluster.DeepCopy()
+			newCluster.Spec.EtcdEncryption = tt.newConfig
+
+			_, err := newCluster.ValidateUpdate(context.TODO(), oldCluster, newCluster)
+			if tt.expectedErr == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.expectedErr)))
+			}
+		})
+	}
+}
+
 func TestClusterCreateCloudStackMultipleWorkerNodeGroupsValidation(t *testing.T) {
 	features.ClearCache()
 	cluster := baseCluster()
@@ -78,3 +78,40 @@ const (
 	// create a cluster.
 	SkipUpgradesForDefaultCNIConfiguredReason = "SkipUpgradesForDefaultCNIConfigured"
 )
+
+const (
+	// GitOpsDriftedCondition reports whether the spec of the Cluster, its datacenter config or its machine configs
+	// were changed outside of the GitOps repository after flux last applied them. It's only set for clusters
+	// managed with flux.
+	GitOpsDriftedCondition ConditionType = "GitOpsDrifted"
+
+	// ChangedOutsideGitOpsReason reports that the spec of some cluster objects was changed by a client other than flux.
+	ChangedOutsideGitOpsReason = "ChangedOutsideGitOps"
+
+	// InSyncWithGitOpsReason reports that the spec of the cluster objects was last set by flux.
+	InSyncWithGitOpsReason = "InSyncWithGitOps"
+)
+
+const (
+	// EtcdEncryptionKeyRotatedCondition reports the progress of the rotation of the etcd encryption key, started
+	// by replacing the encryption provider in the cluster spec. It's only set once a rotation starts.
+	EtcdEncryptionKeyRotatedCondition ConditionType = "EtcdEncryptionKeyRotated"
+
+	// EtcdEncryptionKeyRotationInProgressReason reports that the etcd encryption key is being rotated.
+	EtcdEncryptionKeyRotationInProgressReason = "EtcdEncryptionKeyRotationInProgress"
+
+	// EtcdEncryptionReencryptionFailedReason reports that the encrypted resources couldn't be re-encrypted with the new key.
+	EtcdEncryptionReencryptionFailedReason = "EtcdEncryptionReencryptionFailed"
+)
+
+const (
+	// UpgradeAllowedCondition reports whether the controller is allowed to roll out changes to the cluster machines.
+	// It's only set for clusters with a maintenance window or with their upgrade paused.
+	UpgradeAllowedCondition ConditionType = "UpgradeAllowed"
+
+	// OutsideMaintenanceWindowReason reports that spec changes are held until the next maintenance window starts.
+	OutsideMaintenanceWindowReason = "OutsideMaintenanceWindow"
+
+	// UpgradePausedReason reports that the rollout of the cluster machines is paused with the upgrade-paused annotation.
+	UpgradePausedReason = "UpgradePaused"
+)
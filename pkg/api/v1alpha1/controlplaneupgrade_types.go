@@ -40,6 +40,9 @@ type ControlPlaneUpgradeStatus struct {
 
 	// Ready denotes that the all control planes have finished upgrading and are ready.
 	Ready bool `json:"ready,omitempty"`
+
+	// Paused denotes that the upgrade of the remaining machines is on hold because the cluster upgrade is paused.
+	Paused bool `json:"paused,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -2,10 +2,12 @@ package v1alpha1
 
 import (
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
@@ -17,6 +19,9 @@ var (
 	DefaultKMSTimeout = metav1.Duration{Duration: time.Second * 3}
 )
 
+// KMSPluginSocketDir is the directory of the control plane nodes mounted in the kube-apiserver for the KMS plugin sockets.
+const KMSPluginSocketDir = "/var/run/kmsplugin/"
+
 // ValidateEtcdEncryptionConfig validates the etcd encryption configuration.
 func ValidateEtcdEncryptionConfig(config *[]EtcdEncryption) error {
 	if config == nil {
@@ -68,9 +73,49 @@ func validateKMSConfig(kms *KMS) error {
 	if u.Scheme != "unix" {
 		return errors.Errorf("kms.socketListenAddress has unsupported scheme: %v", u.Scheme)
 	}
+	if kms.Plugin != nil {
+		if err := validateKMSPlugin(kms, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateKMSPlugin(kms *KMS, socket *url.URL) error {
+	if len(kms.Plugin.Image) == 0 {
+		return errors.New("kms.plugin.image cannot be empty")
+	}
+	// The name is used for the static pod of the plugin.
+	if errs := validation.IsDNS1123Label(kms.Name); len(errs) != 0 {
+		return errors.Errorf("kms.name must be a valid DNS label to deploy the plugin: %s", strings.Join(errs, ", "))
+	}
+	if !strings.HasPrefix(socket.Path, KMSPluginSocketDir) {
+		return errors.Errorf("kms.socketListenAddress must be in %s to deploy the plugin", KMSPluginSocketDir)
+	}
 	return nil
 }
 
+// ControlPlaneEtcdEncryption returns the etcd encryption config for the control plane. It's the one in the spec,
+// except during a key rotation, when the providers are the ones in the status: both the old and the new one.
+func (c *Cluster) ControlPlaneEtcdEncryption() *[]EtcdEncryption {
+	if !c.EtcdEncryptionKeyRotationInProgress() || c.Spec.EtcdEncryption == nil || len(*c.Spec.EtcdEncryption) == 0 {
+		return c.Spec.EtcdEncryption
+	}
+
+	// Only one encryption config is supported, so the status providers always belong to the first one.
+	confs := make([]EtcdEncryption, 0, len(*c.Spec.EtcdEncryption))
+	for _, conf := range *c.Spec.EtcdEncryption {
+		confs = append(confs, *conf.DeepCopy())
+	}
+	confs[0].Providers = c.Status.EtcdEncryption.Providers
+	return &confs
+}
+
+// EtcdEncryptionKeyRotationInProgress returns true if the etcd encryption key of the cluster is being rotated.
+func (c *Cluster) EtcdEncryptionKeyRotationInProgress() bool {
+	return c.Status.EtcdEncryption != nil && c.Status.EtcdEncryption.KeyRotationPhase != ""
+}
+
 func setEtcdEncryptionConfigDefaults(cluster *Cluster) error {
 	if cluster.Spec.EtcdEncryption == nil {
 		return nil
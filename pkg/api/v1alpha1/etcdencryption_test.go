@@ -0,0 +1,60 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterControlPlaneEtcdEncryption(t *testing.T) {
+	oldProvider := EtcdEncryptionProvider{KMS: &KMS{Name: "kms-1", SocketListenAddress: "unix:///var/run/kmsplugin/kms-1.sock"}}
+	newProvider := EtcdEncryptionProvider{KMS: &KMS{Name: "kms-2", SocketListenAddress: "unix:///var/run/kmsplugin/kms-2.sock"}}
+	spec := &[]EtcdEncryption{
+		{
+			Providers: []EtcdEncryptionProvider{newProvider},
+			Resources: []string{"secrets"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		status *EtcdEncryptionStatus
+		want   *[]EtcdEncryption
+	}{
+		{
+			name: "no status",
+			want: spec,
+		},
+		{
+			name:   "no rotation",
+			status: &EtcdEncryptionStatus{Providers: []EtcdEncryptionProvider{oldProvider}},
+			want:   spec,
+		},
+		{
+			name: "rotation in progress",
+			status: &EtcdEncryptionStatus{
+				Providers:        []EtcdEncryptionProvider{newProvider, oldProvider},
+				KeyRotationPhase: EtcdEncryptionSwitchingProvider,
+			},
+			want: &[]EtcdEncryption{
+				{
+					Providers: []EtcdEncryptionProvider{newProvider, oldProvider},
+					Resources: []string{"secrets"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := &Cluster{
+				Spec:   ClusterSpec{EtcdEncryption: spec},
+				Status: ClusterStatus{EtcdEncryption: tt.status},
+			}
+			g.Expect(c.ControlPlaneEtcdEncryption()).To(Equal(tt.want))
+			g.Expect(c.EtcdEncryptionKeyRotationInProgress()).To(Equal(tt.status != nil && tt.status.KeyRotationPhase != ""))
+			g.Expect(c.Spec.EtcdEncryption).To(Equal(spec))
+		})
+	}
+}
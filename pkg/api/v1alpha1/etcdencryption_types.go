@@ -27,4 +27,46 @@ type KMS struct {
 	SocketListenAddress string `json:"socketListenAddress"`
 	// Timeout for kube-apiserver to wait for KMS plugin. Default is 3s.
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Plugin defines a KMS plugin deployed and upgraded by EKS Anywhere on the control plane nodes.
+	// If not set, the KMS plugin listening on socketListenAddress must be deployed separately.
+	// +optional
+	Plugin *KMSPlugin `json:"plugin,omitempty"`
+}
+
+// KMSPlugin defines the KMS plugin that EKS Anywhere runs as a static pod on every control plane node.
+type KMSPlugin struct {
+	// Image is the container image of the KMS plugin.
+	Image string `json:"image"`
+	// Command overrides the entrypoint of the image.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args are the arguments of the KMS plugin. They should configure the plugin to listen on the socketListenAddress.
+	// +optional
+	Args []string `json:"args,omitempty"`
+}
+
+// EtcdEncryptionKeyRotationPhase is a step of an etcd encryption key rotation.
+type EtcdEncryptionKeyRotationPhase string
+
+const (
+	// EtcdEncryptionAddingProvider adds the new provider after the current one, so all the API servers
+	// can read data encrypted with the new key before any of them starts writing it.
+	EtcdEncryptionAddingProvider EtcdEncryptionKeyRotationPhase = "AddingProvider"
+	// EtcdEncryptionSwitchingProvider moves the new provider first, so new data is encrypted with the new key.
+	EtcdEncryptionSwitchingProvider EtcdEncryptionKeyRotationPhase = "SwitchingProvider"
+	// EtcdEncryptionReencryptingResources rewrites all the encrypted resources so they are encrypted with the new key.
+	EtcdEncryptionReencryptingResources EtcdEncryptionKeyRotationPhase = "ReencryptingResources"
+	// EtcdEncryptionRemovingProvider removes the old provider once no data is encrypted with the old key.
+	EtcdEncryptionRemovingProvider EtcdEncryptionKeyRotationPhase = "RemovingProvider"
+)
+
+// EtcdEncryptionStatus defines the observed state of the etcd encryption of the cluster.
+type EtcdEncryptionStatus struct {
+	// Providers are the encryption providers configured in the control plane, in order. The first one
+	// encrypts new data, all of them can decrypt existing data.
+	// +optional
+	Providers []EtcdEncryptionProvider `json:"providers,omitempty"`
+	// KeyRotationPhase is the current step of the encryption key rotation. Empty if no rotation is in progress.
+	// +optional
+	KeyRotationPhase EtcdEncryptionKeyRotationPhase `json:"keyRotationPhase,omitempty"`
 }
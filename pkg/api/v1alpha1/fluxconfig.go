@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
@@ -13,14 +15,25 @@ const (
 	RsaAlgorithm     = "rsa"
 	EcdsaAlgorithm   = "ecdsa"
 	Ed25519Algorithm = "ed25519"
+
+	// DefaultGitlabHostname is the GitLab instance used when the FluxConfig doesn't specify one.
+	DefaultGitlabHostname = "gitlab.com"
 )
 
+var gitlabPathSegmentRegex = regexp.MustCompile(`^[0-9A-Za-z_.][0-9A-Za-z-_.]*$`)
+
 func validateFluxConfig(config *FluxConfig) error {
-	if config.Spec.Git != nil && config.Spec.Github != nil {
+	providers := 0
+	for _, set := range []bool{config.Spec.Git != nil, config.Spec.Github != nil, config.Spec.Gitlab != nil} {
+		if set {
+			providers++
+		}
+	}
+	if providers > 1 {
 		return errors.New("must specify only one provider")
 	}
-	if config.Spec.Git == nil && config.Spec.Github == nil {
-		return errors.New("must specify a provider. Valid options are git and github")
+	if providers == 0 {
+		return errors.New("must specify a provider. Valid options are git, github and gitlab")
 	}
 	if config.Spec.Github != nil {
 		err := validateGithubProviderConfig(*config.Spec.Github)
@@ -28,6 +41,12 @@ func validateFluxConfig(config *FluxConfig) error {
 			return err
 		}
 	}
+	if config.Spec.Gitlab != nil {
+		err := validateGitlabProviderConfig(*config.Spec.Gitlab)
+		if err != nil {
+			return err
+		}
+	}
 	if config.Spec.Git != nil {
 		err := validateGitProviderConfig(*config.Spec.Git)
 		if err != nil {
@@ -74,6 +93,38 @@ func validateGithubProviderConfig(config GithubProviderConfig) error {
 	return nil
 }
 
+func validateGitlabProviderConfig(config GitlabProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in gitlabProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in gitlabProviderConfig; repository is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+
+	// Groups can be nested, so the owner of a group repository is the full path of the group.
+	segments := strings.Split(config.Owner, "/")
+	if config.Personal && len(segments) > 1 {
+		return fmt.Errorf("%s is not a valid gitlab user name; personal repositories can't be owned by a subgroup", config.Owner)
+	}
+	for _, s := range segments {
+		if !gitlabPathSegmentRegex.MatchString(s) {
+			return fmt.Errorf("%s is not a valid gitlab owner, the user name or each group in the path can contain only letters, digits, '_', '-' and '.'", config.Owner)
+		}
+	}
+
+	if config.Hostname != "" {
+		u, err := url.Parse("https://" + config.Hostname)
+		if err != nil || u.Host != config.Hostname || u.Path != "" {
+			return fmt.Errorf("'hostname' %s is not valid in gitlabProviderConfig; it must be a host name, optionally with a port, without scheme or path", config.Hostname)
+		}
+	}
+
+	return nil
+}
+
 func validateRepositoryUrl(repositoryUrl string) error {
 	url, err := url.Parse(repositoryUrl)
 	if err != nil {
@@ -105,4 +156,8 @@ func setFluxConfigDefaults(flux *FluxConfig) {
 	if len(c.Branch) == 0 {
 		c.Branch = FluxDefaultBranch
 	}
+
+	if c.Gitlab != nil && len(c.Gitlab.Hostname) == 0 {
+		c.Gitlab.Hostname = DefaultGitlabHostname
+	}
 }
@@ -188,6 +188,138 @@ func TestValidateFluxConfig(t *testing.T) {
 			gitProvider: true,
 			error:       nil,
 		},
+		{
+			testName: "valid fluxconfig gitlab subgroup",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux-gitlab",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "platform/clusters",
+						Repository: "flux-fleet",
+						Hostname:   "gitlab.example.com:8443",
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "gitlab and github",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux-gitlab",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("must specify only one provider"),
+		},
+		{
+			testName: "gitlab empty owner",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux-gitlab",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'owner' is not set or empty in gitlabProviderConfig; owner is a required field"),
+		},
+		{
+			testName: "gitlab personal subgroup",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux-gitlab",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe/clusters",
+						Repository: "flux-fleet",
+						Personal:   true,
+					},
+				},
+			},
+			wantErr: true,
+			error:   fmt.Errorf("janedoe/clusters is not a valid gitlab user name; personal repositories can't be owned by a subgroup"),
+		},
+		{
+			testName: "gitlab invalid owner",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux-gitlab",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "platform//clusters",
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   fmt.Errorf("platform//clusters is not a valid gitlab owner, the user name or each group in the path can contain only letters, digits, '_', '-' and '.'"),
+		},
+		{
+			testName: "gitlab hostname with scheme",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux-gitlab",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "platform",
+						Repository: "flux-fleet",
+						Hostname:   "https://gitlab.example.com",
+					},
+				},
+			},
+			wantErr: true,
+			error:   fmt.Errorf("'hostname' https://gitlab.example.com is not valid in gitlabProviderConfig; it must be a host name, optionally with a port, without scheme or path"),
+		},
 	}
 
 	for _, tt := range tests {
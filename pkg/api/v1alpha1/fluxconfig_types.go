@@ -35,6 +35,9 @@ type FluxConfigSpec struct {
 
 	// Used to specify Git provider that will be used to host the git files
 	Git *GitProviderConfig `json:"git,omitempty"`
+
+	// Used to specify GitLab provider to host the Git repo and host the git files
+	Gitlab *GitlabProviderConfig `json:"gitlab,omitempty"`
 }
 
 type GithubProviderConfig struct {
@@ -56,6 +59,21 @@ type GitProviderConfig struct {
 	SshKeyAlgorithm string `json:"sshKeyAlgorithm,omitempty"`
 }
 
+type GitlabProviderConfig struct {
+	// Owner is the user name or the full path of the group, including subgroups, that owns the repository.
+	// For example, platform/clusters.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// if true, the owner is assumed to be a GitLab user; otherwise a group.
+	Personal bool `json:"personal,omitempty"`
+
+	// Hostname of the GitLab instance, for self-managed GitLab. Defaults to gitlab.com.
+	Hostname string `json:"hostname,omitempty"`
+}
+
 // FluxConfigStatus defines the observed state of FluxConfig.
 type FluxConfigStatus struct{}
 
@@ -97,7 +115,7 @@ func (e *FluxConfigSpec) Equal(n *FluxConfigSpec) bool {
 	if e.ClusterConfigPath != n.ClusterConfigPath {
 		return false
 	}
-	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github)
+	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github) && e.Gitlab.Equal(n.Gitlab)
 }
 
 func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
@@ -110,6 +128,16 @@ func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
 	return *e == *n
 }
 
+func (e *GitlabProviderConfig) Equal(n *GitlabProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
 func (e *GitProviderConfig) Equal(n *GitProviderConfig) bool {
 	if e == n {
 		return true
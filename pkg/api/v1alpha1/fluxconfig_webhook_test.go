@@ -105,6 +105,45 @@ func TestValidateUpdateHasValidatedSpec(t *testing.T) {
 	f.Expect(err).To(MatchError(ContainSubstring("must specify only one provider")))
 }
 
+func TestValidateCreateGitlab(t *testing.T) {
+	ctx := context.Background()
+	fNew := fluxConfig()
+	fNew.Spec.Gitlab = &v1alpha1.GitlabProviderConfig{
+		Owner:      "platform/clusters",
+		Repository: "flux-fleet",
+	}
+
+	f := NewWithT(t)
+	f.Expect(fNew.ValidateCreate(ctx, &fNew)).Error().To(Succeed())
+}
+
+func TestValidateCreateGitlabInvalid(t *testing.T) {
+	ctx := context.Background()
+	fNew := fluxConfig()
+	fNew.Spec.Gitlab = &v1alpha1.GitlabProviderConfig{
+		Owner: "platform/clusters",
+	}
+
+	f := NewWithT(t)
+	_, err := fNew.ValidateCreate(ctx, &fNew)
+	f.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	f.Expect(err).To(MatchError(ContainSubstring("'repository' is not set or empty in gitlabProviderConfig")))
+}
+
+func TestValidateUpdateGitlabImmutable(t *testing.T) {
+	ctx := context.Background()
+	fOld := fluxConfig()
+	fOld.Spec.Gitlab = &v1alpha1.GitlabProviderConfig{
+		Owner:      "platform/clusters",
+		Repository: "flux-fleet",
+	}
+	c := fOld.DeepCopy()
+	c.Spec.Gitlab.Owner = "platform/other"
+
+	f := NewWithT(t)
+	f.Expect(c.ValidateUpdate(ctx, &fOld, c)).Error().To(MatchError(ContainSubstring("Forbidden: config is immutable")))
+}
+
 func fluxConfig() v1alpha1.FluxConfig {
 	return v1alpha1.FluxConfig{
 		TypeMeta:   metav1.TypeMeta{},
@@ -33,6 +33,9 @@ type MachineDeploymentUpgradeStatus struct {
 
 	// Ready denotes that the all machines in the MachineDeployment have finished upgrading and are ready.
 	Ready bool `json:"ready,omitempty"`
+
+	// Paused denotes that the upgrade of the remaining machines is on hold because the cluster upgrade is paused.
+	Paused bool `json:"paused,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,110 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+)
+
+// maintenanceWindowScheduleParser parses standard 5-field cron expressions: minute, hour, day of month, month
+// and day of week.
+var maintenanceWindowScheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// parseMaintenanceWindowSchedule parses the schedule of a window. The schedule is always evaluated in the time zone
+// of the maintenance window, so it can't set its own.
+func parseMaintenanceWindowSchedule(schedule string) (cron.Schedule, error) {
+	if strings.HasPrefix(schedule, "TZ=") || strings.HasPrefix(schedule, "CRON_TZ=") {
+		return nil, errors.Errorf("invalid cron expression %q: it can't set a time zone, use timeZone instead", schedule)
+	}
+	parsed, err := maintenanceWindowScheduleParser.Parse(schedule)
+	if err != nil {
+		return nil, errors.Errorf("invalid cron expression %q: %v", schedule, err)
+	}
+	return parsed, nil
+}
+
+// ValidateMaintenanceWindow validates the maintenance window configuration.
+func ValidateMaintenanceWindow(config *MaintenanceWindowConfiguration) error {
+	if config == nil {
+		return nil
+	}
+
+	if _, err := time.LoadLocation(config.TimeZone); err != nil {
+		return errors.Errorf("maintenanceWindow: invalid timeZone %s: %v", config.TimeZone, err)
+	}
+
+	if len(config.Windows) == 0 {
+		return errors.New("maintenanceWindow: at least one window is required")
+	}
+
+	for i, w := range config.Windows {
+		if _, err := parseMaintenanceWindowSchedule(w.Schedule); err != nil {
+			return errors.Errorf("maintenanceWindow: windows[%d]: %v", i, err)
+		}
+		if w.Duration.Duration <= 0 {
+			return errors.Errorf("maintenanceWindow: windows[%d]: duration must be positive", i)
+		}
+	}
+
+	return nil
+}
+
+func validateMaintenanceWindow(c *Cluster) error {
+	return ValidateMaintenanceWindow(c.Spec.MaintenanceWindow)
+}
+
+// InMaintenanceWindow returns true if the controller is allowed to roll out changes to the cluster at time t,
+// which is always the case for clusters without a maintenance window. When it's not, it also returns the time
+// at which the next window starts.
+func (c *Cluster) InMaintenanceWindow(t time.Time) (bool, time.Time, error) {
+	config := c.Spec.MaintenanceWindow
+	if config == nil {
+		return true, time.Time{}, nil
+	}
+
+	loc, err := time.LoadLocation(config.TimeZone)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("loading maintenance window time zone: %v", err)
+	}
+	t = t.In(loc)
+
+	var next time.Time
+	for _, w := range config.Windows {
+		schedule, err := parseMaintenanceWindowSchedule(w.Schedule)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("parsing maintenance window schedule: %v", err)
+		}
+
+		// The window is open if it started less than its duration ago.
+		if start := schedule.Next(t.Add(-w.Duration.Duration)); !start.IsZero() && !start.After(t) {
+			return true, time.Time{}, nil
+		}
+
+		if start := schedule.Next(t); !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+
+	return false, next, nil
+}
+
+// UpgradePaused returns true if the rollout of the cluster machines is paused.
+func (c *Cluster) UpgradePaused() bool {
+	return c.Annotations[UpgradePausedAnnotation] == "true"
+}
+
+// PauseUpgrade pauses the rollout of the cluster machines.
+func (c *Cluster) PauseUpgrade() {
+	if c.Annotations == nil {
+		c.Annotations = map[string]string{}
+	}
+	c.Annotations[UpgradePausedAnnotation] = "true"
+}
+
+// ResumeUpgrade resumes the rollout of the cluster machines.
+func (c *Cluster) ResumeUpgrade() {
+	delete(c.Annotations, UpgradePausedAnnotation)
+}
@@ -0,0 +1,177 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+	// The binaries embed the time zone database, so the tests don't depend on the host having it.
+	_ "time/tzdata"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *MaintenanceWindowConfiguration
+		wantErr string
+	}{
+		{
+			name: "no maintenance window",
+		},
+		{
+			name: "valid",
+			config: &MaintenanceWindowConfiguration{
+				TimeZone: "Europe/Madrid",
+				Windows: []MaintenanceWindow{
+					{Schedule: "0 2 * * sat,sun", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+				},
+			},
+		},
+		{
+			name: "invalid time zone",
+			config: &MaintenanceWindowConfiguration{
+				TimeZone: "Mars/Olympus_Mons",
+				Windows: []MaintenanceWindow{
+					{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			wantErr: "maintenanceWindow: invalid timeZone Mars/Olympus_Mons",
+		},
+		{
+			name:    "no windows",
+			config:  &MaintenanceWindowConfiguration{},
+			wantErr: "maintenanceWindow: at least one window is required",
+		},
+		{
+			name: "invalid schedule",
+			config: &MaintenanceWindowConfiguration{
+				Windows: []MaintenanceWindow{
+					{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+					{Schedule: "0 25 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			wantErr: "maintenanceWindow: windows[1]: invalid cron expression \"0 25 * * *\": end of range (25) above maximum (23)",
+		},
+		{
+			name: "schedule with time zone",
+			config: &MaintenanceWindowConfiguration{
+				Windows: []MaintenanceWindow{
+					{Schedule: "CRON_TZ=Asia/Tokyo 0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			wantErr: "maintenanceWindow: windows[0]: invalid cron expression \"CRON_TZ=Asia/Tokyo 0 2 * * *\": it can't set a time zone, use timeZone instead",
+		},
+		{
+			name: "no duration",
+			config: &MaintenanceWindowConfiguration{
+				Windows: []MaintenanceWindow{
+					{Schedule: "0 2 * * *"},
+				},
+			},
+			wantErr: "maintenanceWindow: windows[0]: duration must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateMaintenanceWindow(&Cluster{Spec: ClusterSpec{MaintenanceWindow: tt.config}})
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterInMaintenanceWindow(t *testing.T) {
+	// Every Saturday and Sunday from 2am to 6am, and the first day of the month from 10pm to midnight in New York.
+	config := &MaintenanceWindowConfiguration{
+		TimeZone: "America/New_York",
+		Windows: []MaintenanceWindow{
+			{Schedule: "0 2 * * sat,sun", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			{Schedule: "0 22 1 * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+		},
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		config   *MaintenanceWindowConfiguration
+		now      time.Time
+		wantIn   bool
+		wantNext time.Time
+	}{
+		{
+			name:   "no maintenance window",
+			now:    time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+			wantIn: true,
+		},
+		{
+			name:     "before the window",
+			config:   config,
+			now:      time.Date(2024, 1, 10, 12, 0, 0, 0, newYork),
+			wantNext: time.Date(2024, 1, 13, 2, 0, 0, 0, newYork),
+		},
+		{
+			name:   "window start",
+			config: config,
+			now:    time.Date(2024, 1, 13, 2, 0, 0, 0, newYork),
+			wantIn: true,
+		},
+		{
+			name:   "inside the window",
+			config: config,
+			now:    time.Date(2024, 1, 14, 5, 59, 0, 0, newYork),
+			wantIn: true,
+		},
+		{
+			name:     "window end",
+			config:   config,
+			now:      time.Date(2024, 1, 14, 6, 0, 0, 0, newYork),
+			wantNext: time.Date(2024, 1, 20, 2, 0, 0, 0, newYork),
+		},
+		{
+			name:   "inside the window in another time zone",
+			config: config,
+			now:    time.Date(2024, 2, 2, 4, 30, 0, 0, time.UTC),
+			wantIn: true,
+		},
+		{
+			name:     "next window from another schedule",
+			config:   config,
+			now:      time.Date(2024, 1, 29, 12, 0, 0, 0, newYork),
+			wantNext: time.Date(2024, 2, 1, 22, 0, 0, 0, newYork),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := &Cluster{Spec: ClusterSpec{MaintenanceWindow: tt.config}}
+
+			in, next, err := c.InMaintenanceWindow(tt.now)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(in).To(Equal(tt.wantIn))
+			g.Expect(next.Equal(tt.wantNext)).To(BeTrue(), "expected next window at %s, got %s", tt.wantNext, next)
+		})
+	}
+}
+
+func TestClusterPauseUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{}
+	g.Expect(c.UpgradePaused()).To(BeFalse())
+
+	c.PauseUpgrade()
+	g.Expect(c.UpgradePaused()).To(BeTrue())
+	g.Expect(c.Annotations).To(HaveKeyWithValue(UpgradePausedAnnotation, "true"))
+
+	c.ResumeUpgrade()
+	g.Expect(c.UpgradePaused()).To(BeFalse())
+}
@@ -1,7 +1,10 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
+	"path"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -93,6 +96,101 @@ func validateVSphereMachineConfig(config *VSphereMachineConfig) error {
 	if err := validateHostOSConfig(config.Spec.HostOSConfiguration, config.Spec.OSFamily); err != nil {
 		return fmt.Errorf("HostOSConfiguration is invalid for VSphereMachineConfig %s: %v", config.Name, err)
 	}
+	if err := validateVSphereDataDisks(config.Spec.DataDisks, config.Spec.OSFamily); err != nil {
+		return fmt.Errorf("dataDisks are invalid for VSphereMachineConfig %s: %v", config.Name, err)
+	}
+	if err := validateVSpherePCIDevices(config.Spec.PCIDevices); err != nil {
+		return fmt.Errorf("pciDevices are invalid for VSphereMachineConfig %s: %v", config.Name, err)
+	}
+
+	return nil
+}
+
+func validateVSphereDataDisks(disks []VSphereDataDisk, osFamily OSFamily) error {
+	if len(disks) == 0 {
+		return nil
+	}
+
+	// Bottlerocket doesn't run cloud-init, so there is nothing to format and mount the disks with.
+	if osFamily == Bottlerocket {
+		return fmt.Errorf("data disks are not supported for osFamily %s, please use one of the following: %s, %s", osFamily, Ubuntu, RedHat)
+	}
+
+	names := map[string]struct{}{}
+	devices := map[string]struct{}{}
+	mountPaths := map[string]struct{}{}
+	labels := map[string]struct{}{}
+	for _, d := range disks {
+		if d.Name == "" {
+			return errors.New("name is required")
+		}
+		if d.SizeGiB <= 0 {
+			return fmt.Errorf("disk %s sizeGiB must be positive", d.Name)
+		}
+		switch d.ProvisioningMode {
+		case "", VSphereThinProvisioningMode, VSphereThickProvisioningMode, VSphereEagerlyZeroedProvisioningMode:
+		default:
+			return fmt.Errorf("disk %s provisioningMode %s is not supported, please use one of the following: %s, %s, %s",
+				d.Name, d.ProvisioningMode, VSphereThinProvisioningMode, VSphereThickProvisioningMode, VSphereEagerlyZeroedProvisioningMode)
+		}
+		if !strings.HasPrefix(d.Device, "/dev/") {
+			return fmt.Errorf("disk %s device %s must be a block device under /dev", d.Name, d.Device)
+		}
+		if !path.IsAbs(d.MountPath) || path.Clean(d.MountPath) == "/" {
+			return fmt.Errorf("disk %s mountPath %s must be an absolute path other than /", d.Name, d.MountPath)
+		}
+		if d.Filesystem != "ext4" && d.Filesystem != "xfs" {
+			return fmt.Errorf("disk %s filesystem %s is not supported, please use one of the following: ext4, xfs", d.Name, d.Filesystem)
+		}
+		if d.Label == "" {
+			return fmt.Errorf("disk %s label is required", d.Name)
+		}
+		// kubeadm requires the etcd data directory to be empty and ext4 creates a lost+found directory.
+		if path.Clean(d.MountPath) == "/var/lib/etcd" && d.Filesystem == "ext4" {
+			return fmt.Errorf("disk %s mounted at /var/lib/etcd must use the xfs filesystem", d.Name)
+		}
+
+		if err := addUniqueDiskField(names, "name", d.Name); err != nil {
+			return err
+		}
+		if err := addUniqueDiskField(devices, "device", d.Device); err != nil {
+			return err
+		}
+		if err := addUniqueDiskField(mountPaths, "mountPath", path.Clean(d.MountPath)); err != nil {
+			return err
+		}
+		if err := addUniqueDiskField(labels, "label", d.Label); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addUniqueDiskField(seen map[string]struct{}, field, value string) error {
+	if _, ok := seen[value]; ok {
+		return fmt.Errorf("%s %s is used by more than one disk", field, value)
+	}
+	seen[value] = struct{}{}
+	return nil
+}
+
+func validateVSpherePCIDevices(devices []VSpherePCIDevice) error {
+	for i, d := range devices {
+		passthrough := d.DeviceID != nil || d.VendorID != nil
+		if passthrough && d.VGPUProfile != "" {
+			return fmt.Errorf("device %d can't set both vGPUProfile and deviceId/vendorId", i)
+		}
+		if !passthrough && d.VGPUProfile == "" {
+			return fmt.Errorf("device %d must set either vGPUProfile or deviceId and vendorId", i)
+		}
+		if passthrough && (d.DeviceID == nil || d.VendorID == nil) {
+			return fmt.Errorf("device %d must set both deviceId and vendorId", i)
+		}
+		if passthrough && (*d.DeviceID <= 0 || *d.VendorID <= 0) {
+			return fmt.Errorf("device %d deviceId and vendorId must be positive", i)
+		}
+	}
 
 	return nil
 }
@@ -5,6 +5,8 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 func TestVSphereMachineConfigValidate(t *testing.T) {
@@ -200,6 +202,212 @@ func TestVSphereMachineConfigValidate(t *testing.T) {
 	}
 }
 
+func TestVSphereMachineConfigValidateDataDisks(t *testing.T) {
+	containerdDisk := func() VSphereDataDisk {
+		return VSphereDataDisk{
+			Name:       "containerd",
+			SizeGiB:    50,
+			Device:     "/dev/sdb",
+			MountPath:  "/var/lib/containerd",
+			Filesystem: "ext4",
+			Label:      "containerd",
+		}
+	}
+	etcdDisk := VSphereDataDisk{
+		Name:             "etcd",
+		SizeGiB:          20,
+		ProvisioningMode: VSphereEagerlyZeroedProvisioningMode,
+		Device:           "/dev/sdc",
+		MountPath:        "/var/lib/etcd",
+		Filesystem:       "xfs",
+		Label:            "etcd",
+	}
+
+	tests := []struct {
+		name     string
+		osFamily OSFamily
+		disk     func(d *VSphereDataDisk)
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			osFamily: Ubuntu,
+		},
+		{
+			name:     "bottlerocket",
+			osFamily: Bottlerocket,
+			wantErr:  "data disks are not supported for osFamily bottlerocket",
+		},
+		{
+			name:     "no name",
+			osFamily: Ubuntu,
+			disk:     func(d *VSphereDataDisk) { d.Name = "" },
+			wantErr:  "name is required",
+		},
+		{
+			name:     "no size",
+			osFamily: Ubuntu,
+			disk:     func(d *VSphereDataDisk) { d.SizeGiB = 0 },
+			wantErr:  "disk containerd sizeGiB must be positive",
+		},
+		{
+			name:     "invalid provisioning mode",
+			osFamily: RedHat,
+			disk:     func(d *VSphereDataDisk) { d.ProvisioningMode = "Lazy" },
+			wantErr:  "disk containerd provisioningMode Lazy is not supported",
+		},
+		{
+			name:     "invalid device",
+			osFamily: Ubuntu,
+			disk:     func(d *VSphereDataDisk) { d.Device = "sdb" },
+			wantErr:  "disk containerd device sdb must be a block device under /dev",
+		},
+		{
+			name:     "relative mount path",
+			osFamily: Ubuntu,
+			disk:     func(d *VSphereDataDisk) { d.MountPath = "var/lib/containerd" },
+			wantErr:  "disk containerd mountPath var/lib/containerd must be an absolute path other than /",
+		},
+		{
+			name:     "root mount path",
+			osFamily: Ubuntu,
+			disk:     func(d *VSphereDataDisk) { d.MountPath = "/" },
+			wantErr:  "disk containerd mountPath / must be an absolute path other than /",
+		},
+		{
+			name:     "invalid filesystem",
+			osFamily: Ubuntu,
+			disk:     func(d *VSphereDataDisk) { d.Filesystem = "btrfs" },
+			wantErr:  "disk containerd filesystem btrfs is not supported",
+		},
+		{
+			name:     "no label",
+			osFamily: Ubuntu,
+			disk:     func(d *VSphereDataDisk) { d.Label = "" },
+			wantErr:  "disk containerd label is required",
+		},
+		{
+			name:     "ext4 etcd disk",
+			osFamily: Ubuntu,
+			disk:     func(d *VSphereDataDisk) { d.MountPath = "/var/lib/etcd/" },
+			wantErr:  "disk containerd mounted at /var/lib/etcd must use the xfs filesystem",
+		},
+		{
+			name:     "duplicated device",
+			osFamily: Ubuntu,
+			disk:     func(d *VSphereDataDisk) { d.Device = etcdDisk.Device },
+			wantErr:  "device /dev/sdc is used by more than one disk",
+		},
+		{
+			name:     "duplicated mount path",
+			osFamily: Ubuntu,
+			disk: func(d *VSphereDataDisk) {
+				d.MountPath = etcdDisk.MountPath
+				d.Filesystem = "xfs"
+			},
+			wantErr: "mountPath /var/lib/etcd is used by more than one disk",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			disk := containerdDisk()
+			if tt.disk != nil {
+				tt.disk(&disk)
+			}
+			config := &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					OSFamily:     tt.osFamily,
+					Users: []UserConfiguration{
+						{
+							Name:              "ec2-user",
+							SshAuthorizedKeys: []string{"ssh_rsa"},
+						},
+					},
+					DataDisks: []VSphereDataDisk{etcdDisk, disk},
+				},
+			}
+
+			err := config.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring("dataDisks are invalid for VSphereMachineConfig test: " + tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestVSphereMachineConfigValidatePCIDevices(t *testing.T) {
+	tests := []struct {
+		name    string
+		devices []VSpherePCIDevice
+		wantErr string
+	}{
+		{
+			name: "passthrough and vgpu devices",
+			devices: []VSpherePCIDevice{
+				{DeviceID: ptr.Int32(8717), VendorID: ptr.Int32(4318), CustomLabel: "gpu"},
+				{VGPUProfile: "grid_a100-8c"},
+			},
+		},
+		{
+			name:    "empty device",
+			devices: []VSpherePCIDevice{{CustomLabel: "gpu"}},
+			wantErr: "device 0 must set either vGPUProfile or deviceId and vendorId",
+		},
+		{
+			name:    "vgpu and passthrough",
+			devices: []VSpherePCIDevice{{DeviceID: ptr.Int32(8717), VendorID: ptr.Int32(4318), VGPUProfile: "grid_a100-8c"}},
+			wantErr: "device 0 can't set both vGPUProfile and deviceId/vendorId",
+		},
+		{
+			name: "missing vendor id",
+			devices: []VSpherePCIDevice{
+				{VGPUProfile: "grid_a100-8c"},
+				{DeviceID: ptr.Int32(8717)},
+			},
+			wantErr: "device 1 must set both deviceId and vendorId",
+		},
+		{
+			name:    "negative device id",
+			devices: []VSpherePCIDevice{{DeviceID: ptr.Int32(-1), VendorID: ptr.Int32(4318)}},
+			wantErr: "device 0 deviceId and vendorId must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					OSFamily:     Ubuntu,
+					Users: []UserConfiguration{
+						{
+							Name:              "capv",
+							SshAuthorizedKeys: []string{"ssh_rsa"},
+						},
+					},
+					PCIDevices: tt.devices,
+				},
+			}
+
+			err := config.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+				g.Expect(config.PCIPassthroughEnabled()).To(BeTrue())
+			} else {
+				g.Expect(err).To(MatchError("pciDevices are invalid for VSphereMachineConfig test: " + tt.wantErr))
+			}
+		})
+	}
+}
+
 func TestVSphereMachineConfigValidateUsers(t *testing.T) {
 	g := NewWithT(t)
 	tests := []struct {
@@ -32,6 +32,97 @@ type VSphereMachineConfigSpec struct {
 	TagIDs              []string             `json:"tags,omitempty"`
 	CloneMode           CloneMode            `json:"cloneMode,omitempty"`
 	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
+	// DataDisks are additional disks attached to the VM, on top of the disk cloned from the template.
+	// They are formatted and mounted in the guest OS when the machine boots.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=29
+	DataDisks []VSphereDataDisk `json:"dataDisks,omitempty"`
+	// AntiAffinity creates a vSphere DRS VM-VM anti-affinity rule for the machines of each control plane, etcd or
+	// worker node group using this machine config, so DRS places them on different ESXi hosts.
+	// +kubebuilder:validation:Optional
+	AntiAffinity *VSphereAntiAffinity `json:"antiAffinity,omitempty"`
+	// PCIDevices are the PCI passthrough devices and vGPU profiles attached to the VM, for example GPUs.
+	// They are only supported for worker node groups and the template must use hardware version 17 or later.
+	// +kubebuilder:validation:Optional
+	PCIDevices []VSpherePCIDevice `json:"pciDevices,omitempty"`
+}
+
+// VSpherePCIDevice is a PCI device attached to a vSphere VM. It is either a PCI passthrough device,
+// identified by its vendor and device IDs, or a vGPU profile.
+type VSpherePCIDevice struct {
+	// DeviceID is the device ID of the PCI passthrough device, as a decimal integer. It must be set with VendorID.
+	// +optional
+	DeviceID *int32 `json:"deviceId,omitempty"`
+	// VendorID is the vendor ID of the PCI passthrough device, as a decimal integer. It must be set with DeviceID.
+	// +optional
+	VendorID *int32 `json:"vendorId,omitempty"`
+	// VGPUProfile is the name of the vGPU profile, for example grid_a100-8c.
+	// It can't be set with DeviceID and VendorID.
+	// +optional
+	VGPUProfile string `json:"vGPUProfile,omitempty"`
+	// CustomLabel is the label of the device in the VM hardware.
+	// +optional
+	CustomLabel string `json:"customLabel,omitempty"`
+}
+
+// VSphereAntiAffinity configures the DRS anti-affinity rules for the machines of a VSphereMachineConfig.
+type VSphereAntiAffinity struct {
+	// Mandatory makes the rule a hard requirement: DRS never runs two machines of the group on the same host,
+	// even if that leaves a machine powered off. Otherwise DRS only tries to keep them on different hosts.
+	// +optional
+	Mandatory bool `json:"mandatory,omitempty"`
+}
+
+// VSphereDataDisk is an additional disk of a vSphere VM. The disk is created in the same datastore as the VM,
+// since CAPV doesn't support placing data disks on a different datastore or storage policy.
+type VSphereDataDisk struct {
+	// Name identifies the disk. It must be unique among the disks of the machine config.
+	Name string `json:"name"`
+	// SizeGiB is the size of the disk in GiB.
+	SizeGiB int `json:"sizeGiB"`
+	// ProvisioningMode is the provisioning type of the disk: Thin, Thick or EagerlyZeroed.
+	// Defaults to the storage policy setting.
+	// +kubebuilder:validation:Enum=Thin;Thick;EagerlyZeroed
+	ProvisioningMode VSphereDiskProvisioningMode `json:"provisioningMode,omitempty"`
+	// Device is the guest OS block device of the disk, for example /dev/sdb.
+	Device string `json:"device"`
+	// MountPath is the path where the disk filesystem is mounted, for example /var/lib/containerd.
+	MountPath string `json:"mountPath"`
+	// Filesystem is the type of filesystem the disk is formatted with: ext4 or xfs.
+	Filesystem string `json:"filesystem"`
+	// Label is the label of the disk filesystem.
+	Label string `json:"label"`
+}
+
+// VSphereDiskProvisioningMode is the provisioning type of a vSphere disk.
+type VSphereDiskProvisioningMode string
+
+const (
+	// VSphereThinProvisioningMode allocates the disk space on demand.
+	VSphereThinProvisioningMode VSphereDiskProvisioningMode = "Thin"
+	// VSphereThickProvisioningMode allocates all the disk space when the disk is created.
+	VSphereThickProvisioningMode VSphereDiskProvisioningMode = "Thick"
+	// VSphereEagerlyZeroedProvisioningMode allocates all the disk space and zeroes it when the disk is created.
+	VSphereEagerlyZeroedProvisioningMode VSphereDiskProvisioningMode = "EagerlyZeroed"
+)
+
+// DataDisksGiB returns the total size of the VM data disks in GiB.
+func (c *VSphereMachineConfig) DataDisksGiB() int {
+	total := 0
+	for _, d := range c.Spec.DataDisks {
+		total += d.SizeGiB
+	}
+	return total
+}
+
+// AntiAffinityEnabled returns true if DRS anti-affinity rules should be created for the machines using this config.
+func (c *VSphereMachineConfig) AntiAffinityEnabled() bool {
+	return c.Spec.AntiAffinity != nil
+}
+
+// PCIPassthroughEnabled returns true if PCI passthrough devices or vGPU profiles are attached to the VMs.
+func (c *VSphereMachineConfig) PCIPassthroughEnabled() bool {
+	return len(c.Spec.PCIDevices) > 0
 }
 
 // ResourcePaths returns a map of vSphere resource paths defined in the VSphereMachineConfig.
@@ -311,6 +311,21 @@ func (in *CNIConfig) DeepCopy() *CNIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRenewal) DeepCopyInto(out *CertificateRenewal) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRenewal.
+func (in *CertificateRenewal) DeepCopy() *CertificateRenewal {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRenewal)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CiliumConfig) DeepCopyInto(out *CiliumConfig) {
 	*out = *in
@@ -812,6 +827,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			}
 		}
 	}
+	if in.CertificateRenewal != nil {
+		in, out := &in.CertificateRenewal, &out.CertificateRenewal
+		*out = new(CertificateRenewal)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -854,6 +879,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = make([]ClusterCertificateInfo, len(*in))
 		copy(*out, *in)
 	}
+	if in.EtcdEncryption != nil {
+		in, out := &in.EtcdEncryption, &out.EtcdEncryption
+		*out = new(EtcdEncryptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -1252,6 +1282,28 @@ func (in *EtcdEncryptionProvider) DeepCopy() *EtcdEncryptionProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdEncryptionStatus) DeepCopyInto(out *EtcdEncryptionStatus) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]EtcdEncryptionProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdEncryptionStatus.
+func (in *EtcdEncryptionStatus) DeepCopy() *EtcdEncryptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdEncryptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalEtcdConfiguration) DeepCopyInto(out *ExternalEtcdConfiguration) {
 	*out = *in
@@ -1375,6 +1427,11 @@ func (in *FluxConfigSpec) DeepCopyInto(out *FluxConfigSpec) {
 		*out = new(GitProviderConfig)
 		**out = **in
 	}
+	if in.Gitlab != nil {
+		in, out := &in.Gitlab, &out.Gitlab
+		*out = new(GitlabProviderConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxConfigSpec.
@@ -1537,6 +1594,21 @@ func (in *GithubProviderConfig) DeepCopy() *GithubProviderConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitlabProviderConfig) DeepCopyInto(out *GitlabProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitlabProviderConfig.
+func (in *GitlabProviderConfig) DeepCopy() *GitlabProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GitlabProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HardwareAffinity) DeepCopyInto(out *HardwareAffinity) {
 	*out = *in
@@ -1676,6 +1748,11 @@ func (in *KMS) DeepCopyInto(out *KMS) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(KMSPlugin)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMS.
@@ -1688,6 +1765,31 @@ func (in *KMS) DeepCopy() *KMS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSPlugin) DeepCopyInto(out *KMSPlugin) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSPlugin.
+func (in *KMSPlugin) DeepCopy() *KMSPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KindnetdConfig) DeepCopyInto(out *KindnetdConfig) {
 	*out = *in
@@ -1828,6 +1930,42 @@ func (in *MachineHealthCheck) DeepCopy() *MachineHealthCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowConfiguration) DeepCopyInto(out *MaintenanceWindowConfiguration) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowConfiguration.
+func (in *MaintenanceWindowConfiguration) DeepCopy() *MaintenanceWindowConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementCluster) DeepCopyInto(out *ManagementCluster) {
 	*out = *in
@@ -3394,6 +3532,36 @@ func (in *UserConfiguration) DeepCopy() *UserConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereAntiAffinity) DeepCopyInto(out *VSphereAntiAffinity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereAntiAffinity.
+func (in *VSphereAntiAffinity) DeepCopy() *VSphereAntiAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereAntiAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereDataDisk) DeepCopyInto(out *VSphereDataDisk) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereDataDisk.
+func (in *VSphereDataDisk) DeepCopy() *VSphereDataDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereDataDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereDatacenterConfig) DeepCopyInto(out *VSphereDatacenterConfig) {
 	*out = *in
@@ -3577,6 +3745,23 @@ func (in *VSphereMachineConfigSpec) DeepCopyInto(out *VSphereMachineConfigSpec)
 		*out = new(HostOSConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DataDisks != nil {
+		in, out := &in.DataDisks, &out.DataDisks
+		*out = make([]VSphereDataDisk, len(*in))
+		copy(*out, *in)
+	}
+	if in.AntiAffinity != nil {
+		in, out := &in.AntiAffinity, &out.AntiAffinity
+		*out = new(VSphereAntiAffinity)
+		**out = **in
+	}
+	if in.PCIDevices != nil {
+		in, out := &in.PCIDevices, &out.PCIDevices
+		*out = make([]VSpherePCIDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineConfigSpec.
@@ -3604,6 +3789,31 @@ func (in *VSphereMachineConfigStatus) DeepCopy() *VSphereMachineConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSpherePCIDevice) DeepCopyInto(out *VSpherePCIDevice) {
+	*out = *in
+	if in.DeviceID != nil {
+		in, out := &in.DeviceID, &out.DeviceID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VendorID != nil {
+		in, out := &in.VendorID, &out.VendorID
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSpherePCIDevice.
+func (in *VSpherePCIDevice) DeepCopy() *VSpherePCIDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(VSpherePCIDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WeightedHardwareAffinityTerm) DeepCopyInto(out *WeightedHardwareAffinityTerm) {
 	*out = *in
@@ -1,8 +1,10 @@
 package certificates
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -161,3 +163,52 @@ func (r *DefaultSSHRunner) RunCommand(ctx context.Context, node string, cmd stri
 		return res.output, res.err
 	}
 }
+
+// CopyFromNode writes the content of a file on the remote node to w. The file is read with sudo.
+func (r *DefaultSSHRunner) CopyFromNode(ctx context.Context, node, path string, w io.Writer) error {
+	return r.stream(ctx, node, fmt.Sprintf("sudo cat %s", path), nil, w)
+}
+
+// CopyToNode writes the content of rd to a file on the remote node with sudo.
+func (r *DefaultSSHRunner) CopyToNode(ctx context.Context, node string, rd io.Reader, path string) error {
+	return r.stream(ctx, node, fmt.Sprintf("sudo tee %s > /dev/null", path), rd, io.Discard)
+}
+
+// stream runs a command on the remote node with stdin and stdout connected to the given reader and writer,
+// so files can be transferred without holding them in memory.
+func (r *DefaultSSHRunner) stream(ctx context.Context, node, cmd string, stdin io.Reader, stdout io.Writer) error {
+	client, err := r.sshDialer("tcp", fmt.Sprintf("%s:22", node), r.sshConfig)
+	if err != nil {
+		return fmt.Errorf("connect to node %s: %v", node, err)
+	}
+	defer client.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		session, err := client.NewSession()
+		if err != nil {
+			done <- fmt.Errorf("creating session: %v", err)
+			return
+		}
+		defer session.Close()
+
+		stderr := &bytes.Buffer{}
+		session.Stdin = stdin
+		session.Stdout = stdout
+		session.Stderr = stderr
+
+		logger.V(6).Info(cmd)
+		if err := session.Run(cmd); err != nil {
+			done <- fmt.Errorf("executing command: %v, output: %s", err, strings.TrimSpace(stderr.String()))
+			return
+		}
+		done <- nil
+	}()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("cancelling command: %v", ctx.Err())
+	case err := <-done:
+		return err
+	}
+}
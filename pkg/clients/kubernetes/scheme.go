@@ -3,6 +3,7 @@ package kubernetes
 import (
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta3"
@@ -15,6 +16,7 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
+	rufiov1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/rufio"
 	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -38,6 +40,8 @@ var schemeAdders = []schemeAdder{
 	etcdv1.AddToScheme,
 	addonsv1.AddToScheme,
 	tinkerbellv1.AddToScheme,
+	tinkv1alpha1.AddToScheme,
+	rufiov1alpha1.AddToScheme,
 }
 
 func addToScheme(scheme *runtime.Scheme, schemeAdders ...schemeAdder) error {
@@ -47,7 +47,7 @@ func cloudstackEntry() *ConfigManagerEntry {
 			},
 			func(c *Config) error {
 				if c.CloudStackMachineConfigs != nil { // We need this conditional check as CloudStackMachineConfigs will be nil for other providers
-					for _, mcRef := range c.Cluster.MachineConfigRefs() {
+					for _, mcRef := range sortedMachineConfigRefs(c.Cluster) {
 						m, ok := c.CloudStackMachineConfigs[mcRef.Name]
 						if !ok {
 							return fmt.Errorf("CloudStackMachineConfig %s not found", mcRef.Name)
@@ -93,7 +93,7 @@ func (c *ConfigManager) Validate(config *Config) error {
 
 	if len(allErrs) > 0 {
 		aggregate := utilerrors.NewAggregate(allErrs)
-		return fmt.Errorf("invalid cluster config: %v", aggregate)
+		return fmt.Errorf("invalid cluster config: %w", aggregate)
 	}
 
 	return nil
@@ -41,7 +41,7 @@ func nutanixEntry() *ConfigManagerEntry {
 			},
 			func(c *Config) error {
 				if c.NutanixMachineConfigs != nil { // We need this conditional check as NutanixMachineConfigs will be nil for other providers
-					for _, mcRef := range c.Cluster.MachineConfigRefs() {
+					for _, mcRef := range sortedMachineConfigRefs(c.Cluster) {
 						if _, ok := c.NutanixMachineConfigs[mcRef.Name]; !ok {
 							return fmt.Errorf("NutanixMachineConfig %s not found", mcRef.Name)
 						}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -51,8 +53,8 @@ func snowEntry() *ConfigManagerEntry {
 				return nil
 			},
 			func(c *Config) error {
-				for _, m := range c.SnowMachineConfigs {
-					if err := m.Validate(); err != nil {
+				for _, name := range slices.Sorted(maps.Keys(c.SnowMachineConfigs)) {
+					if err := c.SnowMachineConfigs[name].Validate(); err != nil {
 						return err
 					}
 				}
@@ -41,7 +41,7 @@ func tinkerbellEntry() *ConfigManagerEntry {
 			},
 			func(c *Config) error {
 				if c.TinkerbellMachineConfigs != nil { // We need this conditional check as TinkerbellMachineConfigs will be nil for other providers
-					for _, mcRef := range c.Cluster.MachineConfigRefs() {
+					for _, mcRef := range sortedMachineConfigRefs(c.Cluster) {
 						m, ok := c.TinkerbellMachineConfigs[mcRef.Name]
 						if !ok {
 							return fmt.Errorf("TinkerbellMachineConfig %s not found", mcRef.Name)
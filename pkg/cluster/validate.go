@@ -1,9 +1,13 @@
 package cluster
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/runtime"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 )
 
 func ValidateConfig(c *Config) error {
@@ -22,3 +26,13 @@ func validateSameNamespace(c *Config, o namespaceObject) error {
 
 	return nil
 }
+
+// sortedMachineConfigRefs returns the machine config refs of the cluster sorted by kind and name, so the machine
+// configs are always validated in the same order and the same error is reported for the same config.
+func sortedMachineConfigRefs(c *anywherev1.Cluster) []anywherev1.Ref {
+	refs := c.MachineConfigRefs()
+	slices.SortFunc(refs, func(a, b anywherev1.Ref) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+	return refs
+}
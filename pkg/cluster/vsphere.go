@@ -48,7 +48,7 @@ func vsphereEntry() *ConfigManagerEntry {
 			},
 			func(c *Config) error {
 				if c.VSphereMachineConfigs != nil { // We need this conditional check as VSphereMachineConfigs will be nil for other providers
-					for _, mcRef := range c.Cluster.MachineConfigRefs() {
+					for _, mcRef := range sortedMachineConfigRefs(c.Cluster) {
 						m, ok := c.VSphereMachineConfigs[mcRef.Name]
 						if !ok {
 							return fmt.Errorf("VSphereMachineConfig %s not found", mcRef.Name)
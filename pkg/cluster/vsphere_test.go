@@ -43,6 +43,19 @@ func TestValidateVsphereMachineConfigNotFoundError(t *testing.T) {
 	g.Expect(err).To(MatchError(ContainSubstring("VSphereMachineConfig dummy-machine-config not found")))
 }
 
+func TestValidateVsphereMachineConfigsInOrder(t *testing.T) {
+	g := NewWithT(t)
+	got, _ := cluster.ParseConfigFromFile("testdata/cluster_1_19.yaml")
+	got.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name = "missing-b"
+	got.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name = "missing-a"
+
+	cm, _ := cluster.NewDefaultConfigManager()
+	// The machine config refs come from a map, validate several times to catch a random order.
+	for range 20 {
+		g.Expect(cm.Validate(got)).To(MatchError(ContainSubstring("VSphereMachineConfig missing-a not found")))
+	}
+}
+
 func TestDefaultConfigClientBuilderVSphereCluster(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -0,0 +1,247 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	unstructuredutil "github.com/aws/eks-anywhere/pkg/utils/unstructured"
+)
+
+// Impact describes how a change is applied to the cluster machines.
+type Impact string
+
+const (
+	// Rollout means the change makes CAPI replace the affected machines.
+	Rollout Impact = "rollout"
+	// InPlace means the change is applied without replacing any machine.
+	InPlace Impact = "in-place"
+)
+
+// Action describes what happens to an object.
+type Action string
+
+const (
+	// Added means the object only exists in the new spec.
+	Added Action = "added"
+	// Removed means the object only exists in the current spec.
+	Removed Action = "removed"
+	// Modified means the object exists in both specs with different content.
+	Modified Action = "modified"
+)
+
+// FieldChange is a change in a single field of an object.
+type FieldChange struct {
+	// Path is the dot separated path to the field, with list indexes in brackets.
+	Path   string      `json:"path"`
+	Old    interface{} `json:"old,omitempty"`
+	New    interface{} `json:"new,omitempty"`
+	Impact Impact      `json:"impact"`
+}
+
+// ObjectChange groups all the changes for an object.
+type ObjectChange struct {
+	Kind      string        `json:"kind"`
+	Namespace string        `json:"namespace,omitempty"`
+	Name      string        `json:"name"`
+	Action    Action        `json:"action"`
+	Impact    Impact        `json:"impact"`
+	Fields    []FieldChange `json:"fields,omitempty"`
+}
+
+// Report contains all the object changes between two renders of the CAPI objects for a cluster.
+type Report struct {
+	Objects []ObjectChange `json:"objects"`
+}
+
+// RequiresRollout returns true if any of the changes causes a machine rollout.
+func (r *Report) RequiresRollout() bool {
+	for _, o := range r.Objects {
+		if o.Impact == Rollout {
+			return true
+		}
+	}
+	return false
+}
+
+// rolloutFields lists, by kind, the fields that CAPI can't update in place and trigger a machine rollout
+// when changed. Changes to any other field of these objects are applied in place.
+var rolloutFields = map[string][]string{
+	"KubeadmControlPlane": {
+		"spec.version",
+		"spec.kubeadmConfigSpec",
+		"spec.machineTemplate.infrastructureRef",
+	},
+	"MachineDeployment": {
+		"spec.template.spec",
+	},
+	"EtcdadmCluster": {
+		"spec.etcdadmConfigSpec",
+		"spec.infrastructureTemplate",
+	},
+	// Templates are immutable, eks-a creates a new one when they change and
+	// updates the owner object to point to it, which causes a rollout.
+	"KubeadmConfigTemplate": {
+		"spec",
+	},
+}
+
+func fieldImpact(kind, path string) Impact {
+	prefixes := rolloutFields[kind]
+	if strings.HasSuffix(kind, "MachineTemplate") {
+		prefixes = []string{"spec"}
+	}
+
+	for _, p := range prefixes {
+		if path == p || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return Rollout
+		}
+	}
+
+	return InPlace
+}
+
+// ignoredFields are never included in the diff since they are not part of the desired state.
+var ignoredFields = map[string]struct{}{
+	"status":                     {},
+	"metadata.creationTimestamp": {},
+	"metadata.resourceVersion":   {},
+	"metadata.uid":               {},
+	"metadata.generation":        {},
+	"metadata.managedFields":     {},
+}
+
+// Objects compares two multi-document yaml renders of CAPI objects and returns the changes to go from
+// current to new. Objects are matched by kind, namespace and name.
+func Objects(current, new []byte) (*Report, error) {
+	currentObjs, err := unstructuredutil.YamlToUnstructured(current)
+	if err != nil {
+		return nil, fmt.Errorf("parsing current objects: %v", err)
+	}
+	newObjs, err := unstructuredutil.YamlToUnstructured(new)
+	if err != nil {
+		return nil, fmt.Errorf("parsing new objects: %v", err)
+	}
+
+	currentByKey := make(map[string]*unstructured.Unstructured, len(currentObjs))
+	for i := range currentObjs {
+		currentByKey[objectKey(&currentObjs[i])] = &currentObjs[i]
+	}
+
+	report := &Report{Objects: []ObjectChange{}}
+	seen := make(map[string]struct{}, len(newObjs))
+	for i := range newObjs {
+		n := &newObjs[i]
+		key := objectKey(n)
+		seen[key] = struct{}{}
+		c, ok := currentByKey[key]
+		if !ok {
+			report.Objects = append(report.Objects, newObjectChange(n, Added))
+			continue
+		}
+
+		fields := []FieldChange{}
+		diffValues(n.GetKind(), "", c.Object, n.Object, &fields)
+		if len(fields) == 0 {
+			continue
+		}
+		sort.SliceStable(fields, func(i, j int) bool { return fields[i].Path < fields[j].Path })
+
+		change := newObjectChange(n, Modified)
+		change.Fields = fields
+		for _, f := range fields {
+			if f.Impact == Rollout {
+				change.Impact = Rollout
+				break
+			}
+		}
+		report.Objects = append(report.Objects, change)
+	}
+
+	for i := range currentObjs {
+		c := &currentObjs[i]
+		if _, ok := seen[objectKey(c)]; !ok {
+			report.Objects = append(report.Objects, newObjectChange(c, Removed))
+		}
+	}
+
+	return report, nil
+}
+
+// newObjectChange builds an ObjectChange with no field changes. Adding or removing objects
+// creates or deletes machines but doesn't replace the existing ones, so it's never a rollout.
+func newObjectChange(o *unstructured.Unstructured, action Action) ObjectChange {
+	return ObjectChange{
+		Kind:      o.GetKind(),
+		Namespace: o.GetNamespace(),
+		Name:      o.GetName(),
+		Action:    action,
+		Impact:    InPlace,
+	}
+}
+
+func diffValues(kind, path string, current, new interface{}, changes *[]FieldChange) {
+	if _, ok := ignoredFields[path]; ok {
+		return
+	}
+
+	switch c := current.(type) {
+	case map[string]interface{}:
+		n, ok := new.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, k := range unionKeys(c, n) {
+			diffValues(kind, joinPath(path, k), c[k], n[k], changes)
+		}
+		return
+	case []interface{}:
+		n, ok := new.([]interface{})
+		if !ok || len(n) != len(c) {
+			break
+		}
+		for i := range c {
+			diffValues(kind, fmt.Sprintf("%s[%d]", path, i), c[i], n[i], changes)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(current, new) {
+		return
+	}
+
+	*changes = append(*changes, FieldChange{
+		Path:   path,
+		Old:    current,
+		New:    new,
+		Impact: fieldImpact(kind, path),
+	})
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func objectKey(o *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s/%s", o.GetKind(), o.GetNamespace(), o.GetName())
+}
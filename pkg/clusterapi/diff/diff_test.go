@@ -0,0 +1,218 @@
+package diff_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/clusterapi/diff"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const currentObjects = `apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: test
+  namespace: eksa-system
+spec:
+  replicas: 1
+  version: v1.27.1-eks-1-27-4
+  kubeadmConfigSpec:
+    files:
+    - path: /etc/a
+      content: a
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: test-md-0
+  namespace: eksa-system
+spec:
+  replicas: 1
+  template:
+    spec:
+      version: v1.27.1-eks-1-27-4
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: VSphereMachineTemplate
+metadata:
+  name: test-md-0-1
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      numCPUs: 2
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: test-md-1
+  namespace: eksa-system
+spec:
+  replicas: 1
+`
+
+const newObjects = `apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: test
+  namespace: eksa-system
+spec:
+  replicas: 3
+  version: v1.27.1-eks-1-27-4
+  kubeadmConfigSpec:
+    files:
+    - path: /etc/a
+      content: b
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: test-md-0
+  namespace: eksa-system
+  labels:
+    team: a
+spec:
+  replicas: 2
+  template:
+    spec:
+      version: v1.27.1-eks-1-27-4
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: VSphereMachineTemplate
+metadata:
+  name: test-md-0-1
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      numCPUs: 2
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: test-md-2
+  namespace: eksa-system
+spec:
+  replicas: 1
+`
+
+func TestObjects(t *testing.T) {
+	g := NewWithT(t)
+	report, err := diff.Objects([]byte(currentObjects), []byte(newObjects))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.RequiresRollout()).To(BeTrue())
+	g.Expect(report.Objects).To(Equal([]diff.ObjectChange{
+		{
+			Kind:      "KubeadmControlPlane",
+			Namespace: "eksa-system",
+			Name:      "test",
+			Action:    diff.Modified,
+			Impact:    diff.Rollout,
+			Fields: []diff.FieldChange{
+				{Path: "spec.kubeadmConfigSpec.files[0].content", Old: "a", New: "b", Impact: diff.Rollout},
+				{Path: "spec.replicas", Old: float64(1), New: float64(3), Impact: diff.InPlace},
+			},
+		},
+		{
+			Kind:      "MachineDeployment",
+			Namespace: "eksa-system",
+			Name:      "test-md-0",
+			Action:    diff.Modified,
+			Impact:    diff.InPlace,
+			Fields: []diff.FieldChange{
+				{Path: "metadata.labels", New: map[string]interface{}{"team": "a"}, Impact: diff.InPlace},
+				{Path: "spec.replicas", Old: float64(1), New: float64(2), Impact: diff.InPlace},
+			},
+		},
+		{
+			Kind:      "MachineDeployment",
+			Namespace: "eksa-system",
+			Name:      "test-md-2",
+			Action:    diff.Added,
+			Impact:    diff.InPlace,
+		},
+		{
+			Kind:      "MachineDeployment",
+			Namespace: "eksa-system",
+			Name:      "test-md-1",
+			Action:    diff.Removed,
+			Impact:    diff.InPlace,
+		},
+	}))
+}
+
+func TestObjectsMachineTemplateChangeIsRollout(t *testing.T) {
+	g := NewWithT(t)
+	current := `kind: VSphereMachineTemplate
+metadata:
+  name: test-md-0-1
+spec:
+  template:
+    spec:
+      numCPUs: 2
+`
+	new := `kind: VSphereMachineTemplate
+metadata:
+  name: test-md-0-1
+spec:
+  template:
+    spec:
+      numCPUs: 4
+`
+	report, err := diff.Objects([]byte(current), []byte(new))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Objects).To(HaveLen(1))
+	g.Expect(report.Objects[0].Impact).To(Equal(diff.Rollout))
+	g.Expect(report.Objects[0].Fields[0].Path).To(Equal("spec.template.spec.numCPUs"))
+}
+
+func TestObjectsNoChanges(t *testing.T) {
+	g := NewWithT(t)
+	report, err := diff.Objects([]byte(currentObjects), []byte(currentObjects))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Objects).To(BeEmpty())
+	g.Expect(report.RequiresRollout()).To(BeFalse())
+}
+
+func TestObjectsInvalidYaml(t *testing.T) {
+	g := NewWithT(t)
+	_, err := diff.Objects([]byte("kind: [a"), []byte(currentObjects))
+	g.Expect(err).To(MatchError(ContainSubstring("parsing current objects")))
+}
+
+func TestSpecs(t *testing.T) {
+	g := NewWithT(t)
+	currentSpec := test.NewFullClusterSpec(t, "testdata/cluster_docker.yaml")
+	newSpec := test.NewFullClusterSpec(t, "testdata/cluster_docker.yaml")
+	newSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	newSpec.Cluster.Spec.ControlPlaneConfiguration.CertSANs = []string{"foo.bar"}
+	builder := docker.NewDockerTemplateBuilder(time.Now)
+
+	report, err := diff.Specs(builder, builder, currentSpec, newSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.RequiresRollout()).To(BeTrue())
+
+	impacts := map[string]diff.Impact{}
+	for _, o := range report.Objects {
+		g.Expect(o.Action).To(Equal(diff.Modified))
+		impacts[o.Kind+"/"+o.Name] = o.Impact
+	}
+	g.Expect(impacts).To(Equal(map[string]diff.Impact{
+		"KubeadmControlPlane/test":    diff.Rollout,
+		"MachineDeployment/test-md-0": diff.InPlace,
+	}))
+}
+
+func TestSpecsNoChanges(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_docker.yaml")
+	builder := docker.NewDockerTemplateBuilder(time.Now)
+
+	report, err := diff.Specs(builder, builder, spec, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Objects).To(BeEmpty())
+}
@@ -0,0 +1,3 @@
+// Package diff computes semantic diffs between the CAPI objects rendered for two versions
+// of an eks-a cluster spec, classifying each change by whether it triggers a machine rollout.
+package diff
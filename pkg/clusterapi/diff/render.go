@@ -0,0 +1,46 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+// Render generates the CAPI objects for a cluster spec with a provider TemplateBuilder.
+// It always uses the initial names for the machine and kubeadm config templates, so renders
+// for different specs of the same cluster can be compared object by object.
+func Render(builder providers.TemplateBuilder, spec *cluster.Spec) ([]byte, error) {
+	cp, err := builder.GenerateCAPISpecControlPlane(spec, func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+		values["etcdTemplateName"] = clusterapi.EtcdMachineTemplateName(spec.Cluster)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating control plane objects: %v", err)
+	}
+
+	machineTemplateNames, kubeadmConfigTemplateNames := clusterapi.InitialTemplateNamesForWorkers(spec)
+	workers, err := builder.GenerateCAPISpecWorkers(spec, machineTemplateNames, kubeadmConfigTemplateNames)
+	if err != nil {
+		return nil, fmt.Errorf("generating worker objects: %v", err)
+	}
+
+	return bytes.Join([][]byte{cp, workers}, []byte("\n---\n")), nil
+}
+
+// Specs renders the CAPI objects for the current and new cluster specs and returns the changes between them.
+func Specs(currentBuilder, newBuilder providers.TemplateBuilder, currentSpec, newSpec *cluster.Spec) (*Report, error) {
+	current, err := Render(currentBuilder, currentSpec)
+	if err != nil {
+		return nil, fmt.Errorf("rendering CAPI objects for current spec: %v", err)
+	}
+
+	new, err := Render(newBuilder, newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("rendering CAPI objects for new spec: %v", err)
+	}
+
+	return Objects(current, new)
+}
@@ -114,7 +114,7 @@ type ClusterManagerOpt func(*ClusterManager)
 
 // DefaultRetrier builds a retrier with the default configuration.
 func DefaultRetrier() *retrier.Retrier {
-	return retrier.NewWithMaxRetries(maxRetries, defaultBackOffPeriod)
+	return retrier.NewWithMaxRetries(maxRetries, defaultBackOffPeriod, retrier.WithMetricsRecorder(retrier.LogMetrics("cluster manager")))
 }
 
 // New constructs a new ClusterManager.
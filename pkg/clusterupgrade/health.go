@@ -0,0 +1,94 @@
+package clusterupgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+// ClusterHealthCheck returns a HealthCheckFunc that waits, with the retrier, until the EKS Anywhere cluster
+// is at the Kubernetes version of the hop, has been reconciled by the controller and is Ready.
+// It fails without waiting if the rollout of the cluster machines is held by a maintenance window or paused,
+// since the cluster won't become Ready until the hold is lifted.
+func ClusterHealthCheck(c client.Reader, cluster client.ObjectKey, retrier *retrier.Retrier) HealthCheckFunc {
+	return func(ctx context.Context, hop *Hop) error {
+		var held error
+		err := retrier.RetryWithContext(ctx, func() error {
+			err := checkClusterHealth(ctx, c, cluster, hop)
+			if errors.As(err, new(*upgradeHeldError)) {
+				held = err
+				return nil
+			}
+			return err
+		})
+		if held != nil {
+			return held
+		}
+
+		return err
+	}
+}
+
+// upgradeHeldError reports that the controller won't roll out the cluster machines until the hold is lifted.
+type upgradeHeldError struct {
+	reason, message string
+}
+
+func (e *upgradeHeldError) Error() string {
+	if e.reason == anywherev1.UpgradePausedReason {
+		return fmt.Sprintf("upgrade held by pause: %s, remove the %s annotation and run the upgrade again with --resume", e.message, anywherev1.UpgradePausedAnnotation)
+	}
+
+	return fmt.Sprintf("upgrade held by maintenance window: %s, run the upgrade again with --resume once the window is open", e.message)
+}
+
+func checkClusterHealth(ctx context.Context, c client.Reader, key client.ObjectKey, hop *Hop) error {
+	cluster := &anywherev1.Cluster{}
+	if err := c.Get(ctx, key, cluster); err != nil {
+		return fmt.Errorf("getting cluster: %v", err)
+	}
+
+	if cluster.Spec.KubernetesVersion != hop.KubernetesVersion {
+		return fmt.Errorf("cluster kubernetesVersion is %s, expected %s", cluster.Spec.KubernetesVersion, hop.KubernetesVersion)
+	}
+
+	if cluster.Status.ObservedGeneration != cluster.Generation {
+		return fmt.Errorf("cluster generation %d has not been reconciled yet", cluster.Generation)
+	}
+
+	if err := upgradeHeld(cluster); err != nil {
+		return err
+	}
+
+	if failure := cluster.Status.FailureMessage; failure != nil {
+		return fmt.Errorf("cluster has a failure: %s", *failure)
+	}
+
+	if !v1beta1conditions.IsTrue(cluster, anywherev1.ReadyCondition) {
+		return fmt.Errorf("cluster is not ready: %s", v1beta1conditions.GetMessage(cluster, anywherev1.ReadyCondition))
+	}
+
+	return nil
+}
+
+// upgradeHeld returns an upgradeHeldError if the upgrade of the cluster is paused with the upgrade-paused annotation
+// or the controller reports it's held until the next maintenance window.
+func upgradeHeld(cluster *anywherev1.Cluster) error {
+	allowed := v1beta1conditions.Get(cluster, anywherev1.UpgradeAllowedCondition)
+	if allowed != nil && allowed.Status == corev1.ConditionFalse {
+		return &upgradeHeldError{reason: allowed.Reason, message: allowed.Message}
+	}
+
+	if cluster.UpgradePaused() {
+		return &upgradeHeldError{reason: anywherev1.UpgradePausedReason, message: "Upgrade paused"}
+	}
+
+	return nil
+}
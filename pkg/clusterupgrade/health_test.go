@@ -0,0 +1,139 @@
+package clusterupgrade_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterupgrade"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+func TestClusterHealthCheck(t *testing.T) {
+	ready := clusterv1.Condition{Type: anywherev1.ReadyCondition, Status: corev1.ConditionTrue}
+	notReady := clusterv1.Condition{Type: anywherev1.ReadyCondition, Status: corev1.ConditionFalse, Message: "Worker nodes not ready yet"}
+	failure := "invalid config"
+
+	tests := []struct {
+		name    string
+		cluster func(c *anywherev1.Cluster)
+		wantErr string
+	}{
+		{
+			name:    "healthy",
+			cluster: func(c *anywherev1.Cluster) {},
+		},
+		{
+			name: "different version",
+			cluster: func(c *anywherev1.Cluster) {
+				c.Spec.KubernetesVersion = "1.28"
+			},
+			wantErr: "cluster kubernetesVersion is 1.28, expected 1.29",
+		},
+		{
+			name: "not reconciled",
+			cluster: func(c *anywherev1.Cluster) {
+				c.Status.ObservedGeneration = 1
+			},
+			wantErr: "cluster generation 2 has not been reconciled yet",
+		},
+		{
+			name: "failure",
+			cluster: func(c *anywherev1.Cluster) {
+				c.Status.FailureMessage = &failure
+			},
+			wantErr: "cluster has a failure: invalid config",
+		},
+		{
+			name: "not ready",
+			cluster: func(c *anywherev1.Cluster) {
+				c.Status.Conditions = []anywherev1.Condition{notReady}
+			},
+			wantErr: "cluster is not ready: Worker nodes not ready yet",
+		},
+		{
+			name: "outside maintenance window",
+			cluster: func(c *anywherev1.Cluster) {
+				c.Status.Conditions = append(c.Status.Conditions, clusterv1.Condition{
+					Type:    anywherev1.UpgradeAllowedCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  anywherev1.OutsideMaintenanceWindowReason,
+					Message: "Changes held until the next maintenance window at 2024-01-06T02:00:00Z",
+				})
+			},
+			wantErr: "upgrade held by maintenance window: Changes held until the next maintenance window at 2024-01-06T02:00:00Z",
+		},
+		{
+			name: "upgrade paused",
+			cluster: func(c *anywherev1.Cluster) {
+				c.PauseUpgrade()
+			},
+			wantErr: "upgrade held by pause: Upgrade paused, remove the anywhere.eks.amazonaws.com/upgrade-paused annotation",
+		},
+		{
+			name: "upgrade allowed",
+			cluster: func(c *anywherev1.Cluster) {
+				c.Status.Conditions = append(c.Status.Conditions, clusterv1.Condition{
+					Type:   anywherev1.UpgradeAllowedCondition,
+					Status: corev1.ConditionTrue,
+				})
+			},
+		},
+		{
+			name: "no ready condition",
+			cluster: func(c *anywherev1.Cluster) {
+				c.Status.Conditions = nil
+			},
+			wantErr: "cluster is not ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := testCluster("1.29", nil)
+			cluster.Generation = 2
+			cluster.Status.ObservedGeneration = 2
+			cluster.Status.Conditions = []anywherev1.Condition{ready}
+			tt.cluster(cluster)
+
+			c := fake.NewClientBuilder().WithObjects(cluster).Build()
+			healthCheck := clusterupgrade.ClusterHealthCheck(c, client.ObjectKeyFromObject(cluster), retrier.NewWithMaxRetries(1, 0))
+
+			err := healthCheck(context.Background(), &clusterupgrade.Hop{KubernetesVersion: "1.29"})
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterHealthCheckUpgradeHeldFailsFast(t *testing.T) {
+	g := NewWithT(t)
+	cluster := testCluster("1.29", nil)
+	cluster.PauseUpgrade()
+	c := fake.NewClientBuilder().WithObjects(cluster).Build()
+	// It would retry for an hour if the held upgrade was retried.
+	healthCheck := clusterupgrade.ClusterHealthCheck(c, client.ObjectKeyFromObject(cluster), retrier.NewWithMaxRetries(3600, time.Second))
+
+	err := healthCheck(context.Background(), &clusterupgrade.Hop{KubernetesVersion: "1.29"})
+	g.Expect(err).To(MatchError(ContainSubstring("upgrade held by pause")))
+}
+
+func TestClusterHealthCheckNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().Build()
+	healthCheck := clusterupgrade.ClusterHealthCheck(c, client.ObjectKey{Name: "my-cluster", Namespace: "default"}, retrier.NewWithMaxRetries(1, 0))
+
+	err := healthCheck(context.Background(), &clusterupgrade.Hop{KubernetesVersion: "1.29"})
+	g.Expect(err).To(MatchError(ContainSubstring("getting cluster")))
+}
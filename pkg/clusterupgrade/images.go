@@ -0,0 +1,161 @@
+package clusterupgrade
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// setHopImages makes the machine configs of an intermediate hop config use the OS image for the Kubernetes version
+// of the hop instead of the one in the target config. The vSphere templates are cleared, so the provider imports the
+// template from the versions bundle of the hop. For the rest of the providers, an image set in the config can't be
+// resolved for a different Kubernetes version, so it returns the images that need to be provided.
+func setHopImages(config, target *cluster.Config) []string {
+	hopVersions := machineConfigVersions(config)
+	targetVersions := machineConfigVersions(target)
+
+	names := make([]string, 0, len(hopVersions))
+	for name := range hopVersions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var missing []string
+	for _, name := range names {
+		versions := hopVersions[name]
+		if sameVersions(versions, targetVersions[name]) {
+			continue
+		}
+
+		kind, image := machineConfigImage(config, name)
+		if image == "" {
+			continue
+		}
+		if len(versions) > 1 {
+			missing = append(missing, fmt.Sprintf("%s %s is used by node groups with Kubernetes versions %s, use a machine config for each version",
+				kind, name, joinVersions(versions)))
+			continue
+		}
+		if kind == anywherev1.VSphereMachineConfigKind {
+			config.VSphereMachineConfigs[name].Spec.Template = ""
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("%s %s needs an image for Kubernetes %s instead of %s", kind, name, versions[0], image))
+	}
+
+	// The datacenter OS image is shared by all the machines, so it can't be right for all of them if they have different versions.
+	if config.TinkerbellDatacenter != nil && config.TinkerbellDatacenter.Spec.OSImageURL != "" && !sameVersions(allVersions(hopVersions), allVersions(targetVersions)) {
+		missing = append(missing, fmt.Sprintf("%s %s needs an image for Kubernetes %s instead of %s",
+			anywherev1.TinkerbellDatacenterKind, config.TinkerbellDatacenter.Name, joinVersions(allVersions(hopVersions)), config.TinkerbellDatacenter.Spec.OSImageURL))
+	}
+
+	return missing
+}
+
+// machineConfigVersions returns the Kubernetes versions of the node groups that use each machine config, sorted.
+func machineConfigVersions(config *cluster.Config) map[string][]anywherev1.KubernetesVersion {
+	versions := map[string][]anywherev1.KubernetesVersion{}
+	add := func(name string, v anywherev1.KubernetesVersion) {
+		for _, existing := range versions[name] {
+			if existing == v {
+				return
+			}
+		}
+		versions[name] = append(versions[name], v)
+		sort.Slice(versions[name], func(i, j int) bool { return versions[name][i] < versions[name][j] })
+	}
+
+	spec := config.Cluster.Spec
+	if spec.ControlPlaneConfiguration.MachineGroupRef != nil {
+		add(spec.ControlPlaneConfiguration.MachineGroupRef.Name, spec.KubernetesVersion)
+	}
+	if spec.ExternalEtcdConfiguration != nil && spec.ExternalEtcdConfiguration.MachineGroupRef != nil {
+		add(spec.ExternalEtcdConfiguration.MachineGroupRef.Name, spec.KubernetesVersion)
+	}
+	for _, w := range spec.WorkerNodeGroupConfigurations {
+		if w.MachineGroupRef == nil {
+			continue
+		}
+		v := spec.KubernetesVersion
+		if w.KubernetesVersion != nil {
+			v = *w.KubernetesVersion
+		}
+		add(w.MachineGroupRef.Name, v)
+	}
+
+	return versions
+}
+
+// machineConfigImage returns the kind of the machine config with name and the OS image set in it. The image is empty
+// if the provider takes it from the versions bundle.
+func machineConfigImage(config *cluster.Config, name string) (kind, image string) {
+	if m, ok := config.VSphereMachineConfigs[name]; ok {
+		return anywherev1.VSphereMachineConfigKind, m.Spec.Template
+	}
+	if m, ok := config.CloudStackMachineConfigs[name]; ok {
+		return anywherev1.CloudStackMachineConfigKind, firstNonEmpty(m.Spec.Template.Name, m.Spec.Template.Id)
+	}
+	if m, ok := config.NutanixMachineConfigs[name]; ok {
+		image := ""
+		if m.Spec.Image.Name != nil {
+			image = *m.Spec.Image.Name
+		} else if m.Spec.Image.UUID != nil {
+			image = *m.Spec.Image.UUID
+		}
+		return anywherev1.NutanixMachineConfigKind, image
+	}
+	if m, ok := config.SnowMachineConfigs[name]; ok {
+		return anywherev1.SnowMachineConfigKind, m.Spec.AMIID
+	}
+	if m, ok := config.TinkerbellMachineConfigs[name]; ok {
+		return anywherev1.TinkerbellMachineConfigKind, m.Spec.OSImageURL
+	}
+	return "", ""
+}
+
+func allVersions(versions map[string][]anywherev1.KubernetesVersion) []anywherev1.KubernetesVersion {
+	set := map[anywherev1.KubernetesVersion]struct{}{}
+	for _, vs := range versions {
+		for _, v := range vs {
+			set[v] = struct{}{}
+		}
+	}
+	all := make([]anywherev1.KubernetesVersion, 0, len(set))
+	for v := range set {
+		all = append(all, v)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	return all
+}
+
+func sameVersions(a, b []anywherev1.KubernetesVersion) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func joinVersions(versions []anywherev1.KubernetesVersion) string {
+	s := make([]string, 0, len(versions))
+	for _, v := range versions {
+		s = append(s, string(v))
+	}
+	return strings.Join(s, ", ")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
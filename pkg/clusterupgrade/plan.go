@@ -0,0 +1,267 @@
+package clusterupgrade
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/version"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// maxWorkerMinorVersionSkew is the number of minor versions the worker nodes can be behind the control plane.
+const maxWorkerMinorVersionSkew = 2
+
+// Hop is one step of a multi-hop upgrade. It moves the control plane and the worker node groups
+// at most one Kubernetes minor version.
+type Hop struct {
+	KubernetesVersion anywherev1.KubernetesVersion `json:"kubernetesVersion"`
+	// WorkerNodeGroupVersions are the worker node groups with a kubernetesVersion different from the control plane.
+	WorkerNodeGroupVersions map[string]anywherev1.KubernetesVersion `json:"workerNodeGroupVersions,omitempty"`
+	// ConfigFile is the cluster config file used to upgrade the cluster in this hop.
+	ConfigFile string `json:"configFile,omitempty"`
+	Completed  bool   `json:"completed,omitempty"`
+
+	config *cluster.Config
+}
+
+// Config returns the cluster config of the hop. It's only set for the hops of a plan returned by NewPlan.
+func (h *Hop) Config() *cluster.Config {
+	return h.config
+}
+
+// Plan is the chain of hops to upgrade a cluster to a Kubernetes version more than one minor version ahead.
+type Plan struct {
+	ClusterName   string                       `json:"clusterName"`
+	TargetVersion anywherev1.KubernetesVersion `json:"targetVersion"`
+	Hops          []*Hop                       `json:"hops"`
+}
+
+// Completed returns true if all the hops of the plan are completed.
+func (p *Plan) Completed() bool {
+	for _, h := range p.Hops {
+		if !h.Completed {
+			return false
+		}
+	}
+	return true
+}
+
+// NewPlan plans the hops to upgrade the current cluster to the target cluster config, where the target Kubernetes
+// version can be several minor versions ahead. Each hop upgrades the control plane one minor version and the worker
+// node groups with a kubernetesVersion as close as possible to their target version without breaking the version
+// skew policy. The last hop is always the target config. The machine configs of the intermediate hops use the OS image
+// for the Kubernetes version of the hop: it returns an error listing the images needed when they can't be resolved
+// from the versions bundle of the hop.
+func NewPlan(current *anywherev1.Cluster, target *cluster.Config) (*Plan, error) {
+	cp, err := parseVersion(current.Spec.KubernetesVersion)
+	if err != nil {
+		return nil, err
+	}
+	targetVersion := target.Cluster.Spec.KubernetesVersion
+	tgt, err := parseVersion(targetVersion)
+	if err != nil {
+		return nil, err
+	}
+	if tgt.Major() != cp.Major() {
+		return nil, fmt.Errorf("upgrading kubernetes major version is not supported (%s) -> (%s)", current.Spec.KubernetesVersion, targetVersion)
+	}
+	if tgt.LessThan(cp) {
+		return nil, fmt.Errorf("kubernetes version downgrade is not supported (%s) -> (%s)", current.Spec.KubernetesVersion, targetVersion)
+	}
+
+	workerTargets, workers, err := workerNodeGroupVersions(current, target, cp, tgt)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		ClusterName:   target.Cluster.Name,
+		TargetVersion: targetVersion,
+	}
+	for !sameMinor(cp, tgt) || !workersAtTarget(workers, workerTargets) {
+		if cp.LessThan(tgt) {
+			cp = nextMinor(cp)
+		}
+		for name, tw := range workerTargets {
+			w, ok := workers[name]
+			if !ok {
+				// New node groups only need to be in the version skew of the control plane.
+				workers[name] = minVersion(tw, cp)
+				continue
+			}
+			workers[name] = minVersion(tw, nextMinor(w), cp)
+		}
+		plan.Hops = append(plan.Hops, newHop(hopConfig(target, cp, workers)))
+	}
+
+	if len(plan.Hops) > 0 && matchesTarget(plan.Hops[len(plan.Hops)-1].config, target) {
+		plan.Hops = plan.Hops[:len(plan.Hops)-1]
+	}
+	// The worker node groups that move from an explicit kubernetesVersion to the control plane version
+	// can only do it once the control plane is not being upgraded, so this can be an extra hop.
+	plan.Hops = append(plan.Hops, newHop(target.DeepCopy()))
+
+	var missing []string
+	for i, hop := range plan.Hops[:len(plan.Hops)-1] {
+		for _, m := range setHopImages(hop.config, target) {
+			missing = append(missing, fmt.Sprintf("hop %d (Kubernetes %s): %s", i+1, hop.KubernetesVersion, m))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the OS images for the intermediate upgrade hops can't be resolved, upgrade one minor version at a time "+
+			"with a cluster config that references the image for each version:\n- %s", strings.Join(missing, "\n- "))
+	}
+
+	return plan, nil
+}
+
+// workerNodeGroupVersions returns the target and current versions of the worker node groups that need an explicit
+// kubernetesVersion during the intermediate hops. The worker node groups that follow the control plane version
+// both in the current cluster and in the target config are not included.
+func workerNodeGroupVersions(current *anywherev1.Cluster, target *cluster.Config, cp, tgt *version.Version) (targets, currents map[string]*version.Version, err error) {
+	existing := map[string]*anywherev1.WorkerNodeGroupConfiguration{}
+	for i := range current.Spec.WorkerNodeGroupConfigurations {
+		existing[current.Spec.WorkerNodeGroupConfigurations[i].Name] = &current.Spec.WorkerNodeGroupConfigurations[i]
+	}
+
+	targets = map[string]*version.Version{}
+	currents = map[string]*version.Version{}
+	for _, w := range target.Cluster.Spec.WorkerNodeGroupConfigurations {
+		old, exists := existing[w.Name]
+
+		var oldVersion *version.Version
+		if exists {
+			oldVersion = cp
+			if old.KubernetesVersion != nil {
+				if oldVersion, err = parseVersion(*old.KubernetesVersion); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+
+		if w.KubernetesVersion == nil {
+			if exists && old.KubernetesVersion != nil {
+				// The explicit version is kept until the last hop, which can only remove it once the control plane
+				// is at the target version and the worker node group is at most one minor version behind it.
+				targets[w.Name] = oldVersion
+				if previous := version.MajorMinor(tgt.Major(), tgt.Minor()-1); oldVersion.LessThan(previous) {
+					targets[w.Name] = previous
+				}
+				currents[w.Name] = oldVersion
+			}
+			continue
+		}
+
+		tw, err := parseVersion(*w.KubernetesVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tgt.LessThan(tw) || int(tgt.Minor())-int(tw.Minor()) > maxWorkerMinorVersionSkew {
+			return nil, nil, fmt.Errorf("worker node group %s kubernetesVersion %s must be at most %d minor versions behind the cluster kubernetesVersion %s", w.Name, *w.KubernetesVersion, maxWorkerMinorVersionSkew, target.Cluster.Spec.KubernetesVersion)
+		}
+		if oldVersion != nil && tw.LessThan(oldVersion) {
+			return nil, nil, fmt.Errorf("worker node group %s kubernetes version downgrade is not supported (%s) -> (%s)", w.Name, versionString(oldVersion), *w.KubernetesVersion)
+		}
+
+		targets[w.Name] = tw
+		if oldVersion != nil {
+			currents[w.Name] = oldVersion
+		}
+	}
+
+	return targets, currents, nil
+}
+
+func hopConfig(target *cluster.Config, cp *version.Version, workers map[string]*version.Version) *cluster.Config {
+	config := target.DeepCopy()
+	config.Cluster.Spec.KubernetesVersion = versionString(cp)
+	for i := range config.Cluster.Spec.WorkerNodeGroupConfigurations {
+		w := &config.Cluster.Spec.WorkerNodeGroupConfigurations[i]
+		if v, ok := workers[w.Name]; ok {
+			kubeVersion := versionString(v)
+			w.KubernetesVersion = &kubeVersion
+		}
+	}
+	return config
+}
+
+func newHop(config *cluster.Config) *Hop {
+	return &Hop{
+		KubernetesVersion:       config.Cluster.Spec.KubernetesVersion,
+		WorkerNodeGroupVersions: workerVersions(config),
+		config:                  config,
+	}
+}
+
+func workerVersions(config *cluster.Config) map[string]anywherev1.KubernetesVersion {
+	var versions map[string]anywherev1.KubernetesVersion
+	for _, w := range config.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if w.KubernetesVersion == nil || *w.KubernetesVersion == config.Cluster.Spec.KubernetesVersion {
+			continue
+		}
+		if versions == nil {
+			versions = map[string]anywherev1.KubernetesVersion{}
+		}
+		versions[w.Name] = *w.KubernetesVersion
+	}
+	return versions
+}
+
+// matchesTarget returns true if the Kubernetes versions in config are the same as in target.
+func matchesTarget(config, target *cluster.Config) bool {
+	if config.Cluster.Spec.KubernetesVersion != target.Cluster.Spec.KubernetesVersion {
+		return false
+	}
+	for i, w := range config.Cluster.Spec.WorkerNodeGroupConfigurations {
+		tw := target.Cluster.Spec.WorkerNodeGroupConfigurations[i]
+		if (w.KubernetesVersion == nil) != (tw.KubernetesVersion == nil) {
+			return false
+		}
+		if w.KubernetesVersion != nil && *w.KubernetesVersion != *tw.KubernetesVersion {
+			return false
+		}
+	}
+	return true
+}
+
+func workersAtTarget(workers, targets map[string]*version.Version) bool {
+	for name, tw := range targets {
+		w, ok := workers[name]
+		if !ok || !sameMinor(w, tw) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseVersion(v anywherev1.KubernetesVersion) (*version.Version, error) {
+	parsed, err := version.ParseGeneric(string(v))
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubernetes version %s: %v", v, err)
+	}
+	return parsed, nil
+}
+
+func versionString(v *version.Version) anywherev1.KubernetesVersion {
+	return anywherev1.KubernetesVersion(fmt.Sprintf("%d.%d", v.Major(), v.Minor()))
+}
+
+func nextMinor(v *version.Version) *version.Version {
+	return version.MajorMinor(v.Major(), v.Minor()+1)
+}
+
+func sameMinor(a, b *version.Version) bool {
+	return a.Major() == b.Major() && a.Minor() == b.Minor()
+}
+
+func minVersion(versions ...*version.Version) *version.Version {
+	m := versions[0]
+	for _, v := range versions[1:] {
+		if v.LessThan(m) {
+			m = v
+		}
+	}
+	return m
+}
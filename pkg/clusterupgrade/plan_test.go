@@ -0,0 +1,368 @@
+package clusterupgrade_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterupgrade"
+)
+
+type workerVersions map[string]*anywherev1.KubernetesVersion
+
+func kubeVersion(v anywherev1.KubernetesVersion) *anywherev1.KubernetesVersion {
+	return &v
+}
+
+func testCluster(version anywherev1.KubernetesVersion, workers workerVersions) *anywherev1.Cluster {
+	c := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: version,
+		},
+	}
+	for _, name := range []string{"md-0", "md-1"} {
+		if v, ok := workers[name]; ok {
+			c.Spec.WorkerNodeGroupConfigurations = append(c.Spec.WorkerNodeGroupConfigurations, anywherev1.WorkerNodeGroupConfiguration{
+				Name:              name,
+				KubernetesVersion: v,
+			})
+		}
+	}
+	return c
+}
+
+type hopVersions struct {
+	kubernetesVersion anywherev1.KubernetesVersion
+	workers           map[string]anywherev1.KubernetesVersion
+}
+
+func TestNewPlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *anywherev1.Cluster
+		target  *anywherev1.Cluster
+		want    []hopVersions
+	}{
+		{
+			name:    "same version",
+			current: testCluster("1.28", workerVersions{"md-0": nil}),
+			target:  testCluster("1.28", workerVersions{"md-0": nil}),
+			want: []hopVersions{
+				{kubernetesVersion: "1.28"},
+			},
+		},
+		{
+			name:    "one minor version",
+			current: testCluster("1.28", workerVersions{"md-0": nil}),
+			target:  testCluster("1.29", workerVersions{"md-0": nil}),
+			want: []hopVersions{
+				{kubernetesVersion: "1.29"},
+			},
+		},
+		{
+			name:    "several minor versions",
+			current: testCluster("1.27", workerVersions{"md-0": nil}),
+			target:  testCluster("1.30", workerVersions{"md-0": nil}),
+			want: []hopVersions{
+				{kubernetesVersion: "1.28"},
+				{kubernetesVersion: "1.29"},
+				{kubernetesVersion: "1.30"},
+			},
+		},
+		{
+			name:    "worker node group behind the control plane",
+			current: testCluster("1.27", workerVersions{"md-0": kubeVersion("1.27"), "md-1": nil}),
+			target:  testCluster("1.30", workerVersions{"md-0": kubeVersion("1.29"), "md-1": nil}),
+			want: []hopVersions{
+				{kubernetesVersion: "1.28"},
+				{kubernetesVersion: "1.29"},
+				{kubernetesVersion: "1.30", workers: map[string]anywherev1.KubernetesVersion{"md-0": "1.29"}},
+			},
+		},
+		{
+			name:    "worker node group catches up after the control plane",
+			current: testCluster("1.27", workerVersions{"md-0": kubeVersion("1.25")}),
+			target:  testCluster("1.28", workerVersions{"md-0": kubeVersion("1.28")}),
+			want: []hopVersions{
+				{kubernetesVersion: "1.28", workers: map[string]anywherev1.KubernetesVersion{"md-0": "1.26"}},
+				{kubernetesVersion: "1.28", workers: map[string]anywherev1.KubernetesVersion{"md-0": "1.27"}},
+				{kubernetesVersion: "1.28"},
+			},
+		},
+		{
+			name:    "worker node group version removed",
+			current: testCluster("1.27", workerVersions{"md-0": kubeVersion("1.26")}),
+			target:  testCluster("1.29", workerVersions{"md-0": nil}),
+			want: []hopVersions{
+				{kubernetesVersion: "1.28", workers: map[string]anywherev1.KubernetesVersion{"md-0": "1.27"}},
+				{kubernetesVersion: "1.29", workers: map[string]anywherev1.KubernetesVersion{"md-0": "1.28"}},
+				{kubernetesVersion: "1.29"},
+			},
+		},
+		{
+			name:    "worker node group version removed without upgrading the control plane",
+			current: testCluster("1.28", workerVersions{"md-0": kubeVersion("1.26")}),
+			target:  testCluster("1.28", workerVersions{"md-0": nil}),
+			want: []hopVersions{
+				{kubernetesVersion: "1.28", workers: map[string]anywherev1.KubernetesVersion{"md-0": "1.27"}},
+				{kubernetesVersion: "1.28"},
+			},
+		},
+		{
+			name:    "new worker node group",
+			current: testCluster("1.27", workerVersions{"md-0": nil}),
+			target:  testCluster("1.29", workerVersions{"md-0": nil, "md-1": kubeVersion("1.28")}),
+			want: []hopVersions{
+				{kubernetesVersion: "1.28"},
+				{kubernetesVersion: "1.29", workers: map[string]anywherev1.KubernetesVersion{"md-1": "1.28"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			target := &cluster.Config{Cluster: tt.target}
+
+			plan, err := clusterupgrade.NewPlan(tt.current, target)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(plan.ClusterName).To(Equal("my-cluster"))
+			g.Expect(plan.TargetVersion).To(Equal(tt.target.Spec.KubernetesVersion))
+
+			got := make([]hopVersions, 0, len(plan.Hops))
+			for _, hop := range plan.Hops {
+				got = append(got, hopVersions{kubernetesVersion: hop.KubernetesVersion, workers: hop.WorkerNodeGroupVersions})
+				g.Expect(hop.Config().Cluster.Spec.KubernetesVersion).To(Equal(hop.KubernetesVersion))
+			}
+			g.Expect(got).To(Equal(tt.want))
+
+			last := plan.Hops[len(plan.Hops)-1].Config()
+			g.Expect(last).To(Equal(target))
+			g.Expect(last).NotTo(BeIdenticalTo(target))
+		})
+	}
+}
+
+func TestNewPlanIntermediateHopConfig(t *testing.T) {
+	g := NewWithT(t)
+	current := testCluster("1.27", workerVersions{"md-0": kubeVersion("1.26"), "md-1": nil})
+	target := &cluster.Config{
+		Cluster: testCluster("1.29", workerVersions{"md-0": kubeVersion("1.29"), "md-1": nil}),
+		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
+			"md-0": {ObjectMeta: metav1.ObjectMeta{Name: "md-0"}},
+		},
+	}
+
+	plan, err := clusterupgrade.NewPlan(current, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(plan.Hops).To(HaveLen(3))
+
+	first := plan.Hops[0].Config()
+	g.Expect(first.Cluster.Spec.KubernetesVersion).To(Equal(anywherev1.KubernetesVersion("1.28")))
+	g.Expect(first.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubernetesVersion).To(Equal(kubeVersion("1.27")))
+	g.Expect(first.Cluster.Spec.WorkerNodeGroupConfigurations[1].KubernetesVersion).To(BeNil())
+	g.Expect(first.VSphereMachineConfigs).To(Equal(target.VSphereMachineConfigs))
+	g.Expect(target.Cluster.Spec.KubernetesVersion).To(Equal(anywherev1.KubernetesVersion("1.29")), "target config should not be modified")
+	g.Expect(target.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubernetesVersion).To(Equal(kubeVersion("1.29")), "target config should not be modified")
+}
+
+func withMachineConfigs(c *anywherev1.Cluster) *anywherev1.Cluster {
+	c.Spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Name: "cp"}
+	for i := range c.Spec.WorkerNodeGroupConfigurations {
+		w := &c.Spec.WorkerNodeGroupConfigurations[i]
+		w.MachineGroupRef = &anywherev1.Ref{Name: w.Name}
+	}
+	return c
+}
+
+func TestNewPlanHopImagesVSphere(t *testing.T) {
+	g := NewWithT(t)
+	current := withMachineConfigs(testCluster("1.27", workerVersions{"md-0": nil, "md-1": kubeVersion("1.27")}))
+	target := &cluster.Config{
+		Cluster: withMachineConfigs(testCluster("1.29", workerVersions{"md-0": nil, "md-1": kubeVersion("1.27")})),
+		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
+			"cp":   {Spec: anywherev1.VSphereMachineConfigSpec{Template: "ubuntu-1-29"}},
+			"md-0": {Spec: anywherev1.VSphereMachineConfigSpec{Template: "ubuntu-1-29"}},
+			"md-1": {Spec: anywherev1.VSphereMachineConfigSpec{Template: "ubuntu-1-27"}},
+		},
+	}
+
+	plan, err := clusterupgrade.NewPlan(current, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(plan.Hops).To(HaveLen(2))
+
+	// The templates are imported from the versions bundle of the hop.
+	first := plan.Hops[0].Config()
+	g.Expect(first.VSphereMachineConfigs["cp"].Spec.Template).To(BeEmpty())
+	g.Expect(first.VSphereMachineConfigs["md-0"].Spec.Template).To(BeEmpty())
+	g.Expect(first.VSphereMachineConfigs["md-1"].Spec.Template).To(Equal("ubuntu-1-27"))
+	g.Expect(plan.Hops[1].Config().VSphereMachineConfigs["cp"].Spec.Template).To(Equal("ubuntu-1-29"))
+	g.Expect(target.VSphereMachineConfigs["cp"].Spec.Template).To(Equal("ubuntu-1-29"), "target config should not be modified")
+}
+
+func TestNewPlanHopImagesFromBundle(t *testing.T) {
+	g := NewWithT(t)
+	current := withMachineConfigs(testCluster("1.27", workerVersions{"md-0": nil}))
+	target := &cluster.Config{
+		Cluster:              withMachineConfigs(testCluster("1.29", workerVersions{"md-0": nil})),
+		TinkerbellDatacenter: &anywherev1.TinkerbellDatacenterConfig{},
+		TinkerbellMachineConfigs: map[string]*anywherev1.TinkerbellMachineConfig{
+			"cp":   {Spec: anywherev1.TinkerbellMachineConfigSpec{OSFamily: anywherev1.Bottlerocket}},
+			"md-0": {Spec: anywherev1.TinkerbellMachineConfigSpec{OSFamily: anywherev1.Bottlerocket}},
+		},
+	}
+
+	plan, err := clusterupgrade.NewPlan(current, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(plan.Hops).To(HaveLen(2))
+}
+
+func TestNewPlanHopImagesErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		currentWorkers workerVersions
+		target         func(*cluster.Config)
+		wantErr        string
+	}{
+		{
+			name: "cloudstack template",
+			target: func(c *cluster.Config) {
+				c.CloudStackMachineConfigs = map[string]*anywherev1.CloudStackMachineConfig{
+					"cp":   {Spec: anywherev1.CloudStackMachineConfigSpec{Template: anywherev1.CloudStackResourceIdentifier{Name: "rhel-1-30"}}},
+					"md-0": {Spec: anywherev1.CloudStackMachineConfigSpec{Template: anywherev1.CloudStackResourceIdentifier{Id: "rhel-1-30-id"}}},
+				}
+			},
+			wantErr: "the OS images for the intermediate upgrade hops can't be resolved, upgrade one minor version at a time with a cluster config that references the image for each version:\n" +
+				"- hop 1 (Kubernetes 1.28): CloudStackMachineConfig cp needs an image for Kubernetes 1.28 instead of rhel-1-30\n" +
+				"- hop 1 (Kubernetes 1.28): CloudStackMachineConfig md-0 needs an image for Kubernetes 1.28 instead of rhel-1-30-id\n" +
+				"- hop 2 (Kubernetes 1.29): CloudStackMachineConfig cp needs an image for Kubernetes 1.29 instead of rhel-1-30\n" +
+				"- hop 2 (Kubernetes 1.29): CloudStackMachineConfig md-0 needs an image for Kubernetes 1.29 instead of rhel-1-30-id",
+		},
+		{
+			name: "nutanix image",
+			target: func(c *cluster.Config) {
+				name := "ubuntu-1-30"
+				c.NutanixMachineConfigs = map[string]*anywherev1.NutanixMachineConfig{
+					"cp":   {Spec: anywherev1.NutanixMachineConfigSpec{Image: anywherev1.NutanixResourceIdentifier{Name: &name}}},
+					"md-0": {Spec: anywherev1.NutanixMachineConfigSpec{Image: anywherev1.NutanixResourceIdentifier{Name: &name}}},
+				}
+			},
+			wantErr: "hop 1 (Kubernetes 1.28): NutanixMachineConfig cp needs an image for Kubernetes 1.28 instead of ubuntu-1-30",
+		},
+		{
+			name: "snow ami",
+			target: func(c *cluster.Config) {
+				c.SnowMachineConfigs = map[string]*anywherev1.SnowMachineConfig{
+					"cp":   {Spec: anywherev1.SnowMachineConfigSpec{AMIID: "ami-1-30"}},
+					"md-0": {Spec: anywherev1.SnowMachineConfigSpec{}},
+				}
+			},
+			wantErr: "hop 2 (Kubernetes 1.29): SnowMachineConfig cp needs an image for Kubernetes 1.29 instead of ami-1-30",
+		},
+		{
+			name: "tinkerbell machine config os image",
+			target: func(c *cluster.Config) {
+				c.TinkerbellDatacenter = &anywherev1.TinkerbellDatacenterConfig{}
+				c.TinkerbellMachineConfigs = map[string]*anywherev1.TinkerbellMachineConfig{
+					"cp":   {Spec: anywherev1.TinkerbellMachineConfigSpec{OSImageURL: "https://images/ubuntu-1-30.gz"}},
+					"md-0": {Spec: anywherev1.TinkerbellMachineConfigSpec{OSImageURL: "https://images/ubuntu-1-30.gz"}},
+				}
+			},
+			wantErr: "hop 1 (Kubernetes 1.28): TinkerbellMachineConfig md-0 needs an image for Kubernetes 1.28 instead of https://images/ubuntu-1-30.gz",
+		},
+		{
+			name: "tinkerbell datacenter os image",
+			target: func(c *cluster.Config) {
+				c.TinkerbellDatacenter = &anywherev1.TinkerbellDatacenterConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "dc"},
+					Spec:       anywherev1.TinkerbellDatacenterConfigSpec{OSImageURL: "https://images/ubuntu-1-30.gz"},
+				}
+				c.TinkerbellMachineConfigs = map[string]*anywherev1.TinkerbellMachineConfig{"cp": {}, "md-0": {}}
+			},
+			wantErr: "hop 1 (Kubernetes 1.28): TinkerbellDatacenterConfig dc needs an image for Kubernetes 1.28 instead of https://images/ubuntu-1-30.gz",
+		},
+		{
+			name:           "vsphere machine config shared by different versions",
+			currentWorkers: workerVersions{"md-0": kubeVersion("1.26")},
+			target: func(c *cluster.Config) {
+				c.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name = "cp"
+				c.VSphereMachineConfigs = map[string]*anywherev1.VSphereMachineConfig{
+					"cp": {Spec: anywherev1.VSphereMachineConfigSpec{Template: "ubuntu-1-30"}},
+				}
+			},
+			// md-0 goes from 1.26 to following the control plane, so it's one minor version behind in the hops.
+			wantErr: "hop 1 (Kubernetes 1.28): VSphereMachineConfig cp is used by node groups with Kubernetes versions 1.27, 1.28, use a machine config for each version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			currentWorkers := tt.currentWorkers
+			if currentWorkers == nil {
+				currentWorkers = workerVersions{"md-0": nil}
+			}
+			current := withMachineConfigs(testCluster("1.27", currentWorkers))
+			target := &cluster.Config{Cluster: withMachineConfigs(testCluster("1.30", workerVersions{"md-0": nil}))}
+			tt.target(target)
+
+			_, err := clusterupgrade.NewPlan(current, target)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestNewPlanErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *anywherev1.Cluster
+		target  *anywherev1.Cluster
+		wantErr string
+	}{
+		{
+			name:    "invalid version",
+			current: testCluster("1.27", nil),
+			target:  testCluster("invalid", nil),
+			wantErr: "parsing kubernetes version invalid",
+		},
+		{
+			name:    "downgrade",
+			current: testCluster("1.28", nil),
+			target:  testCluster("1.27", nil),
+			wantErr: "kubernetes version downgrade is not supported (1.28) -> (1.27)",
+		},
+		{
+			name:    "worker node group too far behind",
+			current: testCluster("1.27", workerVersions{"md-0": kubeVersion("1.27")}),
+			target:  testCluster("1.30", workerVersions{"md-0": kubeVersion("1.27")}),
+			wantErr: "worker node group md-0 kubernetesVersion 1.27 must be at most 2 minor versions behind the cluster kubernetesVersion 1.30",
+		},
+		{
+			name:    "worker node group ahead of the control plane",
+			current: testCluster("1.27", workerVersions{"md-0": nil}),
+			target:  testCluster("1.28", workerVersions{"md-0": kubeVersion("1.29")}),
+			wantErr: "worker node group md-0 kubernetesVersion 1.29 must be at most 2 minor versions behind the cluster kubernetesVersion 1.28",
+		},
+		{
+			name:    "worker node group downgrade",
+			current: testCluster("1.28", workerVersions{"md-0": nil}),
+			target:  testCluster("1.29", workerVersions{"md-0": kubeVersion("1.27")}),
+			wantErr: "worker node group md-0 kubernetes version downgrade is not supported (1.28) -> (1.27)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := clusterupgrade.NewPlan(tt.current, &cluster.Config{Cluster: tt.target})
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
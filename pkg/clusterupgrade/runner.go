@@ -0,0 +1,162 @@
+package clusterupgrade
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// UpgradeFunc upgrades the cluster with the cluster config in configFile. When resume is true, the tasks
+// completed in a previous failed upgrade are restored from the checkpoint file.
+type UpgradeFunc func(ctx context.Context, configFile string, resume bool) error
+
+// HealthCheckFunc returns an error if the cluster is not healthy after the upgrade of a hop.
+type HealthCheckFunc func(ctx context.Context, hop *Hop) error
+
+// Runner upgrades a cluster one hop after another.
+type Runner struct {
+	writer      filewriter.FileWriter
+	upgrade     UpgradeFunc
+	healthCheck HealthCheckFunc
+}
+
+// NewRunner returns a Runner that upgrades each hop with upgrade and checks the cluster with healthCheck
+// before moving to the next hop. The plan and the cluster config of each hop are saved with writer.
+func NewRunner(writer filewriter.FileWriter, upgrade UpgradeFunc, healthCheck HealthCheckFunc) *Runner {
+	return &Runner{
+		writer:      writer,
+		upgrade:     upgrade,
+		healthCheck: healthCheck,
+	}
+}
+
+// PlanFileName returns the name of the file where the progress of the multi-hop upgrade of a cluster is saved.
+func PlanFileName(clusterName string) string {
+	return fmt.Sprintf("%s-upgrade-plan.yaml", clusterName)
+}
+
+// LoadPlan reads the plan saved by a previous run for the cluster. It returns nil if there isn't one.
+func LoadPlan(writer filewriter.FileWriter, clusterName string) (*Plan, error) {
+	content, err := os.ReadFile(filepath.Join(writer.Dir(), PlanFileName(clusterName)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading upgrade plan: %v", err)
+	}
+
+	plan := &Plan{}
+	if err := yaml.Unmarshal(content, plan); err != nil {
+		return nil, fmt.Errorf("parsing upgrade plan: %v", err)
+	}
+
+	return plan, nil
+}
+
+// Run upgrades the cluster through the hops of the plan that are not completed yet, checking the cluster
+// health after each one. The progress is saved after every hop, so a failed upgrade can continue from the
+// first hop not completed with a plan returned by LoadPlan. When resume is true, the upgrade of that first hop
+// restores the tasks completed in the previous failed run.
+func (r *Runner) Run(ctx context.Context, plan *Plan, resume bool) error {
+	if err := r.writeHopConfigs(plan); err != nil {
+		return err
+	}
+	if err := r.savePlan(plan); err != nil {
+		return err
+	}
+
+	for i, hop := range plan.Hops {
+		if hop.Completed {
+			logger.V(3).Info("Skipping completed upgrade hop", "hop", i+1, "kubernetesVersion", hop.KubernetesVersion)
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("Upgrading cluster to Kubernetes version %s (hop %d of %d)", hop.KubernetesVersion, i+1, len(plan.Hops)))
+		if err := r.upgrade(ctx, hop.ConfigFile, resume); err != nil {
+			return fmt.Errorf("upgrading cluster to kubernetes version %s: %v", hop.KubernetesVersion, err)
+		}
+		// Only the first hop can continue a previous failed upgrade.
+		resume = false
+
+		if err := r.healthCheck(ctx, hop); err != nil {
+			return fmt.Errorf("cluster is not healthy after upgrading to kubernetes version %s: %v", hop.KubernetesVersion, err)
+		}
+
+		hop.Completed = true
+		if err := r.savePlan(plan); err != nil {
+			return err
+		}
+	}
+
+	logger.Info(fmt.Sprintf("Cluster upgraded to Kubernetes version %s", plan.TargetVersion))
+	r.cleanUp(plan)
+	return nil
+}
+
+// cleanUp deletes the plan and the hop configs once all the hops are completed, there is nothing left to resume.
+func (r *Runner) cleanUp(plan *Plan) {
+	files := []string{PlanFileName(plan.ClusterName)}
+	for _, hop := range plan.Hops {
+		files = append(files, filepath.Base(hop.ConfigFile))
+	}
+	for _, f := range files {
+		if err := r.writer.Delete(f); err != nil {
+			logger.V(3).Info("Failed deleting upgrade plan file", "file", f, "error", err)
+		}
+	}
+}
+
+func (r *Runner) writeHopConfigs(plan *Plan) error {
+	for i, hop := range plan.Hops {
+		if hop.ConfigFile != "" {
+			continue
+		}
+		if hop.config == nil {
+			return fmt.Errorf("missing cluster config for upgrade hop %d", i+1)
+		}
+
+		content, err := templater.ObjectsToYaml(runtimeObjects(hop.config)...)
+		if err != nil {
+			return fmt.Errorf("generating cluster config for upgrade hop %d: %v", i+1, err)
+		}
+
+		name := fmt.Sprintf("%s-upgrade-hop-%d-%s.yaml", plan.ClusterName, i+1, hop.KubernetesVersion)
+		// The upgrade of each hop cleans up the temporary files, so the hop configs are kept in the cluster folder.
+		path, err := r.writer.Write(name, content, filewriter.PersistentFile)
+		if err != nil {
+			return fmt.Errorf("writing cluster config for upgrade hop %d: %v", i+1, err)
+		}
+		hop.ConfigFile = path
+	}
+
+	return nil
+}
+
+func (r *Runner) savePlan(plan *Plan) error {
+	content, err := yaml.Marshal(plan)
+	if err != nil {
+		return fmt.Errorf("saving upgrade plan: %v", err)
+	}
+	if _, err := r.writer.Write(PlanFileName(plan.ClusterName), content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("saving upgrade plan: %v", err)
+	}
+	return nil
+}
+
+func runtimeObjects(config *cluster.Config) []runtime.Object {
+	objs := config.ClusterAndChildren()
+	r := make([]runtime.Object, 0, len(objs))
+	for _, o := range objs {
+		r = append(r, o)
+	}
+	return r
+}
@@ -0,0 +1,174 @@
+package clusterupgrade_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterupgrade"
+)
+
+type upgradeCall struct {
+	version anywherev1.KubernetesVersion
+	resume  bool
+}
+
+type fakeUpgrader struct {
+	calls  []upgradeCall
+	failAt anywherev1.KubernetesVersion
+}
+
+func (f *fakeUpgrader) upgrade(_ context.Context, configFile string, resume bool) error {
+	config, err := cluster.ParseConfigFromFile(configFile)
+	if err != nil {
+		return err
+	}
+	version := config.Cluster.Spec.KubernetesVersion
+	f.calls = append(f.calls, upgradeCall{version: version, resume: resume})
+	if version == f.failAt {
+		return errors.New("upgrade failed")
+	}
+	return nil
+}
+
+func healthy(context.Context, *clusterupgrade.Hop) error {
+	return nil
+}
+
+func newTestPlan(t *testing.T) *clusterupgrade.Plan {
+	t.Helper()
+	current := testCluster("1.27", workerVersions{"md-0": nil})
+	target := testCluster("1.30", workerVersions{"md-0": nil})
+	target.TypeMeta.APIVersion = anywherev1.GroupVersion.String()
+	target.TypeMeta.Kind = anywherev1.ClusterKind
+	plan, err := clusterupgrade.NewPlan(current, &cluster.Config{Cluster: target})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return plan
+}
+
+func TestRunnerRun(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir, writer := test.NewWriter(t)
+	upgrader := &fakeUpgrader{}
+	plan := newTestPlan(t)
+
+	runner := clusterupgrade.NewRunner(writer, upgrader.upgrade, healthy)
+	g.Expect(runner.Run(ctx, plan, false)).To(Succeed())
+
+	g.Expect(upgrader.calls).To(Equal([]upgradeCall{
+		{version: "1.28"},
+		{version: "1.29"},
+		{version: "1.30"},
+	}))
+	g.Expect(plan.Completed()).To(BeTrue())
+
+	// Nothing is left to resume once all the hops are completed.
+	g.Expect(filepath.Join(dir, clusterupgrade.PlanFileName("my-cluster"))).NotTo(BeAnExistingFile())
+	for _, hop := range plan.Hops {
+		g.Expect(hop.ConfigFile).NotTo(BeAnExistingFile())
+	}
+}
+
+func TestRunnerRunHealthCheckFails(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+	upgrader := &fakeUpgrader{}
+	plan := newTestPlan(t)
+
+	var checked []anywherev1.KubernetesVersion
+	healthCheck := func(_ context.Context, hop *clusterupgrade.Hop) error {
+		checked = append(checked, hop.KubernetesVersion)
+		if hop.KubernetesVersion == "1.28" {
+			return errors.New("control plane not ready")
+		}
+		return nil
+	}
+
+	runner := clusterupgrade.NewRunner(writer, upgrader.upgrade, healthCheck)
+	g.Expect(runner.Run(ctx, plan, false)).To(MatchError(
+		"cluster is not healthy after upgrading to kubernetes version 1.28: control plane not ready",
+	))
+	g.Expect(upgrader.calls).To(HaveLen(1))
+	g.Expect(checked).To(ConsistOf(anywherev1.KubernetesVersion("1.28")))
+}
+
+func TestRunnerRunResume(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+	upgrader := &fakeUpgrader{failAt: "1.29"}
+
+	runner := clusterupgrade.NewRunner(writer, upgrader.upgrade, healthy)
+	g.Expect(runner.Run(ctx, newTestPlan(t), false)).To(MatchError(
+		"upgrading cluster to kubernetes version 1.29: upgrade failed",
+	))
+
+	saved, err := clusterupgrade.LoadPlan(writer, "my-cluster")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(saved.TargetVersion).To(Equal(anywherev1.KubernetesVersion("1.30")))
+	g.Expect(saved.Hops).To(HaveLen(3))
+	g.Expect(saved.Hops[0].Completed).To(BeTrue())
+	g.Expect(saved.Hops[1].Completed).To(BeFalse())
+	g.Expect(saved.Completed()).To(BeFalse())
+
+	upgrader.failAt = ""
+	upgrader.calls = nil
+	g.Expect(runner.Run(ctx, saved, true)).To(Succeed())
+	g.Expect(upgrader.calls).To(Equal([]upgradeCall{
+		{version: "1.29", resume: true},
+		{version: "1.30"},
+	}))
+
+	saved, err = clusterupgrade.LoadPlan(writer, "my-cluster")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(saved).To(BeNil())
+}
+
+func TestRunnerRunHopConfigsKeptOnTempCleanUp(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+
+	// The upgrade of each hop cleans up the temporary folder when it succeeds.
+	upgrade := func(_ context.Context, configFile string, _ bool) error {
+		g.Expect(configFile).To(BeAnExistingFile())
+		writer.CleanUpTemp()
+		return nil
+	}
+
+	runner := clusterupgrade.NewRunner(writer, upgrade, healthy)
+	g.Expect(runner.Run(ctx, newTestPlan(t), false)).To(Succeed())
+}
+
+func TestLoadPlanInvalid(t *testing.T) {
+	g := NewWithT(t)
+	dir, writer := test.NewWriter(t)
+	g.Expect(os.WriteFile(filepath.Join(dir, clusterupgrade.PlanFileName("my-cluster")), []byte("hops: invalid"), 0o600)).To(Succeed())
+
+	_, err := clusterupgrade.LoadPlan(writer, "my-cluster")
+	g.Expect(err).To(MatchError(ContainSubstring("parsing upgrade plan")))
+}
+
+func TestRunnerRunMissingHopConfig(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	plan := &clusterupgrade.Plan{
+		ClusterName:   "my-cluster",
+		TargetVersion: "1.29",
+		Hops:          []*clusterupgrade.Hop{{KubernetesVersion: "1.29"}},
+	}
+
+	runner := clusterupgrade.NewRunner(writer, (&fakeUpgrader{}).upgrade, healthy)
+	g.Expect(runner.Run(context.Background(), plan, false)).To(MatchError("missing cluster config for upgrade hop 1"))
+}
@@ -18,12 +18,16 @@ const (
 	AwsSecretAccessKeyEnv     = "AWS_SECRET_ACCESS_KEY"
 	EksaAwsConfigFileEnv      = "EKSA_AWS_CONFIG_FILE"
 	EksaRegionEnv             = "EKSA_AWS_REGION"
+	// EksaImageVerificationKeyEnv is the public key file used to verify the signatures of the images in a registry mirror.
+	EksaImageVerificationKeyEnv = "EKSA_IMAGE_VERIFICATION_KEY"
 )
 
 type CliConfig struct {
 	GitSshKeyPassphrase string
 	GitPrivateKeyFile   string
 	GitKnownHostsFile   string
+	// ImageVerificationKeyFile is the public key file used to verify the signatures of the images in a registry mirror.
+	ImageVerificationKeyFile string
 }
 
 // CreateClusterCLIConfig is the config we use for create cluster specific configurations.
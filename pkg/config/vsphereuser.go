@@ -33,6 +33,11 @@ var VSphereAdminPrivsFile string
 //go:embed static/readOnlyPrivs.json
 var VSphereReadOnlyPrivs string
 
+// VSphereDRSPrivsFile holds the privileges needed on a compute cluster to manage its DRS rules.
+//
+//go:embed static/drsPrivs.json
+var VSphereDRSPrivsFile string
+
 func NewVsphereUserConfig() *VSphereUserConfig {
 	eksaVsphereUsername := os.Getenv(EksavSphereUsernameKey)
 	eksaVspherePassword := os.Getenv(EksavSpherePasswordKey)
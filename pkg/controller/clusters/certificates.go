@@ -0,0 +1,293 @@
+package clusters
+
+import (
+	"context"
+	"time"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+)
+
+// CertificateRenewalCheckInterval is how often the certificates of a cluster with automatic
+// certificate renewal are checked, even if nothing else triggers a reconciliation.
+const CertificateRenewalCheckInterval = 12 * time.Hour
+
+// CertificateRenewalUrgentDays is the number of days before the certificates expire under which they are renewed
+// even outside the cluster maintenance window, so a window that doesn't open in time can't let them expire.
+// It's lower than the minimum renewal threshold, so the window always gets a chance to run the renewal first.
+const CertificateRenewalUrgentDays = 3
+
+// etcdCertificateRenewalAnnotation marks a KubeadmControlPlane paused to renew the external etcd certificates.
+// Once the new etcd machines are ready, the KCP is unpaused and rolled out to pick up the new etcd endpoints.
+const etcdCertificateRenewalAnnotation = "anywhere.eks.amazonaws.com/etcd-certificate-renewal"
+
+// ReconcileCertificateRenewal renews the control plane and external etcd certificates that expire within
+// the threshold configured in the cluster spec. Certificates are renewed by rolling out the machines that
+// hold them, which only requires access to the kubernetes API: the new machines get freshly issued certificates.
+// It relies on the certificate expiration status populated by UpdateClusterCertificateStatus.
+func ReconcileCertificateRenewal(ctx context.Context, log logr.Logger, c client.Client, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if cluster.Spec.CertificateRenewal == nil {
+		return controller.Result{}, nil
+	}
+
+	capiCluster, err := controller.GetCAPICluster(ctx, c, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting capi cluster")
+	}
+	if capiCluster == nil {
+		return controller.Result{}, nil
+	}
+
+	kcp, err := controller.GetKubeadmControlPlane(ctx, c, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting kubeadmcontrolplane")
+	}
+	if kcp == nil {
+		return controller.Result{}, nil
+	}
+
+	if _, ok := kcp.Annotations[etcdCertificateRenewalAnnotation]; ok {
+		return resumeControlPlaneAfterEtcdRenewal(ctx, log, c, capiCluster, kcp)
+	}
+
+	// Don't interfere with cluster creation, upgrades or any other ongoing rollout.
+	if !v1beta1conditions.IsTrue(cluster, anywherev1.ReadyCondition) {
+		return controller.Result{}, nil
+	}
+
+	etcdMachines, controlPlaneMachines, err := machinesWithExpiringCertificates(ctx, c, cluster, capiCluster.Namespace)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	// The control plane is always rolled out after renewing the etcd certificates, so we don't need
+	// to trigger both at the same time.
+	if len(etcdMachines) > 0 && cluster.Spec.ExternalEtcdConfiguration != nil {
+		return renewEtcdCertificates(ctx, log, c, capiCluster, kcp)
+	}
+
+	if len(controlPlaneMachines) > 0 {
+		return controller.Result{}, renewControlPlaneCertificates(ctx, log, c, kcp, controlPlaneMachines)
+	}
+
+	return controller.Result{}, nil
+}
+
+// CertificateRenewalPending returns true if renewing the cluster certificates needs to roll out machines, either because
+// some of them have certificates expiring within the threshold or because the control plane is waiting to be rolled out
+// after renewing the external etcd certificates.
+func CertificateRenewalPending(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) (bool, error) {
+	if cluster.Spec.CertificateRenewal == nil {
+		return false, nil
+	}
+
+	for _, info := range cluster.Status.ClusterCertificateInfo {
+		if info.ExpiresInDays <= cluster.Spec.CertificateRenewal.ThresholdDays {
+			return true, nil
+		}
+	}
+
+	kcp, err := controller.GetKubeadmControlPlane(ctx, c, cluster)
+	if err != nil {
+		return false, errors.Wrap(err, "getting kubeadmcontrolplane")
+	}
+	if kcp == nil {
+		return false, nil
+	}
+
+	_, ok := kcp.Annotations[etcdCertificateRenewalAnnotation]
+	return ok, nil
+}
+
+// CertificateRenewalUrgent returns true if the cluster has automatic certificate renewal enabled and some
+// certificates expire within CertificateRenewalUrgentDays, so their renewal can't wait for the maintenance window.
+func CertificateRenewalUrgent(cluster *anywherev1.Cluster) bool {
+	if cluster.Spec.CertificateRenewal == nil {
+		return false
+	}
+
+	for _, info := range cluster.Status.ClusterCertificateInfo {
+		if info.ExpiresInDays <= CertificateRenewalUrgentDays {
+			return true
+		}
+	}
+
+	return false
+}
+
+// machinesWithExpiringCertificates returns the etcd and control plane machines with certificates
+// expiring within the renewal threshold.
+func machinesWithExpiringCertificates(ctx context.Context, c client.Client, cluster *anywherev1.Cluster, namespace string) (etcd, controlPlane []*clusterv1beta2.Machine, err error) {
+	for _, info := range cluster.Status.ClusterCertificateInfo {
+		if info.ExpiresInDays > cluster.Spec.CertificateRenewal.ThresholdDays {
+			continue
+		}
+
+		machine := &clusterv1beta2.Machine{}
+		err := c.Get(ctx, client.ObjectKey{Name: info.Machine, Namespace: namespace}, machine)
+		if apierrors.IsNotFound(err) {
+			// The certificate status is from the previous reconciliation, the machine might be gone already.
+			continue
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "reading machine %s", info.Machine)
+		}
+
+		if _, ok := machine.Labels[clusterv1beta2.MachineControlPlaneLabel]; ok {
+			controlPlane = append(controlPlane, machine)
+		} else {
+			etcd = append(etcd, machine)
+		}
+	}
+
+	return etcd, controlPlane, nil
+}
+
+func renewControlPlaneCertificates(ctx context.Context, log logr.Logger, c client.Client, currentKCP *controlplanev1beta2.KubeadmControlPlane, machines []*clusterv1beta2.Machine) error {
+	// If a rollout was already requested after the expiring machines were created, it's still in progress.
+	rolloutAfter := currentKCP.Spec.Rollout.After
+	if !rolloutAfter.IsZero() && oldestMachine(machines).CreationTimestamp.Before(&rolloutAfter) {
+		return nil
+	}
+
+	log.Info("Control plane certificates about to expire, rolling out control plane machines", "kcp", klog.KObj(currentKCP))
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		kcp := &controlplanev1beta2.KubeadmControlPlane{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(currentKCP), kcp); err != nil {
+			return errors.Wrap(err, "reading kubeadm control plane to renew certificates")
+		}
+		kcp.Spec.Rollout.After = metav1.Now()
+		return c.Update(ctx, kcp)
+	})
+}
+
+func renewEtcdCertificates(ctx context.Context, log logr.Logger, c client.Client, capiCluster *clusterv1beta2.Cluster, currentKCP *controlplanev1beta2.KubeadmControlPlane) (controller.Result, error) {
+	etcdadmCluster, err := getEtcdadmCluster(ctx, c, capiCluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if _, ok := etcdadmCluster.Annotations[etcdv1.UpgradeInProgressAnnotation]; ok || !etcdadmClusterReady(etcdadmCluster) {
+		return controller.Result{}, nil
+	}
+
+	// etcdadm only rolls out new machines when the infrastructure template changes, so we
+	// create a copy of the current one with a new name.
+	templateRef := etcdadmCluster.Spec.InfrastructureTemplate
+	template := &unstructured.Unstructured{}
+	template.SetAPIVersion(templateRef.APIVersion)
+	template.SetKind(templateRef.Kind)
+	if err := c.Get(ctx, client.ObjectKey{Name: templateRef.Name, Namespace: templateRef.Namespace}, template); err != nil {
+		return controller.Result{}, errors.Wrap(err, "reading etcd machine template")
+	}
+
+	newName, err := clusterapi.IncrementName(templateRef.Name)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "generating new name for etcd machine template")
+	}
+
+	newTemplate := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": template.GetAPIVersion(),
+		"kind":       template.GetKind(),
+		"spec":       template.Object["spec"],
+	}}
+	newTemplate.SetName(newName)
+	newTemplate.SetNamespace(template.GetNamespace())
+	newTemplate.SetLabels(template.GetLabels())
+	newTemplate.SetAnnotations(template.GetAnnotations())
+	newTemplate.SetOwnerReferences(template.GetOwnerReferences())
+	if err := c.Create(ctx, newTemplate); err != nil && !apierrors.IsAlreadyExists(err) {
+		return controller.Result{}, errors.Wrap(err, "creating etcd machine template")
+	}
+
+	// Same as with any other etcd change, pause the KCP so it doesn't rollout new nodes as the etcd endpoints change.
+	log.Info("Etcd certificates about to expire, pausing KCP before rolling out etcd machines", "kcp", klog.KObj(currentKCP))
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		kcp := &controlplanev1beta2.KubeadmControlPlane{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(currentKCP), kcp); err != nil {
+			return errors.Wrap(err, "reading kubeadm control plane to pause")
+		}
+		clientutil.AddAnnotation(kcp, clusterv1beta2.PausedAnnotation, "true")
+		clientutil.AddAnnotation(kcp, etcdCertificateRenewalAnnotation, "true")
+		return c.Update(ctx, kcp)
+	}); err != nil {
+		return controller.Result{}, err
+	}
+
+	log.Info("Rolling out etcd machines to renew certificates", "etcdadmCluster", klog.KObj(etcdadmCluster), "machineTemplate", newName)
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		e := &etcdv1.EtcdadmCluster{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(etcdadmCluster), e); err != nil {
+			return errors.Wrap(err, "reading etcdadm cluster to renew certificates")
+		}
+		clientutil.AddAnnotation(e, etcdv1.UpgradeInProgressAnnotation, "true")
+		e.Spec.InfrastructureTemplate.Name = newName
+		return c.Update(ctx, e)
+	}); err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.ResultWithRequeue(10 * time.Second), nil
+}
+
+func resumeControlPlaneAfterEtcdRenewal(ctx context.Context, log logr.Logger, c client.Client, capiCluster *clusterv1beta2.Cluster, currentKCP *controlplanev1beta2.KubeadmControlPlane) (controller.Result, error) {
+	etcdadmCluster, err := getEtcdadmCluster(ctx, c, capiCluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if !etcdadmClusterReady(etcdadmCluster) {
+		log.Info("Etcd certificate renewal in progress, requeuing")
+		return controller.ResultWithRequeue(30 * time.Second), nil
+	}
+
+	// If the KCP has already been unpaused, a cluster reconciliation took care of it and the
+	// control plane is already rolling out with the new etcd endpoints.
+	rollout := annotations.HasPaused(currentKCP)
+	if rollout {
+		log.Info("Etcd certificates renewed, unpausing KCP and rolling out control plane machines", "kcp", klog.KObj(currentKCP))
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		kcp := &controlplanev1beta2.KubeadmControlPlane{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(currentKCP), kcp); err != nil {
+			return errors.Wrap(err, "reading kubeadm control plane to unpause")
+		}
+		delete(kcp.Annotations, etcdCertificateRenewalAnnotation)
+		if rollout {
+			delete(kcp.Annotations, clusterv1beta2.PausedAnnotation)
+			kcp.Spec.Rollout.After = metav1.Now()
+		}
+		return c.Update(ctx, kcp)
+	}); err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.Result{}, nil
+}
+
+func oldestMachine(machines []*clusterv1beta2.Machine) *clusterv1beta2.Machine {
+	oldest := machines[0]
+	for _, m := range machines[1:] {
+		if m.CreationTimestamp.Before(&oldest.CreationTimestamp) {
+			oldest = m
+		}
+	}
+	return oldest
+}
@@ -0,0 +1,417 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
+	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+)
+
+type certificateRenewalTest struct {
+	t *testing.T
+	*WithT
+	ctx            context.Context
+	cluster        *anywherev1.Cluster
+	capiCluster    *clusterv1beta2.Cluster
+	kcp            *controlplanev1beta2.KubeadmControlPlane
+	etcdadmCluster *etcdv1.EtcdadmCluster
+	etcdTemplate   *vspherev1.VSphereMachineTemplate
+	machines       []*clusterv1beta2.Machine
+}
+
+func newCertificateRenewalTest(t *testing.T) *certificateRenewalTest {
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			CertificateRenewal: &anywherev1.CertificateRenewal{
+				ThresholdDays: 30,
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			Conditions: []anywherev1.Condition{
+				{
+					Type:   anywherev1.ReadyCondition,
+					Status: "True",
+				},
+			},
+		},
+	}
+
+	return &certificateRenewalTest{
+		t:       t,
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		cluster: cluster,
+		capiCluster: &clusterv1beta2.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+		kcp: &controlplanev1beta2.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+		machines: []*clusterv1beta2.Machine{
+			certificateRenewalMachine("my-cluster-cp-1", true),
+		},
+	}
+}
+
+func (tt *certificateRenewalTest) withExternalEtcd() {
+	tt.cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+	tt.capiCluster.Spec.ManagedExternalEtcdRef = &clusterv1beta2.ContractVersionedObjectReference{
+		Kind: "EtcdadmCluster",
+		Name: "my-cluster-etcd",
+	}
+	tt.etcdTemplate = &vspherev1.VSphereMachineTemplate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: vspherev1.GroupVersion.String(),
+			Kind:       "VSphereMachineTemplate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster-etcd-1",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: vspherev1.VSphereMachineTemplateSpec{
+			Template: vspherev1.VSphereMachineTemplateResource{
+				Spec: vspherev1.VSphereMachineSpec{
+					VirtualMachineCloneSpec: vspherev1.VirtualMachineCloneSpec{
+						NumCPUs: 2,
+					},
+				},
+			},
+		},
+	}
+	tt.etcdadmCluster = &etcdv1.EtcdadmCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-cluster-etcd",
+			Namespace:  constants.EksaSystemNamespace,
+			Generation: 1,
+		},
+		Spec: etcdv1.EtcdadmClusterSpec{
+			InfrastructureTemplate: corev1.ObjectReference{
+				APIVersion: vspherev1.GroupVersion.String(),
+				Kind:       "VSphereMachineTemplate",
+				Name:       "my-cluster-etcd-1",
+				Namespace:  constants.EksaSystemNamespace,
+			},
+		},
+		Status: etcdv1.EtcdadmClusterStatus{
+			ObservedGeneration: 1,
+			Ready:              true,
+		},
+	}
+	tt.machines = append(tt.machines, certificateRenewalMachine("my-cluster-etcd-1", false))
+}
+
+func (tt *certificateRenewalTest) client() client.Client {
+	objs := []runtime.Object{tt.cluster, tt.capiCluster, tt.kcp}
+	if tt.etcdadmCluster != nil {
+		objs = append(objs, tt.etcdadmCluster, tt.etcdTemplate)
+	}
+	for _, m := range tt.machines {
+		objs = append(objs, m)
+	}
+	return fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+}
+
+func (tt *certificateRenewalTest) reconcile(c client.Client) controller.Result {
+	tt.t.Helper()
+	result, err := clusters.ReconcileCertificateRenewal(tt.ctx, test.NewNullLogger(), c, tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	return result
+}
+
+func (tt *certificateRenewalTest) readKCP(c client.Client) *controlplanev1beta2.KubeadmControlPlane {
+	tt.t.Helper()
+	kcp := &controlplanev1beta2.KubeadmControlPlane{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(tt.kcp), kcp)).To(Succeed())
+	return kcp
+}
+
+func (tt *certificateRenewalTest) readEtcdadmCluster(c client.Client) *etcdv1.EtcdadmCluster {
+	tt.t.Helper()
+	e := &etcdv1.EtcdadmCluster{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(tt.etcdadmCluster), e)).To(Succeed())
+	return e
+}
+
+func certificateRenewalMachine(name string, controlPlane bool) *clusterv1beta2.Machine {
+	m := &clusterv1beta2.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         constants.EksaSystemNamespace,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-300 * 24 * time.Hour)),
+			Labels: map[string]string{
+				clusterv1beta2.ClusterNameLabel: "my-cluster",
+			},
+		},
+	}
+	if controlPlane {
+		m.Labels[clusterv1beta2.MachineControlPlaneLabel] = ""
+	}
+	return m
+}
+
+func TestReconcileCertificateRenewalNotEnabled(t *testing.T) {
+	tt := newCertificateRenewalTest(t)
+	tt.cluster.Spec.CertificateRenewal = nil
+	tt.cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "my-cluster-cp-1", ExpiresInDays: 5},
+	}
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.Result{}))
+	tt.Expect(tt.readKCP(c).Spec.Rollout.After.IsZero()).To(BeTrue())
+}
+
+func TestReconcileCertificateRenewalCertificatesNotExpiring(t *testing.T) {
+	tt := newCertificateRenewalTest(t)
+	tt.cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "my-cluster-cp-1", ExpiresInDays: 65},
+	}
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.Result{}))
+	tt.Expect(tt.readKCP(c).Spec.Rollout.After.IsZero()).To(BeTrue())
+}
+
+func TestReconcileCertificateRenewalClusterNotReady(t *testing.T) {
+	tt := newCertificateRenewalTest(t)
+	tt.cluster.Status.Conditions[0].Status = "False"
+	tt.cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "my-cluster-cp-1", ExpiresInDays: 5},
+	}
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.Result{}))
+	tt.Expect(tt.readKCP(c).Spec.Rollout.After.IsZero()).To(BeTrue())
+}
+
+func TestReconcileCertificateRenewalNoCAPICluster(t *testing.T) {
+	tt := newCertificateRenewalTest(t)
+	tt.cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "my-cluster-cp-1", ExpiresInDays: 5},
+	}
+	c := fake.NewClientBuilder().WithRuntimeObjects(tt.cluster).Build()
+
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.Result{}))
+}
+
+func TestReconcileCertificateRenewalControlPlane(t *testing.T) {
+	tt := newCertificateRenewalTest(t)
+	tt.cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "my-cluster-cp-1", ExpiresInDays: 30},
+		{Machine: "my-cluster-cp-deleted", ExpiresInDays: 2},
+	}
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.Result{}))
+	tt.Expect(tt.readKCP(c).Spec.Rollout.After.IsZero()).To(BeFalse())
+}
+
+func TestReconcileCertificateRenewalControlPlaneRolloutInProgress(t *testing.T) {
+	tt := newCertificateRenewalTest(t)
+	rolloutAfter := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	tt.kcp.Spec.Rollout.After = rolloutAfter
+	tt.cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "my-cluster-cp-1", ExpiresInDays: 5},
+	}
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.Result{}))
+	tt.Expect(tt.readKCP(c).Spec.Rollout.After.Equal(&rolloutAfter)).To(BeTrue())
+}
+
+func TestReconcileCertificateRenewalExternalEtcd(t *testing.T) {
+	tt := newCertificateRenewalTest(t)
+	tt.withExternalEtcd()
+	tt.cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "my-cluster-etcd-1", ExpiresInDays: 10},
+		{Machine: "my-cluster-cp-1", ExpiresInDays: 10},
+	}
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+
+	kcp := tt.readKCP(c)
+	tt.Expect(kcp.Annotations).To(HaveKeyWithValue(clusterv1beta2.PausedAnnotation, "true"))
+	tt.Expect(kcp.Spec.Rollout.After.IsZero()).To(BeTrue())
+
+	etcdadmCluster := tt.readEtcdadmCluster(c)
+	tt.Expect(etcdadmCluster.Annotations).To(HaveKeyWithValue(etcdv1.UpgradeInProgressAnnotation, "true"))
+	tt.Expect(etcdadmCluster.Spec.InfrastructureTemplate.Name).To(Equal("my-cluster-etcd-2"))
+
+	template := &vspherev1.VSphereMachineTemplate{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "my-cluster-etcd-2", Namespace: constants.EksaSystemNamespace}, template)).To(Succeed())
+	tt.Expect(template.Spec).To(Equal(tt.etcdTemplate.Spec))
+
+	// Etcd is rolling out the new machines
+	etcdadmCluster.Generation = 2
+	tt.Expect(c.Update(tt.ctx, etcdadmCluster)).To(Succeed())
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.ResultWithRequeue(30 * time.Second)))
+	tt.Expect(tt.readKCP(c).Annotations).To(HaveKey(clusterv1beta2.PausedAnnotation))
+
+	// Etcd is ready with the new machines
+	etcdadmCluster = tt.readEtcdadmCluster(c)
+	etcdadmCluster.Status.ObservedGeneration = 2
+	tt.Expect(c.Update(tt.ctx, etcdadmCluster)).To(Succeed())
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.Result{}))
+
+	kcp = tt.readKCP(c)
+	tt.Expect(kcp.Annotations).NotTo(HaveKey(clusterv1beta2.PausedAnnotation))
+	tt.Expect(kcp.Annotations).NotTo(HaveKey("anywhere.eks.amazonaws.com/etcd-certificate-renewal"))
+	tt.Expect(kcp.Spec.Rollout.After.IsZero()).To(BeFalse())
+}
+
+func TestReconcileCertificateRenewalExternalEtcdAlreadyUnpaused(t *testing.T) {
+	tt := newCertificateRenewalTest(t)
+	tt.withExternalEtcd()
+	tt.kcp.Annotations = map[string]string{
+		"anywhere.eks.amazonaws.com/etcd-certificate-renewal": "true",
+	}
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.Result{}))
+
+	kcp := tt.readKCP(c)
+	tt.Expect(kcp.Annotations).NotTo(HaveKey("anywhere.eks.amazonaws.com/etcd-certificate-renewal"))
+	tt.Expect(kcp.Spec.Rollout.After.IsZero()).To(BeTrue())
+}
+
+func TestReconcileCertificateRenewalExternalEtcdUpgradeInProgress(t *testing.T) {
+	tt := newCertificateRenewalTest(t)
+	tt.withExternalEtcd()
+	tt.etcdadmCluster.Annotations = map[string]string{
+		etcdv1.UpgradeInProgressAnnotation: "true",
+	}
+	tt.cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "my-cluster-etcd-1", ExpiresInDays: 10},
+	}
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Equal(controller.Result{}))
+	tt.Expect(tt.readKCP(c).Annotations).NotTo(HaveKey(clusterv1beta2.PausedAnnotation))
+	tt.Expect(tt.readEtcdadmCluster(c).Spec.InfrastructureTemplate.Name).To(Equal("my-cluster-etcd-1"))
+}
+
+func TestReconcileCertificateRenewalExternalEtcdMissingTemplate(t *testing.T) {
+	tt := newCertificateRenewalTest(t)
+	tt.withExternalEtcd()
+	tt.cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{Machine: "my-cluster-etcd-1", ExpiresInDays: 10},
+	}
+	c := tt.client()
+	tt.Expect(c.Delete(tt.ctx, tt.etcdTemplate)).To(Succeed())
+
+	_, err := clusters.ReconcileCertificateRenewal(tt.ctx, test.NewNullLogger(), c, tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading etcd machine template")))
+}
+
+func TestCertificateRenewalPending(t *testing.T) {
+	tests := []struct {
+		name         string
+		renewal      *anywherev1.CertificateRenewal
+		certificates []anywherev1.ClusterCertificateInfo
+		kcpAnnotated bool
+		want         bool
+	}{
+		{
+			name:         "not enabled",
+			certificates: []anywherev1.ClusterCertificateInfo{{Machine: "my-cluster-cp-1", ExpiresInDays: 5}},
+		},
+		{
+			name:         "certificates not expiring",
+			renewal:      &anywherev1.CertificateRenewal{ThresholdDays: 30},
+			certificates: []anywherev1.ClusterCertificateInfo{{Machine: "my-cluster-cp-1", ExpiresInDays: 65}},
+		},
+		{
+			name:         "certificates expiring",
+			renewal:      &anywherev1.CertificateRenewal{ThresholdDays: 30},
+			certificates: []anywherev1.ClusterCertificateInfo{{Machine: "my-cluster-cp-1", ExpiresInDays: 30}},
+			want:         true,
+		},
+		{
+			name:         "control plane waiting for etcd certificates renewal",
+			renewal:      &anywherev1.CertificateRenewal{ThresholdDays: 30},
+			kcpAnnotated: true,
+			want:         true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newCertificateRenewalTest(t)
+			tt.cluster.Spec.CertificateRenewal = tc.renewal
+			tt.cluster.Status.ClusterCertificateInfo = tc.certificates
+			if tc.kcpAnnotated {
+				tt.kcp.Annotations = map[string]string{"anywhere.eks.amazonaws.com/etcd-certificate-renewal": "true"}
+			}
+
+			pending, err := clusters.CertificateRenewalPending(tt.ctx, tt.client(), tt.cluster)
+			tt.Expect(err).NotTo(HaveOccurred())
+			tt.Expect(pending).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestCertificateRenewalUrgent(t *testing.T) {
+	tests := []struct {
+		name         string
+		renewal      *anywherev1.CertificateRenewal
+		certificates []anywherev1.ClusterCertificateInfo
+		want         bool
+	}{
+		{
+			name:         "not enabled",
+			certificates: []anywherev1.ClusterCertificateInfo{{Machine: "my-cluster-cp-1", ExpiresInDays: 1}},
+		},
+		{
+			name:         "certificates within threshold",
+			renewal:      &anywherev1.CertificateRenewal{ThresholdDays: 30},
+			certificates: []anywherev1.ClusterCertificateInfo{{Machine: "my-cluster-cp-1", ExpiresInDays: 10}},
+		},
+		{
+			name:    "certificates about to expire",
+			renewal: &anywherev1.CertificateRenewal{ThresholdDays: 30},
+			certificates: []anywherev1.ClusterCertificateInfo{
+				{Machine: "my-cluster-cp-1", ExpiresInDays: 10},
+				{Machine: "my-cluster-cp-2", ExpiresInDays: clusters.CertificateRenewalUrgentDays},
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &anywherev1.Cluster{}
+			cluster.Spec.CertificateRenewal = tc.renewal
+			cluster.Status.ClusterCertificateInfo = tc.certificates
+
+			g.Expect(clusters.CertificateRenewalUrgent(cluster)).To(Equal(tc.want))
+		})
+	}
+}
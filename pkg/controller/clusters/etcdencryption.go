@@ -0,0 +1,212 @@
+package clusters
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+)
+
+// EtcdEncryptionKeyRotationCheckInterval is how often a cluster is reconciled while its etcd encryption
+// key is being rotated, to move the rotation forward once the control plane is rolled out.
+const EtcdEncryptionKeyRotationCheckInterval = 30 * time.Second
+
+// reencryptionPageSize is the number of objects listed at once when re-encrypting resources.
+const reencryptionPageSize = 500
+
+// RemoteClientRegistry gets clients for the workload clusters.
+type RemoteClientRegistry interface {
+	// GetUncachedClient returns a live client that reads from and writes to the workload cluster API server.
+	// Re-encryption needs it to page through all the objects of a resource, which cached clients don't support.
+	GetUncachedClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// ReconcileEtcdEncryption records in the cluster status the etcd encryption providers to configure in the control plane
+// and drives the encryption key rotation when the provider in the spec is replaced by a new one. The rotation goes
+// through several control plane rollouts, one per phase, so the API servers never write data that other API servers
+// can't read. The providers rendered in the control plane come from Cluster.ControlPlaneEtcdEncryption, so this must
+// run before the provider reconciler.
+func ReconcileEtcdEncryption(ctx context.Context, log logr.Logger, c client.Client, remoteClients RemoteClientRegistry, cluster *anywherev1.Cluster) error {
+	if cluster.Spec.EtcdEncryption == nil || len(*cluster.Spec.EtcdEncryption) == 0 {
+		cluster.Status.EtcdEncryption = nil
+		v1beta1conditions.Delete(cluster, anywherev1.EtcdEncryptionKeyRotatedCondition)
+		return nil
+	}
+
+	desired := (*cluster.Spec.EtcdEncryption)[0].Providers
+	status := cluster.Status.EtcdEncryption
+	if status == nil || len(status.Providers) == 0 {
+		cluster.Status.EtcdEncryption = &anywherev1.EtcdEncryptionStatus{Providers: copyProviders(desired)}
+		return nil
+	}
+
+	switch status.KeyRotationPhase {
+	case "":
+		if providerName(status.Providers[0]) == providerName(desired[0]) {
+			// Changes to the provider settings, like the plugin image, are rolled out as any other control plane change.
+			status.Providers = copyProviders(desired)
+			return nil
+		}
+
+		log.Info("Etcd encryption provider replaced, rotating encryption key", "oldProvider", providerName(status.Providers[0]), "newProvider", providerName(desired[0]))
+		status.Providers = append(status.Providers[:1:1], copyProviders(desired)...)
+		setKeyRotationPhase(cluster, anywherev1.EtcdEncryptionAddingProvider)
+		return nil
+	case anywherev1.EtcdEncryptionReencryptingResources:
+		if err := reencryptResources(ctx, log, remoteClients, cluster); err != nil {
+			v1beta1conditions.MarkFalse(cluster, anywherev1.EtcdEncryptionKeyRotatedCondition, anywherev1.EtcdEncryptionReencryptionFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return err
+		}
+		status.Providers = status.Providers[:1]
+		setKeyRotationPhase(cluster, anywherev1.EtcdEncryptionRemovingProvider)
+		return nil
+	}
+
+	rolledOut, err := controlPlaneRolledOut(ctx, c, cluster)
+	if err != nil {
+		return err
+	}
+	if !rolledOut {
+		log.Info("Waiting for control plane to roll out the etcd encryption providers", "phase", status.KeyRotationPhase)
+		return nil
+	}
+
+	switch status.KeyRotationPhase {
+	case anywherev1.EtcdEncryptionAddingProvider:
+		status.Providers[0], status.Providers[1] = status.Providers[1], status.Providers[0]
+		setKeyRotationPhase(cluster, anywherev1.EtcdEncryptionSwitchingProvider)
+	case anywherev1.EtcdEncryptionSwitchingProvider:
+		setKeyRotationPhase(cluster, anywherev1.EtcdEncryptionReencryptingResources)
+	case anywherev1.EtcdEncryptionRemovingProvider:
+		log.Info("Etcd encryption key rotated", "provider", providerName(status.Providers[0]))
+		status.KeyRotationPhase = ""
+		v1beta1conditions.MarkTrue(cluster, anywherev1.EtcdEncryptionKeyRotatedCondition)
+	}
+
+	return nil
+}
+
+func setKeyRotationPhase(cluster *anywherev1.Cluster, phase anywherev1.EtcdEncryptionKeyRotationPhase) {
+	cluster.Status.EtcdEncryption.KeyRotationPhase = phase
+	v1beta1conditions.MarkFalse(cluster, anywherev1.EtcdEncryptionKeyRotatedCondition, anywherev1.EtcdEncryptionKeyRotationInProgressReason, clusterv1.ConditionSeverityInfo, "Rotating etcd encryption key: %s", phase)
+}
+
+func providerName(p anywherev1.EtcdEncryptionProvider) string {
+	if p.KMS == nil {
+		return ""
+	}
+	return p.KMS.Name
+}
+
+func copyProviders(providers []anywherev1.EtcdEncryptionProvider) []anywherev1.EtcdEncryptionProvider {
+	c := make([]anywherev1.EtcdEncryptionProvider, 0, len(providers))
+	for _, p := range providers {
+		c = append(c, *p.DeepCopy())
+	}
+	return c
+}
+
+// controlPlaneRolledOut returns true if all the control plane machines are up to date with a KubeadmControlPlane
+// that has the encryption config for the providers in the cluster status.
+func controlPlaneRolledOut(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) (bool, error) {
+	kcp, err := controller.GetKubeadmControlPlane(ctx, c, cluster)
+	if err != nil {
+		return false, errors.Wrap(err, "getting kubeadmcontrolplane")
+	}
+	if kcp == nil {
+		return false, nil
+	}
+
+	encryptionConfig, err := common.GenerateKMSEncryptionConfiguration(cluster.ControlPlaneEtcdEncryption())
+	if err != nil {
+		return false, err
+	}
+
+	// Each provider writes the encryption config in a different path, so we look for its content.
+	applied := false
+	for _, f := range kcp.Spec.KubeadmConfigSpec.Files {
+		if strings.TrimSpace(f.Content) == encryptionConfig {
+			applied = true
+			break
+		}
+	}
+	if !applied || kcp.Status.ObservedGeneration != kcp.Generation {
+		return false, nil
+	}
+
+	expected := int32(cluster.Spec.ControlPlaneConfiguration.Count)
+	return valueOrZero(kcp.Status.Replicas) == expected &&
+		valueOrZero(kcp.Status.UpToDateReplicas) == expected &&
+		valueOrZero(kcp.Status.ReadyReplicas) == expected, nil
+}
+
+func valueOrZero(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// reencryptResources rewrites all the objects of the encrypted resources in the workload cluster, so
+// the API server encrypts them with the current encryption provider.
+func reencryptResources(ctx context.Context, log logr.Logger, remoteClients RemoteClientRegistry, cluster *anywherev1.Cluster) error {
+	c, err := remoteClients.GetUncachedClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return errors.Wrap(err, "getting workload cluster client")
+	}
+
+	for _, resource := range (*cluster.Spec.EtcdEncryption)[0].Resources {
+		if strings.Contains(resource, "*") {
+			return errors.Errorf("can't re-encrypt wildcard resource %s, replace it with the list of resources to rotate the encryption key", resource)
+		}
+
+		gr := schema.ParseGroupResource(resource)
+		gvk, err := c.RESTMapper().KindFor(gr.WithVersion(""))
+		if err != nil {
+			return errors.Wrapf(err, "getting kind of resource %s", resource)
+		}
+
+		log.Info("Re-encrypting resources with new etcd encryption key", "resource", resource)
+		if err := reencryptKind(ctx, c, gvk); err != nil {
+			return errors.Wrapf(err, "re-encrypting %s", resource)
+		}
+	}
+
+	return nil
+}
+
+func reencryptKind(ctx context.Context, c client.Client, gvk schema.GroupVersionKind) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	for {
+		if err := c.List(ctx, list, client.Limit(reencryptionPageSize), client.Continue(list.GetContinue())); err != nil {
+			return errors.Wrap(err, "listing objects")
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			// An update, even without changes, stores the object encrypted with the current key.
+			// If the object was updated or deleted meanwhile, it's already stored with the current key or gone.
+			err := c.Update(ctx, obj)
+			if err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "updating %s", client.ObjectKeyFromObject(obj))
+			}
+		}
+
+		if list.GetContinue() == "" {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package clusters_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	bootstrapv1beta2 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+)
+
+type etcdEncryptionTest struct {
+	t *testing.T
+	*WithT
+	ctx           context.Context
+	cluster       *anywherev1.Cluster
+	kcp           *controlplanev1beta2.KubeadmControlPlane
+	remoteClients *mocks.MockRemoteClientRegistry
+	oldProvider   anywherev1.EtcdEncryptionProvider
+	newProvider   anywherev1.EtcdEncryptionProvider
+}
+
+func newEtcdEncryptionTest(t *testing.T) *etcdEncryptionTest {
+	tt := &etcdEncryptionTest{
+		t:             t,
+		WithT:         NewWithT(t),
+		ctx:           context.Background(),
+		remoteClients: mocks.NewMockRemoteClientRegistry(gomock.NewController(t)),
+		oldProvider:   kmsProvider("kms-1"),
+		newProvider:   kmsProvider("kms-2"),
+		kcp: &controlplanev1beta2.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "my-cluster",
+				Namespace:  constants.EksaSystemNamespace,
+				Generation: 2,
+			},
+			Status: controlplanev1beta2.KubeadmControlPlaneStatus{
+				ObservedGeneration: 2,
+				Replicas:           ptr.To[int32](3),
+				UpToDateReplicas:   ptr.To[int32](3),
+				ReadyReplicas:      ptr.To[int32](3),
+			},
+		},
+	}
+	tt.cluster = &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{Count: 3},
+			EtcdEncryption: &[]anywherev1.EtcdEncryption{
+				{
+					Providers: []anywherev1.EtcdEncryptionProvider{tt.newProvider},
+					Resources: []string{"secrets"},
+				},
+			},
+		},
+	}
+	return tt
+}
+
+func kmsProvider(name string) anywherev1.EtcdEncryptionProvider {
+	return anywherev1.EtcdEncryptionProvider{
+		KMS: &anywherev1.KMS{
+			Name:                name,
+			SocketListenAddress: "unix:///var/run/kmsplugin/" + name + ".sock",
+			CacheSize:           anywherev1.DefaultKMSCacheSize,
+			Timeout:             &anywherev1.DefaultKMSTimeout,
+		},
+	}
+}
+
+func (tt *etcdEncryptionTest) withRotation(phase anywherev1.EtcdEncryptionKeyRotationPhase, providers ...anywherev1.EtcdEncryptionProvider) {
+	tt.cluster.Status.EtcdEncryption = &anywherev1.EtcdEncryptionStatus{
+		Providers:        providers,
+		KeyRotationPhase: phase,
+	}
+}
+
+// withControlPlaneRolledOut sets in the KCP the encryption config rendered from the cluster status.
+func (tt *etcdEncryptionTest) withControlPlaneRolledOut() {
+	conf, err := common.GenerateKMSEncryptionConfiguration(tt.cluster.ControlPlaneEtcdEncryption())
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.kcp.Spec.KubeadmConfigSpec.Files = []bootstrapv1beta2.File{
+		{Path: "/var/lib/kubeadm/encryption-config.yaml", Content: conf + "\n"},
+	}
+}
+
+func (tt *etcdEncryptionTest) client() client.Client {
+	return fake.NewClientBuilder().WithRuntimeObjects(tt.cluster, tt.kcp).Build()
+}
+
+func (tt *etcdEncryptionTest) reconcile() error {
+	return clusters.ReconcileEtcdEncryption(tt.ctx, test.NewNullLogger(), tt.client(), tt.remoteClients, tt.cluster)
+}
+
+func (tt *etcdEncryptionTest) expectPhase(phase anywherev1.EtcdEncryptionKeyRotationPhase, providers ...anywherev1.EtcdEncryptionProvider) {
+	tt.t.Helper()
+	tt.Expect(tt.cluster.Status.EtcdEncryption).To(Equal(&anywherev1.EtcdEncryptionStatus{
+		Providers:        providers,
+		KeyRotationPhase: phase,
+	}))
+}
+
+func TestReconcileEtcdEncryptionDisabled(t *testing.T) {
+	tt := newEtcdEncryptionTest(t)
+	tt.cluster.Spec.EtcdEncryption = nil
+	tt.withRotation("", tt.oldProvider)
+	v1beta1conditions.MarkTrue(tt.cluster, anywherev1.EtcdEncryptionKeyRotatedCondition)
+
+	tt.Expect(tt.reconcile()).To(Succeed())
+	tt.Expect(tt.cluster.Status.EtcdEncryption).To(BeNil())
+	tt.Expect(v1beta1conditions.Has(tt.cluster, anywherev1.EtcdEncryptionKeyRotatedCondition)).To(BeFalse())
+}
+
+func TestReconcileEtcdEncryptionEnabled(t *testing.T) {
+	tt := newEtcdEncryptionTest(t)
+
+	tt.Expect(tt.reconcile()).To(Succeed())
+	tt.expectPhase("", tt.newProvider)
+	tt.Expect(v1beta1conditions.Has(tt.cluster, anywherev1.EtcdEncryptionKeyRotatedCondition)).To(BeFalse())
+}
+
+func TestReconcileEtcdEncryptionProviderUpdated(t *testing.T) {
+	tt := newEtcdEncryptionTest(t)
+	tt.withRotation("", tt.newProvider)
+	updated := *tt.newProvider.DeepCopy()
+	updated.KMS.Plugin = &anywherev1.KMSPlugin{Image: "public.ecr.aws/kms-plugin:v2"}
+	(*tt.cluster.Spec.EtcdEncryption)[0].Providers = []anywherev1.EtcdEncryptionProvider{updated}
+
+	tt.Expect(tt.reconcile()).To(Succeed())
+	tt.expectPhase("", updated)
+}
+
+func TestReconcileEtcdEncryptionStartKeyRotation(t *testing.T) {
+	tt := newEtcdEncryptionTest(t)
+	tt.withRotation("", tt.oldProvider)
+
+	tt.Expect(tt.reconcile()).To(Succeed())
+	tt.expectPhase(anywherev1.EtcdEncryptionAddingProvider, tt.oldProvider, tt.newProvider)
+	tt.Expect(v1beta1conditions.IsFalse(tt.cluster, anywherev1.EtcdEncryptionKeyRotatedCondition)).To(BeTrue())
+	tt.Expect(v1beta1conditions.GetReason(tt.cluster, anywherev1.EtcdEncryptionKeyRotatedCondition)).To(Equal(anywherev1.EtcdEncryptionKeyRotationInProgressReason))
+}
+
+func TestReconcileEtcdEncryptionWaitForControlPlane(t *testing.T) {
+	tests := []struct {
+		name      string
+		rolledOut bool
+		kcp       func(*controlplanev1beta2.KubeadmControlPlane)
+	}{
+		{
+			name: "encryption config not applied",
+			kcp:  func(*controlplanev1beta2.KubeadmControlPlane) {},
+		},
+		{
+			name:      "outdated status",
+			rolledOut: true,
+			kcp: func(kcp *controlplanev1beta2.KubeadmControlPlane) {
+				kcp.Generation = 3
+			},
+		},
+		{
+			name:      "machines not up to date",
+			rolledOut: true,
+			kcp: func(kcp *controlplanev1beta2.KubeadmControlPlane) {
+				kcp.Status.Replicas = ptr.To[int32](4)
+				kcp.Status.UpToDateReplicas = ptr.To[int32](1)
+			},
+		},
+		{
+			name:      "machines not ready",
+			rolledOut: true,
+			kcp: func(kcp *controlplanev1beta2.KubeadmControlPlane) {
+				kcp.Status.ReadyReplicas = ptr.To[int32](2)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newEtcdEncryptionTest(t)
+			tt.withRotation(anywherev1.EtcdEncryptionAddingProvider, tt.oldProvider, tt.newProvider)
+			if tc.rolledOut {
+				tt.withControlPlaneRolledOut()
+			}
+			tc.kcp(tt.kcp)
+
+			tt.Expect(tt.reconcile()).To(Succeed())
+			tt.expectPhase(anywherev1.EtcdEncryptionAddingProvider, tt.oldProvider, tt.newProvider)
+		})
+	}
+}
+
+func TestReconcileEtcdEncryptionSwitchProvider(t *testing.T) {
+	tt := newEtcdEncryptionTest(t)
+	tt.withRotation(anywherev1.EtcdEncryptionAddingProvider, tt.oldProvider, tt.newProvider)
+	tt.withControlPlaneRolledOut()
+
+	tt.Expect(tt.reconcile()).To(Succeed())
+	tt.expectPhase(anywherev1.EtcdEncryptionSwitchingProvider, tt.newProvider, tt.oldProvider)
+}
+
+func TestReconcileEtcdEncryptionStartReencryption(t *testing.T) {
+	tt := newEtcdEncryptionTest(t)
+	tt.withRotation(anywherev1.EtcdEncryptionSwitchingProvider, tt.newProvider, tt.oldProvider)
+	tt.withControlPlaneRolledOut()
+
+	tt.Expect(tt.reconcile()).To(Succeed())
+	tt.expectPhase(anywherev1.EtcdEncryptionReencryptingResources, tt.newProvider, tt.oldProvider)
+}
+
+func TestReconcileEtcdEncryptionReencryptResources(t *testing.T) {
+	tt := newEtcdEncryptionTest(t)
+	tt.withRotation(anywherev1.EtcdEncryptionReencryptingResources, tt.newProvider, tt.oldProvider)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+	workloadClient := fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(secret).Build()
+	before := &corev1.Secret{}
+	tt.Expect(workloadClient.Get(tt.ctx, client.ObjectKeyFromObject(secret), before)).To(Succeed())
+	tt.remoteClients.EXPECT().GetUncachedClient(tt.ctx, client.ObjectKey{Name: "my-cluster", Namespace: constants.EksaSystemNamespace}).Return(workloadClient, nil)
+
+	tt.Expect(tt.reconcile()).To(Succeed())
+	tt.expectPhase(anywherev1.EtcdEncryptionRemovingProvider, tt.newProvider)
+
+	after := &corev1.Secret{}
+	tt.Expect(workloadClient.Get(tt.ctx, client.ObjectKeyFromObject(secret), after)).To(Succeed())
+	tt.Expect(after.ResourceVersion).NotTo(Equal(before.ResourceVersion))
+	tt.Expect(after.Data).To(Equal(secret.Data))
+}
+
+// pagingClient lists objects like the API server, in pages of at most Limit objects linked by continue tokens,
+// and records the objects updated. Unlike the fake client, it fails if the list isn't paginated.
+type pagingClient struct {
+	client.Client
+	mapper  meta.RESTMapper
+	objects []unstructured.Unstructured
+	updated []string
+}
+
+func (c *pagingClient) RESTMapper() meta.RESTMapper {
+	return c.mapper
+}
+
+func (c *pagingClient) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	o := &client.ListOptions{}
+	o.ApplyOptions(opts)
+	if o.Limit == 0 {
+		return errors.New("list is not paginated")
+	}
+
+	start := 0
+	if o.Continue != "" {
+		var err error
+		if start, err = strconv.Atoi(o.Continue); err != nil {
+			return fmt.Errorf("invalid continue token %s", o.Continue)
+		}
+	}
+	end := min(start+int(o.Limit), len(c.objects))
+
+	l := list.(*unstructured.UnstructuredList)
+	l.Items = append([]unstructured.Unstructured{}, c.objects[start:end]...)
+	l.SetContinue("")
+	if end < len(c.objects) {
+		l.SetContinue(strconv.Itoa(end))
+	}
+
+	return nil
+}
+
+func (c *pagingClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	c.updated = append(c.updated, obj.GetName())
+	return nil
+}
+
+func TestReconcileEtcdEncryptionReencryptResourcesSeveralPages(t *testing.T) {
+	tt := newEtcdEncryptionTest(t)
+	tt.withRotation(anywherev1.EtcdEncryptionReencryptingResources, tt.newProvider, tt.oldProvider)
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+	workloadClient := &pagingClient{mapper: mapper}
+	var secrets []string
+	for i := range 1201 {
+		secret := unstructured.Unstructured{}
+		secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		secret.SetName(fmt.Sprintf("secret-%d", i))
+		workloadClient.objects = append(workloadClient.objects, secret)
+		secrets = append(secrets, secret.GetName())
+	}
+	tt.remoteClients.EXPECT().GetUncachedClient(tt.ctx, client.ObjectKey{Name: "my-cluster", Namespace: constants.EksaSystemNamespace}).Return(workloadClient, nil)
+
+	tt.Expect(tt.reconcile()).To(Succeed())
+	tt.expectPhase(anywherev1.EtcdEncryptionRemovingProvider, tt.newProvider)
+	tt.Expect(workloadClient.updated).To(Equal(secrets))
+}
+
+func TestReconcileEtcdEncryptionReencryptWildcard(t *testing.T) {
+	tt := newEtcdEncryptionTest(t)
+	(*tt.cluster.Spec.EtcdEncryption)[0].Resources = []string{"*.*"}
+	tt.withRotation(anywherev1.EtcdEncryptionReencryptingResources, tt.newProvider, tt.oldProvider)
+	tt.remoteClients.EXPECT().GetUncachedClient(tt.ctx, gomock.Any()).Return(fake.NewClientBuilder().Build(), nil)
+
+	tt.Expect(tt.reconcile()).To(MatchError(ContainSubstring("can't re-encrypt wildcard resource *.*")))
+	tt.expectPhase(anywherev1.EtcdEncryptionReencryptingResources, tt.newProvider, tt.oldProvider)
+	tt.Expect(v1beta1conditions.GetReason(tt.cluster, anywherev1.EtcdEncryptionKeyRotatedCondition)).To(Equal(anywherev1.EtcdEncryptionReencryptionFailedReason))
+}
+
+func TestReconcileEtcdEncryptionKeyRotated(t *testing.T) {
+	tt := newEtcdEncryptionTest(t)
+	tt.withRotation(anywherev1.EtcdEncryptionRemovingProvider, tt.newProvider)
+	tt.withControlPlaneRolledOut()
+
+	tt.Expect(tt.reconcile()).To(Succeed())
+	tt.expectPhase("", tt.newProvider)
+	tt.Expect(v1beta1conditions.IsTrue(tt.cluster, anywherev1.EtcdEncryptionKeyRotatedCondition)).To(BeTrue())
+}
@@ -0,0 +1,200 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+)
+
+// UpgradePausedCheckInterval is how often a cluster with its upgrade paused is reconciled to report
+// the progress of the machines rollout.
+const UpgradePausedCheckInterval = time.Minute
+
+// pausedForUpgradeAnnotation marks the CAPI objects paused by the controller because the cluster upgrade is paused.
+// Only those are unpaused when the upgrade is resumed, so we don't interfere with other reasons to pause them.
+const pausedForUpgradeAnnotation = "anywhere.eks.amazonaws.com/paused-for-upgrade"
+
+// PendingRollout describes the work waiting to roll out machines in an existing cluster.
+type PendingRollout struct {
+	// Changes is true if the cluster has changes that haven't been reconciled yet or work that rolls out
+	// machines other than renewing certificates, like rotating the etcd encryption key.
+	Changes bool
+	// CertificateRenewal is true if certificates need to be renewed by rolling out machines.
+	CertificateRenewal bool
+}
+
+func (p PendingRollout) any() bool {
+	return p.Changes || p.CertificateRenewal
+}
+
+// ReconcileUpgradeSchedule decides if the changes to the cluster can be rolled out now. When the cluster upgrade is
+// paused with the upgrade-paused annotation, the KubeadmControlPlane and MachineDeployments are paused, which stops
+// any rollout in progress between nodes, and all changes are held until the upgrade is resumed. Outside the cluster
+// maintenance window, rollouts of an existing cluster are held until the next window starts. In both cases it
+// returns a result to stop the reconciliation and the UpgradeAllowed condition reports the pending work.
+// pending tells which work is waiting to roll out machines, like renewing certificates or rotating the etcd
+// encryption key, so it must run before any of them. While a certificate renewal is held, the UpgradeAllowed
+// condition has Warning severity and the cluster is requeued at least every CertificateRenewalCheckInterval,
+// so the renewal can run as soon as the certificates get close enough to expiring to bypass the window.
+func ReconcileUpgradeSchedule(ctx context.Context, log logr.Logger, c client.Client, cluster *anywherev1.Cluster, pending PendingRollout, now time.Time) (controller.Result, error) {
+	kcp, err := controller.GetKubeadmControlPlane(ctx, c, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting kubeadmcontrolplane")
+	}
+	machineDeployments, err := controller.GetMachineDeployments(ctx, c, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting machine deployments")
+	}
+
+	objs := make([]client.Object, 0, len(machineDeployments)+1)
+	if kcp != nil {
+		objs = append(objs, kcp)
+	}
+	for i := range machineDeployments {
+		objs = append(objs, &machineDeployments[i])
+	}
+
+	if cluster.UpgradePaused() {
+		if err := pauseForUpgrade(ctx, log, c, objs); err != nil {
+			return controller.Result{}, err
+		}
+		log.Info("Cluster upgrade is paused, holding changes")
+		v1beta1conditions.MarkFalse(cluster, anywherev1.UpgradeAllowedCondition, anywherev1.UpgradePausedReason, clusterv1.ConditionSeverityInfo,
+			"Upgrade paused, %s", pendingRollout(kcp, machineDeployments))
+		return controller.ResultWithRequeue(UpgradePausedCheckInterval), nil
+	}
+
+	if err := resumeAfterUpgradePause(ctx, log, c, objs); err != nil {
+		return controller.Result{}, err
+	}
+
+	if cluster.Spec.MaintenanceWindow == nil {
+		v1beta1conditions.Delete(cluster, anywherev1.UpgradeAllowedCondition)
+		return controller.Result{}, nil
+	}
+
+	// The maintenance window only applies to changes to existing clusters, new ones are created right away.
+	if !pending.any() || kcp == nil {
+		v1beta1conditions.MarkTrue(cluster, anywherev1.UpgradeAllowedCondition)
+		return controller.Result{}, nil
+	}
+
+	inWindow, next, err := cluster.InMaintenanceWindow(now)
+	if err != nil {
+		return controller.Result{}, err
+	}
+	if inWindow {
+		v1beta1conditions.MarkTrue(cluster, anywherev1.UpgradeAllowedCondition)
+		return controller.Result{}, nil
+	}
+
+	if next.IsZero() {
+		log.Info("Outside maintenance window and no upcoming window, holding changes")
+		v1beta1conditions.MarkFalse(cluster, anywherev1.UpgradeAllowedCondition, anywherev1.OutsideMaintenanceWindowReason, clusterv1.ConditionSeverityWarning,
+			"Changes held, the maintenance window schedules don't start in the next years")
+		return controller.ResultWithReturn(), nil
+	}
+
+	log.Info("Outside maintenance window, holding changes", "nextWindow", next)
+	if pending.CertificateRenewal {
+		v1beta1conditions.MarkFalse(cluster, anywherev1.UpgradeAllowedCondition, anywherev1.OutsideMaintenanceWindowReason, clusterv1.ConditionSeverityWarning,
+			"Changes held until the next maintenance window at %s, including the renewal of expiring certificates", next.Format(time.RFC3339))
+		return controller.ResultWithRequeue(min(next.Sub(now), CertificateRenewalCheckInterval)), nil
+	}
+
+	v1beta1conditions.MarkFalse(cluster, anywherev1.UpgradeAllowedCondition, anywherev1.OutsideMaintenanceWindowReason, clusterv1.ConditionSeverityInfo,
+		"Changes held until the next maintenance window at %s", next.Format(time.RFC3339))
+	return controller.ResultWithRequeue(next.Sub(now)), nil
+}
+
+func pauseForUpgrade(ctx context.Context, log logr.Logger, c client.Client, objs []client.Object) error {
+	for _, current := range objs {
+		// If it's already paused for any other reason, leave it to whoever paused it.
+		if annotations.HasPaused(current) {
+			continue
+		}
+
+		log.Info("Pausing rollout for cluster upgrade pause", "object", klog.KObj(current))
+		if err := updateWithRetry(ctx, c, current, func(obj client.Object) {
+			clientutil.AddAnnotation(obj, clusterv1beta2.PausedAnnotation, "true")
+			clientutil.AddAnnotation(obj, pausedForUpgradeAnnotation, "true")
+		}); err != nil {
+			return errors.Wrap(err, "pausing rollout")
+		}
+	}
+
+	return nil
+}
+
+func resumeAfterUpgradePause(ctx context.Context, log logr.Logger, c client.Client, objs []client.Object) error {
+	for _, current := range objs {
+		if _, ok := current.GetAnnotations()[pausedForUpgradeAnnotation]; !ok {
+			continue
+		}
+
+		log.Info("Resuming rollout after cluster upgrade pause", "object", klog.KObj(current))
+		if err := updateWithRetry(ctx, c, current, func(obj client.Object) {
+			a := obj.GetAnnotations()
+			delete(a, clusterv1beta2.PausedAnnotation)
+			delete(a, pausedForUpgradeAnnotation)
+			obj.SetAnnotations(a)
+		}); err != nil {
+			return errors.Wrap(err, "resuming rollout")
+		}
+	}
+
+	return nil
+}
+
+// updateWithRetry reads the latest version of the object before applying mutate and updating it, retrying
+// on conflicts, since current comes from an informer cache and can be stale.
+func updateWithRetry(ctx context.Context, c client.Client, current client.Object, mutate func(client.Object)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj := current.DeepCopyObject().(client.Object)
+		if err := c.Get(ctx, client.ObjectKeyFromObject(current), obj); err != nil {
+			return err
+		}
+		mutate(obj)
+		return c.Update(ctx, obj)
+	})
+}
+
+// pendingRollout describes how many machines are up to date with the control plane and machine deployments specs.
+func pendingRollout(kcp *controlplanev1beta2.KubeadmControlPlane, machineDeployments []clusterv1beta2.MachineDeployment) string {
+	pending := make([]string, 0, len(machineDeployments)+1)
+	if kcp != nil {
+		pending = append(pending, fmt.Sprintf("control plane %d/%d machines up to date",
+			valueOrZero(kcp.Status.UpToDateReplicas), desiredReplicas(kcp.Spec.Replicas, kcp.Status.Replicas)))
+	}
+	for _, md := range machineDeployments {
+		pending = append(pending, fmt.Sprintf("%s %d/%d machines up to date",
+			md.Name, valueOrZero(md.Status.UpToDateReplicas), desiredReplicas(md.Spec.Replicas, md.Status.Replicas)))
+	}
+	if len(pending) == 0 {
+		return "no machines to roll out"
+	}
+	return strings.Join(pending, ", ")
+}
+
+func desiredReplicas(spec, status *int32) int32 {
+	if spec != nil {
+		return *spec
+	}
+	return valueOrZero(status)
+}
@@ -0,0 +1,223 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+)
+
+type upgradeScheduleTest struct {
+	*WithT
+	ctx     context.Context
+	cluster *anywherev1.Cluster
+	kcp     *controlplanev1beta2.KubeadmControlPlane
+	md      *clusterv1beta2.MachineDeployment
+	// now is a Wednesday.
+	now time.Time
+}
+
+func newUpgradeScheduleTest(t *testing.T) *upgradeScheduleTest {
+	return &upgradeScheduleTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		now:   time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+		cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: "default",
+			},
+			Spec: anywherev1.ClusterSpec{
+				MaintenanceWindow: &anywherev1.MaintenanceWindowConfiguration{
+					Windows: []anywherev1.MaintenanceWindow{
+						{Schedule: "0 2 * * sat", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+					},
+				},
+			},
+		},
+		kcp: &controlplanev1beta2.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: constants.EksaSystemNamespace,
+			},
+			Spec: controlplanev1beta2.KubeadmControlPlaneSpec{
+				Replicas: ptr.To[int32](3),
+			},
+			Status: controlplanev1beta2.KubeadmControlPlaneStatus{
+				UpToDateReplicas: ptr.To[int32](1),
+			},
+		},
+		md: &clusterv1beta2.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster-md-0",
+				Namespace: constants.EksaSystemNamespace,
+				Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: "my-cluster"},
+			},
+			Spec: clusterv1beta2.MachineDeploymentSpec{
+				Replicas: ptr.To[int32](2),
+			},
+			Status: clusterv1beta2.MachineDeploymentStatus{
+				UpToDateReplicas: ptr.To[int32](0),
+			},
+		},
+	}
+}
+
+func (tt *upgradeScheduleTest) client(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithObjects(objs...).Build()
+}
+
+func (tt *upgradeScheduleTest) expectPaused(c client.Client, paused bool) {
+	kcp := &controlplanev1beta2.KubeadmControlPlane{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(tt.kcp), kcp)).To(Succeed())
+	md := &clusterv1beta2.MachineDeployment{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(tt.md), md)).To(Succeed())
+
+	for _, obj := range []client.Object{kcp, md} {
+		if paused {
+			tt.Expect(obj.GetAnnotations()).To(HaveKeyWithValue(clusterv1beta2.PausedAnnotation, "true"), obj.GetName())
+		} else {
+			tt.Expect(obj.GetAnnotations()).NotTo(HaveKey(clusterv1beta2.PausedAnnotation), obj.GetName())
+		}
+	}
+}
+
+func TestReconcileUpgradeScheduleNoMaintenanceWindow(t *testing.T) {
+	tt := newUpgradeScheduleTest(t)
+	tt.cluster.Spec.MaintenanceWindow = nil
+	v1beta1conditions.MarkTrue(tt.cluster, anywherev1.UpgradeAllowedCondition)
+	c := tt.client(tt.kcp, tt.md)
+
+	result, err := clusters.ReconcileUpgradeSchedule(tt.ctx, test.NewNullLogger(), c, tt.cluster, clusters.PendingRollout{Changes: true}, tt.now)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeFalse())
+	tt.Expect(v1beta1conditions.Has(tt.cluster, anywherev1.UpgradeAllowedCondition)).To(BeFalse())
+}
+
+func TestReconcileUpgradeScheduleOutsideMaintenanceWindow(t *testing.T) {
+	tt := newUpgradeScheduleTest(t)
+	c := tt.client(tt.kcp, tt.md)
+
+	result, err := clusters.ReconcileUpgradeSchedule(tt.ctx, test.NewNullLogger(), c, tt.cluster, clusters.PendingRollout{Changes: true}, tt.now)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeTrue())
+	tt.Expect(result.ToCtrlResult().RequeueAfter).To(Equal(62 * time.Hour))
+
+	condition := v1beta1conditions.Get(tt.cluster, anywherev1.UpgradeAllowedCondition)
+	tt.Expect(condition).NotTo(BeNil())
+	tt.Expect(condition.Reason).To(Equal(anywherev1.OutsideMaintenanceWindowReason))
+	tt.Expect(condition.Severity).To(Equal(clusterv1.ConditionSeverityInfo))
+	tt.Expect(condition.Message).To(Equal("Changes held until the next maintenance window at 2024-01-13T02:00:00Z"))
+	tt.expectPaused(c, false)
+}
+
+func TestReconcileUpgradeScheduleOutsideMaintenanceWindowCertificateRenewal(t *testing.T) {
+	tt := newUpgradeScheduleTest(t)
+	c := tt.client(tt.kcp, tt.md)
+
+	result, err := clusters.ReconcileUpgradeSchedule(tt.ctx, test.NewNullLogger(), c, tt.cluster, clusters.PendingRollout{CertificateRenewal: true}, tt.now)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeTrue())
+	// Checked before the next window, in case the certificates get close enough to expiring to bypass it.
+	tt.Expect(result.ToCtrlResult().RequeueAfter).To(Equal(clusters.CertificateRenewalCheckInterval))
+
+	condition := v1beta1conditions.Get(tt.cluster, anywherev1.UpgradeAllowedCondition)
+	tt.Expect(condition).NotTo(BeNil())
+	tt.Expect(condition.Reason).To(Equal(anywherev1.OutsideMaintenanceWindowReason))
+	tt.Expect(condition.Severity).To(Equal(clusterv1.ConditionSeverityWarning))
+	tt.Expect(condition.Message).To(Equal("Changes held until the next maintenance window at 2024-01-13T02:00:00Z, including the renewal of expiring certificates"))
+}
+
+func TestReconcileUpgradeScheduleOutsideMaintenanceWindowNoChanges(t *testing.T) {
+	tt := newUpgradeScheduleTest(t)
+	c := tt.client(tt.kcp, tt.md)
+
+	result, err := clusters.ReconcileUpgradeSchedule(tt.ctx, test.NewNullLogger(), c, tt.cluster, clusters.PendingRollout{}, tt.now)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeFalse())
+	tt.Expect(v1beta1conditions.IsTrue(tt.cluster, anywherev1.UpgradeAllowedCondition)).To(BeTrue())
+}
+
+func TestReconcileUpgradeScheduleOutsideMaintenanceWindowNewCluster(t *testing.T) {
+	tt := newUpgradeScheduleTest(t)
+	c := tt.client()
+
+	result, err := clusters.ReconcileUpgradeSchedule(tt.ctx, test.NewNullLogger(), c, tt.cluster, clusters.PendingRollout{Changes: true}, tt.now)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeFalse())
+	tt.Expect(v1beta1conditions.IsTrue(tt.cluster, anywherev1.UpgradeAllowedCondition)).To(BeTrue())
+}
+
+func TestReconcileUpgradeScheduleInsideMaintenanceWindow(t *testing.T) {
+	tt := newUpgradeScheduleTest(t)
+	tt.now = time.Date(2024, 1, 13, 3, 0, 0, 0, time.UTC)
+	c := tt.client(tt.kcp, tt.md)
+
+	result, err := clusters.ReconcileUpgradeSchedule(tt.ctx, test.NewNullLogger(), c, tt.cluster, clusters.PendingRollout{Changes: true}, tt.now)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeFalse())
+	tt.Expect(v1beta1conditions.IsTrue(tt.cluster, anywherev1.UpgradeAllowedCondition)).To(BeTrue())
+}
+
+func TestReconcileUpgradeSchedulePauseAndResume(t *testing.T) {
+	tt := newUpgradeScheduleTest(t)
+	tt.cluster.Spec.MaintenanceWindow = nil
+	tt.cluster.PauseUpgrade()
+	c := tt.client(tt.kcp, tt.md)
+
+	result, err := clusters.ReconcileUpgradeSchedule(tt.ctx, test.NewNullLogger(), c, tt.cluster, clusters.PendingRollout{}, tt.now)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeTrue())
+	tt.Expect(result.ToCtrlResult().RequeueAfter).To(Equal(clusters.UpgradePausedCheckInterval))
+	tt.expectPaused(c, true)
+
+	condition := v1beta1conditions.Get(tt.cluster, anywherev1.UpgradeAllowedCondition)
+	tt.Expect(condition).NotTo(BeNil())
+	tt.Expect(condition.Reason).To(Equal(anywherev1.UpgradePausedReason))
+	tt.Expect(condition.Message).To(Equal("Upgrade paused, control plane 1/3 machines up to date, my-cluster-md-0 0/2 machines up to date"))
+
+	tt.cluster.ResumeUpgrade()
+	result, err = clusters.ReconcileUpgradeSchedule(tt.ctx, test.NewNullLogger(), c, tt.cluster, clusters.PendingRollout{}, tt.now)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeFalse())
+	tt.expectPaused(c, false)
+	tt.Expect(v1beta1conditions.Has(tt.cluster, anywherev1.UpgradeAllowedCondition)).To(BeFalse())
+}
+
+func TestReconcileUpgradeScheduleResumeKeepsOtherPauses(t *testing.T) {
+	tt := newUpgradeScheduleTest(t)
+	tt.cluster.Spec.MaintenanceWindow = nil
+	tt.cluster.PauseUpgrade()
+	// The KCP is already paused for an etcd change, the upgrade pause doesn't own it.
+	tt.kcp.Annotations = map[string]string{clusterv1beta2.PausedAnnotation: "true"}
+	c := tt.client(tt.kcp, tt.md)
+
+	_, err := clusters.ReconcileUpgradeSchedule(tt.ctx, test.NewNullLogger(), c, tt.cluster, clusters.PendingRollout{}, tt.now)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.expectPaused(c, true)
+
+	tt.cluster.ResumeUpgrade()
+	_, err = clusters.ReconcileUpgradeSchedule(tt.ctx, test.NewNullLogger(), c, tt.cluster, clusters.PendingRollout{}, tt.now)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	kcp := &controlplanev1beta2.KubeadmControlPlane{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(tt.kcp), kcp)).To(Succeed())
+	tt.Expect(kcp.Annotations).To(HaveKeyWithValue(clusterv1beta2.PausedAnnotation, "true"))
+	md := &clusterv1beta2.MachineDeployment{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(tt.md), md)).To(Succeed())
+	tt.Expect(md.Annotations).NotTo(HaveKey(clusterv1beta2.PausedAnnotation))
+}
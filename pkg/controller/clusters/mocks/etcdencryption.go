@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/controller/clusters/etcdencryption.go
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=pkg/controller/clusters/mocks/etcdencryption.go -package=mocks -source pkg/controller/clusters/etcdencryption.go RemoteClientRegistry
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MockRemoteClientRegistry is a mock of RemoteClientRegistry interface.
+type MockRemoteClientRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemoteClientRegistryMockRecorder
+	isgomock struct{}
+}
+
+// MockRemoteClientRegistryMockRecorder is the mock recorder for MockRemoteClientRegistry.
+type MockRemoteClientRegistryMockRecorder struct {
+	mock *MockRemoteClientRegistry
+}
+
+// NewMockRemoteClientRegistry creates a new mock instance.
+func NewMockRemoteClientRegistry(ctrl *gomock.Controller) *MockRemoteClientRegistry {
+	mock := &MockRemoteClientRegistry{ctrl: ctrl}
+	mock.recorder = &MockRemoteClientRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemoteClientRegistry) EXPECT() *MockRemoteClientRegistryMockRecorder {
+	return m.recorder
+}
+
+// GetUncachedClient mocks base method.
+func (m *MockRemoteClientRegistry) GetUncachedClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUncachedClient", ctx, cluster)
+	ret0, _ := ret[0].(client.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUncachedClient indicates an expected call of GetUncachedClient.
+func (mr *MockRemoteClientRegistryMockRecorder) GetUncachedClient(ctx, cluster any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUncachedClient", reflect.TypeOf((*MockRemoteClientRegistry)(nil).GetUncachedClient), ctx, cluster)
+}
@@ -15,6 +15,13 @@ type ProviderClusterReconciler interface {
 	Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
 }
 
+// ProviderClusterDeleteReconciler is implemented by the provider cluster reconcilers that clean up provider resources
+// that are not owned by the CAPI cluster when an eks-a cluster is deleted.
+type ProviderClusterDeleteReconciler interface {
+	// ReconcileDelete cleans up the provider resources of the deleted cluster.
+	ReconcileDelete(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error
+}
+
 // ProviderClusterReconcilerRegistry holds a collection of cluster provider reconcilers
 // and ties them to different provider Datacenter kinds.
 type ProviderClusterReconcilerRegistry struct {
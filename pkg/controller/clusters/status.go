@@ -2,12 +2,19 @@ package clusters
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -18,6 +25,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/certificates"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
 )
 
 // UpdateClusterStatusForControlPlane checks the current state of the Cluster's control plane and updates the
@@ -91,6 +100,64 @@ func UpdateClusterCertificateStatus(ctx context.Context, client client.Client, l
 	return nil
 }
 
+// gitOpsDriftIgnoredManagers returns the field managers of the eks-a controller, which updates the cluster objects
+// as part of its reconciliation: the one it applies objects with and the one of its client requests without a field
+// manager, which the API server takes from the client user agent.
+func gitOpsDriftIgnoredManagers() []string {
+	defaultManager, _, _ := strings.Cut(rest.DefaultKubernetesUserAgent(), "/")
+	return []string{serverside.FieldManager, defaultManager}
+}
+
+// UpdateClusterStatusForGitOpsDrift sets the GitOpsDrifted condition for clusters managed with flux. The cluster is
+// drifted when the spec of the Cluster, its datacenter config or its machine configs was changed by a client other
+// than flux after flux last applied them from git.
+func UpdateClusterStatusForGitOpsDrift(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
+	if cluster.Spec.GitOpsRef == nil || cluster.Spec.GitOpsRef.Kind != anywherev1.FluxConfigKind {
+		v1beta1conditions.Delete(cluster, anywherev1.GitOpsDriftedCondition)
+		return nil
+	}
+
+	refs := []anywherev1.Ref{cluster.Spec.DatacenterRef}
+	refs = append(refs, cluster.MachineConfigRefs()...)
+
+	ignoredManagers := gitOpsDriftIgnoredManagers()
+	var drifted []string
+	if managers := drift.SpecChangedOutsideGitOps(cluster, ignoredManagers...); len(managers) > 0 {
+		drifted = append(drifted, fmt.Sprintf("%s %s (%s)", anywherev1.ClusterKind, cluster.Name, strings.Join(managers, ", ")))
+	}
+
+	for _, ref := range refs {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(anywherev1.GroupVersion.WithKind(ref.Kind))
+		err := client.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: ref.Name}, obj)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "getting %s %s", ref.Kind, ref.Name)
+		}
+
+		if managers := drift.SpecChangedOutsideGitOps(obj, ignoredManagers...); len(managers) > 0 {
+			drifted = append(drifted, fmt.Sprintf("%s %s (%s)", ref.Kind, ref.Name, strings.Join(managers, ", ")))
+		}
+	}
+
+	if len(drifted) == 0 {
+		v1beta1conditions.MarkFalse(cluster, anywherev1.GitOpsDriftedCondition, anywherev1.InSyncWithGitOpsReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	v1beta1conditions.Set(cluster, &anywherev1.Condition{
+		Type:     anywherev1.GitOpsDriftedCondition,
+		Status:   corev1.ConditionTrue,
+		Severity: clusterv1.ConditionSeverityWarning,
+		Reason:   anywherev1.ChangedOutsideGitOpsReason,
+		Message:  fmt.Sprintf("Spec changed outside of GitOps: %s", strings.Join(drifted, ", ")),
+	})
+
+	return nil
+}
+
 // updateConditionsForEtcdAndControlPlane updates the ControlPlaneReady condition if etcdadm cluster is not ready.
 func updateConditionsForEtcdAndControlPlane(cluster *anywherev1.Cluster, kcp *controlplanev1beta2.KubeadmControlPlane, etcdadmCluster *etcdv1.EtcdadmCluster) {
 	// Make sure etcd cluster is ready before marking ControlPlaneReady status to true
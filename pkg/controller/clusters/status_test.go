@@ -3,6 +3,8 @@ package clusters_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
@@ -23,6 +25,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
@@ -1480,3 +1483,117 @@ func TestUpdateClusterStatusForCNI(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateClusterStatusForGitOpsDrift(t *testing.T) {
+	fluxApplied := metav1.Unix(1000, 0)
+	edited := metav1.Unix(2000, 0)
+	specFields := &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:memoryMiB":{}}}`)}
+
+	tests := []struct {
+		name          string
+		gitOpsRef     *anywherev1.Ref
+		editedBy      string
+		wantCondition *anywherev1.Condition
+	}{
+		{
+			name:      "no gitops",
+			gitOpsRef: nil,
+		},
+		{
+			name:      "in sync with git",
+			gitOpsRef: &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.GitOpsDriftedCondition,
+				Status:   "False",
+				Severity: clusterv1.ConditionSeverityInfo,
+				Reason:   anywherev1.InSyncWithGitOpsReason,
+			},
+		},
+		{
+			name:      "applied by eks-a controller",
+			gitOpsRef: &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			editedBy:  serverside.FieldManager,
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.GitOpsDriftedCondition,
+				Status:   "False",
+				Severity: clusterv1.ConditionSeverityInfo,
+				Reason:   anywherev1.InSyncWithGitOpsReason,
+			},
+		},
+		{
+			name:      "updated by eks-a controller",
+			gitOpsRef: &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			// The API server takes the field manager of requests without one from the user agent, which starts
+			// with the name of the binary.
+			editedBy: filepath.Base(os.Args[0]),
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.GitOpsDriftedCondition,
+				Status:   "False",
+				Severity: clusterv1.ConditionSeverityInfo,
+				Reason:   anywherev1.InSyncWithGitOpsReason,
+			},
+		},
+		{
+			name:      "changed outside of gitops",
+			gitOpsRef: &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			editedBy:  "kubectl-edit",
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.GitOpsDriftedCondition,
+				Status:   "True",
+				Severity: clusterv1.ConditionSeverityWarning,
+				Reason:   anywherev1.ChangedOutsideGitOpsReason,
+				Message:  "Spec changed outside of GitOps: VSphereMachineConfig test-cluster-cp (kubectl-edit)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = "test-cluster"
+				s.Cluster.Namespace = constants.EksaSystemNamespace
+				s.Cluster.Spec.GitOpsRef = tt.gitOpsRef
+				s.Cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: "test-cluster"}
+				s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "test-cluster-cp"}
+				s.Cluster.Status.Conditions = []anywherev1.Condition{
+					{
+						Type:   anywherev1.GitOpsDriftedCondition,
+						Status: "True",
+					},
+				}
+			})
+
+			managedFields := []metav1.ManagedFieldsEntry{
+				{Manager: "kustomize-controller", APIVersion: anywherev1.GroupVersion.String(), Operation: metav1.ManagedFieldsOperationApply, Time: &fluxApplied, FieldsType: "FieldsV1", FieldsV1: specFields},
+			}
+			if tt.editedBy != "" {
+				managedFields = append(managedFields, metav1.ManagedFieldsEntry{
+					Manager: tt.editedBy, APIVersion: anywherev1.GroupVersion.String(), Operation: metav1.ManagedFieldsOperationUpdate, Time: &edited, FieldsType: "FieldsV1", FieldsV1: specFields,
+				})
+			}
+			machineConfig := &anywherev1.VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:          "test-cluster-cp",
+					Namespace:     constants.EksaSystemNamespace,
+					ManagedFields: managedFields,
+				},
+			}
+
+			client := fake.NewClientBuilder().WithObjects(machineConfig).WithReturnManagedFields().Build()
+			g.Expect(clusters.UpdateClusterStatusForGitOpsDrift(ctx, client, spec.Cluster)).To(Succeed())
+
+			condition := v1beta1conditions.Get(spec.Cluster, anywherev1.GitOpsDriftedCondition)
+			if tt.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(condition.Severity).To(Equal(tt.wantCondition.Severity))
+			g.Expect(condition.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(condition.Message).To(Equal(tt.wantCondition.Message))
+		})
+	}
+}
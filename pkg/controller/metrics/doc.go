@@ -0,0 +1,3 @@
+// Package metrics defines the Prometheus metrics exposed by the EKS-A controller manager.
+// They are registered in the controller-runtime registry, so they are served by the manager metrics endpoint.
+package metrics
@@ -0,0 +1,211 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const namespace = "eksa"
+
+// Reconcile phases of the cluster controller.
+const (
+	PhasePreProvider  = "pre_provider"
+	PhaseProvider     = "provider"
+	PhasePostProvider = "post_provider"
+	PhasePackages     = "packages"
+	PhaseStatus       = "status"
+)
+
+// Kinds of upgrades the in-place upgrade controllers report durations for.
+const (
+	UpgradeKindControlPlane      = "control_plane"
+	UpgradeKindMachineDeployment = "machine_deployment"
+)
+
+// Roles of the nodes upgraded by the NodeUpgrade controller.
+const (
+	NodeRoleControlPlane = "control_plane"
+	NodeRoleWorker       = "worker"
+)
+
+// clusterConditions are the Cluster conditions exported as metrics.
+var clusterConditions = []anywherev1.ConditionType{
+	anywherev1.ReadyCondition,
+	anywherev1.ControlPlaneReadyCondition,
+	anywherev1.WorkersReadyCondition,
+}
+
+var conditionStatuses = []corev1.ConditionStatus{corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown}
+
+// Upgrades take from a few minutes to hours, depending on the number of nodes and the provider.
+var upgradeDurationBuckets = []float64{30, 60, 120, 300, 600, 900, 1200, 1800, 2700, 3600, 5400, 7200, 10800}
+
+var (
+	clusterCondition = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "cluster",
+			Name:      "condition",
+			Help:      "Status of the Cluster conditions. For each condition, the series for the current status is 1 and the rest 0.",
+		},
+		[]string{"namespace", "cluster", "condition", "status"},
+	)
+
+	clusterFailure = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "cluster",
+			Name:      "failure",
+			Help:      "Set to 1 when the Cluster has a terminal failure, labeled with the failure reason.",
+		},
+		[]string{"namespace", "cluster", "reason"},
+	)
+
+	clusterCertificateExpiryDays = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "cluster",
+			Name:      "certificate_expiry_days",
+			Help:      "Days until the certificates of a control plane or external etcd machine expire.",
+		},
+		[]string{"namespace", "cluster", "machine"},
+	)
+
+	clusterReconcilePhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "cluster",
+			Name:      "reconcile_phase_duration_seconds",
+			Help:      "Duration of each phase of the Cluster reconciliation.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+		},
+		[]string{"phase"},
+	)
+
+	upgradeDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "upgrade_duration_seconds",
+			Help:      "Duration of the in-place upgrades of control planes and machine deployments, from creation to completion.",
+			Buckets:   upgradeDurationBuckets,
+		},
+		[]string{"namespace", "cluster", "kind"},
+	)
+
+	nodeUpgradeDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "node_upgrade_duration_seconds",
+			Help:      "Duration of the in-place upgrade of a node, from creation to completion.",
+			Buckets:   upgradeDurationBuckets,
+		},
+		[]string{"namespace", "cluster", "role"},
+	)
+)
+
+// clusterSeries are the label values of the series of a Cluster that can change between reconciliations.
+type clusterSeries struct {
+	failureReason string
+	machines      map[string]bool
+}
+
+var (
+	reportedMu sync.Mutex
+	// reported tracks the series recorded for each Cluster, so the ones that don't apply anymore can be
+	// deleted without removing and adding back the rest, which would leave gaps in the scraped data.
+	reported = map[types.NamespacedName]clusterSeries{}
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		clusterCondition,
+		clusterFailure,
+		clusterCertificateExpiryDays,
+		clusterReconcilePhaseDuration,
+		upgradeDuration,
+		nodeUpgradeDuration,
+	)
+}
+
+// RecordClusterStatus updates the metrics computed from the Cluster status: conditions,
+// failure reason and certificate expiration. The series are updated in place and only the ones
+// for cleared failures and machines that have been rolled out are deleted.
+func RecordClusterStatus(cluster *anywherev1.Cluster) {
+	for _, conditionType := range clusterConditions {
+		current := corev1.ConditionUnknown
+		if c := v1beta1conditions.Get(cluster, conditionType); c != nil {
+			current = c.Status
+		}
+		for _, status := range conditionStatuses {
+			value := 0.0
+			if status == current {
+				value = 1
+			}
+			clusterCondition.WithLabelValues(cluster.Namespace, cluster.Name, string(conditionType), string(status)).Set(value)
+		}
+	}
+
+	series := clusterSeries{machines: map[string]bool{}}
+	if cluster.Status.FailureReason != nil {
+		series.failureReason = string(*cluster.Status.FailureReason)
+		clusterFailure.WithLabelValues(cluster.Namespace, cluster.Name, series.failureReason).Set(1)
+	}
+
+	for _, info := range cluster.Status.ClusterCertificateInfo {
+		series.machines[info.Machine] = true
+		clusterCertificateExpiryDays.WithLabelValues(cluster.Namespace, cluster.Name, info.Machine).Set(float64(info.ExpiresInDays))
+	}
+
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	reportedMu.Lock()
+	defer reportedMu.Unlock()
+
+	previous := reported[key]
+	if previous.failureReason != "" && previous.failureReason != series.failureReason {
+		clusterFailure.DeleteLabelValues(cluster.Namespace, cluster.Name, previous.failureReason)
+	}
+	for machine := range previous.machines {
+		if !series.machines[machine] {
+			clusterCertificateExpiryDays.DeleteLabelValues(cluster.Namespace, cluster.Name, machine)
+		}
+	}
+	reported[key] = series
+}
+
+// DeleteCluster removes all the series for a Cluster. It's called when the Cluster is deleted,
+// to prevent reporting stale data for it.
+func DeleteCluster(cluster *anywherev1.Cluster) {
+	labels := prometheus.Labels{"namespace": cluster.Namespace, "cluster": cluster.Name}
+	clusterCondition.DeletePartialMatch(labels)
+	clusterFailure.DeletePartialMatch(labels)
+	clusterCertificateExpiryDays.DeletePartialMatch(labels)
+
+	reportedMu.Lock()
+	defer reportedMu.Unlock()
+	delete(reported, types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name})
+}
+
+// ObserveReconcilePhase records the time elapsed since start as the duration of a Cluster reconcile phase.
+func ObserveReconcilePhase(phase string, start time.Time) {
+	clusterReconcilePhaseDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+}
+
+// ObserveUpgrade records the duration of a completed control plane or machine deployment upgrade
+// of the CAPI cluster in the namespace.
+func ObserveUpgrade(namespace, cluster, kind string, created metav1.Time) {
+	upgradeDuration.WithLabelValues(namespace, cluster, kind).Observe(time.Since(created.Time).Seconds())
+}
+
+// ObserveNodeUpgrade records the duration of a completed node upgrade of the CAPI cluster in the namespace.
+func ObserveNodeUpgrade(namespace, cluster, role string, created metav1.Time) {
+	nodeUpgradeDuration.WithLabelValues(namespace, cluster, role).Observe(time.Since(created.Time).Seconds())
+}
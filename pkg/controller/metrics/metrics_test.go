@@ -0,0 +1,172 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller/metrics"
+)
+
+func newCluster() *anywherev1.Cluster {
+	failure := anywherev1.EksaVersionInvalidReason
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Status: anywherev1.ClusterStatus{
+			FailureReason: &failure,
+			Conditions: []anywherev1.Condition{
+				{
+					Type:   anywherev1.ReadyCondition,
+					Status: "False",
+				},
+				{
+					Type:   anywherev1.ControlPlaneReadyCondition,
+					Status: "True",
+				},
+			},
+			ClusterCertificateInfo: []anywherev1.ClusterCertificateInfo{
+				{
+					Machine:       "my-cluster-cp-1",
+					ExpiresInDays: 200,
+				},
+			},
+		},
+	}
+}
+
+func TestRecordClusterStatus(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+	t.Cleanup(func() { metrics.DeleteCluster(cluster) })
+
+	metrics.RecordClusterStatus(cluster)
+
+	expected := `
+# HELP eksa_cluster_certificate_expiry_days Days until the certificates of a control plane or external etcd machine expire.
+# TYPE eksa_cluster_certificate_expiry_days gauge
+eksa_cluster_certificate_expiry_days{cluster="my-cluster",machine="my-cluster-cp-1",namespace="default"} 200
+# HELP eksa_cluster_condition Status of the Cluster conditions. For each condition, the series for the current status is 1 and the rest 0.
+# TYPE eksa_cluster_condition gauge
+eksa_cluster_condition{cluster="my-cluster",condition="ControlPlaneReady",namespace="default",status="False"} 0
+eksa_cluster_condition{cluster="my-cluster",condition="ControlPlaneReady",namespace="default",status="True"} 1
+eksa_cluster_condition{cluster="my-cluster",condition="ControlPlaneReady",namespace="default",status="Unknown"} 0
+eksa_cluster_condition{cluster="my-cluster",condition="Ready",namespace="default",status="False"} 1
+eksa_cluster_condition{cluster="my-cluster",condition="Ready",namespace="default",status="True"} 0
+eksa_cluster_condition{cluster="my-cluster",condition="Ready",namespace="default",status="Unknown"} 0
+eksa_cluster_condition{cluster="my-cluster",condition="WorkersReady",namespace="default",status="False"} 0
+eksa_cluster_condition{cluster="my-cluster",condition="WorkersReady",namespace="default",status="True"} 0
+eksa_cluster_condition{cluster="my-cluster",condition="WorkersReady",namespace="default",status="Unknown"} 1
+# HELP eksa_cluster_failure Set to 1 when the Cluster has a terminal failure, labeled with the failure reason.
+# TYPE eksa_cluster_failure gauge
+eksa_cluster_failure{cluster="my-cluster",namespace="default",reason="EksaVersionInvalid"} 1
+`
+	g.Expect(testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected),
+		"eksa_cluster_certificate_expiry_days", "eksa_cluster_condition", "eksa_cluster_failure",
+	)).To(Succeed())
+}
+
+func TestRecordClusterStatusRemovesStaleSeries(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+	t.Cleanup(func() { metrics.DeleteCluster(cluster) })
+
+	metrics.RecordClusterStatus(cluster)
+	cluster.Status.FailureReason = nil
+	cluster.Status.ClusterCertificateInfo = []anywherev1.ClusterCertificateInfo{
+		{
+			Machine:       "my-cluster-cp-2",
+			ExpiresInDays: 364,
+		},
+	}
+	metrics.RecordClusterStatus(cluster)
+
+	expected := `
+# HELP eksa_cluster_certificate_expiry_days Days until the certificates of a control plane or external etcd machine expire.
+# TYPE eksa_cluster_certificate_expiry_days gauge
+eksa_cluster_certificate_expiry_days{cluster="my-cluster",machine="my-cluster-cp-2",namespace="default"} 364
+`
+	g.Expect(testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected),
+		"eksa_cluster_certificate_expiry_days", "eksa_cluster_failure",
+	)).To(Succeed())
+}
+
+func TestRecordClusterStatusUpdatesSeriesInPlace(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+	t.Cleanup(func() { metrics.DeleteCluster(cluster) })
+
+	metrics.RecordClusterStatus(cluster)
+	cluster.Status.ClusterCertificateInfo[0].ExpiresInDays = 199
+	cluster.Status.Conditions[0].Status = "True"
+
+	metrics.RecordClusterStatus(cluster)
+
+	expected := `
+# HELP eksa_cluster_certificate_expiry_days Days until the certificates of a control plane or external etcd machine expire.
+# TYPE eksa_cluster_certificate_expiry_days gauge
+eksa_cluster_certificate_expiry_days{cluster="my-cluster",machine="my-cluster-cp-1",namespace="default"} 199
+# HELP eksa_cluster_failure Set to 1 when the Cluster has a terminal failure, labeled with the failure reason.
+# TYPE eksa_cluster_failure gauge
+eksa_cluster_failure{cluster="my-cluster",namespace="default",reason="EksaVersionInvalid"} 1
+`
+	g.Expect(testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected),
+		"eksa_cluster_certificate_expiry_days", "eksa_cluster_failure",
+	)).To(Succeed())
+	g.Expect(testutil.GatherAndCount(ctrlmetrics.Registry, "eksa_cluster_condition")).To(Equal(9))
+}
+
+func TestRecordClusterStatusReplacesFailureReason(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+	t.Cleanup(func() { metrics.DeleteCluster(cluster) })
+
+	metrics.RecordClusterStatus(cluster)
+	reason := anywherev1.MissingDependentObjectsReason
+	cluster.Status.FailureReason = &reason
+	metrics.RecordClusterStatus(cluster)
+
+	expected := `
+# HELP eksa_cluster_failure Set to 1 when the Cluster has a terminal failure, labeled with the failure reason.
+# TYPE eksa_cluster_failure gauge
+eksa_cluster_failure{cluster="my-cluster",namespace="default",reason="MissingDependentObjects"} 1
+`
+	g.Expect(testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected),
+		"eksa_cluster_failure",
+	)).To(Succeed())
+}
+
+func TestDeleteCluster(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+
+	metrics.RecordClusterStatus(cluster)
+	metrics.DeleteCluster(cluster)
+
+	g.Expect(testutil.GatherAndCount(ctrlmetrics.Registry,
+		"eksa_cluster_certificate_expiry_days", "eksa_cluster_condition", "eksa_cluster_failure",
+	)).To(BeZero())
+}
+
+func TestObserveDurations(t *testing.T) {
+	g := NewWithT(t)
+	created := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+
+	metrics.ObserveReconcilePhase(metrics.PhaseProvider, time.Now())
+	metrics.ObserveUpgrade("eksa-system", "my-cluster", metrics.UpgradeKindControlPlane, created)
+	metrics.ObserveNodeUpgrade("eksa-system", "my-cluster", metrics.NodeRoleWorker, created)
+
+	g.Expect(testutil.GatherAndCount(ctrlmetrics.Registry,
+		"eksa_cluster_reconcile_phase_duration_seconds",
+		"eksa_upgrade_duration_seconds",
+		"eksa_node_upgrade_duration_seconds",
+	)).To(Equal(3))
+}
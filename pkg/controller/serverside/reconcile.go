@@ -10,7 +10,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 )
 
-const fieldManager = "eks-a-controller"
+// FieldManager is the field manager the eks-a controller applies and updates objects with.
+const FieldManager = "eks-a-controller"
 
 func ReconcileYaml(ctx context.Context, c client.Client, yaml []byte) error {
 	objs, err := clientutil.YamlToClientObjects(yaml)
@@ -33,7 +34,7 @@ func ReconcileObjects(ctx context.Context, c client.Client, objs []client.Object
 
 func ReconcileObject(ctx context.Context, c client.Client, obj client.Object) error {
 	// Server side apply
-	err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldManager), client.ForceOwnership)
+	err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
 	if err != nil {
 		return errors.Wrapf(err, "failed to reconcile object %s, %s/%s", obj.GetObjectKind().GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
@@ -44,7 +45,7 @@ func ReconcileObject(ctx context.Context, c client.Client, obj client.Object) er
 // UpdateObject updates the existing object during reconciliation.
 // This is intended for special use cases only as the preferred method to reconcile objects is server-side apply.
 func UpdateObject(ctx context.Context, c client.Client, obj client.Object) error {
-	if err := c.Update(ctx, obj, client.FieldOwner(fieldManager)); err != nil {
+	if err := c.Update(ctx, obj, client.FieldOwner(FieldManager)); err != nil {
 		return errors.Wrapf(err, "failed to reconcile object %s, %s/%s", obj.GetObjectKind().GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
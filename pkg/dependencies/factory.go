@@ -420,6 +420,8 @@ type ProviderOptions struct {
 type TinkerbellOptions struct {
 	// BMCOptions contains options for configuring BMC interactions.
 	BMCOptions *hardware.BMCOptions
+	// ValidateBMCConnectivity enables the validation of the BMC credentials of the hardware before creating a cluster.
+	ValidateBMCConnectivity bool
 }
 
 // WithProvider initializes the provider dependency and adds to the build steps.
@@ -540,6 +542,9 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 			if opts != nil && opts.Tinkerbell != nil && opts.Tinkerbell.BMCOptions != nil {
 				provider.BMCOptions = opts.Tinkerbell.BMCOptions
 			}
+			if opts != nil && opts.Tinkerbell != nil && opts.Tinkerbell.ValidateBMCConnectivity {
+				provider.BMCPowerStateReader = hardware.NewRedfishClient(30 * time.Second)
+			}
 
 			f.dependencies.Provider = provider
 
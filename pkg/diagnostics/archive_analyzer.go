@@ -0,0 +1,167 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/tar"
+)
+
+const (
+	logsDir                  = "logs"
+	generatedArchiveAnalyzer = "%s-analyzers.yaml"
+)
+
+// providerNamespaces maps the namespace of each CAPI provider to its datacenter kind, so the provider
+// analyzers can be selected from the logs in a bundle.
+var providerNamespaces = map[string]string{
+	constants.CapvSystemNamespace:  v1alpha1.VSphereDatacenterKind,
+	constants.CapcSystemNamespace:  v1alpha1.CloudStackDatacenterKind,
+	constants.CaptSystemNamespace:  v1alpha1.TinkerbellDatacenterKind,
+	constants.CapasSystemNamespace: v1alpha1.SnowDatacenterKind,
+	constants.CapxSystemNamespace:  v1alpha1.NutanixDatacenterKind,
+	constants.CapdSystemNamespace:  v1alpha1.DockerDatacenterKind,
+}
+
+// ArchiveAnalyzer runs the EKS Anywhere analyzers against a support bundle archive, without access
+// to the cluster it was collected from.
+type ArchiveAnalyzer struct {
+	analyzerFactory AnalyzerFactory
+	client          BundleClient
+	writer          filewriter.FileWriter
+}
+
+// NewArchiveAnalyzer returns a new ArchiveAnalyzer.
+func NewArchiveAnalyzer(af AnalyzerFactory, client BundleClient, writer filewriter.FileWriter) *ArchiveAnalyzer {
+	return &ArchiveAnalyzer{
+		analyzerFactory: af,
+		client:          client,
+		writer:          writer,
+	}
+}
+
+// Analyze builds the analyzers for the contents of the support bundle archive and runs them with
+// support-bundle analyze, the same way the analysis of a live collection is run.
+func (a *ArchiveAnalyzer) Analyze(ctx context.Context, archivePath string) ([]*executables.SupportBundleAnalysis, error) {
+	namespaces, err := archiveLoggedNamespaces(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleYaml, err := yaml.Marshal(a.bundle(namespaces))
+	if err != nil {
+		return nil, fmt.Errorf("outputting yaml: %v", err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(archivePath), ".tar.gz")
+	bundlePath, err := a.writer.Write(fmt.Sprintf(generatedArchiveAnalyzer, name), bundleYaml)
+	if err != nil {
+		return nil, err
+	}
+	logger.V(3).Info("Analyzers config written", "path", bundlePath)
+
+	analysis, err := a.client.Analyze(ctx, bundlePath, archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("analyzing bundle: %v", err)
+	}
+
+	return analysis, nil
+}
+
+// bundle returns a support bundle spec with the analyzers for a bundle with logs collected from namespaces.
+// Log analyzers run for every namespace with collected logs and provider analyzers run for the providers
+// with collected logs.
+func (a *ArchiveAnalyzer) bundle(namespaces []string) *supportBundle {
+	var analyzers []*Analyze
+	analyzers = append(analyzers, a.analyzerFactory.DefaultAnalyzers()...)
+	analyzers = append(analyzers, a.analyzerFactory.ManagementClusterAnalyzers()...)
+	analyzers = append(analyzers, a.analyzerFactory.PackageAnalyzers()...)
+	analyzers = append(analyzers, a.analyzerFactory.EksaExternalEtcdAnalyzers()...)
+
+	var logCollectors []*Collect
+	for _, namespace := range namespaces {
+		logCollectors = append(logCollectors, &Collect{Logs: &logs{Namespace: namespace, Name: logpath(namespace)}})
+		if kind, ok := providerNamespaces[namespace]; ok {
+			analyzers = append(analyzers, a.analyzerFactory.DataCenterConfigAnalyzers(v1alpha1.Ref{Kind: kind})...)
+		}
+	}
+	analyzers = append(analyzers, a.analyzerFactory.EksaLogTextAnalyzers(logCollectors)...)
+
+	return &supportBundle{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "SupportBundle",
+			APIVersion: troubleshootApiVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "archive",
+		},
+		Spec: supportBundleSpec{
+			Analyzers: analyzers,
+		},
+	}
+}
+
+// archiveLoggedNamespaces returns the namespaces with logs in a support bundle archive.
+func archiveLoggedNamespaces(archivePath string) ([]string, error) {
+	dir, err := os.MkdirTemp("", "eksa-support-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("creating folder to extract support bundle: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := tar.UnGzipTarFile(archivePath, dir); err != nil {
+		return nil, fmt.Errorf("extracting support bundle %s: %v", archivePath, err)
+	}
+
+	root, err := bundleRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return loggedNamespaces(root)
+}
+
+// bundleRoot returns the folder with the contents of the support bundle. Archives generated by
+// troubleshoot.sh contain a single top level folder named after the bundle.
+func bundleRoot(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("reading extracted support bundle: %v", err)
+	}
+
+	if len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(dir, entries[0].Name()), nil
+	}
+
+	return dir, nil
+}
+
+func loggedNamespaces(root string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, logsDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading support bundle logs: %v", err)
+	}
+
+	var namespaces []string
+	for _, e := range entries {
+		if e.IsDir() {
+			namespaces = append(namespaces, e.Name())
+		}
+	}
+
+	return namespaces, nil
+}
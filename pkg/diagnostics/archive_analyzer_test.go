@@ -0,0 +1,100 @@
+package diagnostics_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/tar"
+)
+
+func writeBundleFile(t *testing.T, root, name string, content []byte) {
+	t.Helper()
+	path := filepath.Join(root, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newSupportBundleArchive builds a support bundle archive with the same layout as the ones generated by
+// troubleshoot.sh: a single top level folder with the cluster resources and the pod logs.
+func newSupportBundleArchive(t *testing.T) string {
+	t.Helper()
+	src := t.TempDir()
+	root := filepath.Join(src, "support-bundle-2024-01-01T00_00_00")
+
+	writeBundleFile(t, root, "cluster-resources/deployments/kube-system.json", []byte("{}"))
+	writeBundleFile(t, root, "logs/kube-system/kube-apiserver-cp-1.log", []byte("apiserver"))
+	writeBundleFile(t, root, "logs/capv-system/capv-controller-manager-abc.log", []byte("capv"))
+
+	archive := filepath.Join(t.TempDir(), "support-bundle-2024-01-01T00_00_00.tar.gz")
+	if err := tar.GzipTarFolder(src, archive); err != nil {
+		t.Fatal(err)
+	}
+	return archive
+}
+
+func newArchiveAnalyzer(t *testing.T) (*diagnostics.ArchiveAnalyzer, *gomock.Call, string) {
+	t.Helper()
+	dir := t.TempDir()
+	writer, err := filewriter.NewWriter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := givenTroubleshootClient(t)
+	archive := newSupportBundleArchive(t)
+	call := client.EXPECT().Analyze(gomock.Any(), filepath.Join(dir, filewriter.DefaultTmpFolder, "support-bundle-2024-01-01T00_00_00-analyzers.yaml"), archive)
+
+	return diagnostics.NewArchiveAnalyzer(diagnostics.NewAnalyzerFactory(), client, writer), call, archive
+}
+
+func TestArchiveAnalyzerAnalyze(t *testing.T) {
+	g := NewWithT(t)
+	a, call, archive := newArchiveAnalyzer(t)
+	want := []*executables.SupportBundleAnalysis{{Title: "coredns Status", IsPass: true}}
+
+	var spec string
+	call.DoAndReturn(func(_ context.Context, bundlePath, _ string) ([]*executables.SupportBundleAnalysis, error) {
+		content, err := os.ReadFile(bundlePath)
+		g.Expect(err).ToNot(HaveOccurred())
+		spec = string(content)
+		return want, nil
+	})
+
+	g.Expect(a.Analyze(context.Background(), archive)).To(Equal(want))
+	g.Expect(spec).To(ContainSubstring("kind: SupportBundle"))
+	g.Expect(spec).To(ContainSubstring("logs/kube-system/kube-apiserver-*.log"))
+	g.Expect(spec).To(ContainSubstring("logs/capv-system/capv-controller-manager-*.log"))
+	// Analyzers for namespaces that were not collected are not included.
+	g.Expect(spec).ToNot(ContainSubstring("logs/capt-system"))
+}
+
+func TestArchiveAnalyzerAnalyzeError(t *testing.T) {
+	g := NewWithT(t)
+	a, call, archive := newArchiveAnalyzer(t)
+	call.Return(nil, errors.New("analyze failed"))
+
+	_, err := a.Analyze(context.Background(), archive)
+	g.Expect(err).To(MatchError(ContainSubstring("analyzing bundle: analyze failed")))
+}
+
+func TestArchiveAnalyzerAnalyzeMissingArchive(t *testing.T) {
+	g := NewWithT(t)
+	writer, err := filewriter.NewWriter(t.TempDir())
+	g.Expect(err).ToNot(HaveOccurred())
+	a := diagnostics.NewArchiveAnalyzer(diagnostics.NewAnalyzerFactory(), givenTroubleshootClient(t), writer)
+
+	_, err = a.Analyze(context.Background(), filepath.Join(t.TempDir(), "missing.tar.gz"))
+	g.Expect(err).To(MatchError(ContainSubstring("extracting support bundle")))
+}
@@ -230,11 +230,17 @@ func (e *EksaDiagnosticBundle) PrintAnalysis() error {
 	if e.analysis == nil {
 		return nil
 	}
-	analysis, err := yaml.Marshal(e.analysis)
+	return PrintAnalysis(e.analysis)
+}
+
+// PrintAnalysis prints a support bundle analysis followed by its probable causes of failure.
+func PrintAnalysis(analysis []*executables.SupportBundleAnalysis) error {
+	out, err := yaml.Marshal(analysis)
 	if err != nil {
 		return fmt.Errorf("outputing yaml: %v", err)
 	}
-	fmt.Println(string(analysis))
+	fmt.Println(string(out))
+	fmt.Print(FormatProbableCauses(ProbableCauses(analysis)))
 	return nil
 }
 
@@ -0,0 +1,222 @@
+package diagnostics
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+// failureSignature is a known failure that can be identified by a pattern in the logs of a support bundle.
+type failureSignature struct {
+	// Cause is a short description of the failure.
+	Cause string
+	// Remediation explains how to fix the failure.
+	Remediation string
+	// Rank orders the signatures by how likely they are to be the root cause of other failures,
+	// lower ranks first. For example, expired certificates cause the controllers to fail to reach the
+	// API server, so they rank before the failures those controllers report.
+	Rank int
+	// Logs are the pod log files the pattern is searched in, relative to the logs of their namespace.
+	Logs []namespacedLog
+	// RegexPattern identifies the failure in the logs.
+	RegexPattern string
+}
+
+type namespacedLog struct {
+	Namespace string
+	File      string
+}
+
+func (l namespacedLog) path() string {
+	return path.Join(logpath(l.Namespace), l.File)
+}
+
+// failureSignatures are the known EKS Anywhere failures with their remediation.
+var failureSignatures = []failureSignature{
+	{
+		Cause:       "Cluster certificates have expired",
+		Remediation: "Renew the certificates with `eksctl anywhere renew certificates`. To avoid this in the future, upgrade the cluster at least once a year or set `spec.certificateRenewal` in the Cluster.",
+		Rank:        1,
+		Logs: []namespacedLog{
+			{Namespace: constants.KubeSystemNamespace, File: "kube-apiserver-*.log"},
+			{Namespace: constants.CapiKubeadmControlPlaneSystemNamespace, File: "capi-kubeadm-control-plane-controller-manager-*.log"},
+			{Namespace: constants.EksaSystemNamespace, File: "eksa-controller-manager-*.log"},
+		},
+		RegexPattern: `x509: certificate has expired or is not yet valid`,
+	},
+	{
+		Cause:       "etcd has lost quorum",
+		Remediation: "Check that a majority of the etcd members are running and can reach each other on ports 2379 and 2380. Bring the failed members back or, if they can't be recovered, restore etcd from a backup.",
+		Rank:        2,
+		Logs: []namespacedLog{
+			{Namespace: constants.KubeSystemNamespace, File: "etcd-*.log"},
+			{Namespace: constants.KubeSystemNamespace, File: "kube-apiserver-*.log"},
+		},
+		RegexPattern: `etcdserver: no leader|raft.node: [0-9a-f]+ lost leader|lost leader [0-9a-f]+ at term`,
+	},
+	{
+		Cause:       "kube-vip keeps losing the control plane endpoint leader election",
+		Remediation: "Check that the control plane endpoint IP is not used by another host and is excluded from the DHCP range, that the control plane nodes can reach the API server and that their clocks are in sync.",
+		Rank:        3,
+		Logs: []namespacedLog{
+			{Namespace: constants.KubeSystemNamespace, File: "kube-vip-*.log"},
+		},
+		RegexPattern: `(?i)leaderelection.*failed to renew lease|lost leadership|error retrieving resource lock`,
+	},
+	{
+		Cause:       "Cilium is not ready on some nodes",
+		Remediation: "Pods on nodes without a running Cilium agent have no networking. Check the cilium pod logs in kube-system, that the nodes can reach the API server and that the pod and service CIDRs don't overlap with the node network.",
+		Rank:        4,
+		Logs: []namespacedLog{
+			{Namespace: constants.KubeSystemNamespace, File: "cilium-*.log"},
+		},
+		RegexPattern: `level=fatal|Unable to contact k8s api-server|Cilium API client timeout exceeded`,
+	},
+	{
+		Cause:       "vSphere VM not found",
+		Remediation: "The VM or template backing a machine doesn't exist in vCenter. Check that the template of each VSphereMachineConfig exists in the datacenter, that the vSphere user can read it and that no VMs were deleted outside of EKS Anywhere.",
+		Rank:        5,
+		Logs: []namespacedLog{
+			{Namespace: constants.CapvSystemNamespace, File: "capv-controller-manager-*.log"},
+		},
+		RegexPattern: `(?i)(vm|virtual machine|template).{0,80}not found`,
+	},
+	{
+		Cause:       "Tinkerbell workflow timed out",
+		Remediation: "A machine didn't finish provisioning in time. Check the machine console: it must PXE boot from the NIC in the hardware CSV, reach the Tinkerbell IP and download the OS image. Check the action logs of the tink-worker container on the machine.",
+		Rank:        5,
+		Logs: []namespacedLog{
+			{Namespace: constants.CaptSystemNamespace, File: "capt-controller-manager-*.log"},
+			{Namespace: constants.EksaSystemNamespace, File: "tink-controller-*.log"},
+		},
+		RegexPattern: `STATE_TIMEOUT|(?i)workflow.{0,80}timed out`,
+	},
+}
+
+func (s failureSignature) checkName(log namespacedLog) string {
+	return fmt.Sprintf("%s %s. Log: %s", logAnalysisAnalyzerPrefix, s.Cause, log.path())
+}
+
+func (s failureSignature) analyzer(log namespacedLog) *Analyze {
+	logPath := log.path()
+	return &Analyze{
+		TextAnalyze: &textAnalyze{
+			analyzeMeta: analyzeMeta{
+				CheckName: s.checkName(log),
+			},
+			FileName:     logPath,
+			RegexPattern: s.RegexPattern,
+			Outcomes: []*outcome{
+				{
+					Fail: &singleOutcome{
+						When:    "true",
+						Message: fmt.Sprintf("%s. See %s. Remediation: %s", s.Cause, logPath, s.Remediation),
+					},
+				},
+				{
+					Pass: &singleOutcome{
+						When:    "false",
+						Message: fmt.Sprintf("No evidence of: %s", s.Cause),
+					},
+				},
+			},
+		},
+	}
+}
+
+// failureSignatureAnalyzersByNamespace returns the failure signature analyzers for the pod logs of each namespace.
+func failureSignatureAnalyzersByNamespace() map[string][]*Analyze {
+	analyzers := map[string][]*Analyze{}
+	for _, s := range failureSignatures {
+		for _, log := range s.Logs {
+			analyzers[log.Namespace] = append(analyzers[log.Namespace], s.analyzer(log))
+		}
+	}
+	return analyzers
+}
+
+// ProbableCause is a failure identified in a support bundle analysis.
+type ProbableCause struct {
+	Cause       string   `json:"cause"`
+	Remediation string   `json:"remediation,omitempty"`
+	Evidence    []string `json:"evidence"`
+	rank        int
+}
+
+// unknownCauseRank ranks failed analyzers that don't match a known failure signature after all known failures.
+const unknownCauseRank = 100
+
+// ProbableCauses returns the failures in a support bundle analysis, ranked from the most to the least likely
+// root cause. Known failures come with a remediation. Failed analyzers that don't match a known failure
+// are ranked last.
+func ProbableCauses(analysis []*executables.SupportBundleAnalysis) []ProbableCause {
+	type match struct {
+		signature failureSignature
+		log       namespacedLog
+	}
+	matches := map[string]match{}
+	for _, s := range failureSignatures {
+		for _, log := range s.Logs {
+			matches[s.checkName(log)] = match{signature: s, log: log}
+		}
+	}
+
+	causes := map[string]*ProbableCause{}
+	var order []string
+	for _, a := range analysis {
+		if a == nil || !a.IsFail {
+			continue
+		}
+
+		cause := &ProbableCause{Cause: a.Title, rank: unknownCauseRank}
+		evidence := a.Message
+		if m, ok := matches[a.Title]; ok {
+			cause = &ProbableCause{Cause: m.signature.Cause, Remediation: m.signature.Remediation, rank: m.signature.Rank}
+			evidence = fmt.Sprintf("Found in %s", m.log.path())
+		}
+
+		if existing, ok := causes[cause.Cause]; ok {
+			cause = existing
+		} else {
+			causes[cause.Cause] = cause
+			order = append(order, cause.Cause)
+		}
+		cause.Evidence = append(cause.Evidence, evidence)
+	}
+
+	ranked := make([]ProbableCause, 0, len(order))
+	for _, c := range order {
+		ranked = append(ranked, *causes[c])
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].rank != ranked[j].rank {
+			return ranked[i].rank < ranked[j].rank
+		}
+		return len(ranked[i].Evidence) > len(ranked[j].Evidence)
+	})
+
+	return ranked
+}
+
+// FormatProbableCauses renders a ranked list of probable causes for the console.
+func FormatProbableCauses(causes []ProbableCause) string {
+	if len(causes) == 0 {
+		return "No probable causes of failure found in the support bundle analysis.\n"
+	}
+
+	out := "Probable causes, from most to least likely:\n"
+	for i, c := range causes {
+		out += fmt.Sprintf("%d. %s\n", i+1, c.Cause)
+		if c.Remediation != "" {
+			out += fmt.Sprintf("   Remediation: %s\n", c.Remediation)
+		}
+		for _, e := range c.Evidence {
+			out += fmt.Sprintf("   - %s\n", e)
+		}
+	}
+
+	return out
+}
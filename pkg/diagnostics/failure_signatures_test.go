@@ -0,0 +1,150 @@
+package diagnostics_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+func failureSignatureAnalyzers(t *testing.T) map[string]*regexp.Regexp {
+	t.Helper()
+	collectorFactory := diagnostics.NewDefaultCollectorFactory(test.NewFileReader())
+	collectors := collectorFactory.DefaultCollectors()
+	collectors = append(collectors, collectorFactory.ManagementClusterCollectors()...)
+	collectors = append(collectors, collectorFactory.DataCenterConfigCollectors(eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellDatacenterKind}, nil)...)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &eksav1alpha1.Endpoint{Host: "1.1.1.1"}
+	})
+	collectors = append(collectors, collectorFactory.DataCenterConfigCollectors(eksav1alpha1.Ref{Kind: eksav1alpha1.VSphereDatacenterKind}, spec)...)
+
+	patterns := map[string]*regexp.Regexp{}
+	for _, a := range diagnostics.NewAnalyzerFactory().EksaLogTextAnalyzers(collectors) {
+		patterns[a.TextAnalyze.CheckName] = regexp.MustCompile(a.TextAnalyze.RegexPattern)
+	}
+	return patterns
+}
+
+func TestFailureSignaturesMatchKnownLogs(t *testing.T) {
+	tests := []struct {
+		checkName string
+		log       string
+	}{
+		{
+			checkName: "log analysis: Cluster certificates have expired. Log: logs/kube-system/kube-apiserver-*.log",
+			log:       `E0101 00:00:00.000000 1 authentication.go:63] "Unable to authenticate the request" err="[x509: certificate has expired or is not yet valid: current time 2025-01-01T00:00:00Z is after 2024-12-31T00:00:00Z]"`,
+		},
+		{
+			checkName: "log analysis: etcd has lost quorum. Log: logs/kube-system/etcd-*.log",
+			log:       `{"level":"info","msg":"raft.node: 8e9e05c52164694d lost leader 91bc3c398fb3c146 at term 5"}`,
+		},
+		{
+			checkName: "log analysis: etcd has lost quorum. Log: logs/kube-system/kube-apiserver-*.log",
+			log:       `W0101 00:00:00.000000 1 watcher.go:229] watch chan error: etcdserver: no leader`,
+		},
+		{
+			checkName: "log analysis: kube-vip keeps losing the control plane endpoint leader election. Log: logs/kube-system/kube-vip-*.log",
+			log:       `E0101 00:00:00.000000 1 leaderelection.go:369] Failed to update lock: context deadline exceeded, failed to renew lease kube-system/plndr-cp-lock`,
+		},
+		{
+			checkName: "log analysis: Cilium is not ready on some nodes. Log: logs/kube-system/cilium-*.log",
+			log:       `level=fatal msg="Unable to contact k8s api-server" subsys=daemon`,
+		},
+		{
+			checkName: "log analysis: vSphere VM not found. Log: logs/capv-system/capv-controller-manager-*.log",
+			log:       `E0101 00:00:00.000000 1 controller.go:324] "Reconciler error" err="failed to reconcile VM: unable to find template by name \"ubuntu-2204-kube-v1.30\": template not found"`,
+		},
+		{
+			checkName: "log analysis: Tinkerbell workflow timed out. Log: logs/capt-system/capt-controller-manager-*.log",
+			log:       `"msg"="Workflow state" "state"="STATE_TIMEOUT" "workflow"="my-cluster-cp-abcde"`,
+		},
+	}
+
+	patterns := failureSignatureAnalyzers(t)
+	for _, tt := range tests {
+		t.Run(tt.checkName, func(t *testing.T) {
+			g := NewWithT(t)
+			pattern, ok := patterns[tt.checkName]
+			g.Expect(ok).To(BeTrue(), "analyzer %s not found", tt.checkName)
+			g.Expect(pattern.MatchString(tt.log)).To(BeTrue())
+		})
+	}
+}
+
+func TestFailureSignaturesDontMatchHealthyLogs(t *testing.T) {
+	g := NewWithT(t)
+	healthy := strings.Join([]string{
+		`I0101 00:00:00.000000 1 leaderelection.go:258] successfully acquired lease kube-system/plndr-cp-lock`,
+		`level=info msg="Cilium health daemon started" subsys=daemon`,
+		`{"level":"info","msg":"raft.node: 8e9e05c52164694d elected leader 8e9e05c52164694d at term 2"}`,
+		`"msg"="Workflow state" "state"="STATE_SUCCESS" "workflow"="my-cluster-cp-abcde"`,
+	}, "\n")
+
+	for name, pattern := range failureSignatureAnalyzers(t) {
+		if strings.HasPrefix(name, "log analysis: API server pod missing") {
+			continue
+		}
+		g.Expect(pattern.MatchString(healthy)).To(BeFalse(), name)
+	}
+}
+
+func TestProbableCauses(t *testing.T) {
+	g := NewWithT(t)
+	analysis := []*executables.SupportBundleAnalysis{
+		{
+			Title:   "coredns",
+			IsFail:  true,
+			Message: "coredns is not ready.",
+		},
+		{
+			Title:   "log analysis: Cilium is not ready on some nodes. Log: logs/kube-system/cilium-*.log",
+			IsFail:  true,
+			Message: "Cilium is not ready on some nodes.",
+		},
+		{
+			Title:   "log analysis: Cluster certificates have expired. Log: logs/kube-system/kube-apiserver-*.log",
+			IsFail:  true,
+			Message: "Cluster certificates have expired.",
+		},
+		{
+			Title:   "log analysis: Cluster certificates have expired. Log: logs/eksa-system/eksa-controller-manager-*.log",
+			IsFail:  true,
+			Message: "Cluster certificates have expired.",
+		},
+		{
+			Title:  "log analysis: etcd has lost quorum. Log: logs/kube-system/etcd-*.log",
+			IsPass: true,
+		},
+	}
+
+	causes := diagnostics.ProbableCauses(analysis)
+	g.Expect(causes).To(HaveLen(3))
+	g.Expect(causes[0].Cause).To(Equal("Cluster certificates have expired"))
+	g.Expect(causes[0].Remediation).To(ContainSubstring("eksctl anywhere renew certificates"))
+	g.Expect(causes[0].Evidence).To(ConsistOf(
+		"Found in logs/kube-system/kube-apiserver-*.log",
+		"Found in logs/eksa-system/eksa-controller-manager-*.log",
+	))
+	g.Expect(causes[1].Cause).To(Equal("Cilium is not ready on some nodes"))
+	g.Expect(causes[2].Cause).To(Equal("coredns"))
+	g.Expect(causes[2].Remediation).To(BeEmpty())
+	g.Expect(causes[2].Evidence).To(ConsistOf("coredns is not ready."))
+
+	out := diagnostics.FormatProbableCauses(causes)
+	g.Expect(out).To(HavePrefix("Probable causes, from most to least likely:\n1. Cluster certificates have expired\n"))
+	g.Expect(out).To(ContainSubstring("3. coredns\n   - coredns is not ready.\n"))
+}
+
+func TestProbableCausesNoFailures(t *testing.T) {
+	g := NewWithT(t)
+	causes := diagnostics.ProbableCauses([]*executables.SupportBundleAnalysis{{Title: "coredns", IsPass: true}})
+	g.Expect(causes).To(BeEmpty())
+	g.Expect(diagnostics.FormatProbableCauses(causes)).To(Equal("No probable causes of failure found in the support bundle analysis.\n"))
+}
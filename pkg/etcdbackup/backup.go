@@ -0,0 +1,98 @@
+package etcdbackup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const snapshotTimeFormat = "2006-01-02T15_04_05"
+
+// SnapshotName returns the name of a snapshot of a cluster taken at t.
+func SnapshotName(clusterName string, t time.Time) string {
+	return fmt.Sprintf("%s-etcd-snapshot-%s.db", clusterName, t.Format(snapshotTimeFormat))
+}
+
+// ClusterTopology returns the etcd topology of the cluster in the config.
+func ClusterTopology(cfg *certificates.RenewalConfig) Topology {
+	if len(cfg.Etcd.Nodes) > 0 {
+		return TopologyExternal
+	}
+	return TopologyStacked
+}
+
+// OSType returns the OS type used to pick the etcd and control plane operations for the nodes in the config.
+func OSType(cfg *certificates.RenewalConfig) certificates.OSType {
+	if cfg.OS == string(certificates.OSTypeBottlerocket) {
+		return certificates.OSTypeBottlerocket
+	}
+	return certificates.OSTypeLinux
+}
+
+// Backuper takes etcd snapshots.
+type Backuper struct {
+	SSH  SSHClient
+	Etcd EtcdNode
+	// Node is the node the snapshot is taken from: the first etcd node with external etcd,
+	// the first control plane node otherwise.
+	Node string
+}
+
+// NewBackuper returns a Backuper for the cluster nodes in the config.
+func NewBackuper(cfg *certificates.RenewalConfig) (*Backuper, error) {
+	topology := ClusterTopology(cfg)
+	etcd, err := NewEtcdNode(OSType(cfg), topology)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := cfg.ControlPlane
+	if topology == TopologyExternal {
+		nodes = cfg.Etcd
+	}
+	ssh, err := certificates.NewSSHRunner(nodes.SSH)
+	if err != nil {
+		return nil, fmt.Errorf("building ssh client: %v", err)
+	}
+
+	return &Backuper{SSH: ssh, Etcd: etcd, Node: nodes.Nodes[0]}, nil
+}
+
+// Backup takes a snapshot of etcd, checks it wasn't corrupted while being copied from the node
+// and saves it in the store with the name. It returns the location of the snapshot in the store.
+func (b *Backuper) Backup(ctx context.Context, store Store, name string) (string, error) {
+	f, err := os.CreateTemp("", "etcd-snapshot-*.db")
+	if err != nil {
+		return "", fmt.Errorf("creating snapshot file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	logger.Info("Taking etcd snapshot", "node", b.Node)
+	hash := sha256.New()
+	checksum, err := b.Etcd.SaveSnapshot(ctx, b.Node, b.SSH, io.MultiWriter(f, hash))
+	if err != nil {
+		return "", fmt.Errorf("taking etcd snapshot on node %s: %v", b.Node, err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("writing snapshot file: %v", err)
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != checksum {
+		return "", fmt.Errorf("snapshot checksum %s doesn't match the checksum %s computed on node %s", got, checksum, b.Node)
+	}
+
+	location, err := store.Save(ctx, f.Name(), name)
+	if err != nil {
+		return "", fmt.Errorf("saving etcd snapshot: %v", err)
+	}
+
+	logger.V(4).Info("Saved etcd snapshot", "location", location, "sha256", checksum)
+	return location, nil
+}
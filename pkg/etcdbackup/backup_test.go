@@ -0,0 +1,96 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/mocks"
+)
+
+func writeSnapshot(content string) func(context.Context, string, etcdbackup.SSHClient, io.Writer) (string, error) {
+	return func(_ context.Context, _ string, _ etcdbackup.SSHClient, w io.Writer) (string, error) {
+		if _, err := w.Write([]byte(content)); err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:]), nil
+	}
+}
+
+func TestSnapshotName(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(etcdbackup.SnapshotName("mgmt", time.Date(2024, 10, 17, 12, 30, 0, 0, time.UTC))).To(Equal("mgmt-etcd-snapshot-2024-10-17T12_30_00.db"))
+}
+
+func TestClusterTopology(t *testing.T) {
+	g := NewWithT(t)
+	cfg := &certificates.RenewalConfig{ControlPlane: certificates.NodeConfig{Nodes: []string{"cp-1"}}}
+	g.Expect(etcdbackup.ClusterTopology(cfg)).To(Equal(etcdbackup.TopologyStacked))
+
+	cfg.Etcd.Nodes = []string{"etcd-1"}
+	g.Expect(etcdbackup.ClusterTopology(cfg)).To(Equal(etcdbackup.TopologyExternal))
+}
+
+func TestBackuperBackup(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	ssh := mocks.NewMockSSHClient(ctrl)
+	etcd := mocks.NewMockEtcdNode(ctrl)
+	dir := t.TempDir()
+	b := &etcdbackup.Backuper{SSH: ssh, Etcd: etcd, Node: "etcd-1"}
+
+	etcd.EXPECT().SaveSnapshot(ctx, "etcd-1", ssh, gomock.Any()).DoAndReturn(writeSnapshot("snapshot"))
+
+	location, err := b.Backup(ctx, etcdbackup.NewLocalStore(dir), "snapshot.db")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(location).To(Equal(filepath.Join(dir, "snapshot.db")))
+	g.Expect(os.ReadFile(location)).To(BeEquivalentTo("snapshot"))
+}
+
+func TestBackuperBackupChecksumMismatch(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	ssh := mocks.NewMockSSHClient(ctrl)
+	etcd := mocks.NewMockEtcdNode(ctrl)
+	dir := t.TempDir()
+	b := &etcdbackup.Backuper{SSH: ssh, Etcd: etcd, Node: "etcd-1"}
+
+	etcd.EXPECT().SaveSnapshot(ctx, "etcd-1", ssh, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, _ etcdbackup.SSHClient, w io.Writer) (string, error) {
+			_, err := w.Write([]byte("truncated"))
+			return "abc123", err
+		},
+	)
+
+	_, err := b.Backup(ctx, etcdbackup.NewLocalStore(dir), "snapshot.db")
+	g.Expect(err).To(MatchError(ContainSubstring("doesn't match the checksum abc123 computed on node etcd-1")))
+	g.Expect(filepath.Join(dir, "snapshot.db")).NotTo(BeAnExistingFile())
+}
+
+func TestBackuperBackupSaveSnapshotError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	ssh := mocks.NewMockSSHClient(ctrl)
+	etcd := mocks.NewMockEtcdNode(ctrl)
+	b := &etcdbackup.Backuper{SSH: ssh, Etcd: etcd, Node: "cp-1"}
+
+	etcd.EXPECT().SaveSnapshot(ctx, "cp-1", ssh, gomock.Any()).Return("", errors.New("connection refused"))
+
+	_, err := b.Backup(ctx, etcdbackup.NewLocalStore(t.TempDir()), "snapshot.db")
+	g.Expect(err).To(MatchError("taking etcd snapshot on node cp-1: connection refused"))
+}
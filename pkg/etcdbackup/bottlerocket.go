@@ -0,0 +1,190 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	brEtcdDataDir    = "/var/lib/etcd/data"
+	brEtcdRestoreDir = brEtcdDataDir + "/etcd-restore"
+	brEtcdPKIDir     = "/var/lib/etcd/pki"
+	brEtcdSnapshot   = brEtcdDataDir + "/etcd-snapshot.db"
+	brEtcdManifest   = "/etc/kubernetes/manifests/etcd"
+	brManifestsDir   = "/etc/kubernetes/manifests"
+	brStoppedDir     = "/tmp/etcd-restore-manifests"
+	// brAdminSnapshot is the snapshot in the admin container, where the SSH commands run.
+	brAdminSnapshot = "/tmp/etcd-snapshot.db"
+	// brHostAdminSnapshot is brAdminSnapshot seen from the host.
+	brHostAdminSnapshot = "/run/host-containerd/io.containerd.runtime.v2.task/default/admin/rootfs/tmp/etcd-snapshot.db"
+	brEtcdContainerID   = `ETCD_CONTAINER_ID=$(ctr -n k8s.io c ls | grep -w "etcd-io" | cut -d " " -f1 | tail -1)`
+
+	// Stacked etcd is a kubeadm static pod, with its certificates in the kubeadm folder and the default data directory.
+	brStackedEtcdDataDir    = "/var/lib/etcd"
+	brStackedEtcdRestoreDir = brStackedEtcdDataDir + "/etcd-restore"
+	brStackedEtcdPKIDir     = "/var/lib/kubeadm/pki/etcd"
+	brStackedEtcdSnapshot   = brStackedEtcdDataDir + "/etcd-snapshot.db"
+)
+
+func sheltie(commands ...string) string {
+	return fmt.Sprintf("sudo sheltie << 'EOF'\nset -euo pipefail\n%s\nEOF", strings.Join(commands, "\n"))
+}
+
+func brEtcdExec(execID, cmd string) string {
+	return fmt.Sprintf("ctr -n k8s.io t exec --exec-id %s ${ETCD_CONTAINER_ID} %s", execID, cmd)
+}
+
+// bottlerocketExternalEtcd runs the etcd operations on Bottlerocket etcd nodes, where etcd is a static pod
+// created by etcdadm. The commands run in the host with sheltie, and etcdctl and etcdutl in the etcd container.
+type bottlerocketExternalEtcd struct{}
+
+func (b *bottlerocketExternalEtcd) SaveSnapshot(ctx context.Context, node string, ssh SSHClient, w io.Writer) (string, error) {
+	cmd := sheltie(
+		brEtcdContainerID,
+		fmt.Sprintf("ETCD_ENDPOINT=$(grep -wA1 ETCD_ADVERTISE_CLIENT_URLS %s | tail -1 | grep -oE '[^ ]+$')", brEtcdManifest),
+		brEtcdExec("etcd-snapshot-save", fmt.Sprintf(
+			"etcdctl --endpoints=${ETCD_ENDPOINT} --cacert=%[1]s/ca.crt --cert=%[1]s/server.crt --key=%[1]s/server.key snapshot save %[2]s",
+			brEtcdPKIDir, brEtcdSnapshot)),
+		fmt.Sprintf("mv %s %s", brEtcdSnapshot, brHostAdminSnapshot),
+	)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return "", fmt.Errorf("saving etcd snapshot: %v", err)
+	}
+
+	return fetchSnapshot(ctx, node, ssh, brAdminSnapshot, w)
+}
+
+func (b *bottlerocketExternalEtcd) Member(ctx context.Context, node string, ssh SSHClient) (Member, error) {
+	content, err := ssh.RunCommand(ctx, node, sheltie(fmt.Sprintf("cat %s", brEtcdManifest)))
+	if err != nil {
+		return Member{}, fmt.Errorf("reading etcd manifest: %v", err)
+	}
+	return parseStaticPod(content)
+}
+
+func (b *bottlerocketExternalEtcd) RestoreSnapshot(ctx context.Context, node string, ssh SSHClient, snapshot io.Reader, config RestoreConfig) error {
+	if err := ssh.CopyToNode(ctx, node, snapshot, brAdminSnapshot); err != nil {
+		return fmt.Errorf("copying snapshot to node %s: %v", node, err)
+	}
+
+	// The etcd data directory is mounted in the etcd container, so the restored data is written in the node.
+	cmd := sheltie(
+		fmt.Sprintf("mv %s %s", brHostAdminSnapshot, brEtcdSnapshot),
+		fmt.Sprintf("rm -rf %s", brEtcdRestoreDir),
+		brEtcdContainerID,
+		brEtcdExec("etcd-snapshot-restore", fmt.Sprintf("etcdutl snapshot restore %s %s", brEtcdSnapshot, restoreArgs(config, brEtcdRestoreDir))),
+		fmt.Sprintf("rm -f %s", brEtcdSnapshot),
+	)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("restoring etcd snapshot: %v", err)
+	}
+
+	return nil
+}
+
+func (b *bottlerocketExternalEtcd) ReplaceDataDir(ctx context.Context, node string, ssh SSHClient) error {
+	// The etcd static pod is stopped while the data directory is replaced, giving the kubelet time to stop the container.
+	cmd := sheltie(
+		fmt.Sprintf("mkdir -p %s", brStoppedDir),
+		fmt.Sprintf("mv %s %s/", brEtcdManifest, brStoppedDir),
+		"sleep 20",
+		fmt.Sprintf("rm -rf %[1]s/member.bak && mv %[1]s/member %[1]s/member.bak && mv %[2]s/member %[1]s/member && rm -rf %[2]s",
+			brEtcdDataDir, brEtcdRestoreDir),
+		fmt.Sprintf("mv %s/etcd %s/", brStoppedDir, brManifestsDir),
+		fmt.Sprintf("rmdir %s", brStoppedDir),
+	)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("replacing etcd data directory: %v", err)
+	}
+	return nil
+}
+
+// bottlerocketStackedEtcd runs the etcd operations on Bottlerocket control plane nodes, where etcd is a static pod
+// created by kubeadm. The commands run in the host with sheltie, and etcdctl and etcdutl in the etcd container.
+type bottlerocketStackedEtcd struct{}
+
+func (b *bottlerocketStackedEtcd) SaveSnapshot(ctx context.Context, node string, ssh SSHClient, w io.Writer) (string, error) {
+	cmd := sheltie(
+		brEtcdContainerID,
+		brEtcdExec("etcd-snapshot-save", fmt.Sprintf(
+			"etcdctl --endpoints=https://127.0.0.1:2379 --cacert=%[1]s/ca.crt --cert=%[1]s/server.crt --key=%[1]s/server.key snapshot save %[2]s",
+			brStackedEtcdPKIDir, brStackedEtcdSnapshot)),
+		fmt.Sprintf("mv %s %s", brStackedEtcdSnapshot, brHostAdminSnapshot),
+	)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return "", fmt.Errorf("saving etcd snapshot: %v", err)
+	}
+
+	return fetchSnapshot(ctx, node, ssh, brAdminSnapshot, w)
+}
+
+func (b *bottlerocketStackedEtcd) Member(ctx context.Context, node string, ssh SSHClient) (Member, error) {
+	content, err := ssh.RunCommand(ctx, node, sheltie(fmt.Sprintf("cat %s", brEtcdManifest)))
+	if err != nil {
+		return Member{}, fmt.Errorf("reading etcd manifest: %v", err)
+	}
+	return parseStaticPod(content)
+}
+
+func (b *bottlerocketStackedEtcd) RestoreSnapshot(ctx context.Context, node string, ssh SSHClient, snapshot io.Reader, config RestoreConfig) error {
+	if err := ssh.CopyToNode(ctx, node, snapshot, brAdminSnapshot); err != nil {
+		return fmt.Errorf("copying snapshot to node %s: %v", node, err)
+	}
+
+	// The etcd data directory is mounted in the etcd container, so the restored data is written in the node.
+	cmd := sheltie(
+		fmt.Sprintf("mv %s %s", brHostAdminSnapshot, brStackedEtcdSnapshot),
+		fmt.Sprintf("rm -rf %s", brStackedEtcdRestoreDir),
+		brEtcdContainerID,
+		brEtcdExec("etcd-snapshot-restore", fmt.Sprintf("etcdutl snapshot restore %s %s", brStackedEtcdSnapshot, restoreArgs(config, brStackedEtcdRestoreDir))),
+		fmt.Sprintf("rm -f %s", brStackedEtcdSnapshot),
+	)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("restoring etcd snapshot: %v", err)
+	}
+
+	return nil
+}
+
+// ReplaceDataDir expects the control plane static pods, including etcd, to be stopped. It waits for the kubelet
+// to stop the etcd container before replacing the data directory, and etcd starts with the control plane.
+func (b *bottlerocketStackedEtcd) ReplaceDataDir(ctx context.Context, node string, ssh SSHClient) error {
+	// The kubelet may have already removed the etcd container, so not finding it isn't an error.
+	cmd := sheltie(
+		brEtcdContainerID+" || true",
+		`while [ -n "${ETCD_CONTAINER_ID}" ] && ctr -n k8s.io t ls | grep -w "${ETCD_CONTAINER_ID}" | grep -qw RUNNING; do sleep 2; done`,
+		fmt.Sprintf("rm -rf %[1]s/member.bak && mv %[1]s/member %[1]s/member.bak && mv %[2]s/member %[1]s/member && rm -rf %[2]s",
+			brStackedEtcdDataDir, brStackedEtcdRestoreDir),
+	)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("replacing etcd data directory: %v", err)
+	}
+	return nil
+}
+
+// bottlerocketControlPlane stops and starts the static pods of Bottlerocket control plane nodes.
+type bottlerocketControlPlane struct{}
+
+func (b *bottlerocketControlPlane) StopControlPlane(ctx context.Context, node string, ssh SSHClient) error {
+	cmd := sheltie(
+		fmt.Sprintf("mkdir -p %s", brStoppedDir),
+		fmt.Sprintf("mv %s/* %s/", brManifestsDir, brStoppedDir),
+	)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("stopping control plane components: %v", err)
+	}
+	return nil
+}
+
+func (b *bottlerocketControlPlane) StartControlPlane(ctx context.Context, node string, ssh SSHClient) error {
+	cmd := sheltie(
+		fmt.Sprintf("mv %s/* %s/", brStoppedDir, brManifestsDir),
+		fmt.Sprintf("rmdir %s", brStoppedDir),
+	)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("starting control plane components: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Package etcdbackup takes snapshots of the etcd cluster of an EKS Anywhere cluster and restores them, over SSH.
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+// Topology is the etcd topology of a cluster.
+type Topology string
+
+const (
+	// TopologyStacked is etcd running as a static pod in the control plane nodes.
+	TopologyStacked Topology = "stacked"
+	// TopologyExternal is etcd running in dedicated nodes managed by etcdadm.
+	TopologyExternal Topology = "external"
+)
+
+// Member is an etcd member.
+type Member struct {
+	Name    string
+	PeerURL string
+}
+
+// RestoreConfig configures the restore of a snapshot on an etcd member.
+type RestoreConfig struct {
+	Member Member
+	// InitialCluster is the comma-separated list of name=peerURL of all the members of the restored cluster.
+	InitialCluster string
+	// Token is the initial cluster token of the restored cluster. It must be the same for all members.
+	Token string
+}
+
+// EtcdNode runs the etcd operations on a node running an etcd member.
+type EtcdNode interface {
+	// SaveSnapshot takes a snapshot of etcd on the node, writes it to w and returns its sha256 checksum computed on the node.
+	SaveSnapshot(ctx context.Context, node string, ssh SSHClient, w io.Writer) (string, error)
+	// Member returns the etcd member running on the node.
+	Member(ctx context.Context, node string, ssh SSHClient) (Member, error)
+	// RestoreSnapshot copies the snapshot to the node and restores it in a new data directory.
+	RestoreSnapshot(ctx context.Context, node string, ssh SSHClient, snapshot io.Reader, config RestoreConfig) error
+	// ReplaceDataDir replaces the etcd data directory with the one created by RestoreSnapshot and starts etcd with it.
+	// The previous data directory is kept as member.bak.
+	ReplaceDataDir(ctx context.Context, node string, ssh SSHClient) error
+}
+
+// ControlPlaneNode stops and starts the control plane components of a control plane node.
+type ControlPlaneNode interface {
+	StopControlPlane(ctx context.Context, node string, ssh SSHClient) error
+	StartControlPlane(ctx context.Context, node string, ssh SSHClient) error
+}
+
+// NewEtcdNode returns the EtcdNode for an OS and etcd topology.
+func NewEtcdNode(osType certificates.OSType, topology Topology) (EtcdNode, error) {
+	switch {
+	case osType == certificates.OSTypeLinux && topology == TopologyExternal:
+		return &linuxExternalEtcd{}, nil
+	case osType == certificates.OSTypeLinux && topology == TopologyStacked:
+		return &linuxStackedEtcd{}, nil
+	case osType == certificates.OSTypeBottlerocket && topology == TopologyExternal:
+		return &bottlerocketExternalEtcd{}, nil
+	case osType == certificates.OSTypeBottlerocket && topology == TopologyStacked:
+		return &bottlerocketStackedEtcd{}, nil
+	default:
+		return nil, fmt.Errorf("etcd backup and restore is not supported for %s etcd on %s", topology, osType)
+	}
+}
+
+// NewControlPlaneNode returns the ControlPlaneNode for an OS.
+func NewControlPlaneNode(osType certificates.OSType) (ControlPlaneNode, error) {
+	switch osType {
+	case certificates.OSTypeLinux:
+		return &linuxControlPlane{}, nil
+	case certificates.OSTypeBottlerocket:
+		return &bottlerocketControlPlane{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported os %q", osType)
+	}
+}
+
+// fetchSnapshot copies a snapshot from the node to w, removes it from the node and returns its checksum.
+func fetchSnapshot(ctx context.Context, node string, ssh SSHClient, path string, w io.Writer) (string, error) {
+	out, err := ssh.RunCommand(ctx, node, fmt.Sprintf("sudo sha256sum %s", path))
+	if err != nil {
+		return "", fmt.Errorf("computing snapshot checksum: %v", err)
+	}
+	checksum := strings.Fields(out)
+	if len(checksum) == 0 {
+		return "", fmt.Errorf("computing snapshot checksum: empty output")
+	}
+
+	if err := ssh.CopyFromNode(ctx, node, path, w); err != nil {
+		return "", fmt.Errorf("copying snapshot from node %s: %v", node, err)
+	}
+
+	if _, err := ssh.RunCommand(ctx, node, fmt.Sprintf("sudo rm -f %s", path)); err != nil {
+		return "", fmt.Errorf("removing snapshot from node %s: %v", node, err)
+	}
+
+	return checksum[0], nil
+}
+
+func restoreArgs(config RestoreConfig, dataDir string) string {
+	return fmt.Sprintf("--name=%s --initial-cluster=%s --initial-cluster-token=%s --initial-advertise-peer-urls=%s --data-dir=%s",
+		config.Member.Name, config.InitialCluster, config.Token, config.Member.PeerURL, dataDir)
+}
+
+// parseEnvFile reads the etcd member from an etcd env file like /etc/etcd/etcd.env.
+func parseEnvFile(content string) (Member, error) {
+	m := Member{}
+	for _, line := range strings.Split(content, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch strings.TrimPrefix(key, "export ") {
+		case "ETCD_NAME":
+			m.Name = value
+		case "ETCD_INITIAL_ADVERTISE_PEER_URLS":
+			m.PeerURL = value
+		}
+	}
+
+	return m, validateMember(m)
+}
+
+// parseStaticPod reads the etcd member from the etcd static pod manifest, either from the command flags (kubeadm)
+// or from the environment (etcdadm with the kubelet init system).
+func parseStaticPod(content string) (Member, error) {
+	pod := &corev1.Pod{}
+	if err := yaml.Unmarshal([]byte(content), pod); err != nil {
+		return Member{}, fmt.Errorf("parsing etcd manifest: %v", err)
+	}
+
+	m := Member{}
+	for _, c := range pod.Spec.Containers {
+		if c.Name != "etcd" {
+			continue
+		}
+		for _, arg := range append(c.Command, c.Args...) {
+			key, value, _ := strings.Cut(arg, "=")
+			switch key {
+			case "--name":
+				m.Name = value
+			case "--initial-advertise-peer-urls":
+				m.PeerURL = value
+			}
+		}
+		for _, env := range c.Env {
+			switch env.Name {
+			case "ETCD_NAME":
+				m.Name = env.Value
+			case "ETCD_INITIAL_ADVERTISE_PEER_URLS":
+				m.PeerURL = env.Value
+			}
+		}
+	}
+
+	return m, validateMember(m)
+}
+
+func validateMember(m Member) error {
+	if m.Name == "" || m.PeerURL == "" {
+		return fmt.Errorf("etcd member name and initial advertise peer urls not found")
+	}
+	return nil
+}
+
+// initialCluster returns the --initial-cluster value for the members.
+func initialCluster(members []Member) string {
+	peers := make([]string, 0, len(members))
+	for _, m := range members {
+		peers = append(peers, fmt.Sprintf("%s=%s", m.Name, m.PeerURL))
+	}
+	return strings.Join(peers, ",")
+}
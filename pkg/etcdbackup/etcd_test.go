@@ -0,0 +1,415 @@
+package etcdbackup_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/mocks"
+)
+
+const stackedEtcdManifest = `apiVersion: v1
+kind: Pod
+metadata:
+  name: etcd
+  namespace: kube-system
+spec:
+  containers:
+  - name: etcd
+    command:
+    - etcd
+    - --data-dir=/var/lib/etcd
+    - --initial-advertise-peer-urls=https://10.0.0.1:2380
+    - --initial-cluster=cp-1=https://10.0.0.1:2380
+    - --name=cp-1
+`
+
+const bottlerocketEtcdManifest = `apiVersion: v1
+kind: Pod
+metadata:
+  name: etcd
+spec:
+  containers:
+  - name: etcd
+    env:
+    - name: ETCD_NAME
+      value: etcd-1
+    - name: ETCD_INITIAL_ADVERTISE_PEER_URLS
+      value: https://10.0.0.5:2380
+`
+
+func newEtcdNode(t *testing.T, osType certificates.OSType, topology etcdbackup.Topology) etcdbackup.EtcdNode {
+	t.Helper()
+	n, err := etcdbackup.NewEtcdNode(osType, topology)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestNewEtcdNodeUnsupported(t *testing.T) {
+	g := NewWithT(t)
+	_, err := etcdbackup.NewEtcdNode(certificates.OSTypeBottlerocket, etcdbackup.Topology("unknown"))
+	g.Expect(err).To(MatchError("etcd backup and restore is not supported for unknown etcd on bottlerocket"))
+}
+
+func TestEtcdNodeSaveSnapshot(t *testing.T) {
+	tests := []struct {
+		name     string
+		osType   certificates.OSType
+		topology etcdbackup.Topology
+		saveCmd  string
+		path     string
+	}{
+		{
+			name:     "linux external",
+			osType:   certificates.OSTypeLinux,
+			topology: etcdbackup.TopologyExternal,
+			saveCmd:  "sudo sh -c 'set -a && . /etc/etcd/etcdctl.env && set +a && export PATH=$PATH:/opt/bin:/usr/local/bin && etcdctl snapshot save /tmp/etcd-snapshot.db'",
+			path:     "/tmp/etcd-snapshot.db",
+		},
+		{
+			name:     "linux stacked",
+			osType:   certificates.OSTypeLinux,
+			topology: etcdbackup.TopologyStacked,
+			saveCmd:  "sudo sh -c 'crictl exec $(crictl ps -q --name etcd --state running | head -n 1) etcdctl --endpoints=https://127.0.0.1:2379 --cacert=/etc/kubernetes/pki/etcd/ca.crt --cert=/etc/kubernetes/pki/etcd/server.crt --key=/etc/kubernetes/pki/etcd/server.key snapshot save /var/lib/etcd/etcd-snapshot.db'",
+			path:     "/var/lib/etcd/etcd-snapshot.db",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+			node := newEtcdNode(t, tt.osType, tt.topology)
+			out := &bytes.Buffer{}
+
+			gomock.InOrder(
+				ssh.EXPECT().RunCommand(ctx, "node-1", tt.saveCmd).Return("Snapshot saved", nil),
+				ssh.EXPECT().RunCommand(ctx, "node-1", "sudo sha256sum "+tt.path).Return("abc123  "+tt.path, nil),
+				ssh.EXPECT().CopyFromNode(ctx, "node-1", tt.path, out).DoAndReturn(
+					func(_ context.Context, _, _ string, w io.Writer) error {
+						_, err := w.Write([]byte("snapshot"))
+						return err
+					},
+				),
+				ssh.EXPECT().RunCommand(ctx, "node-1", "sudo rm -f "+tt.path).Return("", nil),
+			)
+
+			checksum, err := node.SaveSnapshot(ctx, "node-1", ssh, out)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(checksum).To(Equal("abc123"))
+			g.Expect(out.String()).To(Equal("snapshot"))
+		})
+	}
+}
+
+func TestEtcdNodeSaveSnapshotBottlerocket(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology etcdbackup.Topology
+		want     []string
+	}{
+		{
+			name:     "external",
+			topology: etcdbackup.TopologyExternal,
+			want: []string{
+				"--cacert=/var/lib/etcd/pki/ca.crt",
+				"snapshot save /var/lib/etcd/data/etcd-snapshot.db",
+				"mv /var/lib/etcd/data/etcd-snapshot.db /run/host-containerd/io.containerd.runtime.v2.task/default/admin/rootfs/tmp/etcd-snapshot.db",
+			},
+		},
+		{
+			name:     "stacked",
+			topology: etcdbackup.TopologyStacked,
+			want: []string{
+				"etcdctl --endpoints=https://127.0.0.1:2379 --cacert=/var/lib/kubeadm/pki/etcd/ca.crt",
+				"snapshot save /var/lib/etcd/etcd-snapshot.db",
+				"mv /var/lib/etcd/etcd-snapshot.db /run/host-containerd/io.containerd.runtime.v2.task/default/admin/rootfs/tmp/etcd-snapshot.db",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+			node := newEtcdNode(t, certificates.OSTypeBottlerocket, tt.topology)
+
+			ssh.EXPECT().RunCommand(ctx, "node-1", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _, cmd string, _ ...certificates.SSHOption) (string, error) {
+					g.Expect(cmd).To(HavePrefix("sudo sheltie << 'EOF'"))
+					for _, want := range tt.want {
+						g.Expect(cmd).To(ContainSubstring(want))
+					}
+					return "", nil
+				},
+			)
+			ssh.EXPECT().RunCommand(ctx, "node-1", "sudo sha256sum /tmp/etcd-snapshot.db").Return("abc123  /tmp/etcd-snapshot.db", nil)
+			ssh.EXPECT().CopyFromNode(ctx, "node-1", "/tmp/etcd-snapshot.db", gomock.Any()).Return(nil)
+			ssh.EXPECT().RunCommand(ctx, "node-1", "sudo rm -f /tmp/etcd-snapshot.db").Return("", nil)
+
+			checksum, err := node.SaveSnapshot(ctx, "node-1", ssh, io.Discard)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(checksum).To(Equal("abc123"))
+		})
+	}
+}
+
+func TestEtcdNodeSaveSnapshotError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+	node := newEtcdNode(t, certificates.OSTypeLinux, etcdbackup.TopologyExternal)
+
+	ssh.EXPECT().RunCommand(ctx, "etcd-1", gomock.Any()).Return("", errors.New("etcdctl not found"))
+
+	_, err := node.SaveSnapshot(ctx, "etcd-1", ssh, io.Discard)
+	g.Expect(err).To(MatchError("saving etcd snapshot: etcdctl not found"))
+}
+
+func TestEtcdNodeMember(t *testing.T) {
+	tests := []struct {
+		name     string
+		osType   certificates.OSType
+		topology etcdbackup.Topology
+		content  string
+		want     etcdbackup.Member
+	}{
+		{
+			name:     "linux external",
+			osType:   certificates.OSTypeLinux,
+			topology: etcdbackup.TopologyExternal,
+			content:  "ETCD_NAME=etcd-1\nETCD_DATA_DIR=/var/lib/etcd\nETCD_INITIAL_ADVERTISE_PEER_URLS=https://10.0.0.5:2380\n",
+			want:     etcdbackup.Member{Name: "etcd-1", PeerURL: "https://10.0.0.5:2380"},
+		},
+		{
+			name:     "linux stacked",
+			osType:   certificates.OSTypeLinux,
+			topology: etcdbackup.TopologyStacked,
+			content:  stackedEtcdManifest,
+			want:     etcdbackup.Member{Name: "cp-1", PeerURL: "https://10.0.0.1:2380"},
+		},
+		{
+			name:     "bottlerocket external",
+			osType:   certificates.OSTypeBottlerocket,
+			topology: etcdbackup.TopologyExternal,
+			content:  bottlerocketEtcdManifest,
+			want:     etcdbackup.Member{Name: "etcd-1", PeerURL: "https://10.0.0.5:2380"},
+		},
+		{
+			name:     "bottlerocket stacked",
+			osType:   certificates.OSTypeBottlerocket,
+			topology: etcdbackup.TopologyStacked,
+			content:  stackedEtcdManifest,
+			want:     etcdbackup.Member{Name: "cp-1", PeerURL: "https://10.0.0.1:2380"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+			node := newEtcdNode(t, tt.osType, tt.topology)
+
+			ssh.EXPECT().RunCommand(ctx, "node-1", gomock.Any()).Return(tt.content, nil)
+
+			g.Expect(node.Member(ctx, "node-1", ssh)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestEtcdNodeMemberNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+	node := newEtcdNode(t, certificates.OSTypeLinux, etcdbackup.TopologyExternal)
+
+	ssh.EXPECT().RunCommand(ctx, "etcd-1", "sudo cat /etc/etcd/etcd.env").Return("ETCD_DATA_DIR=/var/lib/etcd", nil)
+
+	_, err := node.Member(ctx, "etcd-1", ssh)
+	g.Expect(err).To(MatchError("etcd member name and initial advertise peer urls not found"))
+}
+
+func TestEtcdNodeRestoreSnapshot(t *testing.T) {
+	config := etcdbackup.RestoreConfig{
+		Member:         etcdbackup.Member{Name: "etcd-1", PeerURL: "https://10.0.0.5:2380"},
+		InitialCluster: "etcd-1=https://10.0.0.5:2380,etcd-2=https://10.0.0.6:2380",
+		Token:          "eksa-restore-1",
+	}
+	restoreArgs := "--name=etcd-1 --initial-cluster=etcd-1=https://10.0.0.5:2380,etcd-2=https://10.0.0.6:2380 --initial-cluster-token=eksa-restore-1 --initial-advertise-peer-urls=https://10.0.0.5:2380"
+
+	tests := []struct {
+		name       string
+		osType     certificates.OSType
+		topology   etcdbackup.Topology
+		path       string
+		restoreCmd string
+	}{
+		{
+			name:       "linux external",
+			osType:     certificates.OSTypeLinux,
+			topology:   etcdbackup.TopologyExternal,
+			path:       "/tmp/etcd-snapshot.db",
+			restoreCmd: "etcdutl snapshot restore /tmp/etcd-snapshot.db " + restoreArgs + " --data-dir=/var/lib/etcd/etcd-restore",
+		},
+		{
+			name:       "linux stacked",
+			osType:     certificates.OSTypeLinux,
+			topology:   etcdbackup.TopologyStacked,
+			path:       "/var/lib/etcd/etcd-snapshot.db",
+			restoreCmd: "crictl exec $(crictl ps -q --name etcd --state running | head -n 1) etcdutl snapshot restore /var/lib/etcd/etcd-snapshot.db " + restoreArgs + " --data-dir=/var/lib/etcd/etcd-restore",
+		},
+		{
+			name:       "bottlerocket external",
+			osType:     certificates.OSTypeBottlerocket,
+			topology:   etcdbackup.TopologyExternal,
+			path:       "/tmp/etcd-snapshot.db",
+			restoreCmd: "etcdutl snapshot restore /var/lib/etcd/data/etcd-snapshot.db " + restoreArgs + " --data-dir=/var/lib/etcd/data/etcd-restore",
+		},
+		{
+			name:       "bottlerocket stacked",
+			osType:     certificates.OSTypeBottlerocket,
+			topology:   etcdbackup.TopologyStacked,
+			path:       "/tmp/etcd-snapshot.db",
+			restoreCmd: "etcdutl snapshot restore /var/lib/etcd/etcd-snapshot.db " + restoreArgs + " --data-dir=/var/lib/etcd/etcd-restore",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+			node := newEtcdNode(t, tt.osType, tt.topology)
+			snapshot := strings.NewReader("snapshot")
+
+			ssh.EXPECT().CopyToNode(ctx, "node-1", snapshot, tt.path).Return(nil)
+			ssh.EXPECT().RunCommand(ctx, "node-1", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _, cmd string, _ ...certificates.SSHOption) (string, error) {
+					g.Expect(cmd).To(ContainSubstring(tt.restoreCmd))
+					return "", nil
+				},
+			)
+
+			g.Expect(node.RestoreSnapshot(ctx, "node-1", ssh, snapshot, config)).To(Succeed())
+		})
+	}
+}
+
+func TestEtcdNodeReplaceDataDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		osType   certificates.OSType
+		topology etcdbackup.Topology
+		want     []string
+	}{
+		{
+			name:     "linux external",
+			osType:   certificates.OSTypeLinux,
+			topology: etcdbackup.TopologyExternal,
+			want: []string{
+				"systemctl stop etcd.service",
+				"mv /var/lib/etcd/member /var/lib/etcd/member.bak && mv /var/lib/etcd/etcd-restore/member /var/lib/etcd/member",
+				"systemctl start --no-block etcd.service",
+			},
+		},
+		{
+			name:     "linux stacked",
+			osType:   certificates.OSTypeLinux,
+			topology: etcdbackup.TopologyStacked,
+			want: []string{
+				"while crictl ps -q --name etcd --state running | grep -q .; do sleep 2; done",
+				"mv /var/lib/etcd/member /var/lib/etcd/member.bak && mv /var/lib/etcd/etcd-restore/member /var/lib/etcd/member",
+			},
+		},
+		{
+			name:     "bottlerocket external",
+			osType:   certificates.OSTypeBottlerocket,
+			topology: etcdbackup.TopologyExternal,
+			want: []string{
+				"mv /etc/kubernetes/manifests/etcd /tmp/etcd-restore-manifests/",
+				"mv /var/lib/etcd/data/member /var/lib/etcd/data/member.bak && mv /var/lib/etcd/data/etcd-restore/member /var/lib/etcd/data/member",
+				"mv /tmp/etcd-restore-manifests/etcd /etc/kubernetes/manifests/",
+			},
+		},
+		{
+			name:     "bottlerocket stacked",
+			osType:   certificates.OSTypeBottlerocket,
+			topology: etcdbackup.TopologyStacked,
+			want: []string{
+				"ctr -n k8s.io t ls | grep -w \"${ETCD_CONTAINER_ID}\" | grep -qw RUNNING; do sleep 2; done",
+				"mv /var/lib/etcd/member /var/lib/etcd/member.bak && mv /var/lib/etcd/etcd-restore/member /var/lib/etcd/member",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+			node := newEtcdNode(t, tt.osType, tt.topology)
+
+			ssh.EXPECT().RunCommand(ctx, "node-1", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _, cmd string, _ ...certificates.SSHOption) (string, error) {
+					for _, want := range tt.want {
+						g.Expect(cmd).To(ContainSubstring(want))
+					}
+					return "", nil
+				},
+			)
+
+			g.Expect(node.ReplaceDataDir(ctx, "node-1", ssh)).To(Succeed())
+		})
+	}
+}
+
+func TestControlPlaneNodeStopStart(t *testing.T) {
+	tests := []struct {
+		name      string
+		osType    certificates.OSType
+		wantStop  string
+		wantStart string
+	}{
+		{
+			name:      "linux",
+			osType:    certificates.OSTypeLinux,
+			wantStop:  "sudo sh -c 'mkdir -p /etc/kubernetes/etcd-restore-manifests && mv /etc/kubernetes/manifests/*.yaml /etc/kubernetes/etcd-restore-manifests/'",
+			wantStart: "sudo sh -c 'mv /etc/kubernetes/etcd-restore-manifests/*.yaml /etc/kubernetes/manifests/ && rmdir /etc/kubernetes/etcd-restore-manifests'",
+		},
+		{
+			name:      "bottlerocket",
+			osType:    certificates.OSTypeBottlerocket,
+			wantStop:  "sudo sheltie << 'EOF'\nset -euo pipefail\nmkdir -p /tmp/etcd-restore-manifests\nmv /etc/kubernetes/manifests/* /tmp/etcd-restore-manifests/\nEOF",
+			wantStart: "sudo sheltie << 'EOF'\nset -euo pipefail\nmv /tmp/etcd-restore-manifests/* /etc/kubernetes/manifests/\nrmdir /tmp/etcd-restore-manifests\nEOF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+			cp, err := etcdbackup.NewControlPlaneNode(tt.osType)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ssh.EXPECT().RunCommand(ctx, "cp-1", tt.wantStop).Return("", nil)
+			ssh.EXPECT().RunCommand(ctx, "cp-1", tt.wantStart).Return("", nil)
+
+			g.Expect(cp.StopControlPlane(ctx, "cp-1", ssh)).To(Succeed())
+			g.Expect(cp.StartControlPlane(ctx, "cp-1", ssh)).To(Succeed())
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	linuxEtcdDataDir        = "/var/lib/etcd"
+	linuxEtcdRestoreDir     = linuxEtcdDataDir + "/etcd-restore"
+	linuxEtcdEnvFile        = "/etc/etcd/etcd.env"
+	linuxEtcdctlEnvFile     = "/etc/etcd/etcdctl.env"
+	linuxExternalSnapshot   = "/tmp/etcd-snapshot.db"
+	linuxStackedSnapshot    = linuxEtcdDataDir + "/etcd-snapshot.db"
+	linuxStackedEtcdPKIDir  = "/etc/kubernetes/pki/etcd"
+	linuxManifestsDir       = "/etc/kubernetes/manifests"
+	linuxStoppedManifestDir = "/etc/kubernetes/etcd-restore-manifests"
+	// linuxEtcdPath adds the folders where etcdadm installs the etcd binaries to the PATH of the sudo shell.
+	linuxEtcdPath = "export PATH=$PATH:/opt/bin:/usr/local/bin"
+)
+
+// replaceDataDir moves the restored member folder in the data directory and keeps the previous one as member.bak.
+func replaceDataDir() string {
+	return fmt.Sprintf("rm -rf %[1]s/member.bak && mv %[1]s/member %[1]s/member.bak && mv %[2]s/member %[1]s/member && rm -rf %[2]s",
+		linuxEtcdDataDir, linuxEtcdRestoreDir)
+}
+
+// linuxExternalEtcd runs the etcd operations on Ubuntu and RHEL etcd nodes created by etcdadm, where etcd is a systemd service.
+type linuxExternalEtcd struct{}
+
+func (l *linuxExternalEtcd) SaveSnapshot(ctx context.Context, node string, ssh SSHClient, w io.Writer) (string, error) {
+	cmd := fmt.Sprintf("sudo sh -c 'set -a && . %s && set +a && %s && etcdctl snapshot save %s'",
+		linuxEtcdctlEnvFile, linuxEtcdPath, linuxExternalSnapshot)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return "", fmt.Errorf("saving etcd snapshot: %v", err)
+	}
+
+	return fetchSnapshot(ctx, node, ssh, linuxExternalSnapshot, w)
+}
+
+func (l *linuxExternalEtcd) Member(ctx context.Context, node string, ssh SSHClient) (Member, error) {
+	content, err := ssh.RunCommand(ctx, node, fmt.Sprintf("sudo cat %s", linuxEtcdEnvFile))
+	if err != nil {
+		return Member{}, fmt.Errorf("reading etcd env file: %v", err)
+	}
+	return parseEnvFile(content)
+}
+
+func (l *linuxExternalEtcd) RestoreSnapshot(ctx context.Context, node string, ssh SSHClient, snapshot io.Reader, config RestoreConfig) error {
+	if err := ssh.CopyToNode(ctx, node, snapshot, linuxExternalSnapshot); err != nil {
+		return fmt.Errorf("copying snapshot to node %s: %v", node, err)
+	}
+
+	// etcdutl is only available from etcd v3.5, the restore command of etcdctl is used with older versions.
+	cmd := fmt.Sprintf(`sudo sh -c '%[1]s && rm -rf %[2]s && if command -v etcdutl > /dev/null; then etcdutl snapshot restore %[3]s %[4]s; else ETCDCTL_API=3 etcdctl snapshot restore %[3]s %[4]s; fi && rm -f %[3]s'`,
+		linuxEtcdPath, linuxEtcdRestoreDir, linuxExternalSnapshot, restoreArgs(config, linuxEtcdRestoreDir))
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("restoring etcd snapshot: %v", err)
+	}
+
+	return nil
+}
+
+func (l *linuxExternalEtcd) ReplaceDataDir(ctx context.Context, node string, ssh SSHClient) error {
+	// etcd is started without waiting since it doesn't get ready until the other members are started.
+	cmd := fmt.Sprintf("sudo sh -c 'systemctl stop etcd.service && %s && systemctl start --no-block etcd.service'", replaceDataDir())
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("replacing etcd data directory: %v", err)
+	}
+	return nil
+}
+
+// linuxStackedEtcd runs the etcd operations on Ubuntu and RHEL control plane nodes where etcd is a kubeadm static pod.
+// etcdctl and etcdutl are run in the etcd container since they aren't installed in the node.
+type linuxStackedEtcd struct{}
+
+func (l *linuxStackedEtcd) etcdContainer(cmd string) string {
+	return fmt.Sprintf("crictl exec $(crictl ps -q --name etcd --state running | head -n 1) %s", cmd)
+}
+
+func (l *linuxStackedEtcd) SaveSnapshot(ctx context.Context, node string, ssh SSHClient, w io.Writer) (string, error) {
+	cmd := fmt.Sprintf("sudo sh -c '%s'", l.etcdContainer(fmt.Sprintf(
+		"etcdctl --endpoints=https://127.0.0.1:2379 --cacert=%[1]s/ca.crt --cert=%[1]s/server.crt --key=%[1]s/server.key snapshot save %[2]s",
+		linuxStackedEtcdPKIDir, linuxStackedSnapshot)))
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return "", fmt.Errorf("saving etcd snapshot: %v", err)
+	}
+
+	return fetchSnapshot(ctx, node, ssh, linuxStackedSnapshot, w)
+}
+
+func (l *linuxStackedEtcd) Member(ctx context.Context, node string, ssh SSHClient) (Member, error) {
+	content, err := ssh.RunCommand(ctx, node, fmt.Sprintf("sudo cat %s/etcd.yaml", linuxManifestsDir))
+	if err != nil {
+		return Member{}, fmt.Errorf("reading etcd manifest: %v", err)
+	}
+	return parseStaticPod(content)
+}
+
+func (l *linuxStackedEtcd) RestoreSnapshot(ctx context.Context, node string, ssh SSHClient, snapshot io.Reader, config RestoreConfig) error {
+	if err := ssh.CopyToNode(ctx, node, snapshot, linuxStackedSnapshot); err != nil {
+		return fmt.Errorf("copying snapshot to node %s: %v", node, err)
+	}
+
+	// The etcd data directory is mounted in the etcd container, so the restored data is written in the node.
+	cmd := fmt.Sprintf("sudo sh -c 'rm -rf %s && %s && rm -f %s'",
+		linuxEtcdRestoreDir,
+		l.etcdContainer(fmt.Sprintf("etcdutl snapshot restore %s %s", linuxStackedSnapshot, restoreArgs(config, linuxEtcdRestoreDir))),
+		linuxStackedSnapshot)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("restoring etcd snapshot: %v", err)
+	}
+
+	return nil
+}
+
+// ReplaceDataDir expects the control plane static pods, including etcd, to be stopped. It waits for the kubelet
+// to stop the etcd container before replacing the data directory, and etcd starts with the control plane.
+func (l *linuxStackedEtcd) ReplaceDataDir(ctx context.Context, node string, ssh SSHClient) error {
+	cmd := fmt.Sprintf("sudo sh -c 'while crictl ps -q --name etcd --state running | grep -q .; do sleep 2; done && %s'", replaceDataDir())
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("replacing etcd data directory: %v", err)
+	}
+	return nil
+}
+
+// linuxControlPlane stops and starts the static pods of Ubuntu and RHEL control plane nodes.
+type linuxControlPlane struct{}
+
+func (l *linuxControlPlane) StopControlPlane(ctx context.Context, node string, ssh SSHClient) error {
+	cmd := fmt.Sprintf("sudo sh -c 'mkdir -p %[2]s && mv %[1]s/*.yaml %[2]s/'", linuxManifestsDir, linuxStoppedManifestDir)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("stopping control plane components: %v", err)
+	}
+	return nil
+}
+
+func (l *linuxControlPlane) StartControlPlane(ctx context.Context, node string, ssh SSHClient) error {
+	cmd := fmt.Sprintf("sudo sh -c 'mv %[2]s/*.yaml %[1]s/ && rmdir %[2]s'", linuxManifestsDir, linuxStoppedManifestDir)
+	if _, err := ssh.RunCommand(ctx, node, cmd); err != nil {
+		return fmt.Errorf("starting control plane components: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/etcdbackup/etcd.go
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=pkg/etcdbackup/mocks/etcd.go -package=mocks -source pkg/etcdbackup/etcd.go EtcdNode ControlPlaneNode
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	etcdbackup "github.com/aws/eks-anywhere/pkg/etcdbackup"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEtcdNode is a mock of EtcdNode interface.
+type MockEtcdNode struct {
+	ctrl     *gomock.Controller
+	recorder *MockEtcdNodeMockRecorder
+	isgomock struct{}
+}
+
+// MockEtcdNodeMockRecorder is the mock recorder for MockEtcdNode.
+type MockEtcdNodeMockRecorder struct {
+	mock *MockEtcdNode
+}
+
+// NewMockEtcdNode creates a new mock instance.
+func NewMockEtcdNode(ctrl *gomock.Controller) *MockEtcdNode {
+	mock := &MockEtcdNode{ctrl: ctrl}
+	mock.recorder = &MockEtcdNodeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEtcdNode) EXPECT() *MockEtcdNodeMockRecorder {
+	return m.recorder
+}
+
+// Member mocks base method.
+func (m *MockEtcdNode) Member(ctx context.Context, node string, ssh etcdbackup.SSHClient) (etcdbackup.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Member", ctx, node, ssh)
+	ret0, _ := ret[0].(etcdbackup.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Member indicates an expected call of Member.
+func (mr *MockEtcdNodeMockRecorder) Member(ctx, node, ssh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Member", reflect.TypeOf((*MockEtcdNode)(nil).Member), ctx, node, ssh)
+}
+
+// ReplaceDataDir mocks base method.
+func (m *MockEtcdNode) ReplaceDataDir(ctx context.Context, node string, ssh etcdbackup.SSHClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceDataDir", ctx, node, ssh)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceDataDir indicates an expected call of ReplaceDataDir.
+func (mr *MockEtcdNodeMockRecorder) ReplaceDataDir(ctx, node, ssh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceDataDir", reflect.TypeOf((*MockEtcdNode)(nil).ReplaceDataDir), ctx, node, ssh)
+}
+
+// RestoreSnapshot mocks base method.
+func (m *MockEtcdNode) RestoreSnapshot(ctx context.Context, node string, ssh etcdbackup.SSHClient, snapshot io.Reader, config etcdbackup.RestoreConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSnapshot", ctx, node, ssh, snapshot, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreSnapshot indicates an expected call of RestoreSnapshot.
+func (mr *MockEtcdNodeMockRecorder) RestoreSnapshot(ctx, node, ssh, snapshot, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSnapshot", reflect.TypeOf((*MockEtcdNode)(nil).RestoreSnapshot), ctx, node, ssh, snapshot, config)
+}
+
+// SaveSnapshot mocks base method.
+func (m *MockEtcdNode) SaveSnapshot(ctx context.Context, node string, ssh etcdbackup.SSHClient, w io.Writer) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSnapshot", ctx, node, ssh, w)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveSnapshot indicates an expected call of SaveSnapshot.
+func (mr *MockEtcdNodeMockRecorder) SaveSnapshot(ctx, node, ssh, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSnapshot", reflect.TypeOf((*MockEtcdNode)(nil).SaveSnapshot), ctx, node, ssh, w)
+}
+
+// MockControlPlaneNode is a mock of ControlPlaneNode interface.
+type MockControlPlaneNode struct {
+	ctrl     *gomock.Controller
+	recorder *MockControlPlaneNodeMockRecorder
+	isgomock struct{}
+}
+
+// MockControlPlaneNodeMockRecorder is the mock recorder for MockControlPlaneNode.
+type MockControlPlaneNodeMockRecorder struct {
+	mock *MockControlPlaneNode
+}
+
+// NewMockControlPlaneNode creates a new mock instance.
+func NewMockControlPlaneNode(ctrl *gomock.Controller) *MockControlPlaneNode {
+	mock := &MockControlPlaneNode{ctrl: ctrl}
+	mock.recorder = &MockControlPlaneNodeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockControlPlaneNode) EXPECT() *MockControlPlaneNodeMockRecorder {
+	return m.recorder
+}
+
+// StartControlPlane mocks base method.
+func (m *MockControlPlaneNode) StartControlPlane(ctx context.Context, node string, ssh etcdbackup.SSHClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartControlPlane", ctx, node, ssh)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartControlPlane indicates an expected call of StartControlPlane.
+func (mr *MockControlPlaneNodeMockRecorder) StartControlPlane(ctx, node, ssh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartControlPlane", reflect.TypeOf((*MockControlPlaneNode)(nil).StartControlPlane), ctx, node, ssh)
+}
+
+// StopControlPlane mocks base method.
+func (m *MockControlPlaneNode) StopControlPlane(ctx context.Context, node string, ssh etcdbackup.SSHClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopControlPlane", ctx, node, ssh)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopControlPlane indicates an expected call of StopControlPlane.
+func (mr *MockControlPlaneNodeMockRecorder) StopControlPlane(ctx, node, ssh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopControlPlane", reflect.TypeOf((*MockControlPlaneNode)(nil).StopControlPlane), ctx, node, ssh)
+}
@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/etcdbackup/ssh.go
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod -destination=pkg/etcdbackup/mocks/ssh.go -package=mocks -source pkg/etcdbackup/ssh.go SSHClient
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	certificates "github.com/aws/eks-anywhere/pkg/certificates"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSSHClient is a mock of SSHClient interface.
+type MockSSHClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSHClientMockRecorder
+	isgomock struct{}
+}
+
+// MockSSHClientMockRecorder is the mock recorder for MockSSHClient.
+type MockSSHClientMockRecorder struct {
+	mock *MockSSHClient
+}
+
+// NewMockSSHClient creates a new mock instance.
+func NewMockSSHClient(ctrl *gomock.Controller) *MockSSHClient {
+	mock := &MockSSHClient{ctrl: ctrl}
+	mock.recorder = &MockSSHClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSHClient) EXPECT() *MockSSHClientMockRecorder {
+	return m.recorder
+}
+
+// CopyFromNode mocks base method.
+func (m *MockSSHClient) CopyFromNode(ctx context.Context, node, path string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyFromNode", ctx, node, path, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyFromNode indicates an expected call of CopyFromNode.
+func (mr *MockSSHClientMockRecorder) CopyFromNode(ctx, node, path, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyFromNode", reflect.TypeOf((*MockSSHClient)(nil).CopyFromNode), ctx, node, path, w)
+}
+
+// CopyToNode mocks base method.
+func (m *MockSSHClient) CopyToNode(ctx context.Context, node string, r io.Reader, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyToNode", ctx, node, r, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyToNode indicates an expected call of CopyToNode.
+func (mr *MockSSHClientMockRecorder) CopyToNode(ctx, node, r, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyToNode", reflect.TypeOf((*MockSSHClient)(nil).CopyToNode), ctx, node, r, path)
+}
+
+// RunCommand mocks base method.
+func (m *MockSSHClient) RunCommand(ctx context.Context, node, cmd string, opts ...certificates.SSHOption) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, node, cmd}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RunCommand", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunCommand indicates an expected call of RunCommand.
+func (mr *MockSSHClientMockRecorder) RunCommand(ctx, node, cmd any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, node, cmd}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommand", reflect.TypeOf((*MockSSHClient)(nil).RunCommand), varargs...)
+}
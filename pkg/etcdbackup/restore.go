@@ -0,0 +1,207 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+// Restorer restores an etcd snapshot in all the etcd members of a cluster.
+type Restorer struct {
+	ClusterName       string
+	Kube              kubernetes.Client
+	EtcdNodes         []string
+	ControlPlaneNodes []string
+	SSHEtcd           SSHClient
+	SSHControlPlane   SSHClient
+	Etcd              EtcdNode
+	ControlPlane      ControlPlaneNode
+	// Retrier retries resuming the cluster reconciliation while the API server restarts.
+	Retrier *retrier.Retrier
+}
+
+// NewRestorer returns a Restorer for the cluster nodes in the config. kube is a client for the management cluster,
+// used to pause the cluster reconciliation during the restore.
+func NewRestorer(kube kubernetes.Client, cfg *certificates.RenewalConfig) (*Restorer, error) {
+	topology := ClusterTopology(cfg)
+	osType := OSType(cfg)
+	etcd, err := NewEtcdNode(osType, topology)
+	if err != nil {
+		return nil, err
+	}
+	controlPlane, err := NewControlPlaneNode(osType)
+	if err != nil {
+		return nil, err
+	}
+
+	sshControlPlane, err := certificates.NewSSHRunner(cfg.ControlPlane.SSH)
+	if err != nil {
+		return nil, fmt.Errorf("building control plane ssh client: %v", err)
+	}
+
+	r := &Restorer{
+		ClusterName:       cfg.ClusterName,
+		Kube:              kube,
+		EtcdNodes:         cfg.ControlPlane.Nodes,
+		ControlPlaneNodes: cfg.ControlPlane.Nodes,
+		SSHEtcd:           sshControlPlane,
+		SSHControlPlane:   sshControlPlane,
+		Etcd:              etcd,
+		ControlPlane:      controlPlane,
+		Retrier:           retrier.NewWithMaxRetries(30, 10*time.Second),
+	}
+
+	if topology == TopologyExternal {
+		r.EtcdNodes = cfg.Etcd.Nodes
+		r.SSHEtcd, err = certificates.NewSSHRunner(cfg.Etcd.SSH)
+		if err != nil {
+			return nil, fmt.Errorf("building etcd ssh client: %v", err)
+		}
+	}
+
+	return r, nil
+}
+
+// Restore restores the snapshot file in all the etcd members. The members keep their name and peer URL,
+// so the snapshot can be restored in a cluster rebuilt with new nodes. The control plane is stopped while
+// the etcd data is replaced and the cluster reconciliation is paused during the restore. The restore doesn't
+// start if the reconciliation can't be paused and it's resumed even if the restore fails.
+func (r *Restorer) Restore(ctx context.Context, snapshot string) (err error) {
+	members := make([]Member, 0, len(r.EtcdNodes))
+	for _, node := range r.EtcdNodes {
+		m, err := r.Etcd.Member(ctx, node, r.SSHEtcd)
+		if err != nil {
+			return fmt.Errorf("getting etcd member of node %s: %v", node, err)
+		}
+		members = append(members, m)
+	}
+
+	if err := r.setPaused(ctx, true); err != nil {
+		// Pausing can fail after updating some of the clusters, so don't leave those paused.
+		if resumeErr := r.setPaused(ctx, false); resumeErr != nil {
+			logResumeFailure(resumeErr)
+		}
+		return fmt.Errorf("pausing the cluster reconciliation: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			r.resume(ctx)
+		}
+	}()
+
+	token := fmt.Sprintf("eksa-restore-%d", time.Now().Unix())
+	for i, node := range r.EtcdNodes {
+		logger.Info("Restoring etcd snapshot", "node", node)
+		if err := r.restoreSnapshot(ctx, node, snapshot, RestoreConfig{
+			Member:         members[i],
+			InitialCluster: initialCluster(members),
+			Token:          token,
+		}); err != nil {
+			return fmt.Errorf("restoring etcd snapshot on node %s: %v", node, err)
+		}
+	}
+
+	logger.Info("Stopping control plane components")
+	for _, node := range r.ControlPlaneNodes {
+		if err := r.ControlPlane.StopControlPlane(ctx, node, r.SSHControlPlane); err != nil {
+			r.startControlPlane(ctx)
+			return fmt.Errorf("node %s: %v", node, err)
+		}
+	}
+
+	for _, node := range r.EtcdNodes {
+		logger.Info("Replacing etcd data", "node", node)
+		if err := r.Etcd.ReplaceDataDir(ctx, node, r.SSHEtcd); err != nil {
+			r.startControlPlane(ctx)
+			return fmt.Errorf("node %s: %v", node, err)
+		}
+	}
+
+	logger.Info("Starting control plane components")
+	if err := r.startControlPlane(ctx); err != nil {
+		return err
+	}
+
+	r.resume(ctx)
+	logger.MarkSuccess("Restored etcd snapshot")
+	return nil
+}
+
+// resume resumes the cluster reconciliation, retrying while the API server restarts.
+func (r *Restorer) resume(ctx context.Context) {
+	if err := r.Retrier.RetryWithContext(ctx, func() error { return r.setPaused(ctx, false) }); err != nil {
+		logResumeFailure(err)
+	}
+}
+
+func logResumeFailure(err error) {
+	logger.Info("Warning: failed to resume the cluster reconciliation, remove the paused annotation from the cluster and unpause the CAPI cluster manually", "error", err)
+}
+
+func (r *Restorer) restoreSnapshot(ctx context.Context, node, snapshot string, config RestoreConfig) error {
+	f, err := os.Open(snapshot)
+	if err != nil {
+		return fmt.Errorf("opening snapshot: %v", err)
+	}
+	defer f.Close()
+
+	return r.Etcd.RestoreSnapshot(ctx, node, r.SSHEtcd, f, config)
+}
+
+// startControlPlane starts the control plane components of all the nodes, even if it fails for some of them.
+func (r *Restorer) startControlPlane(ctx context.Context) error {
+	var firstErr error
+	for _, node := range r.ControlPlaneNodes {
+		if err := r.ControlPlane.StartControlPlane(ctx, node, r.SSHControlPlane); err != nil {
+			logger.Error(err, "Starting control plane components, start them manually", "node", node)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("node %s: %v", node, err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// setPaused pauses or resumes the reconciliation of the EKS Anywhere and CAPI clusters.
+func (r *Restorer) setPaused(ctx context.Context, paused bool) error {
+	clusters := &anywherev1.ClusterList{}
+	if err := r.Kube.List(ctx, clusters); err != nil {
+		return fmt.Errorf("listing clusters: %v", err)
+	}
+	for i := range clusters.Items {
+		c := &clusters.Items[i]
+		if c.Name != r.ClusterName {
+			continue
+		}
+		if paused {
+			c.PauseReconcile()
+		} else {
+			c.ClearPauseAnnotation()
+		}
+		if err := r.Kube.Update(ctx, c); err != nil {
+			return fmt.Errorf("updating cluster %s: %v", c.Name, err)
+		}
+	}
+
+	capiCluster := &clusterv1beta2.Cluster{}
+	if err := r.Kube.Get(ctx, r.ClusterName, constants.EksaSystemNamespace, capiCluster); err != nil {
+		return fmt.Errorf("getting CAPI cluster: %v", err)
+	}
+	capiCluster.Spec.Paused = ptr.To(paused)
+	if err := r.Kube.Update(ctx, capiCluster); err != nil {
+		return fmt.Errorf("updating CAPI cluster: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	kubemocks "github.com/aws/eks-anywhere/pkg/clients/kubernetes/mocks"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+type restoreTest struct {
+	*WithT
+	ctx          context.Context
+	kube         *kubemocks.MockClient
+	sshEtcd      *mocks.MockSSHClient
+	sshCP        *mocks.MockSSHClient
+	etcd         *mocks.MockEtcdNode
+	controlPlane *mocks.MockControlPlaneNode
+	restorer     *etcdbackup.Restorer
+	snapshot     string
+}
+
+func newRestoreTest(t *testing.T) *restoreTest {
+	ctrl := gomock.NewController(t)
+	tt := &restoreTest{
+		WithT:        NewWithT(t),
+		ctx:          context.Background(),
+		kube:         kubemocks.NewMockClient(ctrl),
+		sshEtcd:      mocks.NewMockSSHClient(ctrl),
+		sshCP:        mocks.NewMockSSHClient(ctrl),
+		etcd:         mocks.NewMockEtcdNode(ctrl),
+		controlPlane: mocks.NewMockControlPlaneNode(ctrl),
+		snapshot:     filepath.Join(t.TempDir(), "snapshot.db"),
+	}
+	tt.Expect(os.WriteFile(tt.snapshot, []byte("snapshot"), 0o600)).To(Succeed())
+	tt.restorer = &etcdbackup.Restorer{
+		ClusterName:       "mgmt",
+		Kube:              tt.kube,
+		EtcdNodes:         []string{"etcd-1", "etcd-2"},
+		ControlPlaneNodes: []string{"cp-1"},
+		SSHEtcd:           tt.sshEtcd,
+		SSHControlPlane:   tt.sshCP,
+		Etcd:              tt.etcd,
+		ControlPlane:      tt.controlPlane,
+		Retrier:           retrier.NewWithMaxRetries(1, 0),
+	}
+	return tt
+}
+
+func (tt *restoreTest) expectMembers() {
+	tt.etcd.EXPECT().Member(tt.ctx, "etcd-1", tt.sshEtcd).Return(etcdbackup.Member{Name: "etcd-1", PeerURL: "https://10.0.0.5:2380"}, nil)
+	tt.etcd.EXPECT().Member(tt.ctx, "etcd-2", tt.sshEtcd).Return(etcdbackup.Member{Name: "etcd-2", PeerURL: "https://10.0.0.6:2380"}, nil)
+}
+
+func (tt *restoreTest) expectSetPaused(paused bool) {
+	tt.kube.EXPECT().List(tt.ctx, &anywherev1.ClusterList{}).DoAndReturn(
+		func(_ context.Context, list kubernetes.ObjectList, _ ...kubernetes.ListOption) error {
+			list.(*anywherev1.ClusterList).Items = []anywherev1.Cluster{
+				{ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: "default"}},
+			}
+			return nil
+		},
+	)
+	tt.kube.EXPECT().Update(tt.ctx, gomock.AssignableToTypeOf(&anywherev1.Cluster{})).DoAndReturn(
+		func(_ context.Context, obj kubernetes.Object) error {
+			c := obj.(*anywherev1.Cluster)
+			tt.Expect(c.Name).To(Equal("mgmt"))
+			tt.Expect(c.IsReconcilePaused()).To(Equal(paused))
+			return nil
+		},
+	)
+	tt.kube.EXPECT().Get(tt.ctx, "mgmt", "eksa-system", &clusterv1beta2.Cluster{}).Return(nil)
+	tt.kube.EXPECT().Update(tt.ctx, &clusterv1beta2.Cluster{Spec: clusterv1beta2.ClusterSpec{Paused: ptr.To(paused)}}).Return(nil)
+}
+
+func (tt *restoreTest) expectRestoreSnapshot(node string) *gomock.Call {
+	return tt.etcd.EXPECT().RestoreSnapshot(tt.ctx, node, tt.sshEtcd, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, _ etcdbackup.SSHClient, snapshot io.Reader, config etcdbackup.RestoreConfig) error {
+			tt.Expect(io.ReadAll(snapshot)).To(BeEquivalentTo("snapshot"))
+			tt.Expect(config.Member.Name).To(Equal(node))
+			tt.Expect(config.InitialCluster).To(Equal("etcd-1=https://10.0.0.5:2380,etcd-2=https://10.0.0.6:2380"))
+			tt.Expect(config.Token).To(HavePrefix("eksa-restore-"))
+			return nil
+		},
+	)
+}
+
+func TestRestorerRestore(t *testing.T) {
+	tt := newRestoreTest(t)
+
+	tt.expectMembers()
+	gomock.InOrder(
+		tt.expectRestoreSnapshot("etcd-1"),
+		tt.expectRestoreSnapshot("etcd-2"),
+		tt.controlPlane.EXPECT().StopControlPlane(tt.ctx, "cp-1", tt.sshCP).Return(nil),
+		tt.etcd.EXPECT().ReplaceDataDir(tt.ctx, "etcd-1", tt.sshEtcd).Return(nil),
+		tt.etcd.EXPECT().ReplaceDataDir(tt.ctx, "etcd-2", tt.sshEtcd).Return(nil),
+		tt.controlPlane.EXPECT().StartControlPlane(tt.ctx, "cp-1", tt.sshCP).Return(nil),
+	)
+	tt.expectSetPaused(true)
+	tt.expectSetPaused(false)
+
+	tt.Expect(tt.restorer.Restore(tt.ctx, tt.snapshot)).To(Succeed())
+}
+
+func TestRestorerRestoreClusterUnreachable(t *testing.T) {
+	tt := newRestoreTest(t)
+
+	tt.expectMembers()
+	tt.kube.EXPECT().List(tt.ctx, gomock.Any()).Return(errors.New("connection refused")).Times(2)
+
+	tt.Expect(tt.restorer.Restore(tt.ctx, tt.snapshot)).To(MatchError("pausing the cluster reconciliation: listing clusters: connection refused"))
+}
+
+func TestRestorerRestoreResumeError(t *testing.T) {
+	tt := newRestoreTest(t)
+
+	tt.expectMembers()
+	tt.expectSetPaused(true)
+	tt.expectRestoreSnapshot("etcd-1")
+	tt.expectRestoreSnapshot("etcd-2")
+	tt.controlPlane.EXPECT().StopControlPlane(tt.ctx, "cp-1", tt.sshCP).Return(nil)
+	tt.etcd.EXPECT().ReplaceDataDir(tt.ctx, gomock.Any(), tt.sshEtcd).Return(nil).Times(2)
+	tt.controlPlane.EXPECT().StartControlPlane(tt.ctx, "cp-1", tt.sshCP).Return(nil)
+	tt.kube.EXPECT().List(tt.ctx, gomock.Any()).Return(errors.New("connection refused"))
+
+	tt.Expect(tt.restorer.Restore(tt.ctx, tt.snapshot)).To(Succeed())
+}
+
+func TestRestorerRestoreSnapshotError(t *testing.T) {
+	tt := newRestoreTest(t)
+
+	tt.expectMembers()
+	tt.expectSetPaused(true)
+	tt.etcd.EXPECT().RestoreSnapshot(tt.ctx, "etcd-1", tt.sshEtcd, gomock.Any(), gomock.Any()).Return(errors.New("disk full"))
+	tt.expectSetPaused(false)
+
+	tt.Expect(tt.restorer.Restore(tt.ctx, tt.snapshot)).To(MatchError("restoring etcd snapshot on node etcd-1: disk full"))
+}
+
+func TestRestorerRestoreMemberError(t *testing.T) {
+	tt := newRestoreTest(t)
+
+	tt.etcd.EXPECT().Member(tt.ctx, "etcd-1", tt.sshEtcd).Return(etcdbackup.Member{}, errors.New("no such file"))
+
+	tt.Expect(tt.restorer.Restore(tt.ctx, tt.snapshot)).To(MatchError("getting etcd member of node etcd-1: no such file"))
+}
+
+func TestRestorerRestoreReplaceDataDirError(t *testing.T) {
+	tt := newRestoreTest(t)
+
+	tt.expectMembers()
+	tt.expectSetPaused(true)
+	tt.expectRestoreSnapshot("etcd-1")
+	tt.expectRestoreSnapshot("etcd-2")
+	tt.controlPlane.EXPECT().StopControlPlane(tt.ctx, "cp-1", tt.sshCP).Return(nil)
+	tt.etcd.EXPECT().ReplaceDataDir(tt.ctx, "etcd-1", tt.sshEtcd).Return(errors.New("etcd.service not found"))
+	tt.controlPlane.EXPECT().StartControlPlane(tt.ctx, "cp-1", tt.sshCP).Return(nil)
+	tt.expectSetPaused(false)
+
+	tt.Expect(tt.restorer.Restore(tt.ctx, tt.snapshot)).To(MatchError("node etcd-1: etcd.service not found"))
+}
@@ -0,0 +1,17 @@
+package etcdbackup
+
+import (
+	"context"
+	"io"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+// SSHClient runs commands on the cluster nodes and copies files to and from them over SSH.
+type SSHClient interface {
+	certificates.SSHRunner
+	// CopyFromNode writes the content of a file on the node to w.
+	CopyFromNode(ctx context.Context, node, path string, w io.Writer) error
+	// CopyToNode writes the content of r to a file on the node.
+	CopyToNode(ctx context.Context, node string, r io.Reader, path string) error
+}
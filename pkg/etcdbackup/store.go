@@ -0,0 +1,157 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/aws/eks-anywhere/internal/pkg/s3"
+)
+
+const s3Scheme = "s3://"
+
+// Store saves the snapshots and fetches them back.
+type Store interface {
+	// Save saves the snapshot file with the name and returns its location.
+	Save(ctx context.Context, file, name string) (string, error)
+	// Fetch copies the snapshot at location to file.
+	Fetch(ctx context.Context, location, file string) error
+}
+
+// S3Options configures the client of an S3-compatible object storage.
+type S3Options struct {
+	// Endpoint is the URL of an S3-compatible endpoint. It's only needed when not using AWS S3.
+	Endpoint string
+	Region   string
+}
+
+// IsS3Location returns true if the location is an s3://bucket/key URL.
+func IsS3Location(location string) bool {
+	return strings.HasPrefix(location, s3Scheme)
+}
+
+// NewStore returns an S3Store for s3://bucket/prefix locations and a LocalStore for the other ones.
+func NewStore(location string, opts S3Options) (Store, error) {
+	if IsS3Location(location) {
+		return NewS3Store(location, opts)
+	}
+	return NewLocalStore(location), nil
+}
+
+// LocalStore saves snapshots in a local folder.
+type LocalStore struct {
+	Dir string
+}
+
+// NewLocalStore returns a LocalStore that saves snapshots in dir.
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{Dir: dir}
+}
+
+// Save copies the snapshot file to the store folder.
+func (s *LocalStore) Save(_ context.Context, file, name string) (string, error) {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return "", fmt.Errorf("creating snapshot folder: %v", err)
+	}
+
+	dst := filepath.Join(s.Dir, name)
+	if err := copyFile(file, dst); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// Fetch copies the snapshot at the location path to file.
+func (s *LocalStore) Fetch(_ context.Context, location, file string) error {
+	return copyFile(location, file)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("opening snapshot: %v", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating snapshot file: %v", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("copying snapshot to %s: %v", dst, err)
+	}
+	return out.Close()
+}
+
+// S3Store saves snapshots in an S3 or S3-compatible bucket. The credentials are read from the standard
+// AWS environment variables and shared config files.
+type S3Store struct {
+	Bucket  string
+	Prefix  string
+	session *session.Session
+}
+
+// NewS3Store returns an S3Store for an s3://bucket/prefix location.
+func NewS3Store(location string, opts S3Options) (*S3Store, error) {
+	bucket, prefix, err := parseS3Location(location)
+	if err != nil {
+		return nil, err
+	}
+
+	config := aws.NewConfig()
+	if opts.Endpoint != "" {
+		// S3-compatible storages generally don't support virtual hosted buckets.
+		config = config.WithEndpoint(opts.Endpoint).WithS3ForcePathStyle(true)
+	}
+	if opts.Region != "" {
+		config = config.WithRegion(opts.Region)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating S3 session: %v", err)
+	}
+
+	return &S3Store{Bucket: bucket, Prefix: prefix, session: sess}, nil
+}
+
+// Save uploads the snapshot file to the bucket under the store prefix.
+func (s *S3Store) Save(_ context.Context, file, name string) (string, error) {
+	key := path.Join(s.Prefix, name)
+	if err := s3.UploadFile(s.session, file, key, s.Bucket); err != nil {
+		return "", err
+	}
+	return s3Scheme + path.Join(s.Bucket, key), nil
+}
+
+// Fetch downloads the snapshot at an s3://bucket/key location to file.
+func (s *S3Store) Fetch(_ context.Context, location, file string) error {
+	bucket, key, err := parseS3Location(location)
+	if err != nil {
+		return err
+	}
+	if key == "" {
+		return fmt.Errorf("invalid snapshot location %s, the object key is missing", location)
+	}
+	return s3.DownloadToDisk(s.session, key, bucket, file)
+}
+
+func parseS3Location(location string) (bucket, key string, err error) {
+	u, err := url.Parse(location)
+	if err != nil || u.Scheme != "s3" || u.Host == "" {
+		return "", "", fmt.Errorf("invalid S3 location %s, must be s3://bucket[/key]", location)
+	}
+	return u.Host, strings.Trim(u.Path, "/"), nil
+}
@@ -0,0 +1,58 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+func TestLocalStoreSaveFetch(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.db")
+	g.Expect(os.WriteFile(src, []byte("snapshot"), 0o600)).To(Succeed())
+	store := etcdbackup.NewLocalStore(filepath.Join(dir, "backups"))
+
+	location, err := store.Save(ctx, src, "snapshot.db")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(location).To(Equal(filepath.Join(dir, "backups", "snapshot.db")))
+
+	dst := filepath.Join(dir, "fetched.db")
+	g.Expect(store.Fetch(ctx, location, dst)).To(Succeed())
+	g.Expect(os.ReadFile(dst)).To(BeEquivalentTo("snapshot"))
+}
+
+func TestLocalStoreFetchNotFound(t *testing.T) {
+	g := NewWithT(t)
+	store := etcdbackup.NewLocalStore(t.TempDir())
+
+	err := store.Fetch(context.Background(), "missing.db", filepath.Join(t.TempDir(), "dst.db"))
+	g.Expect(err).To(MatchError(ContainSubstring("opening snapshot")))
+}
+
+func TestNewStore(t *testing.T) {
+	g := NewWithT(t)
+
+	store, err := etcdbackup.NewStore("backups", etcdbackup.S3Options{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(store).To(Equal(etcdbackup.NewLocalStore("backups")))
+
+	store, err = etcdbackup.NewStore("s3://etcd-backups/mgmt/", etcdbackup.S3Options{Endpoint: "https://minio.local:9000", Region: "us-west-2"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(store).To(BeAssignableToTypeOf(&etcdbackup.S3Store{}))
+	s3Store := store.(*etcdbackup.S3Store)
+	g.Expect(s3Store.Bucket).To(Equal("etcd-backups"))
+	g.Expect(s3Store.Prefix).To(Equal("mgmt"))
+}
+
+func TestNewStoreInvalidS3Location(t *testing.T) {
+	g := NewWithT(t)
+	_, err := etcdbackup.NewStore("s3:///key", etcdbackup.S3Options{})
+	g.Expect(err).To(MatchError("invalid S3 location s3:///key, must be s3://bucket[/key]"))
+}
@@ -0,0 +1,4 @@
+// Package events emits machine-readable events describing the progress of CLI workflows, such as
+// tasks starting and ending, retries, validation results and errors. By default events are discarded;
+// commands can configure an Emitter with Set to stream them, for example as JSON Lines.
+package events
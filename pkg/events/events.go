@@ -0,0 +1,152 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Type identifies the kind of an Event.
+type Type string
+
+const (
+	// TaskStart is emitted when a workflow task starts running.
+	TaskStart Type = "taskStart"
+	// TaskEnd is emitted when a workflow task finishes running, successfully or not.
+	TaskEnd Type = "taskEnd"
+	// TaskRestored is emitted when a workflow task is restored from a checkpoint instead of being run.
+	TaskRestored Type = "taskRestored"
+	// Retry is emitted every time a retrier is about to retry a failed operation.
+	Retry Type = "retry"
+	// Validation is emitted for every validation result.
+	Validation Type = "validation"
+	// Error is emitted when a workflow task fails.
+	Error Type = "error"
+)
+
+// Event is a machine-readable record of something that happened during a CLI workflow.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type Type      `json:"type"`
+	// Task is the name of the workflow task the event belongs to, if any.
+	Task string `json:"task,omitempty"`
+	// Name is the name of the validation for Validation events.
+	Name string `json:"name,omitempty"`
+	// Passed reports the outcome of a validation for Validation events.
+	Passed *bool `json:"passed,omitempty"`
+	// DurationSeconds is the total duration of a task for TaskEnd events.
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+	// SubtaskDurationsSeconds contains the duration of the profiled subtasks of a task for TaskEnd events.
+	SubtaskDurationsSeconds map[string]float64 `json:"subtaskDurationsSeconds,omitempty"`
+	// Attempt is the number of the attempt that failed for Retry events.
+	Attempt int `json:"attempt,omitempty"`
+	// WaitSeconds is the time the retrier waits before the next attempt for Retry events.
+	WaitSeconds float64 `json:"waitSeconds,omitempty"`
+	Error       string  `json:"error,omitempty"`
+	Remediation string  `json:"remediation,omitempty"`
+}
+
+// Emitter receives events.
+type Emitter interface {
+	Emit(Event)
+}
+
+type discard struct{}
+
+func (discard) Emit(Event) {}
+
+var (
+	pkgEmitter  Emitter = discard{}
+	currentTask string
+	pkgMtx      sync.RWMutex
+)
+
+// Set configures the package emitter. Passing nil disables event emission.
+func Set(e Emitter) {
+	pkgMtx.Lock()
+	defer pkgMtx.Unlock()
+	if e == nil {
+		e = discard{}
+	}
+	pkgEmitter = e
+	currentTask = ""
+}
+
+// Emit sends e to the package emitter. If not set, the event time defaults to now and the
+// event task defaults to the task currently running.
+func Emit(e Event) {
+	pkgMtx.RLock()
+	emitter := pkgEmitter
+	task := currentTask
+	pkgMtx.RUnlock()
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.Task == "" {
+		e.Task = task
+	}
+	emitter.Emit(e)
+}
+
+// TaskStarted emits a TaskStart event and tracks name as the running task so following
+// events are attributed to it.
+func TaskStarted(name string) {
+	pkgMtx.Lock()
+	currentTask = name
+	pkgMtx.Unlock()
+
+	Emit(Event{Type: TaskStart, Task: name})
+}
+
+// TaskEnded emits a TaskEnd event with the task durations taken from a task profiler.
+// The entry of durations keyed by the task name is the task total duration, the rest are subtasks.
+func TaskEnded(name string, durations map[string]time.Duration) {
+	e := Event{Type: TaskEnd, Task: name}
+	for k, v := range durations {
+		if k == name {
+			e.DurationSeconds = v.Seconds()
+			continue
+		}
+		if e.SubtaskDurationsSeconds == nil {
+			e.SubtaskDurationsSeconds = map[string]float64{}
+		}
+		e.SubtaskDurationsSeconds[k] = v.Seconds()
+	}
+	Emit(e)
+
+	pkgMtx.Lock()
+	if currentTask == name {
+		currentTask = ""
+	}
+	pkgMtx.Unlock()
+}
+
+// TaskRestoredFromCheckpoint emits a TaskRestored event.
+func TaskRestoredFromCheckpoint(name string) {
+	Emit(Event{Type: TaskRestored, Task: name})
+}
+
+// TaskFailed emits an Error event for a failed task.
+func TaskFailed(name string, err error) {
+	Emit(Event{Type: Error, Task: name, Error: err.Error()})
+}
+
+// Retried emits a Retry event for a failed attempt that will be retried after wait.
+func Retried(attempt int, err error, wait time.Duration) {
+	e := Event{Type: Retry, Attempt: attempt, WaitSeconds: wait.Seconds()}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	Emit(e)
+}
+
+// ValidationReported emits a Validation event with the result of a validation.
+func ValidationReported(name string, err error, remediation string) {
+	passed := err == nil
+	e := Event{Type: Validation, Name: name, Passed: &passed}
+	if err != nil {
+		e.Error = err.Error()
+		e.Remediation = remediation
+	}
+	Emit(e)
+}
@@ -0,0 +1,92 @@
+package events_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/events"
+)
+
+func readEvents(t *testing.T, out *bytes.Buffer) []events.Event {
+	t.Helper()
+	var got []events.Event
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		e := events.Event{}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("line %q is not a valid json object: %v", line, err)
+		}
+		got = append(got, e)
+	}
+	return got
+}
+
+func TestTaskEventsJSONL(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	events.Set(events.NewJSONLEmitter(out))
+	t.Cleanup(func() { events.Set(nil) })
+
+	events.TaskStarted("bootstrap-cluster-init")
+	events.Retried(1, errors.New("connection refused"), 2*time.Second)
+	events.TaskFailed("bootstrap-cluster-init", errors.New("creating bootstrap cluster"))
+	events.TaskEnded("bootstrap-cluster-init", map[string]time.Duration{
+		"bootstrap-cluster-init": 90 * time.Second,
+		"kind-create":            60 * time.Second,
+	})
+	events.Retried(1, errors.New("timeout"), 0)
+
+	got := readEvents(t, out)
+	g.Expect(got).To(HaveLen(5))
+
+	g.Expect(got[0].Type).To(Equal(events.TaskStart))
+	g.Expect(got[0].Task).To(Equal("bootstrap-cluster-init"))
+	g.Expect(got[0].Time.IsZero()).To(BeFalse())
+
+	g.Expect(got[1].Type).To(Equal(events.Retry))
+	g.Expect(got[1].Task).To(Equal("bootstrap-cluster-init"))
+	g.Expect(got[1].Attempt).To(Equal(1))
+	g.Expect(got[1].WaitSeconds).To(Equal(2.0))
+	g.Expect(got[1].Error).To(Equal("connection refused"))
+
+	g.Expect(got[2].Type).To(Equal(events.Error))
+	g.Expect(got[2].Error).To(Equal("creating bootstrap cluster"))
+
+	g.Expect(got[3].Type).To(Equal(events.TaskEnd))
+	g.Expect(got[3].DurationSeconds).To(Equal(90.0))
+	g.Expect(got[3].SubtaskDurationsSeconds).To(Equal(map[string]float64{"kind-create": 60}))
+
+	g.Expect(got[4].Type).To(Equal(events.Retry))
+	g.Expect(got[4].Task).To(BeEmpty(), "events after a task ends shouldn't be attributed to it")
+}
+
+func TestValidationReported(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	events.Set(events.NewJSONLEmitter(out))
+	t.Cleanup(func() { events.Set(nil) })
+
+	events.ValidationReported("vsphere provider validation", nil, "")
+	events.ValidationReported("docker version", errors.New("too old"), "upgrade docker")
+
+	got := readEvents(t, out)
+	g.Expect(got).To(HaveLen(2))
+	g.Expect(*got[0].Passed).To(BeTrue())
+	g.Expect(got[0].Name).To(Equal("vsphere provider validation"))
+	g.Expect(got[0].Error).To(BeEmpty())
+	g.Expect(*got[1].Passed).To(BeFalse())
+	g.Expect(got[1].Error).To(Equal("too old"))
+	g.Expect(got[1].Remediation).To(Equal("upgrade docker"))
+}
+
+func TestEmitDisabledByDefault(t *testing.T) {
+	events.Set(nil)
+	// Shouldn't panic nor write anywhere.
+	events.TaskStarted("task")
+	events.TaskEnded("task", nil)
+}
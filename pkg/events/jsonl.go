@@ -0,0 +1,26 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// JSONLEmitter writes events to an io.Writer in JSON Lines format, one JSON object per line.
+type JSONLEmitter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONLEmitter returns an emitter that writes events to w.
+func NewJSONLEmitter(w io.Writer) *JSONLEmitter {
+	return &JSONLEmitter{encoder: json.NewEncoder(w)}
+}
+
+// Emit writes e as a single JSON line. Encoding errors are dropped: events are informative
+// and must never make a workflow fail.
+func (j *JSONLEmitter) Emit(e Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_ = j.encoder.Encode(e)
+}
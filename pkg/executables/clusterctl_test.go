@@ -42,6 +42,7 @@ type clusterctlTest struct {
 func newClusterctlTest(t *testing.T) *clusterctlTest {
 	ctrl := gomock.NewController(t)
 	_, writer := test.NewWriter(t)
+	removeClusterDirOnCleanup(t, "cluster-name")
 	reader := files.NewReader()
 	e := mockexecutables.NewMockExecutable(ctrl)
 
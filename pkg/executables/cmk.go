@@ -15,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
@@ -34,6 +35,7 @@ type Cmk struct {
 	writer     filewriter.FileWriter
 	executable Executable
 	configMap  map[string]decoder.CloudStackProfileConfig
+	*retrier.Retrier
 }
 
 type listTemplatesResponse struct {
@@ -413,6 +415,8 @@ func NewCmk(executable Executable, writer filewriter.FileWriter, config *decoder
 		writer:     writer,
 		executable: executable,
 		configMap:  configMap,
+		// Most cmk errors are caused by the request, so only transient errors from the CloudStack API are retried.
+		Retrier: retrier.NewWithTransientErrorsPolicy(1, maxTransientRetries, backOffPeriod, maxBackOffPeriod, retrier.WithMetricsRecorder(retrier.LogMetrics("cmk"))),
 	}, nil
 }
 
@@ -471,7 +475,11 @@ func (c *Cmk) exec(ctx context.Context, profile string, args ...string) (stdout
 	}
 
 	argsWithConfigFile := append([]string{"-c", configFile}, args...)
-	return c.executable.Execute(ctx, argsWithConfigFile...)
+	err = c.RetryWithContext(ctx, func() error {
+		stdout, err = c.executable.Execute(ctx, argsWithConfigFile...)
+		return err
+	})
+	return stdout, err
 }
 
 func (c *Cmk) buildCmkConfigFile(profile string) (configFile string, err error) {
@@ -17,6 +17,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/executables"
 	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 )
 
 const (
@@ -1117,3 +1118,33 @@ func TestCmkGetManagementApiEndpoint(t *testing.T) {
 	_, err = cmk.GetManagementApiEndpoint("xxx")
 	tt.Expect(err).NotTo(BeNil())
 }
+
+func TestCmkRetriesTransientErrors(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+	cmk.Retrier = retrier.NewWithTransientErrorsPolicy(1, 3, 0, 0)
+
+	gomock.InOrder(
+		executable.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("dial tcp 1.1.1.1:8080: connect: connection refused")),
+		executable.EXPECT().Execute(ctx, gomock.Any()).Return(*bytes.NewBufferString("{}"), nil),
+	)
+
+	g.Expect(cmk.CleanupVms(ctx, execConfig.Profiles[0].Name, "cluster", true)).To(Succeed())
+}
+
+func TestCmkDoesNotRetryOtherErrors(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	executable.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("unable to find template"))
+
+	g.Expect(cmk.CleanupVms(ctx, execConfig.Profiles[0].Name, "cluster", true)).To(MatchError(ContainSubstring("unable to find template")))
+}
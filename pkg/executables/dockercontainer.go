@@ -36,7 +36,7 @@ func newDockerContainer(image, workingDir string, mountDirs []string, dockerClie
 		mountDirs:     mountDirs,
 		containerName: containerNamePrefix + strconv.FormatInt(time.Now().UnixNano(), 10),
 		dockerClient:  dockerClient,
-		Retrier:       retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+		Retrier:       retrier.NewWithTransientErrorsPolicy(maxRetries, maxTransientRetries, backOffPeriod, maxBackOffPeriod, retrier.WithMetricsRecorder(retrier.LogMetrics("docker container"))),
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -15,6 +16,7 @@ const (
 	eksaGithubTokenEnv         = "EKSA_GITHUB_TOKEN"
 	githubTokenEnv             = "GITHUB_TOKEN"
 	githubProvider             = "github"
+	gitlabProvider             = "gitlab"
 	gitProvider                = "git"
 	defaultPrivateKeyAlgorithm = "ecdsa"
 )
@@ -64,6 +66,42 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, flux
 	return err
 }
 
+// BootstrapGitlab creates the GitLab project if it doesn’t exist, and commits the toolkit
+// components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
+// If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	c := fluxConfig.Spec
+	params := []string{
+		"bootstrap",
+		gitlabProvider,
+		"--repository", c.Gitlab.Repository,
+		"--owner", c.Gitlab.Owner,
+		"--hostname", gitlab.Hostname(c.Gitlab),
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
+	}
+	params = setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	if c.Gitlab.Personal {
+		params = append(params, "--personal")
+	}
+
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	env := make(map[string]string)
+	env[gitlab.GitlabTokenEnv] = token
+
+	_, err = f.ExecuteWithEnv(ctx, env, params...)
+	if err != nil {
+		return fmt.Errorf("executing flux bootstrap gitlab: %v", err)
+	}
+
+	return err
+}
+
 // BootstrapGit commits the toolkit components manifests to the branch of a Git repository.
 // It then configures the target cluster to synchronize with the repository. If the toolkit components are present on the cluster, the
 // bootstrap command will perform an upgrade if needed.
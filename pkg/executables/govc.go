@@ -58,10 +58,12 @@ type deployOption struct {
 type FolderType string
 
 const (
-	datastore     FolderType = "datastore"
-	vm            FolderType = "vm"
-	maxRetries               = 5
-	backOffPeriod            = 5 * time.Second
+	datastore           FolderType = "datastore"
+	vm                  FolderType = "vm"
+	maxRetries                     = 5
+	maxTransientRetries            = 12
+	backOffPeriod                  = 5 * time.Second
+	maxBackOffPeriod               = time.Minute
 )
 
 type Govc struct {
@@ -81,7 +83,7 @@ func NewGovc(executable Executable, writer filewriter.FileWriter, opts ...GovcOp
 	g := &Govc{
 		writer:       writer,
 		Executable:   executable,
-		Retrier:      retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+		Retrier:      retrier.NewWithTransientErrorsPolicy(maxRetries, maxTransientRetries, backOffPeriod, maxBackOffPeriod, retrier.WithMetricsRecorder(retrier.LogMetrics("govc"))),
 		requiredEnvs: envVars,
 	}
 
@@ -299,6 +301,39 @@ func (g *Govc) GetHardDiskSize(ctx context.Context, vm, datacenter string) (map[
 	return hardDiskMap, nil
 }
 
+type vmInfoResponse struct {
+	VirtualMachines []struct {
+		Config struct {
+			Version string
+		}
+	}
+}
+
+// GetVMHardwareVersion returns the virtual hardware version of the VM, for example 17 for vmx-17.
+func (g *Govc) GetVMHardwareVersion(ctx context.Context, vm, datacenter string) (int, error) {
+	response, err := g.exec(ctx, "vm.info", "-dc", datacenter, "-json", vm)
+	if err != nil {
+		return 0, fmt.Errorf("getting vm info for %s: %v", vm, err)
+	}
+
+	info := &vmInfoResponse{}
+	if err = json.Unmarshal(response.Bytes(), info); err != nil {
+		return 0, fmt.Errorf("unmarshalling vm info for %s: %v", vm, err)
+	}
+
+	if len(info.VirtualMachines) == 0 {
+		return 0, fmt.Errorf("vm %s not found", vm)
+	}
+
+	version := info.VirtualMachines[0].Config.Version
+	hardwareVersion, err := strconv.Atoi(strings.TrimPrefix(version, "vmx-"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid hardware version %s for vm %s", version, vm)
+	}
+
+	return hardwareVersion, nil
+}
+
 func (g *Govc) TemplateHasSnapshot(ctx context.Context, template string) (bool, error) {
 	envMap, err := g.validateAndSetupCreds()
 	if err != nil {
@@ -418,9 +453,16 @@ func (g *Govc) DeployTemplateFromLibrary(ctx context.Context, templateDir, templ
 	return nil
 }
 
-func (g *Govc) ImportTemplate(ctx context.Context, library, ovaURL, name string) error {
+// ImportTemplate imports an OVA into a content library. vCenter pulls the OVA from the URL and,
+// if sha256 is not empty, fails the import when the OVA checksum doesn't match.
+func (g *Govc) ImportTemplate(ctx context.Context, library, ovaURL, name, sha256 string) error {
 	logger.V(4).Info("Importing template", "ova", ovaURL, "templateName", name)
-	if _, err := g.exec(ctx, "library.import", "-k", "-pull", "-n", name, library, ovaURL); err != nil {
+	params := []string{"library.import", "-k", "-pull", "-n", name}
+	if sha256 != "" {
+		params = append(params, "-c", sha256, "-a", "SHA256")
+	}
+	params = append(params, library, ovaURL)
+	if _, err := g.exec(ctx, params...); err != nil {
 		return fmt.Errorf("importing template: %v", err)
 	}
 	return nil
@@ -1114,11 +1156,19 @@ type objectType string
 const virtualMachine objectType = "VirtualMachine"
 
 func (g *Govc) CreateCategoryForVM(ctx context.Context, name string) error {
-	return g.createCategory(ctx, name, []objectType{virtualMachine})
+	return g.createCategory(ctx, name, []objectType{virtualMachine}, false)
 }
 
-func (g *Govc) createCategory(ctx context.Context, name string, objectTypes []objectType) error {
+// CreateMultiValueCategoryForVM creates a tag category for VMs that allows attaching several of its tags to the same VM.
+func (g *Govc) CreateMultiValueCategoryForVM(ctx context.Context, name string) error {
+	return g.createCategory(ctx, name, []objectType{virtualMachine}, true)
+}
+
+func (g *Govc) createCategory(ctx context.Context, name string, objectTypes []objectType, multiValue bool) error {
 	params := []string{"tags.category.create"}
+	if multiValue {
+		params = append(params, "-m")
+	}
 	for _, t := range objectTypes {
 		params = append(params, "-t", string(t))
 	}
@@ -1252,6 +1302,34 @@ func (g *Govc) CreateRole(ctx context.Context, name string, privileges []string)
 	return nil
 }
 
+// GetRolePrivileges returns the privileges of a role.
+func (g *Govc) GetRolePrivileges(ctx context.Context, name string) ([]string, error) {
+	response, err := g.exec(ctx, "role.ls", name)
+	if err != nil {
+		return nil, fmt.Errorf("getting privileges of role %s: %v", name, err)
+	}
+
+	var privileges []string
+	for _, p := range strings.Split(response.String(), "\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			privileges = append(privileges, p)
+		}
+	}
+
+	return privileges, nil
+}
+
+// AddRolePrivileges adds privileges to an existing role.
+func (g *Govc) AddRolePrivileges(ctx context.Context, name string, privileges []string) error {
+	params := append([]string{"role.update", "-a", name}, privileges...)
+
+	if _, err := g.exec(ctx, params...); err != nil {
+		return fmt.Errorf("adding privileges to role %s: %v", name, err)
+	}
+
+	return nil
+}
+
 // SetGroupRoleOnObject sets a role for a given group on target object.
 func (g *Govc) SetGroupRoleOnObject(ctx context.Context, principal string, role string, object string, domain string) error {
 	principal = principal + "@" + domain
@@ -1679,6 +1679,19 @@ func TestCreateCategoryForVMError(t *testing.T) {
 	}
 }
 
+func TestCreateMultiValueCategoryForVMSuccess(t *testing.T) {
+	category := "category"
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.category.create", "-m", "-t", "VirtualMachine", category).Return(*bytes.NewBufferString(""), nil)
+
+	err := g.CreateMultiValueCategoryForVM(ctx, category)
+	if err != nil {
+		t.Fatalf("Govc.CreateMultiValueCategoryForVM() err = %v, want err nil", err)
+	}
+}
+
 func TestImportTemplateSuccess(t *testing.T) {
 	ovaURL := "ovaURL"
 	name := "name"
@@ -1687,7 +1700,21 @@ func TestImportTemplateSuccess(t *testing.T) {
 	_, g, executable, env := setup(t)
 	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.import", "-k", "-pull", "-n", name, templateLibrary, ovaURL).Return(*bytes.NewBufferString(""), nil)
 
-	if err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name); err != nil {
+	if err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name, ""); err != nil {
+		t.Fatalf("Govc.ImportTemplate() err = %v, want err nil", err)
+	}
+}
+
+func TestImportTemplateWithChecksum(t *testing.T) {
+	ovaURL := "ovaURL"
+	name := "name"
+	sha256 := "f8b4a9e8d3c2"
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.import", "-k", "-pull", "-n", name, "-c", sha256, "-a", "SHA256", templateLibrary, ovaURL).Return(*bytes.NewBufferString(""), nil)
+
+	if err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name, sha256); err != nil {
 		t.Fatalf("Govc.ImportTemplate() err = %v, want err nil", err)
 	}
 }
@@ -1700,7 +1727,7 @@ func TestImportTemplateError(t *testing.T) {
 	_, g, executable, env := setup(t)
 	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.import", "-k", "-pull", "-n", name, templateLibrary, ovaURL).Return(bytes.Buffer{}, errors.New("error from execute with env"))
 
-	if err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name); err == nil {
+	if err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name, ""); err == nil {
 		t.Fatal("Govc.ImportTemplate() err = nil, want err not nil")
 	}
 }
@@ -2169,6 +2196,54 @@ func TestGovcRoleExistsError(t *testing.T) {
 	gt.Expect(err).ToNot(BeNil())
 }
 
+func TestGovcGetRolePrivileges(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	role := "EKSAUserRole"
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "role.ls", role).Return(*bytes.NewBufferString("Datastore.Browse\nSystem.Read\n\n"), nil)
+
+	privileges, err := g.GetRolePrivileges(ctx, role)
+	gt := NewWithT(t)
+	gt.Expect(err).To(BeNil())
+	gt.Expect(privileges).To(Equal([]string{"Datastore.Browse", "System.Read"}))
+}
+
+func TestGovcGetRolePrivilegesError(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	role := "EKSAUserRole"
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "role.ls", role).Return(*bytes.NewBufferString(""), errors.New("operation failed"))
+
+	_, err := g.GetRolePrivileges(ctx, role)
+	gt := NewWithT(t)
+	gt.Expect(err).To(MatchError("getting privileges of role EKSAUserRole: operation failed"))
+}
+
+func TestGovcAddRolePrivileges(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	role := "EKSAUserRole"
+	privileges := []string{"Datastore.Browse", "VirtualMachine.Config.AddNewDisk"}
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "role.update", "-a", role, "Datastore.Browse", "VirtualMachine.Config.AddNewDisk").Return(*bytes.NewBufferString(""), nil)
+
+	gt := NewWithT(t)
+	gt.Expect(g.AddRolePrivileges(ctx, role, privileges)).To(Succeed())
+}
+
+func TestGovcAddRolePrivilegesError(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	role := "EKSAUserRole"
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "role.update", "-a", role, "Datastore.Browse").Return(*bytes.NewBufferString(""), errors.New("operation failed"))
+
+	gt := NewWithT(t)
+	gt.Expect(g.AddRolePrivileges(ctx, role, []string{"Datastore.Browse"})).To(MatchError("adding privileges to role EKSAUserRole: operation failed"))
+}
+
 func TestGovcAddUserToGroup(t *testing.T) {
 	ctx := context.Background()
 	_, g, executable, env := setup(t)
@@ -2327,6 +2402,66 @@ func TestGovcGetVMDiskSizeInGBError(t *testing.T) {
 	}
 }
 
+func TestGovcGetVMHardwareVersion(t *testing.T) {
+	datacenter := "SDDC-Datacenter"
+	template := "/SDDC-Datacenter/vm/Templates/ubuntu-2204-kube-v1-31"
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	gt := NewWithT(t)
+
+	response := `{"virtualMachines":[{"config":{"name":"ubuntu-2204-kube-v1-31","version":"vmx-17"}}]}`
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.info", "-dc", datacenter, "-json", template).Return(*bytes.NewBufferString(response), nil)
+
+	version, err := g.GetVMHardwareVersion(ctx, template, datacenter)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(version).To(Equal(17))
+}
+
+func TestGovcGetVMHardwareVersionError(t *testing.T) {
+	datacenter := "SDDC-Datacenter"
+	template := "ubuntu-2204-kube-v1-31"
+	ctx := context.Background()
+
+	tests := []struct {
+		testName string
+		response string
+		govcErr  error
+		wantErr  string
+	}{
+		{
+			testName: "govc error",
+			govcErr:  errors.New("govc failed"),
+			wantErr:  "getting vm info for ubuntu-2204-kube-v1-31: govc failed",
+		},
+		{
+			testName: "invalid json",
+			response: "not json",
+			wantErr:  "unmarshalling vm info for ubuntu-2204-kube-v1-31: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			testName: "vm not found",
+			response: `{"virtualMachines":null}`,
+			wantErr:  "vm ubuntu-2204-kube-v1-31 not found",
+		},
+		{
+			testName: "invalid version",
+			response: `{"virtualMachines":[{"config":{"version":"unknown"}}]}`,
+			wantErr:  "invalid hardware version unknown for vm ubuntu-2204-kube-v1-31",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			_, g, executable, env := setup(t)
+			gt := NewWithT(t)
+			executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.info", "-dc", datacenter, "-json", template).Return(*bytes.NewBufferString(tt.response), tt.govcErr)
+
+			_, err := g.GetVMHardwareVersion(ctx, template, datacenter)
+			gt.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
+
 func TestGovcGetHardDiskSize(t *testing.T) {
 	datacenter := "SDDC-Datacenter"
 	template := "bottlerocket-kube-v1-21"
@@ -30,6 +30,7 @@ func TestKindCreateBootstrapClusterSuccess(t *testing.T) {
 	_, writer := test.NewWriter(t)
 
 	clusterName := "test_cluster"
+	removeClusterDirOnCleanup(t, clusterName)
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = clusterName
 		s.VersionsBundles["1.19"] = versionBundle
@@ -153,6 +154,7 @@ func TestKindCreateBootstrapClusterSuccessWithRegistryMirror(t *testing.T) {
 	_, writer := test.NewWriter(t)
 
 	clusterName := "test_cluster"
+	removeClusterDirOnCleanup(t, clusterName)
 	eksClusterName := "test_cluster-eks-a-cluster"
 	kubeConfigFile := "test_cluster.kind.kubeconfig"
 	registryMirror := "registry-mirror.test"
@@ -331,6 +333,8 @@ func TestKindCreateBootstrapClusterExecutableError(t *testing.T) {
 		s.VersionsBundles["1.19"] = versionBundle
 	})
 
+	removeClusterDirOnCleanup(t, clusterSpec.Cluster.Name)
+
 	ctx := context.Background()
 	_, writer := test.NewWriter(t)
 
@@ -367,6 +371,8 @@ func TestKindCreateBootstrapClusterExecutableWithRegistryMirrorError(t *testing.
 		t.Fatal(err)
 	}
 
+	removeClusterDirOnCleanup(t, clusterSpec.Cluster.Name)
+
 	ctx := context.Background()
 	_, writer := test.NewWriter(t)
 
@@ -645,3 +651,13 @@ func TestKindCreateBootstrapClusterAuditPolicyError(t *testing.T) {
 		t.Fatal("Expected an error when CreateAuditPolicy fails, but got nil")
 	}
 }
+
+// removeClusterDirOnCleanup deletes the folder the executables write the generated files of the cluster to.
+// It's relative to the working directory, since the expected configs reference the files with relative paths.
+func removeClusterDirOnCleanup(t *testing.T, clusterName string) {
+	t.Cleanup(func() {
+		if err := os.RemoveAll(clusterName); err != nil {
+			t.Errorf("removing generated files of cluster %s: %v", clusterName, err)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package executables
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -64,3 +65,79 @@ func (k *Sonobuoy) GetResults(ctx context.Context, contextName string, args ...s
 	}
 	return command + output.String(), err
 }
+
+// SonobuoyPluginProgress is the progress reported by a running sonobuoy plugin.
+type SonobuoyPluginProgress struct {
+	Message   string   `json:"msg"`
+	Total     int      `json:"total"`
+	Completed int      `json:"completed"`
+	Failures  []string `json:"failures"`
+}
+
+// SonobuoyPluginStatus is the status of a sonobuoy plugin.
+type SonobuoyPluginStatus struct {
+	Plugin       string                  `json:"plugin"`
+	Node         string                  `json:"node"`
+	Status       string                  `json:"status"`
+	ResultStatus string                  `json:"result-status"`
+	Progress     *SonobuoyPluginProgress `json:"progress,omitempty"`
+}
+
+// SonobuoyStatus is the status of a sonobuoy run, as reported by sonobuoy status.
+type SonobuoyStatus struct {
+	Status  string                 `json:"status"`
+	Plugins []SonobuoyPluginStatus `json:"plugins"`
+}
+
+// Done returns true if the run has finished, either because it completed or failed.
+func (s *SonobuoyStatus) Done() bool {
+	return s.Status == "complete" || s.Status == "failed"
+}
+
+// Version returns the version of the sonobuoy binary.
+func (k *Sonobuoy) Version(ctx context.Context) (string, error) {
+	output, err := k.Execute(ctx, "version", "--short")
+	if err != nil {
+		return "", fmt.Errorf("executing sonobuoy version: %v", err)
+	}
+	return strings.TrimSpace(output.String()), nil
+}
+
+// Start starts a sonobuoy run in the cluster, without waiting for it to finish.
+func (k *Sonobuoy) Start(ctx context.Context, kubeconfig string, args ...string) error {
+	executionArgs := append([]string{"run", "--kubeconfig", kubeconfig}, args...)
+	if _, err := k.Execute(ctx, executionArgs...); err != nil {
+		return fmt.Errorf("executing sonobuoy run: %v", err)
+	}
+	return nil
+}
+
+// Status returns the status of the sonobuoy run in the cluster.
+func (k *Sonobuoy) Status(ctx context.Context, kubeconfig string) (*SonobuoyStatus, error) {
+	output, err := k.Execute(ctx, "status", "--json", "--kubeconfig", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("executing sonobuoy status: %v", err)
+	}
+	status := &SonobuoyStatus{}
+	if err := json.Unmarshal(output.Bytes(), status); err != nil {
+		return nil, fmt.Errorf("parsing sonobuoy status: %v", err)
+	}
+	return status, nil
+}
+
+// Retrieve downloads the results of the sonobuoy run to a folder and returns the path of the results tarball.
+func (k *Sonobuoy) Retrieve(ctx context.Context, kubeconfig, dir string) (string, error) {
+	output, err := k.Execute(ctx, "retrieve", dir, "--kubeconfig", kubeconfig)
+	if err != nil {
+		return "", fmt.Errorf("executing sonobuoy retrieve: %v", err)
+	}
+	return strings.TrimSpace(output.String()), nil
+}
+
+// Delete removes the sonobuoy resources from the cluster and waits for them to be deleted.
+func (k *Sonobuoy) Delete(ctx context.Context, kubeconfig string) error {
+	if _, err := k.Execute(ctx, "delete", "--wait", "--kubeconfig", kubeconfig); err != nil {
+		return fmt.Errorf("executing sonobuoy delete: %v", err)
+	}
+	return nil
+}
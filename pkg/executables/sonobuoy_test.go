@@ -0,0 +1,92 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+const sonobuoyKubeconfig = "mgmt/mgmt-eks-a-cluster.kubeconfig"
+
+func newSonobuoy(t *testing.T) (*executables.Sonobuoy, *mockexecutables.MockExecutable) {
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	return executables.NewSonobuoy(executable), executable
+}
+
+func TestSonobuoyVersion(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	sonobuoy, executable := newSonobuoy(t)
+	executable.EXPECT().Execute(ctx, "version", "--short").Return(*bytes.NewBufferString("v0.57.1\n"), nil)
+
+	g.Expect(sonobuoy.Version(ctx)).To(Equal("v0.57.1"))
+}
+
+func TestSonobuoyStart(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	sonobuoy, executable := newSonobuoy(t)
+	executable.EXPECT().Execute(ctx, "run", "--kubeconfig", sonobuoyKubeconfig, "--mode", "quick").Return(bytes.Buffer{}, errors.New("no cluster"))
+
+	g.Expect(sonobuoy.Start(ctx, sonobuoyKubeconfig, "--mode", "quick")).To(MatchError("executing sonobuoy run: no cluster"))
+}
+
+func TestSonobuoyStatus(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	sonobuoy, executable := newSonobuoy(t)
+	output := `{"plugins":[{"plugin":"e2e","node":"global","status":"running","result-status":"","progress":{"msg":"PASSED","total":400,"completed":12,"failures":["test a"]}}],"status":"running"}`
+	executable.EXPECT().Execute(ctx, "status", "--json", "--kubeconfig", sonobuoyKubeconfig).Return(*bytes.NewBufferString(output), nil)
+
+	status, err := sonobuoy.Status(ctx, sonobuoyKubeconfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status.Done()).To(BeFalse())
+	g.Expect(status.Plugins).To(Equal([]executables.SonobuoyPluginStatus{
+		{
+			Plugin: "e2e",
+			Node:   "global",
+			Status: "running",
+			Progress: &executables.SonobuoyPluginProgress{
+				Message:   "PASSED",
+				Total:     400,
+				Completed: 12,
+				Failures:  []string{"test a"},
+			},
+		},
+	}))
+}
+
+func TestSonobuoyStatusInvalidOutput(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	sonobuoy, executable := newSonobuoy(t)
+	executable.EXPECT().Execute(ctx, "status", "--json", "--kubeconfig", sonobuoyKubeconfig).Return(*bytes.NewBufferString("sonobuoy not running"), nil)
+
+	_, err := sonobuoy.Status(ctx, sonobuoyKubeconfig)
+	g.Expect(err).To(MatchError(ContainSubstring("parsing sonobuoy status")))
+}
+
+func TestSonobuoyRetrieve(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	sonobuoy, executable := newSonobuoy(t)
+	executable.EXPECT().Execute(ctx, "retrieve", "results", "--kubeconfig", sonobuoyKubeconfig).Return(*bytes.NewBufferString("results/202410171200_sonobuoy.tar.gz\n"), nil)
+
+	g.Expect(sonobuoy.Retrieve(ctx, sonobuoyKubeconfig, "results")).To(Equal("results/202410171200_sonobuoy.tar.gz"))
+}
+
+func TestSonobuoyDelete(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	sonobuoy, executable := newSonobuoy(t)
+	executable.EXPECT().Execute(ctx, "delete", "--wait", "--kubeconfig", sonobuoyKubeconfig)
+
+	g.Expect(sonobuoy.Delete(ctx, sonobuoyKubeconfig)).To(Succeed())
+}
@@ -21,8 +21,11 @@ import (
 	"github.com/aws/eks-anywhere/pkg/git/gogithub"
 	"github.com/aws/eks-anywhere/pkg/git/providers/codecommit"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 )
 
+const gitlabBasicAuthUser = "oauth2"
+
 type GitTools struct {
 	Provider            git.ProviderClient
 	Client              git.Client
@@ -54,6 +57,19 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 		gitAuth = &http.BasicAuth{Password: githubToken, Username: fluxConfig.Spec.Github.Owner}
 		repo = fluxConfig.Spec.Github.Repository
 		repoUrl = github.RepoUrl(fluxConfig.Spec.Github.Owner, repo)
+	case fluxConfig.Spec.Gitlab != nil:
+		gitlabToken, err := gitlab.GetGitlabAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		auth := git.TokenAuth{Token: gitlabToken, Username: fluxConfig.Spec.Gitlab.Owner}
+		tools.Provider = gitlab.New(fluxConfig.Spec.Gitlab, auth)
+
+		// GitLab accepts any user name with a personal access token as password over https.
+		gitAuth = &http.BasicAuth{Password: gitlabToken, Username: gitlabBasicAuthUser}
+		repo = fluxConfig.Spec.Gitlab.Repository
+		repoUrl = gitlab.RepoUrl(fluxConfig.Spec.Gitlab)
 	case fluxConfig.Spec.Git != nil:
 		privateKeyFile := os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		privateKeyPassphrase := os.Getenv(config.EksaGitPassphraseTokenEnv)
@@ -0,0 +1,343 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName    = "gitlab"
+	EksaGitlabTokenEnv = "EKSA_GITLAB_TOKEN"
+	GitlabTokenEnv     = "GITLAB_TOKEN"
+	apiPath            = "/api/v4"
+	tokenHeader        = "PRIVATE-TOKEN"
+	// apiScope is the token scope required to create projects and add deploy keys.
+	apiScope = "api"
+)
+
+type gitlabProvider struct {
+	httpClient *http.Client
+	config     *v1alpha1.GitlabProviderConfig
+	auth       git.TokenAuth
+	baseURL    string
+}
+
+// Opt configures the GitLab provider.
+type Opt func(*gitlabProvider)
+
+// WithHTTPClient sets the http client used to call the GitLab API.
+func WithHTTPClient(client *http.Client) Opt {
+	return func(g *gitlabProvider) {
+		g.httpClient = client
+	}
+}
+
+// New returns a git provider for the GitLab instance in the config. It talks to the GitLab REST API v4,
+// so it works with gitlab.com and with self-managed instances.
+func New(config *v1alpha1.GitlabProviderConfig, auth git.TokenAuth, opts ...Opt) *gitlabProvider {
+	g := &gitlabProvider{
+		httpClient: &http.Client{},
+		config:     config,
+		auth:       auth,
+		baseURL:    "https://" + Hostname(config) + apiPath,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+type project struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+	Namespace         struct {
+		ID       int    `json:"id"`
+		Kind     string `json:"kind"`
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+}
+
+func (p *project) repository() *git.Repository {
+	r := &git.Repository{
+		Name:     p.Name,
+		Owner:    p.Namespace.FullPath,
+		CloneUrl: p.HTTPURLToRepo,
+	}
+	if p.Namespace.Kind == "group" {
+		r.Organization = p.Namespace.FullPath
+	}
+
+	return r
+}
+
+type namespace struct {
+	ID       int    `json:"id"`
+	Kind     string `json:"kind"`
+	FullPath string `json:"full_path"`
+}
+
+// apiError is returned when the GitLab API responds with an unexpected status.
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	if e.message == "" {
+		return fmt.Sprintf("gitlab API returned %d %s", e.status, http.StatusText(e.status))
+	}
+	return fmt.Sprintf("gitlab API returned %d %s: %s", e.status, http.StatusText(e.status), e.message)
+}
+
+func isNotFound(err error) bool {
+	e, ok := err.(*apiError)
+	return ok && e.status == http.StatusNotFound
+}
+
+// CreateRepo creates an empty GitLab project. Group projects are created in the namespace with the full
+// path of the owner, so subgroups are supported. The project must be initialized locally or a file must be
+// added to it before it can be successfully cloned.
+func (g *gitlabProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new GitLab project", "repo", opts.Name, "owner", opts.Owner)
+	visibility := "public"
+	if opts.Privacy {
+		visibility = "private"
+	}
+	body := map[string]interface{}{
+		"name":                   opts.Name,
+		"path":                   opts.Name,
+		"description":            opts.Description,
+		"visibility":             visibility,
+		"initialize_with_readme": opts.AutoInit,
+	}
+
+	if !opts.Personal {
+		ns := &namespace{}
+		if err := g.do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(opts.Owner), nil, ns); err != nil {
+			return nil, fmt.Errorf("failed to find GitLab group %s: %v", opts.Owner, err)
+		}
+		body["namespace_id"] = ns.ID
+	}
+
+	p := &project{}
+	if err := g.do(ctx, http.MethodPost, "/projects", body, p); err != nil {
+		return nil, fmt.Errorf("failed to create new GitLab project %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new GitLab project", "repo", p.PathWithNamespace)
+
+	return p.repository(), nil
+}
+
+// GetRepo describes the remote repository in the config.
+// If the repo does not exist, a nil repo is returned.
+func (g *gitlabProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	logger.V(3).Info("Describing GitLab project", "name", g.config.Repository, "owner", g.config.Owner)
+	p := &project{}
+	err := g.do(ctx, http.MethodGet, projectPath(g.config.Owner, g.config.Repository), nil, p)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", g.config.Repository, err)
+	}
+
+	return p.repository(), nil
+}
+
+// DeleteRepo deletes a GitLab project.
+func (g *gitlabProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	if err := g.do(ctx, http.MethodDelete, projectPath(opts.Owner, opts.Repository), nil, nil); err != nil {
+		return fmt.Errorf("deleting GitLab project %s: %v", path.Join(opts.Owner, opts.Repository), err)
+	}
+	return nil
+}
+
+// AddDeployKeyToRepo adds a deploy key to a GitLab project.
+func (g *gitlabProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	logger.V(3).Info("Adding deploy key to repository", "repository", opts.Repository, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"title":    opts.Title,
+		"key":      opts.Key,
+		"can_push": !opts.ReadOnly,
+	}
+	if err := g.do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Repository)+"/deploy_keys", body, nil); err != nil {
+		return fmt.Errorf("adding deploy key to repo: %v", err)
+	}
+	return nil
+}
+
+// Validate checks the token is valid and has the api scope, and that the authenticated user is the owner of
+// a personal repository or has access to the group of a group repository.
+func (g *gitlabProvider) Validate(ctx context.Context) error {
+	user := struct {
+		Username string `json:"username"`
+	}{}
+	if err := g.do(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+		return fmt.Errorf("authenticating with GitLab %s: %v", Hostname(g.config), err)
+	}
+
+	token := struct {
+		Scopes []string `json:"scopes"`
+	}{}
+	if err := g.do(ctx, http.MethodGet, "/personal_access_tokens/self", nil, &token); err != nil {
+		return fmt.Errorf("getting GitLab access token scopes: %v", err)
+	}
+	if !contains(token.Scopes, apiScope) {
+		return fmt.Errorf("gitlab access token must have the %s scope, it has %s", apiScope, strings.Join(token.Scopes, ","))
+	}
+	logger.MarkPass("GitLab access token has the required api scope")
+
+	if g.config.Personal {
+		if !strings.EqualFold(g.config.Owner, user.Username) {
+			return fmt.Errorf("the authenticated GitLab user and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", g.config.Owner, g.config.Owner)
+		}
+		return nil
+	}
+
+	if err := g.do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(g.config.Owner), nil, &namespace{}); err != nil {
+		return fmt.Errorf("the authenticated GitLab user doesn't have proper access to group %s, %v", g.config.Owner, err)
+	}
+
+	return nil
+}
+
+// PathExists checks if a path exists in the remote repository. If the owner, repository or branch doesn't exist,
+// it returns false and no error.
+func (g *gitlabProvider) PathExists(ctx context.Context, owner, repo, branch, p string) (bool, error) {
+	query := url.Values{}
+	query.Set("ref", branch)
+	query.Set("path", p)
+	query.Set("per_page", "1")
+
+	var tree []interface{}
+	err := g.do(ctx, http.MethodGet, projectPath(owner, repo)+"/repository/tree?"+query.Encode(), nil, &tree)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitlab repository: %v", p, err)
+	}
+	if len(tree) > 0 {
+		return true, nil
+	}
+
+	// The tree of a file is empty, so check for a file with that path.
+	filePath := projectPath(owner, repo) + "/repository/files/" + url.PathEscape(p) + "?ref=" + url.QueryEscape(branch)
+	err = g.do(ctx, http.MethodHead, filePath, nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitlab repository: %v", p, err)
+	}
+
+	return true, nil
+}
+
+func (g *gitlabProvider) do(ctx context.Context, method, resource string, body, obj interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.baseURL+resource, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(tokenHeader, g.auth.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		e := struct {
+			Message interface{} `json:"message"`
+			Error   string      `json:"error"`
+		}{}
+		_ = json.Unmarshal(respBody, &e)
+		message := e.Error
+		if e.Message != nil {
+			message = fmt.Sprint(e.Message)
+		}
+		return &apiError{status: resp.StatusCode, message: message}
+	}
+
+	if obj == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(respBody, obj)
+}
+
+// projectPath returns the API path of a project. GitLab identifies projects by their url encoded full path,
+// which includes the groups and subgroups.
+func projectPath(owner, repo string) string {
+	return "/projects/" + url.PathEscape(path.Join(owner, repo))
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Hostname returns the host of the GitLab instance in the config.
+func Hostname(config *v1alpha1.GitlabProviderConfig) string {
+	if config.Hostname == "" {
+		return v1alpha1.DefaultGitlabHostname
+	}
+	return config.Hostname
+}
+
+// RepoUrl returns the https clone url of a GitLab repository.
+func RepoUrl(config *v1alpha1.GitlabProviderConfig) string {
+	return fmt.Sprintf("https://%s/%s/%s.git", Hostname(config), config.Owner, config.Repository)
+}
+
+// GetGitlabAccessTokenFromEnv returns the GitLab access token from the EKSA_GITLAB_TOKEN environment variable.
+// It also sets GITLAB_TOKEN, which is the variable flux reads the token from.
+func GetGitlabAccessTokenFromEnv() (string, error) {
+	val, ok := os.LookupEnv(EksaGitlabTokenEnv)
+	if !ok || len(val) == 0 {
+		return "", fmt.Errorf("gitlab access token environment variable %s is invalid; could not get var from environment", EksaGitlabTokenEnv)
+	}
+
+	if err := os.Setenv(GitlabTokenEnv, val); err != nil {
+		return "", fmt.Errorf("unable to set %s: %v", GitlabTokenEnv, err)
+	}
+
+	return val, nil
+}
@@ -0,0 +1,425 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+)
+
+const testToken = "glpat-test"
+
+// fakeGitlab is a minimal GitLab API v4 with a single user, a tree of groups and the projects in them.
+type fakeGitlab struct {
+	username string
+	scopes   []string
+	groups   map[string]int
+
+	mu         sync.Mutex
+	projects   map[string]map[string]interface{}
+	files      map[string][]string
+	deployKeys map[string][]map[string]interface{}
+	nextID     int
+}
+
+func newFakeGitlab() *fakeGitlab {
+	return &fakeGitlab{
+		username:   "janedoe",
+		scopes:     []string{"api", "read_user"},
+		groups:     map[string]int{"platform": 10, "platform/clusters": 11},
+		projects:   map[string]map[string]interface{}{},
+		files:      map[string][]string{},
+		deployKeys: map[string][]map[string]interface{}{},
+		nextID:     100,
+	}
+}
+
+func (f *fakeGitlab) addProject(fullPath string, files ...string) {
+	i := strings.LastIndex(fullPath, "/")
+	ns, name := fullPath[:i], fullPath[i+1:]
+	kind := "group"
+	if ns == f.username {
+		kind = "user"
+	}
+	f.nextID++
+	f.projects[fullPath] = map[string]interface{}{
+		"id":                  f.nextID,
+		"name":                name,
+		"path_with_namespace": fullPath,
+		"http_url_to_repo":    "https://gitlab.example.com/" + fullPath + ".git",
+		"namespace":           map[string]interface{}{"kind": kind, "full_path": ns},
+	}
+	f.files[fullPath] = files
+}
+
+func (f *fakeGitlab) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != testToken {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "401 Unauthorized"})
+			return
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		// Projects and namespaces are identified by their url encoded full path, so the raw path
+		// must be used to split the resource from its subresources.
+		segments := strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), "/api/v4/"), "/")
+		switch {
+		case r.Method == http.MethodGet && segments[0] == "user":
+			writeJSON(w, http.StatusOK, map[string]string{"username": f.username})
+		case r.Method == http.MethodGet && strings.Join(segments, "/") == "personal_access_tokens/self":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"scopes": f.scopes})
+		case r.Method == http.MethodGet && segments[0] == "namespaces" && len(segments) == 2:
+			id, ok := f.groups[unescape(t, segments[1])]
+			if !ok {
+				writeJSON(w, http.StatusNotFound, map[string]string{"message": "404 Namespace Not Found"})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": id, "kind": "group", "full_path": unescape(t, segments[1])})
+		case r.Method == http.MethodPost && segments[0] == "projects" && len(segments) == 1:
+			f.createProject(w, r)
+		case segments[0] == "projects" && len(segments) >= 2:
+			f.handleProject(t, w, r, unescape(t, segments[1]), segments[2:])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func (f *fakeGitlab) createProject(w http.ResponseWriter, r *http.Request) {
+	body := struct {
+		Name        string `json:"name"`
+		NamespaceID int    `json:"namespace_id"`
+		Visibility  string `json:"visibility"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	ns := f.username
+	if body.NamespaceID != 0 {
+		for path, id := range f.groups {
+			if id == body.NamespaceID {
+				ns = path
+			}
+		}
+	}
+	fullPath := ns + "/" + body.Name
+	if _, ok := f.projects[fullPath]; ok {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"message": map[string][]string{"name": {"has already been taken"}}})
+		return
+	}
+	f.addProject(fullPath)
+	f.projects[fullPath]["visibility"] = body.Visibility
+	writeJSON(w, http.StatusCreated, f.projects[fullPath])
+}
+
+func (f *fakeGitlab) handleProject(t *testing.T, w http.ResponseWriter, r *http.Request, fullPath string, sub []string) {
+	p, ok := f.projects[fullPath]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "404 Project Not Found"})
+		return
+	}
+
+	switch {
+	case len(sub) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, p)
+	case len(sub) == 0 && r.Method == http.MethodDelete:
+		delete(f.projects, fullPath)
+		writeJSON(w, http.StatusAccepted, map[string]string{"message": "202 Accepted"})
+	case len(sub) == 1 && sub[0] == "deploy_keys" && r.Method == http.MethodPost:
+		key := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&key); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.deployKeys[fullPath] = append(f.deployKeys[fullPath], key)
+		writeJSON(w, http.StatusCreated, key)
+	case len(sub) == 2 && sub[0] == "repository" && sub[1] == "tree":
+		var tree []map[string]string
+		dir := r.URL.Query().Get("path")
+		for _, file := range f.files[fullPath] {
+			if strings.HasPrefix(file, dir+"/") {
+				tree = append(tree, map[string]string{"path": file})
+			}
+		}
+		if tree == nil {
+			// GitLab returns an empty tree for files and an error for paths that don't exist.
+			if !contains(f.files[fullPath], dir) {
+				writeJSON(w, http.StatusNotFound, map[string]string{"message": "404 Tree Not Found"})
+				return
+			}
+			tree = []map[string]string{}
+		}
+		writeJSON(w, http.StatusOK, tree)
+	case len(sub) == 3 && sub[0] == "repository" && sub[1] == "files" && r.Method == http.MethodHead:
+		if !contains(f.files[fullPath], unescape(t, sub[2])) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func unescape(t *testing.T, s string) string {
+	t.Helper()
+	out, err := url.PathUnescape(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func newProvider(t *testing.T, f *fakeGitlab, config *v1alpha1.GitlabProviderConfig) git.ProviderClient {
+	t.Helper()
+	server := httptest.NewTLSServer(f.handler(t))
+	t.Cleanup(server.Close)
+
+	config.Hostname = server.Listener.Addr().String()
+	return gitlab.New(config, git.TokenAuth{Token: testToken, Username: config.Owner}, gitlab.WithHTTPClient(server.Client()))
+}
+
+func TestGetRepo(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeGitlab()
+	f.addProject("platform/clusters/flux-fleet")
+	p := newProvider(t, f, &v1alpha1.GitlabProviderConfig{Owner: "platform/clusters", Repository: "flux-fleet"})
+
+	repo, err := p.GetRepo(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "flux-fleet",
+		Owner:        "platform/clusters",
+		Organization: "platform/clusters",
+		CloneUrl:     "https://gitlab.example.com/platform/clusters/flux-fleet.git",
+	}))
+}
+
+func TestGetRepoDoesNotExist(t *testing.T) {
+	g := NewWithT(t)
+	p := newProvider(t, newFakeGitlab(), &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "flux-fleet"})
+
+	repo, err := p.GetRepo(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestGetRepoUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeGitlab()
+	server := httptest.NewTLSServer(f.handler(t))
+	t.Cleanup(server.Close)
+	config := &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "flux-fleet", Hostname: server.Listener.Addr().String()}
+	p := gitlab.New(config, git.TokenAuth{Token: "wrong"}, gitlab.WithHTTPClient(server.Client()))
+
+	_, err := p.GetRepo(context.Background())
+	g.Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+}
+
+func TestCreateRepoSubgroup(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeGitlab()
+	p := newProvider(t, f, &v1alpha1.GitlabProviderConfig{Owner: "platform/clusters", Repository: "flux-fleet"})
+
+	repo, err := p.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "flux-fleet", Owner: "platform/clusters", Privacy: true})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo.Owner).To(Equal("platform/clusters"))
+	g.Expect(f.projects).To(HaveKey("platform/clusters/flux-fleet"))
+	g.Expect(f.projects["platform/clusters/flux-fleet"]).To(HaveKeyWithValue("visibility", "private"))
+}
+
+func TestCreateRepoPersonal(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeGitlab()
+	p := newProvider(t, f, &v1alpha1.GitlabProviderConfig{Owner: "janedoe", Repository: "flux-fleet", Personal: true})
+
+	repo, err := p.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "flux-fleet", Owner: "janedoe", Personal: true})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo.Owner).To(Equal("janedoe"))
+	g.Expect(repo.Organization).To(BeEmpty())
+	g.Expect(f.projects).To(HaveKey("janedoe/flux-fleet"))
+}
+
+func TestCreateRepoGroupNotFound(t *testing.T) {
+	g := NewWithT(t)
+	p := newProvider(t, newFakeGitlab(), &v1alpha1.GitlabProviderConfig{Owner: "missing", Repository: "flux-fleet"})
+
+	_, err := p.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "flux-fleet", Owner: "missing"})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to find GitLab group missing")))
+}
+
+func TestCreateRepoAlreadyExists(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeGitlab()
+	f.addProject("platform/flux-fleet")
+	p := newProvider(t, f, &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "flux-fleet"})
+
+	_, err := p.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "flux-fleet", Owner: "platform"})
+	g.Expect(err).To(MatchError(ContainSubstring("has already been taken")))
+}
+
+func TestAddDeployKeyToRepo(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeGitlab()
+	f.addProject("platform/clusters/flux-fleet")
+	p := newProvider(t, f, &v1alpha1.GitlabProviderConfig{Owner: "platform/clusters", Repository: "flux-fleet"})
+
+	err := p.AddDeployKeyToRepo(context.Background(), git.AddDeployKeyOpts{
+		Owner:      "platform/clusters",
+		Repository: "flux-fleet",
+		Key:        "ssh-ed25519 AAAA",
+		Title:      "flux",
+		ReadOnly:   true,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(f.deployKeys["platform/clusters/flux-fleet"]).To(ConsistOf(map[string]interface{}{
+		"title":    "flux",
+		"key":      "ssh-ed25519 AAAA",
+		"can_push": false,
+	}))
+}
+
+func TestDeleteRepo(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeGitlab()
+	f.addProject("platform/flux-fleet")
+	p := newProvider(t, f, &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "flux-fleet"})
+
+	g.Expect(p.DeleteRepo(context.Background(), git.DeleteRepoOpts{Owner: "platform", Repository: "flux-fleet"})).To(Succeed())
+	g.Expect(f.projects).To(BeEmpty())
+}
+
+func TestPathExists(t *testing.T) {
+	f := newFakeGitlab()
+	f.addProject("platform/clusters/flux-fleet", "clusters/mgmt/eksa-system/eksa-cluster.yaml", "README.md")
+	p := newProvider(t, f, &v1alpha1.GitlabProviderConfig{Owner: "platform/clusters", Repository: "flux-fleet"})
+
+	tests := []struct {
+		name  string
+		owner string
+		path  string
+		want  bool
+	}{
+		{name: "directory", owner: "platform/clusters", path: "clusters/mgmt", want: true},
+		{name: "file", owner: "platform/clusters", path: "README.md", want: true},
+		{name: "missing path", owner: "platform/clusters", path: "clusters/workload", want: false},
+		{name: "missing project", owner: "platform", path: "clusters/mgmt", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			exists, err := p.PathExists(context.Background(), tt.owner, "flux-fleet", "main", tt.path)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(exists).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *v1alpha1.GitlabProviderConfig
+		scopes  []string
+		wantErr string
+	}{
+		{
+			name:   "group",
+			config: &v1alpha1.GitlabProviderConfig{Owner: "platform/clusters", Repository: "flux-fleet"},
+		},
+		{
+			name:   "personal",
+			config: &v1alpha1.GitlabProviderConfig{Owner: "JaneDoe", Repository: "flux-fleet", Personal: true},
+		},
+		{
+			name:    "personal owner is not the token user",
+			config:  &v1alpha1.GitlabProviderConfig{Owner: "johndoe", Repository: "flux-fleet", Personal: true},
+			wantErr: "the authenticated GitLab user and owner johndoe specified in the EKS-A gitops spec don't match",
+		},
+		{
+			name:    "no access to group",
+			config:  &v1alpha1.GitlabProviderConfig{Owner: "platform/other", Repository: "flux-fleet"},
+			wantErr: "the authenticated GitLab user doesn't have proper access to group platform/other",
+		},
+		{
+			name:    "token without api scope",
+			config:  &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "flux-fleet"},
+			scopes:  []string{"read_repository"},
+			wantErr: "gitlab access token must have the api scope, it has read_repository",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			f := newFakeGitlab()
+			if tt.scopes != nil {
+				f.scopes = tt.scopes
+			}
+			p := newProvider(t, f, tt.config)
+
+			err := p.Validate(context.Background())
+			if tt.wantErr == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(gitlab.RepoUrl(&v1alpha1.GitlabProviderConfig{Owner: "platform/clusters", Repository: "flux-fleet"})).To(
+		Equal("https://gitlab.com/platform/clusters/flux-fleet.git"),
+	)
+	g.Expect(gitlab.RepoUrl(&v1alpha1.GitlabProviderConfig{Owner: "janedoe", Repository: "flux-fleet", Hostname: "gitlab.example.com"})).To(
+		Equal("https://gitlab.example.com/janedoe/flux-fleet.git"),
+	)
+}
+
+func TestGetGitlabAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "glpat-abc")
+	t.Setenv(gitlab.GitlabTokenEnv, "")
+
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(token).To(Equal("glpat-abc"))
+	g.Expect(os.Getenv(gitlab.GitlabTokenEnv)).To(Equal("glpat-abc"))
+}
+
+func TestGetGitlabAccessTokenFromEnvMissing(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "")
+
+	_, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring(gitlab.EksaGitlabTokenEnv)))
+}
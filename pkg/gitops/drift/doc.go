@@ -0,0 +1,3 @@
+// Package drift detects when the EKS-A objects in a cluster no longer match the cluster config
+// stored in its GitOps repository, for example after they are edited with kubectl.
+package drift
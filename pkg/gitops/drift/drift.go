@@ -0,0 +1,152 @@
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	unstructuredutil "github.com/aws/eks-anywhere/pkg/utils/unstructured"
+)
+
+// FieldDrift is a field whose value in the cluster doesn't match the value in git.
+type FieldDrift struct {
+	// Path is the dot separated path to the field, with list indexes in brackets.
+	Path string      `json:"path"`
+	Git  interface{} `json:"git"`
+	Live interface{} `json:"live"`
+}
+
+// ObjectDrift groups all the drifted fields of an object.
+type ObjectDrift struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Missing is true when the object is in git but doesn't exist in the cluster.
+	Missing bool         `json:"missing,omitempty"`
+	Fields  []FieldDrift `json:"fields,omitempty"`
+}
+
+// Report contains the objects in the cluster that drifted from git.
+type Report struct {
+	Objects []ObjectDrift `json:"objects"`
+}
+
+// HasDrift returns true if any object drifted from git.
+func (r *Report) HasDrift() bool {
+	return len(r.Objects) > 0
+}
+
+// Compare reads the live version of each object in a multi-document cluster config from git and returns
+// the fields of their spec that don't match. Only the fields set in git are compared, since the live
+// objects also contain the defaults set by the webhooks. Objects without a namespace are looked up
+// in defaultNamespace.
+func Compare(ctx context.Context, c client.Reader, gitConfig []byte, defaultNamespace string) (*Report, error) {
+	objs, err := unstructuredutil.YamlToUnstructured(gitConfig)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cluster config from git: %v", err)
+	}
+
+	report := &Report{Objects: []ObjectDrift{}}
+	for i := range objs {
+		o := &objs[i]
+		if o.GetNamespace() == "" {
+			o.SetNamespace(defaultNamespace)
+		}
+
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(o.GroupVersionKind())
+		err := c.Get(ctx, client.ObjectKeyFromObject(o), live)
+		if apierrors.IsNotFound(err) {
+			report.Objects = append(report.Objects, newObjectDrift(o, true))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s %s from cluster: %v", o.GetKind(), o.GetName(), err)
+		}
+
+		liveObj, err := normalize(live.Object)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s %s from cluster: %v", o.GetKind(), o.GetName(), err)
+		}
+
+		fields := []FieldDrift{}
+		diffValues("spec", o.Object["spec"], liveObj["spec"], &fields)
+		if len(fields) == 0 {
+			continue
+		}
+		sort.SliceStable(fields, func(i, j int) bool { return fields[i].Path < fields[j].Path })
+
+		d := newObjectDrift(o, false)
+		d.Fields = fields
+		report.Objects = append(report.Objects, d)
+	}
+
+	return report, nil
+}
+
+func newObjectDrift(o *unstructured.Unstructured, missing bool) ObjectDrift {
+	return ObjectDrift{
+		Kind:      o.GetKind(),
+		Namespace: o.GetNamespace(),
+		Name:      o.GetName(),
+		Missing:   missing,
+	}
+}
+
+// diffValues walks the git value and records every field that doesn't have the same value in the live object.
+// Lists are compared element by element when they have the same length, otherwise as a whole.
+func diffValues(path string, git, live interface{}, drifts *[]FieldDrift) {
+	switch g := git.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for k, v := range g {
+			diffValues(path+"."+k, v, l[k], drifts)
+		}
+		return
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(g) {
+			break
+		}
+		for i := range g {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), g[i], l[i], drifts)
+		}
+		return
+	case nil:
+		// Null fields in git are unset, so the live value comes from the defaults.
+		return
+	}
+
+	if reflect.DeepEqual(git, live) {
+		return
+	}
+
+	*drifts = append(*drifts, FieldDrift{
+		Path: path,
+		Git:  git,
+		Live: live,
+	})
+}
+
+// normalize round trips an object through json so its numbers have the same type as the ones parsed from yaml.
+func normalize(obj map[string]interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	n := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
@@ -0,0 +1,206 @@
+package drift_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+)
+
+const gitConfig = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: mgmt
+spec:
+  kubernetesVersion: "1.30"
+  controlPlaneConfiguration:
+    count: 3
+  workerNodeGroupConfigurations:
+  - name: md-0
+    count: 2
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereMachineConfig
+metadata:
+  name: mgmt-cp
+spec:
+  memoryMiB: 8192
+  numCPUs: 2
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereMachineConfig
+metadata:
+  name: mgmt-md-0
+spec:
+  numCPUs: 2
+`
+
+func newClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = anywherev1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func liveObjects() (*anywherev1.Cluster, *anywherev1.VSphereMachineConfig) {
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube130,
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				Count: 3,
+			},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: ptrInt(2)},
+			},
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				Pods: anywherev1.Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+			},
+		},
+	}
+	machineConfig := &anywherev1.VSphereMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mgmt-cp", Namespace: "default"},
+		Spec: anywherev1.VSphereMachineConfigSpec{
+			MemoryMiB: 8192,
+			NumCPUs:   2,
+			Template:  "/Datacenter/vm/ubuntu",
+		},
+	}
+	return cluster, machineConfig
+}
+
+func ptrInt(i int) *int {
+	return &i
+}
+
+func TestCompareNoDrift(t *testing.T) {
+	g := NewWithT(t)
+	cluster, machineConfig := liveObjects()
+	workerMachineConfig := machineConfig.DeepCopy()
+	workerMachineConfig.Name = "mgmt-md-0"
+	c := newClient(cluster, machineConfig, workerMachineConfig)
+
+	report, err := drift.Compare(context.Background(), c, []byte(gitConfig), "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.HasDrift()).To(BeFalse())
+}
+
+func TestCompareDrift(t *testing.T) {
+	g := NewWithT(t)
+	cluster, machineConfig := liveObjects()
+	cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptrInt(5)
+	machineConfig.Spec.MemoryMiB = 16384
+	c := newClient(cluster, machineConfig)
+
+	report, err := drift.Compare(context.Background(), c, []byte(gitConfig), "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.HasDrift()).To(BeTrue())
+	g.Expect(report.Objects).To(Equal([]drift.ObjectDrift{
+		{
+			Kind:      anywherev1.ClusterKind,
+			Namespace: "default",
+			Name:      "mgmt",
+			Fields: []drift.FieldDrift{
+				{Path: "spec.workerNodeGroupConfigurations[0].count", Git: float64(2), Live: float64(5)},
+			},
+		},
+		{
+			Kind:      anywherev1.VSphereMachineConfigKind,
+			Namespace: "default",
+			Name:      "mgmt-cp",
+			Fields: []drift.FieldDrift{
+				{Path: "spec.memoryMiB", Git: float64(8192), Live: float64(16384)},
+			},
+		},
+		{
+			Kind:      anywherev1.VSphereMachineConfigKind,
+			Namespace: "default",
+			Name:      "mgmt-md-0",
+			Missing:   true,
+		},
+	}))
+}
+
+func TestCompareInvalidConfig(t *testing.T) {
+	g := NewWithT(t)
+	_, err := drift.Compare(context.Background(), newClient(), []byte("spec: [}"), "default")
+	g.Expect(err).To(MatchError(ContainSubstring("parsing cluster config from git")))
+}
+
+func managedFields(manager string, time metav1.Time, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		Time:       &time,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func TestSpecChangedOutsideGitOps(t *testing.T) {
+	applied := metav1.Unix(1000, 0)
+	before := metav1.Unix(500, 0)
+	after := metav1.Unix(2000, 0)
+	spec := `{"f:spec":{"f:numCPUs":{}}}`
+	metadata := `{"f:metadata":{"f:annotations":{}}}`
+
+	tests := []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		ignored       []string
+		want          []string
+	}{
+		{
+			name: "never applied by flux",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFields("kubectl-edit", after, spec),
+			},
+		},
+		{
+			name: "changed before flux applied",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFields(drift.FluxFieldManager, applied, spec),
+				managedFields("kubectl-edit", before, spec),
+			},
+		},
+		{
+			name: "metadata changed after flux applied",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFields(drift.FluxFieldManager, applied, spec),
+				managedFields("kubectl-edit", after, metadata),
+			},
+		},
+		{
+			name: "spec changed by ignored manager",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFields(drift.FluxFieldManager, applied, spec),
+				managedFields("manager", after, spec),
+			},
+			ignored: []string{"manager"},
+		},
+		{
+			name: "spec changed after flux applied",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFields(drift.FluxFieldManager, applied, spec),
+				managedFields("kubectl-edit", after, spec),
+				managedFields("kubectl-patch", after, spec),
+				managedFields("kubectl-edit", after, spec),
+			},
+			want: []string{"kubectl-edit", "kubectl-patch"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			obj := &metav1.ObjectMeta{ManagedFields: tt.managedFields}
+			g.Expect(drift.SpecChangedOutsideGitOps(obj, tt.ignored...)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package drift
+
+import (
+	"encoding/json"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FluxFieldManager is the field manager flux's kustomize-controller uses to apply the objects in git.
+const FluxFieldManager = "kustomize-controller"
+
+const specField = "f:spec"
+
+// SpecChangedOutsideGitOps returns the field managers that changed the spec of an object after flux last applied it
+// from git, ignoring the managers in ignoredManagers. It works from the object managed fields, so it doesn't need
+// access to the repository. It can't tell if the change left the spec with the same value it has in git.
+// Objects never applied by flux return no managers.
+func SpecChangedOutsideGitOps(obj metav1.Object, ignoredManagers ...string) []string {
+	ignored := make(map[string]struct{}, len(ignoredManagers)+1)
+	ignored[FluxFieldManager] = struct{}{}
+	for _, m := range ignoredManagers {
+		ignored[m] = struct{}{}
+	}
+
+	var lastApplied *metav1.Time
+	for _, e := range obj.GetManagedFields() {
+		if e.Manager != FluxFieldManager || e.Time == nil {
+			continue
+		}
+		if lastApplied == nil || lastApplied.Before(e.Time) {
+			lastApplied = e.Time
+		}
+	}
+	if lastApplied == nil {
+		return nil
+	}
+
+	seen := map[string]struct{}{}
+	var managers []string
+	for _, e := range obj.GetManagedFields() {
+		if _, ok := ignored[e.Manager]; ok {
+			continue
+		}
+		if e.Time == nil || !lastApplied.Before(e.Time) || !managesSpec(e) {
+			continue
+		}
+		if _, ok := seen[e.Manager]; ok {
+			continue
+		}
+		seen[e.Manager] = struct{}{}
+		managers = append(managers, e.Manager)
+	}
+	sort.Strings(managers)
+
+	return managers
+}
+
+func managesSpec(e metav1.ManagedFieldsEntry) bool {
+	if e.FieldsV1 == nil {
+		return false
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(e.FieldsV1.Raw, &fields); err != nil {
+		return false
+	}
+	_, ok := fields[specField]
+	return ok
+}
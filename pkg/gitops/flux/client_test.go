@@ -58,6 +58,21 @@ func TestFluxClientBootstrapGithubError(t *testing.T) {
 	tt.Expect(tt.c.BootstrapGithub(tt.ctx, tt.cluster, tt.fluxConfig)).To(MatchError(ContainSubstring("error in bootstrap github")), "fluxClient.BootstrapGithub() should fail after 5 tries")
 }
 
+func TestFluxClientBootstrapGitlabSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in bootstrap gitlab")).Times(4)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).Times(1)
+
+	tt.Expect(tt.c.BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig)).To(Succeed(), "fluxClient.BootstrapGitlab() should succeed with 5 tries")
+}
+
+func TestFluxClientBootstrapGitlabError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in bootstrap gitlab")).Times(5)
+
+	tt.Expect(tt.c.BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig)).To(MatchError(ContainSubstring("error in bootstrap gitlab")), "fluxClient.BootstrapGitlab() should fail after 5 tries")
+}
+
 func TestFluxClientBootstrapGitSuccess(t *testing.T) {
 	tt := newFluxClientTest(t)
 	tt.f.EXPECT().BootstrapGit(tt.ctx, tt.cluster, tt.fluxConfig, nil).Return(errors.New("error in bootstrap git")).Times(4)
@@ -2,7 +2,9 @@ package flux
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"path"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -27,6 +29,7 @@ const (
 
 type GitOpsFluxClient interface {
 	BootstrapGithub(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
 	Uninstall(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	GetCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (eksaCluster *v1alpha1.Cluster, err error)
@@ -118,6 +121,11 @@ func (f *Flux) Bootstrap(ctx context.Context, cluster *types.Cluster, clusterSpe
 		return fmt.Errorf("installing GitHub gitops: %v", err)
 	}
 
+	if err := f.BootstrapGitlab(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing GitLab gitops: %v", err)
+	}
+
 	if err := f.BootstrapGit(ctx, cluster, clusterSpec); err != nil {
 		_ = f.Uninstall(ctx, cluster, clusterSpec)
 		return fmt.Errorf("installing generic git gitops: %v", err)
@@ -134,6 +142,15 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, clus
 	return f.fluxClient.BootstrapGithub(ctx, cluster, clusterSpec.FluxConfig)
 }
 
+// BootstrapGitlab bootstraps flux with the GitLab repository of the FluxConfig, if any.
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.Cluster.IsManaged() || clusterSpec.FluxConfig.Spec.Gitlab == nil {
+		return nil
+	}
+
+	return f.fluxClient.BootstrapGitlab(ctx, cluster, clusterSpec.FluxConfig)
+}
+
 func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	if clusterSpec.Cluster.IsManaged() || clusterSpec.FluxConfig.Spec.Git == nil {
 		return nil
@@ -246,6 +263,37 @@ func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec,
 	return nil
 }
 
+// GetGitEksaSpec returns the EKS-A cluster config file stored in the git repository for the cluster.
+// The repository is cloned if it doesn't exist locally, otherwise it's pulled so the config matches the remote.
+func (f *Flux) GetGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec) ([]byte, error) {
+	if f.shouldSkipFlux() {
+		return nil, errors.New("GitOps is not configured for the cluster")
+	}
+
+	fc := newFluxForCluster(f, clusterSpec, nil, nil)
+
+	localRepoExists := validations.FileExists(path.Join(f.writer.Dir(), ".git"))
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	if localRepoExists {
+		err := f.gitClient.Pull(ctx, fc.branch())
+		upToDate := &git.RepositoryUpToDateError{}
+		if err != nil && !errors.As(err, &upToDate) {
+			return nil, fmt.Errorf("pulling from remote repository: %v", err)
+		}
+	}
+
+	configPath := path.Join(f.writer.Dir(), fc.eksaSystemDir(), clusterConfigFileName)
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading cluster config from git repository: %v", err)
+	}
+
+	return content, nil
+}
+
 func (f *Flux) Validations(ctx context.Context, clusterSpec *cluster.Spec) []validations.Validation {
 	if f.shouldSkipFlux() {
 		return nil
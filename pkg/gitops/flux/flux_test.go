@@ -443,6 +443,68 @@ func TestInstallGitOpsGitProviderSuccess(t *testing.T) {
 	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, managementComponents, clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
 }
 
+func TestInstallGitOpsGitlabProviderSuccess(t *testing.T) {
+	clusterName := "management-cluster"
+	clusterConfig := NewCluster(clusterName)
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	clusterSpec.FluxConfig.Spec.Gitlab = &v1alpha1.GitlabProviderConfig{Owner: "platform/clusters", Repository: "testRepo"}
+	clusterSpec.FluxConfig.Spec.Github = nil
+
+	managementComponents := cluster.ManagementComponentsFromBundles(clusterSpec.Bundles)
+
+	cluster := &types.Cluster{}
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "testRepo"}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(path.Dir("clusters/management-cluster")).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().BootstrapGitlab(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	datacenterConfig := datacenterConfig(clusterName)
+	machineConfig := machineConfig(clusterName)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, managementComponents, clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
+}
+
+func TestInstallGitOpsGitlabCreatesRepoInGroup(t *testing.T) {
+	clusterName := "management-cluster"
+	clusterConfig := NewCluster(clusterName)
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	clusterSpec.FluxConfig.Spec.Gitlab = &v1alpha1.GitlabProviderConfig{Owner: "platform/clusters", Repository: "testRepo"}
+	clusterSpec.FluxConfig.Spec.Github = nil
+
+	managementComponents := cluster.ManagementComponentsFromBundles(clusterSpec.Bundles)
+
+	cluster := &types.Cluster{}
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(nil, nil)
+	g.git.EXPECT().CreateRepo(g.ctx, git.CreateRepoOpts{
+		Name:        "testRepo",
+		Owner:       "platform/clusters",
+		Description: "EKS-A cluster configuration repository",
+		Privacy:     true,
+	}).Return(nil)
+	g.git.EXPECT().Init().Return(nil)
+	g.git.EXPECT().Commit(gomock.Any()).Return(nil).Times(2)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(path.Dir("clusters/management-cluster")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().BootstrapGitlab(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	datacenterConfig := datacenterConfig(clusterName)
+	machineConfig := machineConfig(clusterName)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, managementComponents, clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
+}
+
 func TestInstallGitOpsCommitFilesError(t *testing.T) {
 	clusterName := "test-cluster"
 	clusterConfig := NewCluster(clusterName)
@@ -902,6 +964,78 @@ func TestUpdateGitRepoEksaSpecSkip(t *testing.T) {
 	g.Expect(f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
 }
 
+func TestGetGitEksaSpecLocalRepoNotExists(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, NewCluster("management-cluster"), "")
+	eksaSystemDirPath := "clusters/management-cluster/management-cluster/eksa-system"
+
+	g.git.EXPECT().Clone(g.ctx).DoAndReturn(func(_ context.Context) error {
+		w, err := g.writer.WithDir(eksaSystemDirPath)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(defaultEksaClusterConfigFileName, []byte("kind: Cluster"), filewriter.PersistentFile)
+		return err
+	})
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	g.Expect(g.gitOpsFlux.GetGitEksaSpec(g.ctx, clusterSpec)).To(Equal([]byte("kind: Cluster")))
+}
+
+func TestGetGitEksaSpecLocalRepoExists(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, NewCluster("management-cluster"), "")
+	eksaSystemDirPath := "clusters/management-cluster/management-cluster/eksa-system"
+
+	if _, err := g.writer.WithDir(".git"); err != nil {
+		t.Fatalf("failed to add .git dir: %v", err)
+	}
+	w, err := g.writer.WithDir(eksaSystemDirPath)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = w.Write(defaultEksaClusterConfigFileName, []byte("kind: Cluster"), filewriter.PersistentFile)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(&git.RepositoryUpToDateError{})
+
+	g.Expect(g.gitOpsFlux.GetGitEksaSpec(g.ctx, clusterSpec)).To(Equal([]byte("kind: Cluster")))
+}
+
+func TestGetGitEksaSpecErrorPull(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, NewCluster("management-cluster"), "")
+
+	if _, err := g.writer.WithDir(".git"); err != nil {
+		t.Fatalf("failed to add .git dir: %v", err)
+	}
+
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(errors.New("failed to pull"))
+
+	_, err := g.gitOpsFlux.GetGitEksaSpec(g.ctx, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("failed to pull")))
+}
+
+func TestGetGitEksaSpecErrorMissingConfig(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, NewCluster("management-cluster"), "")
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	_, err := g.gitOpsFlux.GetGitEksaSpec(g.ctx, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("reading cluster config from git repository")))
+}
+
+func TestGetGitEksaSpecSkip(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, NewCluster("management-cluster"), "")
+	f := flux.NewFlux(nil, nil, nil, nil)
+
+	_, err := f.GetGitEksaSpec(g.ctx, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("GitOps is not configured")))
+}
+
 func TestForceReconcileGitRepo(t *testing.T) {
 	cluster := &types.Cluster{}
 	clusterConfig := NewCluster("")
@@ -75,6 +75,20 @@ func (mr *MockFluxClientMockRecorder) BootstrapGithub(ctx, arg1, fluxConfig any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGithub", reflect.TypeOf((*MockFluxClient)(nil).BootstrapGithub), ctx, arg1, fluxConfig)
 }
 
+// BootstrapGitlab mocks base method.
+func (m *MockFluxClient) BootstrapGitlab(ctx context.Context, arg1 *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapGitlab", ctx, arg1, fluxConfig)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapGitlab indicates an expected call of BootstrapGitlab.
+func (mr *MockFluxClientMockRecorder) BootstrapGitlab(ctx, arg1, fluxConfig any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGitlab", reflect.TypeOf((*MockFluxClient)(nil).BootstrapGitlab), ctx, arg1, fluxConfig)
+}
+
 // Reconcile mocks base method.
 func (m *MockFluxClient) Reconcile(ctx context.Context, arg1 *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
 	m.ctrl.T.Helper()
@@ -246,6 +260,20 @@ func (mr *MockGitOpsFluxClientMockRecorder) BootstrapGithub(ctx, arg1, fluxConfi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGithub", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapGithub), ctx, arg1, fluxConfig)
 }
 
+// BootstrapGitlab mocks base method.
+func (m *MockGitOpsFluxClient) BootstrapGitlab(ctx context.Context, arg1 *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapGitlab", ctx, arg1, fluxConfig)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapGitlab indicates an expected call of BootstrapGitlab.
+func (mr *MockGitOpsFluxClientMockRecorder) BootstrapGitlab(ctx, arg1, fluxConfig any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGitlab", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapGitlab), ctx, arg1, fluxConfig)
+}
+
 // DeleteSystemSecret mocks base method.
 func (m *MockGitOpsFluxClient) DeleteSystemSecret(ctx context.Context, arg1 *types.Cluster, namespace string) error {
 	m.ctrl.T.Helper()
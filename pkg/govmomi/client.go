@@ -2,10 +2,12 @@ package govmomi
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -38,6 +40,9 @@ type VMOMIClient struct {
 	Finder               VMOMIFinder
 	username             string
 	AuthorizationManager VMOMIAuthorizationManager
+	// Rest is the vSphere Automation API client. It's created from the vSphere credentials on first use if not set.
+	Rest     *rest.Client
+	userinfo *url.Userinfo
 }
 
 func NewVMOMIClientCustom(gcvm *govmomi.Client, f VMOMIFinder, username string, am VMOMIAuthorizationManager) *VMOMIClient {
@@ -13,6 +13,13 @@ import (
 type VSphereClient interface {
 	Username() string
 	GetPrivsOnEntity(ctx context.Context, path string, objType string, username string) ([]string, error)
+	GetComputeClusterDRS(ctx context.Context, resourcePool string) (*ComputeClusterDRS, error)
+	ReconcileVMAntiAffinityRule(ctx context.Context, resourcePool string, rule VMAntiAffinityRule) error
+	DeleteVMAntiAffinityRule(ctx context.Context, resourcePool, name string) error
+	ListImportedTemplates(ctx context.Context, folder string) ([]ImportedTemplate, error)
+	AdoptImportedTemplate(ctx context.Context, template, owner string) error
+	ReleaseImportedTemplate(ctx context.Context, template, owner string) error
+	DeleteImportedTemplate(ctx context.Context, template, templateLibrary string) error
 }
 
 type VMOMIFinderBuilder interface {
@@ -65,5 +72,11 @@ func (vcb *vMOMIClientBuilder) Build(ctx context.Context, host string, username
 
 	am := vcb.amb.Build(gvmc.Client)
 
-	return &VMOMIClient{gvmc, f, username, am}, nil
+	return &VMOMIClient{
+		Gcvm:                 gvmc,
+		Finder:               f,
+		username:             username,
+		AuthorizationManager: am,
+		userinfo:             u.User,
+	}, nil
 }
@@ -0,0 +1,219 @@
+package govmomi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// ComputeClusterDRS describes the DRS settings of a vSphere compute cluster.
+type ComputeClusterDRS struct {
+	// Path is the inventory path of the compute cluster.
+	Path string
+	// Enabled is true if DRS is enabled in the compute cluster.
+	Enabled bool
+	// Hosts is the number of ESXi hosts in the compute cluster.
+	Hosts int
+}
+
+// VMAntiAffinityRule is a DRS rule that keeps a set of VMs on different ESXi hosts.
+type VMAntiAffinityRule struct {
+	Name string
+	// VMs are the inventory paths of the VMs in the rule.
+	VMs []string
+	// Mandatory rules are never violated by DRS. Otherwise DRS only tries to satisfy them.
+	Mandatory bool
+}
+
+// GetComputeClusterDRS returns the DRS settings of the compute cluster that owns the resource pool.
+func (vsc *VMOMIClient) GetComputeClusterDRS(ctx context.Context, resourcePool string) (*ComputeClusterDRS, error) {
+	cluster, err := vsc.computeClusterForResourcePool(ctx, resourcePool)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting compute cluster %s configuration: %v", cluster.InventoryPath, err)
+	}
+
+	hosts, err := cluster.Hosts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting compute cluster %s hosts: %v", cluster.InventoryPath, err)
+	}
+
+	return &ComputeClusterDRS{
+		Path:    cluster.InventoryPath,
+		Enabled: config.DrsConfig.Enabled != nil && *config.DrsConfig.Enabled,
+		Hosts:   len(hosts),
+	}, nil
+}
+
+// ReconcileVMAntiAffinityRule creates or updates the VM anti-affinity rule in the compute cluster that owns the
+// resource pool. The rule is matched by name and it's only updated if its VMs or settings changed.
+func (vsc *VMOMIClient) ReconcileVMAntiAffinityRule(ctx context.Context, resourcePool string, rule VMAntiAffinityRule) error {
+	cluster, err := vsc.computeClusterForResourcePool(ctx, resourcePool)
+	if err != nil {
+		return err
+	}
+
+	vms := make([]types.ManagedObjectReference, 0, len(rule.VMs))
+	for _, path := range rule.VMs {
+		vm, err := vsc.getVirtualMachine(ctx, path)
+		if err != nil {
+			return fmt.Errorf("getting VM %s for anti-affinity rule %s: %v", path, rule.Name, err)
+		}
+		vms = append(vms, vm)
+	}
+
+	current, err := findClusterRule(ctx, cluster, rule.Name)
+	if err != nil {
+		return err
+	}
+
+	info := &types.ClusterAntiAffinityRuleSpec{
+		ClusterRuleInfo: types.ClusterRuleInfo{
+			Name:        rule.Name,
+			Enabled:     types.NewBool(true),
+			Mandatory:   types.NewBool(rule.Mandatory),
+			UserCreated: types.NewBool(true),
+		},
+		Vm: vms,
+	}
+
+	operation := types.ArrayUpdateOperationAdd
+	if current != nil {
+		existing, ok := current.(*types.ClusterAntiAffinityRuleSpec)
+		if !ok {
+			return fmt.Errorf("rule %s in compute cluster %s is not a VM anti-affinity rule", rule.Name, cluster.InventoryPath)
+		}
+		if antiAffinityRuleUpToDate(existing, info) {
+			return nil
+		}
+		info.Key = existing.Key
+		info.RuleUuid = existing.RuleUuid
+		operation = types.ArrayUpdateOperationEdit
+	}
+
+	return reconfigureClusterRule(ctx, cluster, types.ClusterRuleSpec{
+		ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: operation},
+		Info:            info,
+	})
+}
+
+// DeleteVMAntiAffinityRule deletes the rule from the compute cluster that owns the resource pool.
+// It's a noop if the rule doesn't exist.
+func (vsc *VMOMIClient) DeleteVMAntiAffinityRule(ctx context.Context, resourcePool, name string) error {
+	cluster, err := vsc.computeClusterForResourcePool(ctx, resourcePool)
+	if err != nil {
+		return err
+	}
+
+	current, err := findClusterRule(ctx, cluster, name)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return nil
+	}
+
+	return reconfigureClusterRule(ctx, cluster, types.ClusterRuleSpec{
+		ArrayUpdateSpec: types.ArrayUpdateSpec{
+			Operation: types.ArrayUpdateOperationRemove,
+			RemoveKey: current.GetClusterRuleInfo().Key,
+		},
+	})
+}
+
+func (vsc *VMOMIClient) computeClusterForResourcePool(ctx context.Context, resourcePool string) (*object.ClusterComputeResource, error) {
+	pool, err := vsc.Finder.ResourcePool(ctx, resourcePool)
+	if err != nil {
+		return nil, fmt.Errorf("getting resource pool %s: %v", resourcePool, err)
+	}
+
+	owner, err := pool.Owner(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting owner of resource pool %s: %v", resourcePool, err)
+	}
+
+	cluster, ok := owner.(*object.ClusterComputeResource)
+	if !ok {
+		return nil, fmt.Errorf("resource pool %s doesn't belong to a compute cluster", resourcePool)
+	}
+
+	path, err := find.InventoryPath(ctx, pool.Client(), cluster.Reference())
+	if err != nil {
+		return nil, fmt.Errorf("getting compute cluster path for resource pool %s: %v", resourcePool, err)
+	}
+	cluster.InventoryPath = path
+
+	return cluster, nil
+}
+
+func findClusterRule(ctx context.Context, cluster *object.ClusterComputeResource, name string) (types.BaseClusterRuleInfo, error) {
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting compute cluster %s configuration: %v", cluster.InventoryPath, err)
+	}
+
+	for _, rule := range config.Rule {
+		if rule.GetClusterRuleInfo().Name == name {
+			return rule, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func reconfigureClusterRule(ctx context.Context, cluster *object.ClusterComputeResource, spec types.ClusterRuleSpec) error {
+	task, err := cluster.Reconfigure(ctx, &types.ClusterConfigSpecEx{RulesSpec: []types.ClusterRuleSpec{spec}}, true)
+	if err != nil {
+		return fmt.Errorf("reconfiguring compute cluster %s rules: %v", cluster.InventoryPath, err)
+	}
+
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("reconfiguring compute cluster %s rules: %v", cluster.InventoryPath, err)
+	}
+
+	return nil
+}
+
+func antiAffinityRuleUpToDate(current, desired *types.ClusterAntiAffinityRuleSpec) bool {
+	if !boolValue(current.Enabled) || boolValue(current.Mandatory) != boolValue(desired.Mandatory) {
+		return false
+	}
+
+	return sameRefs(current.Vm, desired.Vm)
+}
+
+func sameRefs(a, b []types.ManagedObjectReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	values := func(refs []types.ManagedObjectReference) []string {
+		v := make([]string, 0, len(refs))
+		for _, r := range refs {
+			v = append(v, r.Value)
+		}
+		sort.Strings(v)
+		return v
+	}
+
+	av, bv := values(a), values(b)
+	for i := range av {
+		if av[i] != bv[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,157 @@
+package govmomi_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+)
+
+const (
+	simResourcePool = "/DC0/host/DC0_C0/Resources"
+	simVM0          = "/DC0/vm/DC0_C0_RP0_VM0"
+	simVM1          = "/DC0/vm/DC0_C0_RP0_VM1"
+)
+
+func newSimulatorClient(ctx context.Context, t *testing.T, c *vim25.Client) *govmomi.VMOMIClient {
+	f := find.NewFinder(c, true)
+	dc, err := f.Datacenter(ctx, "DC0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SetDatacenter(dc)
+
+	return govmomi.NewVMOMIClientCustom(nil, f, "user", nil)
+}
+
+func simulatorClusterRules(ctx context.Context, t *testing.T, c *vim25.Client) []types.BaseClusterRuleInfo {
+	cluster, err := find.NewFinder(c).ClusterComputeResource(ctx, "/DC0/host/DC0_C0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config.Rule
+}
+
+func simulatorVMRef(ctx context.Context, t *testing.T, c *vim25.Client, path string) types.ManagedObjectReference {
+	vm, err := find.NewFinder(c).VirtualMachine(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vm.Reference()
+}
+
+func TestGetComputeClusterDRS(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		g := NewWithT(t)
+		vsc := newSimulatorClient(ctx, t, c)
+
+		cluster, err := find.NewFinder(c).ClusterComputeResource(ctx, "/DC0/host/DC0_C0")
+		g.Expect(err).NotTo(HaveOccurred())
+		task, err := cluster.Reconfigure(ctx, &types.ClusterConfigSpecEx{
+			DrsConfig: &types.ClusterDrsConfigInfo{Enabled: types.NewBool(true)},
+		}, true)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(task.Wait(ctx)).To(Succeed())
+
+		drs, err := vsc.GetComputeClusterDRS(ctx, simResourcePool)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(drs).To(Equal(&govmomi.ComputeClusterDRS{
+			Path:    "/DC0/host/DC0_C0",
+			Enabled: true,
+			Hosts:   3,
+		}))
+	})
+}
+
+func TestGetComputeClusterDRSStandaloneHost(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		g := NewWithT(t)
+		vsc := newSimulatorClient(ctx, t, c)
+
+		_, err := vsc.GetComputeClusterDRS(ctx, "/DC0/host/DC0_H0/Resources")
+		g.Expect(err).To(MatchError(ContainSubstring("resource pool /DC0/host/DC0_H0/Resources doesn't belong to a compute cluster")))
+	})
+}
+
+func TestReconcileVMAntiAffinityRule(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		g := NewWithT(t)
+		vsc := newSimulatorClient(ctx, t, c)
+		rule := govmomi.VMAntiAffinityRule{
+			Name:      "my-cluster-control-plane-anti-affinity",
+			VMs:       []string{simVM0, simVM1},
+			Mandatory: true,
+		}
+
+		g.Expect(vsc.ReconcileVMAntiAffinityRule(ctx, simResourcePool, rule)).To(Succeed())
+
+		rules := simulatorClusterRules(ctx, t, c)
+		g.Expect(rules).To(HaveLen(1))
+		created, ok := rules[0].(*types.ClusterAntiAffinityRuleSpec)
+		g.Expect(ok).To(BeTrue())
+		g.Expect(created.Name).To(Equal(rule.Name))
+		g.Expect(*created.Mandatory).To(BeTrue())
+		g.Expect(*created.Enabled).To(BeTrue())
+		g.Expect(created.Vm).To(ConsistOf(simulatorVMRef(ctx, t, c, simVM0), simulatorVMRef(ctx, t, c, simVM1)))
+
+		// Reconciling the same rule again is a noop.
+		g.Expect(vsc.ReconcileVMAntiAffinityRule(ctx, simResourcePool, rule)).To(Succeed())
+		g.Expect(simulatorClusterRules(ctx, t, c)).To(HaveLen(1))
+
+		rule.VMs = []string{simVM1, "/DC0/vm/DC0_H0_VM0"}
+		rule.Mandatory = false
+		g.Expect(vsc.ReconcileVMAntiAffinityRule(ctx, simResourcePool, rule)).To(Succeed())
+
+		rules = simulatorClusterRules(ctx, t, c)
+		g.Expect(rules).To(HaveLen(1))
+		updated := rules[0].(*types.ClusterAntiAffinityRuleSpec)
+		g.Expect(updated.Key).To(Equal(created.Key))
+		g.Expect(*updated.Mandatory).To(BeFalse())
+		g.Expect(updated.Vm).To(ConsistOf(simulatorVMRef(ctx, t, c, simVM1), simulatorVMRef(ctx, t, c, "/DC0/vm/DC0_H0_VM0")))
+	})
+}
+
+func TestReconcileVMAntiAffinityRuleMissingVM(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		g := NewWithT(t)
+		vsc := newSimulatorClient(ctx, t, c)
+		rule := govmomi.VMAntiAffinityRule{
+			Name: "my-cluster-etcd-anti-affinity",
+			VMs:  []string{simVM0, "/DC0/vm/missing"},
+		}
+
+		g.Expect(vsc.ReconcileVMAntiAffinityRule(ctx, simResourcePool, rule)).To(
+			MatchError(ContainSubstring("getting VM /DC0/vm/missing for anti-affinity rule my-cluster-etcd-anti-affinity")),
+		)
+		g.Expect(simulatorClusterRules(ctx, t, c)).To(BeEmpty())
+	})
+}
+
+func TestDeleteVMAntiAffinityRule(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		g := NewWithT(t)
+		vsc := newSimulatorClient(ctx, t, c)
+		name := "my-cluster-md-0-anti-affinity"
+
+		g.Expect(vsc.DeleteVMAntiAffinityRule(ctx, simResourcePool, name)).To(Succeed())
+
+		g.Expect(vsc.ReconcileVMAntiAffinityRule(ctx, simResourcePool, govmomi.VMAntiAffinityRule{
+			Name: name,
+			VMs:  []string{simVM0, simVM1},
+		})).To(Succeed())
+		g.Expect(simulatorClusterRules(ctx, t, c)).To(HaveLen(1))
+
+		g.Expect(vsc.DeleteVMAntiAffinityRule(ctx, simResourcePool, name)).To(Succeed())
+		g.Expect(simulatorClusterRules(ctx, t, c)).To(BeEmpty())
+	})
+}
@@ -47,6 +47,63 @@ func (m *MockVSphereClient) EXPECT() *MockVSphereClientMockRecorder {
 	return m.recorder
 }
 
+// AdoptImportedTemplate mocks base method.
+func (m *MockVSphereClient) AdoptImportedTemplate(ctx context.Context, template, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdoptImportedTemplate", ctx, template, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdoptImportedTemplate indicates an expected call of AdoptImportedTemplate.
+func (mr *MockVSphereClientMockRecorder) AdoptImportedTemplate(ctx, template, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdoptImportedTemplate", reflect.TypeOf((*MockVSphereClient)(nil).AdoptImportedTemplate), ctx, template, owner)
+}
+
+// DeleteImportedTemplate mocks base method.
+func (m *MockVSphereClient) DeleteImportedTemplate(ctx context.Context, template, templateLibrary string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImportedTemplate", ctx, template, templateLibrary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImportedTemplate indicates an expected call of DeleteImportedTemplate.
+func (mr *MockVSphereClientMockRecorder) DeleteImportedTemplate(ctx, template, templateLibrary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImportedTemplate", reflect.TypeOf((*MockVSphereClient)(nil).DeleteImportedTemplate), ctx, template, templateLibrary)
+}
+
+// DeleteVMAntiAffinityRule mocks base method.
+func (m *MockVSphereClient) DeleteVMAntiAffinityRule(ctx context.Context, resourcePool, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVMAntiAffinityRule", ctx, resourcePool, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVMAntiAffinityRule indicates an expected call of DeleteVMAntiAffinityRule.
+func (mr *MockVSphereClientMockRecorder) DeleteVMAntiAffinityRule(ctx, resourcePool, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVMAntiAffinityRule", reflect.TypeOf((*MockVSphereClient)(nil).DeleteVMAntiAffinityRule), ctx, resourcePool, name)
+}
+
+// GetComputeClusterDRS mocks base method.
+func (m *MockVSphereClient) GetComputeClusterDRS(ctx context.Context, resourcePool string) (*govmomi.ComputeClusterDRS, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeClusterDRS", ctx, resourcePool)
+	ret0, _ := ret[0].(*govmomi.ComputeClusterDRS)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeClusterDRS indicates an expected call of GetComputeClusterDRS.
+func (mr *MockVSphereClientMockRecorder) GetComputeClusterDRS(ctx, resourcePool any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeClusterDRS", reflect.TypeOf((*MockVSphereClient)(nil).GetComputeClusterDRS), ctx, resourcePool)
+}
+
 // GetPrivsOnEntity mocks base method.
 func (m *MockVSphereClient) GetPrivsOnEntity(ctx context.Context, path, objType, username string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -62,6 +119,49 @@ func (mr *MockVSphereClientMockRecorder) GetPrivsOnEntity(ctx, path, objType, us
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivsOnEntity", reflect.TypeOf((*MockVSphereClient)(nil).GetPrivsOnEntity), ctx, path, objType, username)
 }
 
+// ListImportedTemplates mocks base method.
+func (m *MockVSphereClient) ListImportedTemplates(ctx context.Context, folder string) ([]govmomi.ImportedTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImportedTemplates", ctx, folder)
+	ret0, _ := ret[0].([]govmomi.ImportedTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImportedTemplates indicates an expected call of ListImportedTemplates.
+func (mr *MockVSphereClientMockRecorder) ListImportedTemplates(ctx, folder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImportedTemplates", reflect.TypeOf((*MockVSphereClient)(nil).ListImportedTemplates), ctx, folder)
+}
+
+// ReconcileVMAntiAffinityRule mocks base method.
+func (m *MockVSphereClient) ReconcileVMAntiAffinityRule(ctx context.Context, resourcePool string, rule govmomi.VMAntiAffinityRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileVMAntiAffinityRule", ctx, resourcePool, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileVMAntiAffinityRule indicates an expected call of ReconcileVMAntiAffinityRule.
+func (mr *MockVSphereClientMockRecorder) ReconcileVMAntiAffinityRule(ctx, resourcePool, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileVMAntiAffinityRule", reflect.TypeOf((*MockVSphereClient)(nil).ReconcileVMAntiAffinityRule), ctx, resourcePool, rule)
+}
+
+// ReleaseImportedTemplate mocks base method.
+func (m *MockVSphereClient) ReleaseImportedTemplate(ctx context.Context, template, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseImportedTemplate", ctx, template, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseImportedTemplate indicates an expected call of ReleaseImportedTemplate.
+func (mr *MockVSphereClientMockRecorder) ReleaseImportedTemplate(ctx, template, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseImportedTemplate", reflect.TypeOf((*MockVSphereClient)(nil).ReleaseImportedTemplate), ctx, template, owner)
+}
+
 // Username mocks base method.
 func (m *MockVSphereClient) Username() string {
 	m.ctrl.T.Helper()
@@ -0,0 +1,285 @@
+package govmomi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// TemplateOwnerCategory is the tag category for the tags that mark the templates imported by EKS Anywhere and
+// record which management clusters use them. Templates without any of these tags are never garbage collected.
+const TemplateOwnerCategory = "eksaTemplateOwner"
+
+// TemplateImportedTag marks a template as imported by EKS Anywhere. The management clusters using the template
+// adopt it by tagging it with their owner tag.
+const TemplateImportedTag = TemplateOwnerCategory + ":imported"
+
+// TemplateOwnerTag returns the name of the tag that marks a template as used by a management cluster.
+// It includes the cluster UID, so management clusters with the same name don't collect each other's templates.
+func TemplateOwnerTag(managementCluster, uid string) string {
+	return fmt.Sprintf("%s:%s-%s", TemplateOwnerCategory, managementCluster, uid)
+}
+
+// ImportedTemplate is a VM template imported by EKS Anywhere.
+type ImportedTemplate struct {
+	// Path is the inventory path of the template.
+	Path string
+	// Owners are the owner tags of the management clusters using the template.
+	Owners []string
+	// Created is when the template VM was created.
+	Created time.Time
+}
+
+// ListImportedTemplates returns the templates in the folder that are tagged as imported or with at least one owner.
+func (vsc *VMOMIClient) ListImportedTemplates(ctx context.Context, folder string) ([]ImportedTemplate, error) {
+	f, err := vsc.Finder.Folder(ctx, folder)
+	if err != nil {
+		return nil, fmt.Errorf("getting templates folder %s: %v", folder, err)
+	}
+
+	children, err := f.Children(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing templates folder %s: %v", folder, err)
+	}
+
+	var refs []types.ManagedObjectReference
+	for _, child := range children {
+		if vm, ok := child.(*object.VirtualMachine); ok {
+			refs = append(refs, vm.Reference())
+		}
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	ownerTags, err := vsc.templateOwnerTags(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	if len(ownerTags) == 0 {
+		return nil, nil
+	}
+
+	var vms []mo.VirtualMachine
+	if err := property.DefaultCollector(f.Client()).Retrieve(ctx, refs, []string{"name", "config.template", "config.createDate"}, &vms); err != nil {
+		return nil, fmt.Errorf("getting VMs in templates folder %s: %v", folder, err)
+	}
+
+	var templates []ImportedTemplate
+	for _, vm := range vms {
+		names, ok := ownerTags[vm.Reference().Value]
+		if !ok || vm.Config == nil || !vm.Config.Template {
+			continue
+		}
+
+		t := ImportedTemplate{
+			Path: path.Join(f.InventoryPath, vm.Name),
+		}
+		for _, name := range names {
+			if name != TemplateImportedTag {
+				t.Owners = append(t.Owners, name)
+			}
+		}
+		if vm.Config.CreateDate != nil {
+			t.Created = *vm.Config.CreateDate
+		}
+		templates = append(templates, t)
+	}
+
+	return templates, nil
+}
+
+// AdoptImportedTemplate tags the template with the owner tag of a management cluster, creating the tag if
+// it doesn't exist. It's a noop if the template is already tagged with it.
+func (vsc *VMOMIClient) AdoptImportedTemplate(ctx context.Context, template, owner string) error {
+	vm, err := vsc.Finder.VirtualMachine(ctx, template)
+	if err != nil {
+		return fmt.Errorf("getting template %s: %v", template, err)
+	}
+
+	rc, err := vsc.restClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	m := tags.NewManager(rc)
+	category, err := findCategory(ctx, m, TemplateOwnerCategory)
+	if err != nil {
+		return err
+	}
+	if category == nil {
+		return fmt.Errorf("adding owner %s to template %s: tag category %s not found", owner, template, TemplateOwnerCategory)
+	}
+
+	categoryTags, err := m.GetTagsForCategory(ctx, category.ID)
+	if err != nil {
+		return fmt.Errorf("listing tags of category %s: %v", TemplateOwnerCategory, err)
+	}
+
+	tagID := ""
+	for _, tag := range categoryTags {
+		if tag.Name == owner {
+			tagID = tag.ID
+			break
+		}
+	}
+	if tagID == "" {
+		if tagID, err = m.CreateTag(ctx, &tags.Tag{Name: owner, CategoryID: category.ID}); err != nil {
+			return fmt.Errorf("creating owner tag %s: %v", owner, err)
+		}
+	}
+
+	if err := m.AttachTag(ctx, tagID, vm.Reference()); err != nil {
+		return fmt.Errorf("adding owner %s to template %s: %v", owner, template, err)
+	}
+
+	return nil
+}
+
+// ReleaseImportedTemplate removes the owner tag of a management cluster from the template.
+// It's a noop if the template isn't tagged with it.
+func (vsc *VMOMIClient) ReleaseImportedTemplate(ctx context.Context, template, owner string) error {
+	vm, err := vsc.Finder.VirtualMachine(ctx, template)
+	if err != nil {
+		return fmt.Errorf("getting template %s: %v", template, err)
+	}
+
+	rc, err := vsc.restClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := tags.NewManager(rc).DetachTag(ctx, owner, vm.Reference()); err != nil {
+		return fmt.Errorf("removing owner %s from template %s: %v", owner, template, err)
+	}
+
+	return nil
+}
+
+// DeleteImportedTemplate deletes the template and the content library item it was deployed from, if it still exists.
+func (vsc *VMOMIClient) DeleteImportedTemplate(ctx context.Context, template, templateLibrary string) error {
+	vm, err := vsc.Finder.VirtualMachine(ctx, template)
+	if err != nil {
+		return fmt.Errorf("getting template %s: %v", template, err)
+	}
+
+	task, err := vm.Destroy(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting template %s: %v", template, err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("deleting template %s: %v", template, err)
+	}
+
+	rc, err := vsc.restClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	m := library.NewManager(rc)
+	libraries, err := m.FindLibrary(ctx, library.Find{Name: templateLibrary})
+	if err != nil {
+		return fmt.Errorf("finding content library %s: %v", templateLibrary, err)
+	}
+
+	for _, id := range libraries {
+		items, err := m.FindLibraryItems(ctx, library.FindItem{LibraryID: id, Name: path.Base(template)})
+		if err != nil {
+			return fmt.Errorf("finding template %s in content library %s: %v", path.Base(template), templateLibrary, err)
+		}
+
+		for _, item := range items {
+			if err := m.DeleteLibraryItem(ctx, &library.Item{ID: item}); err != nil {
+				return fmt.Errorf("deleting template %s from content library %s: %v", path.Base(template), templateLibrary, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// templateOwnerTags returns the names of the tags of the owner category of the VMs by VM id.
+// VMs without any of these tags are not included.
+func (vsc *VMOMIClient) templateOwnerTags(ctx context.Context, vms []types.ManagedObjectReference) (map[string][]string, error) {
+	rc, err := vsc.restClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := tags.NewManager(rc)
+	category, err := findCategory(ctx, m, TemplateOwnerCategory)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, nil
+	}
+
+	objs := make([]mo.Reference, 0, len(vms))
+	for _, vm := range vms {
+		objs = append(objs, vm)
+	}
+
+	attached, err := m.GetAttachedTagsOnObjects(ctx, objs)
+	if err != nil {
+		return nil, fmt.Errorf("getting template tags: %v", err)
+	}
+
+	ownerTags := map[string][]string{}
+	for _, a := range attached {
+		for _, tag := range a.Tags {
+			if tag.CategoryID != category.ID {
+				continue
+			}
+			id := a.ObjectID.Reference().Value
+			ownerTags[id] = append(ownerTags[id], tag.Name)
+		}
+	}
+
+	return ownerTags, nil
+}
+
+func findCategory(ctx context.Context, m *tags.Manager, name string) (*tags.Category, error) {
+	categories, err := m.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing tag categories: %v", err)
+	}
+
+	for i := range categories {
+		if categories[i].Name == name {
+			return &categories[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// restClient returns a client for the vSphere Automation API, which manages tags and content libraries.
+// It logs in with the client credentials the first time it's called, unless Rest was already set.
+func (vsc *VMOMIClient) restClient(ctx context.Context) (*rest.Client, error) {
+	if vsc.Rest != nil {
+		return vsc.Rest, nil
+	}
+
+	if vsc.Gcvm == nil || vsc.userinfo == nil {
+		return nil, errors.New("vSphere client can't log in to the vSphere Automation API without credentials")
+	}
+
+	c := rest.NewClient(vsc.Gcvm.Client)
+	if err := c.Login(ctx, vsc.userinfo); err != nil {
+		return nil, fmt.Errorf("logging in to the vSphere Automation API: %v", err)
+	}
+	vsc.Rest = c
+
+	return c, nil
+}
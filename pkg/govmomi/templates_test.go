@@ -0,0 +1,259 @@
+package govmomi_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/rest"
+	_ "github.com/vmware/govmomi/vapi/simulator"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+)
+
+const (
+	simTemplatesFolder = "/DC0/vm/Templates"
+	simTemplate        = simTemplatesFolder + "/DC0_C0_RP0_VM0"
+)
+
+type templatesTest struct {
+	*WithT
+	ctx  context.Context
+	c    *vim25.Client
+	tags *tags.Manager
+	vsc  *govmomi.VMOMIClient
+}
+
+func newTemplatesTest(ctx context.Context, t *testing.T, c *vim25.Client) *templatesTest {
+	rc := rest.NewClient(c)
+	if err := rc.Login(ctx, simulator.DefaultLogin); err != nil {
+		t.Fatal(err)
+	}
+
+	vsc := newSimulatorClient(ctx, t, c)
+	vsc.Rest = rc
+
+	return &templatesTest{
+		WithT: NewWithT(t),
+		ctx:   ctx,
+		c:     c,
+		tags:  tags.NewManager(rc),
+		vsc:   vsc,
+	}
+}
+
+// moveToTemplates powers off the VM, converts it to a template and moves it to the templates folder.
+func (tt *templatesTest) moveToTemplates(vmPath string) *object.VirtualMachine {
+	f := find.NewFinder(tt.c)
+	vm, err := f.VirtualMachine(tt.ctx, vmPath)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	task, err := vm.PowerOff(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(task.Wait(tt.ctx)).To(Succeed())
+	tt.Expect(vm.MarkAsTemplate(tt.ctx)).To(Succeed())
+
+	folder, err := f.Folder(tt.ctx, simTemplatesFolder)
+	if err != nil {
+		vmFolder, err := f.Folder(tt.ctx, "/DC0/vm")
+		tt.Expect(err).NotTo(HaveOccurred())
+		folder, err = vmFolder.CreateFolder(tt.ctx, "Templates")
+		tt.Expect(err).NotTo(HaveOccurred())
+	}
+
+	task, err = folder.MoveInto(tt.ctx, []types.ManagedObjectReference{vm.Reference()})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(task.Wait(tt.ctx)).To(Succeed())
+
+	return vm
+}
+
+const (
+	simOwner1 = govmomi.TemplateOwnerCategory + ":mgmt-1-uid"
+	simOwner2 = govmomi.TemplateOwnerCategory + ":mgmt-2-uid"
+)
+
+func (tt *templatesTest) tagOwner(vm *object.VirtualMachine, tag string) {
+	category, err := tt.tags.GetCategory(tt.ctx, govmomi.TemplateOwnerCategory)
+	if err != nil {
+		_, err = tt.tags.CreateCategory(tt.ctx, &tags.Category{
+			Name:            govmomi.TemplateOwnerCategory,
+			Cardinality:     "MULTIPLE",
+			AssociableTypes: []string{"VirtualMachine"},
+		})
+		tt.Expect(err).NotTo(HaveOccurred())
+		category, err = tt.tags.GetCategory(tt.ctx, govmomi.TemplateOwnerCategory)
+		tt.Expect(err).NotTo(HaveOccurred())
+	}
+
+	existing, err := tt.tags.ListTagsForCategory(tt.ctx, category.ID)
+	tt.Expect(err).NotTo(HaveOccurred())
+	if !tt.hasTag(existing, tag) {
+		_, err = tt.tags.CreateTag(tt.ctx, &tags.Tag{Name: tag, CategoryID: category.ID})
+		tt.Expect(err).NotTo(HaveOccurred())
+	}
+
+	tt.Expect(tt.tags.AttachTag(tt.ctx, tag, vm)).To(Succeed())
+}
+
+func (tt *templatesTest) hasTag(ids []string, name string) bool {
+	for _, id := range ids {
+		tag, err := tt.tags.GetTag(tt.ctx, id)
+		tt.Expect(err).NotTo(HaveOccurred())
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestListImportedTemplates(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		tt := newTemplatesTest(ctx, t, c)
+		vm := tt.moveToTemplates(simVM0)
+		tt.moveToTemplates(simVM1)
+		tt.tagOwner(vm, govmomi.TemplateImportedTag)
+		tt.tagOwner(vm, simOwner1)
+		tt.tagOwner(vm, simOwner2)
+
+		templates, err := tt.vsc.ListImportedTemplates(ctx, simTemplatesFolder)
+		tt.Expect(err).NotTo(HaveOccurred())
+		tt.Expect(templates).To(HaveLen(1))
+		tt.Expect(templates[0].Path).To(Equal(simTemplate))
+		tt.Expect(templates[0].Owners).To(ConsistOf(simOwner1, simOwner2))
+		tt.Expect(templates[0].Created).NotTo(BeZero())
+	})
+}
+
+func TestListImportedTemplatesWithoutOwners(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		tt := newTemplatesTest(ctx, t, c)
+		vm := tt.moveToTemplates(simVM0)
+		tt.tagOwner(vm, govmomi.TemplateImportedTag)
+
+		templates, err := tt.vsc.ListImportedTemplates(ctx, simTemplatesFolder)
+		tt.Expect(err).NotTo(HaveOccurred())
+		tt.Expect(templates).To(HaveLen(1))
+		tt.Expect(templates[0].Path).To(Equal(simTemplate))
+		tt.Expect(templates[0].Owners).To(BeEmpty())
+	})
+}
+
+func TestListImportedTemplatesNoOwnerCategory(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		tt := newTemplatesTest(ctx, t, c)
+		tt.moveToTemplates(simVM0)
+
+		templates, err := tt.vsc.ListImportedTemplates(ctx, simTemplatesFolder)
+		tt.Expect(err).NotTo(HaveOccurred())
+		tt.Expect(templates).To(BeEmpty())
+	})
+}
+
+func TestListImportedTemplatesMissingFolder(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		tt := newTemplatesTest(ctx, t, c)
+
+		_, err := tt.vsc.ListImportedTemplates(ctx, simTemplatesFolder)
+		tt.Expect(err).To(MatchError(ContainSubstring("getting templates folder /DC0/vm/Templates")))
+	})
+}
+
+func TestReleaseImportedTemplate(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		tt := newTemplatesTest(ctx, t, c)
+		vm := tt.moveToTemplates(simVM0)
+		tt.tagOwner(vm, simOwner1)
+		tt.tagOwner(vm, simOwner2)
+
+		tt.Expect(tt.vsc.ReleaseImportedTemplate(ctx, simTemplate, simOwner1)).To(Succeed())
+
+		templates, err := tt.vsc.ListImportedTemplates(ctx, simTemplatesFolder)
+		tt.Expect(err).NotTo(HaveOccurred())
+		tt.Expect(templates).To(HaveLen(1))
+		tt.Expect(templates[0].Owners).To(ConsistOf(simOwner2))
+	})
+}
+
+func TestAdoptImportedTemplate(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		tt := newTemplatesTest(ctx, t, c)
+		vm := tt.moveToTemplates(simVM0)
+		tt.tagOwner(vm, govmomi.TemplateImportedTag)
+		tt.tagOwner(vm, simOwner2)
+
+		tt.Expect(tt.vsc.AdoptImportedTemplate(ctx, simTemplate, simOwner1)).To(Succeed())
+		// Adopting a template twice is a noop.
+		tt.Expect(tt.vsc.AdoptImportedTemplate(ctx, simTemplate, simOwner1)).To(Succeed())
+
+		templates, err := tt.vsc.ListImportedTemplates(ctx, simTemplatesFolder)
+		tt.Expect(err).NotTo(HaveOccurred())
+		tt.Expect(templates).To(HaveLen(1))
+		tt.Expect(templates[0].Owners).To(ConsistOf(simOwner1, simOwner2))
+	})
+}
+
+func TestAdoptImportedTemplateNoOwnerCategory(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		tt := newTemplatesTest(ctx, t, c)
+		tt.moveToTemplates(simVM0)
+
+		tt.Expect(tt.vsc.AdoptImportedTemplate(ctx, simTemplate, simOwner1)).To(
+			MatchError("adding owner " + simOwner1 + " to template " + simTemplate + ": tag category eksaTemplateOwner not found"),
+		)
+	})
+}
+
+func TestDeleteImportedTemplate(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		tt := newTemplatesTest(ctx, t, c)
+		tt.moveToTemplates(simVM0)
+
+		ds, err := find.NewFinder(c).Datastore(ctx, "/DC0/datastore/LocalDS_0")
+		tt.Expect(err).NotTo(HaveOccurred())
+		m := library.NewManager(tt.vsc.Rest)
+		libraryID, err := m.CreateLibrary(ctx, library.Library{
+			Name:    "eks-a-templates",
+			Type:    "LOCAL",
+			Storage: []library.StorageBacking{{DatastoreID: ds.Reference().Value, Type: "DATASTORE"}},
+		})
+		tt.Expect(err).NotTo(HaveOccurred())
+		_, err = m.CreateLibraryItem(ctx, library.Item{Name: "DC0_C0_RP0_VM0", Type: "ovf", LibraryID: libraryID})
+		tt.Expect(err).NotTo(HaveOccurred())
+
+		tt.Expect(tt.vsc.DeleteImportedTemplate(ctx, simTemplate, "eks-a-templates")).To(Succeed())
+
+		_, err = find.NewFinder(c).VirtualMachine(ctx, simTemplate)
+		tt.Expect(err).To(HaveOccurred())
+		items, err := m.FindLibraryItems(ctx, library.FindItem{LibraryID: libraryID, Name: "DC0_C0_RP0_VM0"})
+		tt.Expect(err).NotTo(HaveOccurred())
+		tt.Expect(items).To(BeEmpty())
+	})
+}
+
+func TestDeleteImportedTemplateNoLibrary(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		tt := newTemplatesTest(ctx, t, c)
+		tt.moveToTemplates(simVM0)
+
+		tt.Expect(tt.vsc.DeleteImportedTemplate(ctx, simTemplate, "eks-a-templates")).To(Succeed())
+	})
+}
+
+func TestImportedTemplatesWithoutCredentials(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		g := NewWithT(t)
+		vsc := newSimulatorClient(ctx, t, c)
+
+		g.Expect(vsc.ReleaseImportedTemplate(ctx, simVM0, simOwner1)).To(
+			MatchError("vSphere client can't log in to the vSphere Automation API without credentials"),
+		)
+	})
+}
@@ -1,10 +1,20 @@
 package bundles
 
-import releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+import (
+	"golang.org/x/exp/slices"
 
-func Charts(bundles *releasev1.Bundles) []releasev1.Image {
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// Charts returns a list of all charts included in the Bundles, filtered by kubernetes version.
+// If not kubernetes versions are provided, all charts are returned.
+func Charts(bundles *releasev1.Bundles, kubeVersions ...string) []releasev1.Image {
 	var charts []releasev1.Image
 	for _, v := range bundles.Spec.VersionsBundles {
+		if len(kubeVersions) > 0 && !slices.Contains(kubeVersions, v.KubeVersion) {
+			continue
+		}
+
 		versionsBundleCharts := v.Charts()
 		for _, c := range versionsBundleCharts {
 			charts = append(charts, *c)
@@ -22,3 +22,21 @@ func TestCharts(t *testing.T) {
 
 	g.Expect(bundles.Charts(b)).NotTo(BeEmpty())
 }
+
+func TestChartsFilteredByKubeVersion(t *testing.T) {
+	g := NewWithT(t)
+	b := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{
+				{KubeVersion: "1.29"},
+				{KubeVersion: "1.30"},
+			},
+		},
+	}
+
+	all := bundles.Charts(b)
+	filtered := bundles.Charts(b, "1.30")
+	g.Expect(filtered).NotTo(BeEmpty())
+	g.Expect(filtered).To(HaveLen(len(all) / 2))
+	g.Expect(bundles.Charts(b, "1.31")).To(BeEmpty())
+}
@@ -122,7 +122,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.APIServerExtraArgs)).
-		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster.Spec.EtcdEncryption)).
+		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster.ControlPlaneEtcdEncryption())).
 		Append(sharedExtraArgs)
 	clusterapi.SetPodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig, apiServerExtraArgs)
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
@@ -257,12 +257,18 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		values["maxSurge"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
 	}
 
-	if clusterSpec.Cluster.Spec.EtcdEncryption != nil && len(*clusterSpec.Cluster.Spec.EtcdEncryption) != 0 {
-		conf, err := common.GenerateKMSEncryptionConfiguration(clusterSpec.Cluster.Spec.EtcdEncryption)
+	if etcdEncryption := clusterSpec.Cluster.ControlPlaneEtcdEncryption(); etcdEncryption != nil && len(*etcdEncryption) != 0 {
+		conf, err := common.GenerateKMSEncryptionConfiguration(etcdEncryption)
 		if err != nil {
 			return nil, err
 		}
 		values["encryptionProviderConfig"] = conf
+
+		kmsPluginManifests, err := common.GenerateKMSPluginManifests(etcdEncryption)
+		if err != nil {
+			return nil, err
+		}
+		values["kmsPluginManifests"] = kmsPluginManifests
 	}
 
 	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration != nil {
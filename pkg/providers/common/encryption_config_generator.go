@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiserverv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
 	"sigs.k8s.io/yaml"
@@ -15,6 +16,8 @@ const (
 	encryptionConfigurationKind  = "EncryptionConfiguration"
 	encryptionProviderVersion    = "v1"
 	encryptionProviderNamePrefix = "aws-encryption-provider"
+	kmsPluginManifestsDir        = "/etc/kubernetes/manifests"
+	kmsPluginVolumeName          = "kms-plugin"
 )
 
 var identityProvider = apiserverv1.ProviderConfiguration{
@@ -65,3 +68,88 @@ func GenerateKMSEncryptionConfiguration(confs *[]v1alpha1.EtcdEncryption) (strin
 	}
 	return strings.Trim(string(marshaledConf), "\n"), nil
 }
+
+// KMSPluginManifest is the static pod manifest of a KMS plugin and its path in the control plane nodes.
+type KMSPluginManifest struct {
+	Path    string
+	Content string
+}
+
+// GenerateKMSPluginManifests generates the static pod manifests for the KMS providers with a plugin
+// in the EtcdEncryption configs. The plugins share the socket directory mounted in the kube-apiserver.
+func GenerateKMSPluginManifests(confs *[]v1alpha1.EtcdEncryption) ([]KMSPluginManifest, error) {
+	if confs == nil {
+		return nil, nil
+	}
+
+	var manifests []KMSPluginManifest
+	for _, conf := range *confs {
+		for _, provider := range conf.Providers {
+			if provider.KMS == nil || provider.KMS.Plugin == nil {
+				continue
+			}
+			content, err := kmsPluginPod(provider.KMS)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, KMSPluginManifest{
+				Path:    fmt.Sprintf("%s/kms-plugin-%s.yaml", kmsPluginManifestsDir, provider.KMS.Name),
+				Content: content,
+			})
+		}
+	}
+	return manifests, nil
+}
+
+func kmsPluginPod(kms *v1alpha1.KMS) (string, error) {
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	pod := &corev1.Pod{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "kms-plugin-" + kms.Name,
+			Namespace: "kube-system",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":     "kms-plugin",
+				"app.kubernetes.io/instance": kms.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			HostNetwork:       true,
+			PriorityClassName: "system-node-critical",
+			Containers: []corev1.Container{
+				{
+					Name:    "kms-plugin",
+					Image:   kms.Plugin.Image,
+					Command: kms.Plugin.Command,
+					Args:    kms.Plugin.Args,
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      kmsPluginVolumeName,
+							MountPath: v1alpha1.KMSPluginSocketDir,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: kmsPluginVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: v1alpha1.KMSPluginSocketDir,
+							Type: &hostPathType,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	marshaledPod, err := yaml.Marshal(pod)
+	if err != nil {
+		return "", fmt.Errorf("marshaling kms plugin %s pod: %v", kms.Name, err)
+	}
+	return strings.Trim(string(marshaledPod), "\n"), nil
+}
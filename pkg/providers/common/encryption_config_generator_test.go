@@ -98,3 +98,42 @@ func TestGenerateEncryptionConfiguration(t *testing.T) {
 	}
 	test.AssertContentToFile(t, conf, expectedEncryptionConfig)
 }
+
+func TestGenerateKMSPluginManifests(t *testing.T) {
+	g := NewWithT(t)
+	encryptionConf := &[]v1alpha1.EtcdEncryption{
+		{
+			Providers: []v1alpha1.EtcdEncryptionProvider{
+				{
+					KMS: &v1alpha1.KMS{
+						Name:                "kms-2",
+						SocketListenAddress: "unix:///var/run/kmsplugin/kms-2.sock",
+						Plugin: &v1alpha1.KMSPlugin{
+							Image:   "public.ecr.aws/kms-plugin:v2",
+							Command: []string{"/kms-plugin"},
+							Args:    []string{"--listen=/var/run/kmsplugin/kms-2.sock"},
+						},
+					},
+				},
+				{
+					KMS: &v1alpha1.KMS{
+						Name:                "kms-1",
+						SocketListenAddress: "unix:///var/run/kmsplugin/kms-1.sock",
+					},
+				},
+			},
+			Resources: []string{"secrets"},
+		},
+	}
+
+	manifests, err := GenerateKMSPluginManifests(encryptionConf)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(manifests).To(HaveLen(1))
+	g.Expect(manifests[0].Path).To(Equal("/etc/kubernetes/manifests/kms-plugin-kms-2.yaml"))
+	test.AssertContentToFile(t, manifests[0].Content, "testdata/expected_kms_plugin_pod.yaml")
+}
+
+func TestGenerateKMSPluginManifestsEmpty(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(GenerateKMSPluginManifests(nil)).To(BeEmpty())
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	capxv1beta1 "github.com/nutanix-cloud-native/cluster-api-provider-nutanix/api/v1beta1"
 	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
@@ -57,6 +58,26 @@ func NewNutanixTemplateBuilder(
 	}
 }
 
+// TemplateBuilderForSpec returns a TemplateBuilder configured with the datacenter and machine configs of a cluster spec.
+// The machine configs are defaulted on a copy, so the spec is not modified.
+// The Nutanix credentials are read from the environment.
+func TemplateBuilderForSpec(spec *cluster.Spec) *TemplateBuilder {
+	machineConfigs := make(map[string]*v1alpha1.NutanixMachineConfig, len(spec.NutanixMachineConfigs))
+	for name, machineConfig := range spec.NutanixMachineConfigs {
+		machineConfigs[name] = machineConfig.DeepCopy()
+		machineConfigs[name].SetDefaults()
+	}
+	controlPlaneMachineSpec, etcdMachineSpec := getControlPlaneMachineSpecs(machineConfigs, &spec.Cluster.Spec.ControlPlaneConfiguration, spec.Cluster.Spec.ExternalEtcdConfiguration)
+	workerNodeGroupMachineSpecs := make(map[string]v1alpha1.NutanixMachineConfigSpec, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroupConfiguration := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if machineConfig, ok := machineConfigs[workerNodeGroupConfiguration.MachineGroupRef.Name]; ok {
+			workerNodeGroupMachineSpecs[workerNodeGroupConfiguration.MachineGroupRef.Name] = machineConfig.Spec
+		}
+	}
+
+	return NewNutanixTemplateBuilder(&spec.NutanixDatacenter.Spec, controlPlaneMachineSpec, etcdMachineSpec, workerNodeGroupMachineSpecs, GetCredsFromEnv(), time.Now)
+}
+
 func (ntb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spec, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
 	var etcdMachineSpec v1alpha1.NutanixMachineConfigSpec
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
@@ -167,7 +188,7 @@ func buildTemplateMapCP(
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.APIServerExtraArgs)).
-		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster.Spec.EtcdEncryption))
+		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster.ControlPlaneEtcdEncryption()))
 	clusterapi.SetPodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig, apiServerExtraArgs)
 
 	var auditPolicy string
@@ -335,13 +356,19 @@ func buildTemplateMapCP(
 	if etcdURL != "" {
 		values["externalEtcdReleaseUrl"] = etcdURL
 	}
-	if clusterSpec.Cluster.Spec.EtcdEncryption != nil && len(*clusterSpec.Cluster.Spec.EtcdEncryption) != 0 {
-		conf, err := common.GenerateKMSEncryptionConfiguration(clusterSpec.Cluster.Spec.EtcdEncryption)
+	if etcdEncryption := clusterSpec.Cluster.ControlPlaneEtcdEncryption(); etcdEncryption != nil && len(*etcdEncryption) != 0 {
+		conf, err := common.GenerateKMSEncryptionConfiguration(etcdEncryption)
 		if err != nil {
 			return nil, err
 		}
 
 		values["encryptionProviderConfig"] = conf
+
+		kmsPluginManifests, err := common.GenerateKMSPluginManifests(etcdEncryption)
+		if err != nil {
+			return nil, err
+		}
+		values["kmsPluginManifests"] = kmsPluginManifests
 	}
 
 	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration != nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
 
@@ -701,6 +702,52 @@ func TestTemplateBuilderEtcdEncryptionKubernetes129(t *testing.T) {
 	}
 }
 
+func TestTemplateBuilderEtcdEncryptionKeyRotation(t *testing.T) {
+	clusterSpec := test.NewFullClusterSpec(t, "testdata/cluster_nutanix_etcd_encryption.yaml")
+	oldProvider := anywherev1.EtcdEncryptionProvider{
+		KMS: &anywherev1.KMS{
+			Name:                "kms-1",
+			SocketListenAddress: "unix:///var/run/kmsplugin/kms-1.sock",
+			CacheSize:           anywherev1.DefaultKMSCacheSize,
+			Timeout:             &anywherev1.DefaultKMSTimeout,
+			Plugin:              &anywherev1.KMSPlugin{Image: "public.ecr.aws/kms-plugin:v1", Args: []string{"--listen=/var/run/kmsplugin/kms-1.sock"}},
+		},
+	}
+	newProvider := anywherev1.EtcdEncryptionProvider{
+		KMS: &anywherev1.KMS{
+			Name:                "kms-2",
+			SocketListenAddress: "unix:///var/run/kmsplugin/kms-2.sock",
+			CacheSize:           anywherev1.DefaultKMSCacheSize,
+			Timeout:             &anywherev1.DefaultKMSTimeout,
+			Plugin:              &anywherev1.KMSPlugin{Image: "public.ecr.aws/kms-plugin:v1", Args: []string{"--listen=/var/run/kmsplugin/kms-2.sock"}},
+		},
+	}
+	clusterSpec.Cluster.Spec.EtcdEncryption = &[]anywherev1.EtcdEncryption{
+		{
+			Providers: []anywherev1.EtcdEncryptionProvider{newProvider},
+			Resources: []string{"secrets"},
+		},
+	}
+	clusterSpec.Cluster.Status.EtcdEncryption = &anywherev1.EtcdEncryptionStatus{
+		Providers:        []anywherev1.EtcdEncryptionProvider{newProvider, oldProvider},
+		KeyRotationPhase: anywherev1.EtcdEncryptionSwitchingProvider,
+	}
+
+	machineCfg := clusterSpec.NutanixMachineConfig(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name)
+
+	t.Setenv(constants.EksaNutanixUsernameKey, "admin")
+	t.Setenv(constants.EksaNutanixPasswordKey, "password")
+	creds := GetCredsFromEnv()
+
+	bldr := NewNutanixTemplateBuilder(&clusterSpec.NutanixDatacenter.Spec, &machineCfg.Spec, nil,
+		map[string]anywherev1.NutanixMachineConfigSpec{}, creds, time.Now)
+
+	data, err := bldr.GenerateCAPISpecControlPlane(clusterSpec)
+	assert.NoError(t, err)
+
+	test.AssertContentToFile(t, string(data), "testdata/expected_results_etcd_encryption_key_rotation.yaml")
+}
+
 func TestTemplateBuilderFailureDomains(t *testing.T) {
 	for _, tc := range []struct {
 		Input  string
@@ -1012,3 +1059,21 @@ func minimalNutanixConfigSpec(t *testing.T) (*anywherev1.NutanixDatacenterConfig
 
 	return dcConf, machineConf, workerConfs
 }
+
+func TestTemplateBuilderForSpec(t *testing.T) {
+	t.Setenv(constants.EksaNutanixUsernameKey, "admin")
+	t.Setenv(constants.EksaNutanixPasswordKey, "password")
+	clusterSpec := test.NewFullClusterSpec(t, "testdata/eksa-cluster.yaml")
+	machineConfig := clusterSpec.NutanixMachineConfig("eksa-unit-test")
+	machineConfig.Spec.MemorySize = resource.MustParse("16Gi")
+	original := machineConfig.DeepCopy()
+
+	bldr := TemplateBuilderForSpec(clusterSpec)
+	names := map[string]string{"eksa-unit-test": "eksa-unit-test"}
+	data, err := bldr.GenerateCAPISpecWorkers(clusterSpec, names, names)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), "vcpuSockets: 4")
+	assert.Contains(t, string(data), "memorySize: 16Gi")
+	assert.Equal(t, original, clusterSpec.NutanixMachineConfig("eksa-unit-test"))
+}
@@ -148,7 +148,7 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 	}
 	clusterSpec.TinkerbellMachineConfigs = p.machineConfigs
 	if p.hardwareCSVIsProvided() {
-		if err := p.readCSVToCatalogue(); err != nil {
+		if err := p.readCSVToCatalogue(ctx); err != nil {
 			return err
 		}
 	}
@@ -255,10 +255,17 @@ func (p *Provider) getHardwareFromManagementCluster(ctx context.Context, cluster
 	return nil
 }
 
-func (p *Provider) readCSVToCatalogue() error {
+func (p *Provider) readCSVToCatalogue(ctx context.Context) error {
 	// Create a catalogue writer used to write hardware to the catalogue.
 	catalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
 
+	// The BMCs are checked once all the machines are read, so all the unreachable ones are reported together.
+	var bmcChecker *hardware.BMCConnectivityChecker
+	if p.BMCPowerStateReader != nil {
+		bmcChecker = hardware.NewBMCConnectivityChecker(p.BMCPowerStateReader, bmcConnectivityConcurrency)
+		catalogueWriter = hardware.MultiMachineWriter(catalogueWriter, bmcChecker)
+	}
+
 	machineValidator := hardware.NewDefaultMachineValidator()
 
 	// Translate all Machine instances from the p.machines source into Kubernetes object types.
@@ -269,5 +276,13 @@ func (p *Provider) readCSVToCatalogue() error {
 		return err
 	}
 
-	return hardware.TranslateAll(machines, catalogueWriter, machineValidator)
+	if err := hardware.TranslateAll(machines, catalogueWriter, machineValidator); err != nil {
+		return err
+	}
+
+	if bmcChecker != nil {
+		return bmcChecker.Check(ctx)
+	}
+
+	return nil
 }
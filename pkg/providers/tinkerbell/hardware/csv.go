@@ -117,3 +117,22 @@ func BuildHardwareYAML(path string, opts *BMCOptions) ([]byte, error) {
 
 	return unstructuredutil.StripNull(b.Bytes())
 }
+
+// BuildCatalogueFromCSV builds a Catalogue with the Hardware, BMC Machines and Secrets of every entry in
+// the csv at the provided path. Entries are validated with the default MachineAssertions.
+func BuildCatalogueFromCSV(path string, opts *BMCOptions) (*Catalogue, error) {
+	reader, err := NewNormalizedCSVReaderFromFile(path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("reading csv: %v", err)
+	}
+
+	catalogue := NewCatalogue()
+	writer := NewMachineCatalogueWriter(catalogue)
+	validator := NewDefaultMachineValidator()
+
+	if err := TranslateAll(reader, writer, validator); err != nil {
+		return nil, fmt.Errorf("building hardware catalogue: %v", err)
+	}
+
+	return catalogue, nil
+}
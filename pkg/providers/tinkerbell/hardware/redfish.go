@@ -0,0 +1,245 @@
+package hardware
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	redfishSessionsPath = "/redfish/v1/SessionService/Sessions"
+	redfishSystemsPath  = "/redfish/v1/Systems"
+	redfishTokenHeader  = "X-Auth-Token"
+	defaultRedfishPort  = 443
+)
+
+// BMCPowerStateReader reads the power state of the machine managed by a BMC.
+type BMCPowerStateReader interface {
+	PowerState(ctx context.Context, host, username, password string) (string, error)
+}
+
+// BMCConnectivityChecker collects the Machines written to it and checks that their BMCs are reachable with the
+// Machine credentials by reading their power state. Machines without a BMC and machines using the RPC provider,
+// which doesn't use credentials, are ignored.
+type BMCConnectivityChecker struct {
+	reader      BMCPowerStateReader
+	concurrency int
+	machines    []Machine
+}
+
+// NewBMCConnectivityChecker returns a BMCConnectivityChecker that reads the power state of up to concurrency
+// BMCs at the same time.
+func NewBMCConnectivityChecker(reader BMCPowerStateReader, concurrency int) *BMCConnectivityChecker {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &BMCConnectivityChecker{
+		reader:      reader,
+		concurrency: concurrency,
+	}
+}
+
+// Write implements MachineWriter. It saves the Machine to be checked by Check.
+func (c *BMCConnectivityChecker) Write(m Machine) error {
+	if !m.HasBMC() || (m.BMCOptions != nil && m.BMCOptions.RPC != nil && m.BMCOptions.RPC.ConsumerURL != "") {
+		return nil
+	}
+	c.machines = append(c.machines, m)
+	return nil
+}
+
+// Check checks the BMCs of all the Machines written so far and returns an error listing every BMC that
+// is not reachable.
+func (c *BMCConnectivityChecker) Check(ctx context.Context) error {
+	errs := make([]error, len(c.machines))
+	sem := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
+	for i, m := range c.machines {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			state, err := c.reader.PowerState(ctx, m.BMCIPAddress, m.BMCUsername, m.BMCPassword)
+			if err != nil {
+				errs[i] = fmt.Errorf("hardware %s: BMC %s: %v", m.Hostname, m.BMCIPAddress, err)
+				return
+			}
+			logger.V(2).Info("BMC is reachable", "hardware", m.Hostname, "bmc", m.BMCIPAddress, "powerState", state)
+		}()
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d BMCs are not reachable:\n- %s", len(failed), len(c.machines), strings.Join(failed, "\n- "))
+	}
+
+	return nil
+}
+
+// RedfishClient reads the power state of machines by opening a Redfish session with their BMC.
+type RedfishClient struct {
+	httpClient *http.Client
+	port       int
+}
+
+// RedfishClientOpt configures a RedfishClient.
+type RedfishClientOpt func(*RedfishClient)
+
+// WithRedfishPort sets the port the BMC serves the Redfish API on. It defaults to 443.
+func WithRedfishPort(port int) RedfishClientOpt {
+	return func(c *RedfishClient) {
+		c.port = port
+	}
+}
+
+// NewRedfishClient returns a new RedfishClient. Each request to a BMC fails after timeout.
+func NewRedfishClient(timeout time.Duration, opts ...RedfishClientOpt) *RedfishClient {
+	c := &RedfishClient{
+		httpClient: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				// BMCs serve self-signed certificates. This is consistent with the Rufio Machines we create.
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec G402
+			},
+		},
+		port: defaultRedfishPort,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// PowerState opens a Redfish session with the BMC at host and returns the power state of its first system.
+// The session is closed before returning.
+func (c *RedfishClient) PowerState(ctx context.Context, host, username, password string) (string, error) {
+	baseURL := "https://" + net.JoinHostPort(host, strconv.Itoa(c.port))
+
+	token, session, err := c.openSession(ctx, baseURL, username, password)
+	if err != nil {
+		return "", err
+	}
+	defer c.closeSession(ctx, baseURL, session, token)
+
+	systems := struct {
+		Members []struct {
+			ID string `json:"@odata.id"`
+		} `json:"Members"`
+	}{}
+	if err := c.get(ctx, baseURL+redfishSystemsPath, token, &systems); err != nil {
+		return "", fmt.Errorf("listing systems: %v", err)
+	}
+	if len(systems.Members) == 0 {
+		return "", fmt.Errorf("BMC doesn't manage any system")
+	}
+
+	system := struct {
+		PowerState string `json:"PowerState"`
+	}{}
+	if err := c.get(ctx, baseURL+systems.Members[0].ID, token, &system); err != nil {
+		return "", fmt.Errorf("reading system: %v", err)
+	}
+
+	return system.PowerState, nil
+}
+
+func (c *RedfishClient) openSession(ctx context.Context, baseURL, username, password string) (token, session string, err error) {
+	body, err := json.Marshal(map[string]string{"UserName": username, "Password": password})
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+redfishSessionsPath, bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("connecting to Redfish API: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", "", fmt.Errorf("authentication failed, check the BMC username and password")
+	default:
+		return "", "", fmt.Errorf("opening Redfish session: unexpected status %s", resp.Status)
+	}
+
+	token = resp.Header.Get(redfishTokenHeader)
+	if token == "" {
+		return "", "", fmt.Errorf("opening Redfish session: response is missing the %s header", redfishTokenHeader)
+	}
+
+	return token, resp.Header.Get("Location"), nil
+}
+
+func (c *RedfishClient) closeSession(ctx context.Context, baseURL, session, token string) {
+	if session == "" {
+		return
+	}
+	if session[0] == '/' {
+		session = baseURL + session
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, session, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set(redfishTokenHeader, token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		logger.V(4).Info("Failed closing Redfish session", "session", session, "error", err)
+		return
+	}
+	resp.Body.Close()
+}
+
+func (c *RedfishClient) get(ctx context.Context, url, token string, obj interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(redfishTokenHeader, token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, obj)
+}
@@ -0,0 +1,221 @@
+package hardware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// fakeRedfish is a minimal Redfish API that supports session authentication and reading the power state
+// of a single system.
+type fakeRedfish struct {
+	username   string
+	password   string
+	powerState string
+
+	mu       sync.Mutex
+	sessions map[string]bool
+}
+
+func newFakeRedfishServer(t *testing.T, f *fakeRedfish) (host string, port int) {
+	t.Helper()
+	f.sessions = map[string]bool{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		creds := struct{ UserName, Password string }{}
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if creds.UserName != f.username || creds.Password != f.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.mu.Lock()
+		f.sessions["token-1"] = true
+		f.mu.Unlock()
+		w.Header().Set("X-Auth-Token", "token-1")
+		w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("DELETE /redfish/v1/SessionService/Sessions/1", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		delete(f.sessions, r.Header.Get("X-Auth-Token"))
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /redfish/v1/Systems", f.authenticated(map[string]interface{}{
+		"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1"}},
+	}))
+	mux.HandleFunc("GET /redfish/v1/Systems/1", f.authenticated(map[string]interface{}{
+		"PowerState": f.powerState,
+	}))
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	h, p, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err = strconv.Atoi(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return h, port
+}
+
+func (f *fakeRedfish) authenticated(body interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		ok := f.sessions[r.Header.Get("X-Auth-Token")]
+		f.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func (f *fakeRedfish) openSessions() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.sessions)
+}
+
+func TestRedfishClientPowerState(t *testing.T) {
+	g := NewWithT(t)
+	f := &fakeRedfish{username: "admin", password: "password", powerState: "On"}
+	host, port := newFakeRedfishServer(t, f)
+	client := hardware.NewRedfishClient(5*time.Second, hardware.WithRedfishPort(port))
+
+	state, err := client.PowerState(context.Background(), host, "admin", "password")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(state).To(Equal("On"))
+	g.Expect(f.openSessions()).To(BeZero())
+}
+
+func TestRedfishClientPowerStateInvalidCredentials(t *testing.T) {
+	g := NewWithT(t)
+	f := &fakeRedfish{username: "admin", password: "password", powerState: "On"}
+	host, port := newFakeRedfishServer(t, f)
+	client := hardware.NewRedfishClient(5*time.Second, hardware.WithRedfishPort(port))
+
+	_, err := client.PowerState(context.Background(), host, "admin", "wrong")
+	g.Expect(err).To(MatchError(ContainSubstring("authentication failed")))
+}
+
+func TestRedfishClientPowerStateUnreachable(t *testing.T) {
+	g := NewWithT(t)
+	// Reserve a port and release it so nothing is listening on it.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).ToNot(HaveOccurred())
+	port := l.Addr().(*net.TCPAddr).Port
+	g.Expect(l.Close()).To(Succeed())
+	client := hardware.NewRedfishClient(5*time.Second, hardware.WithRedfishPort(port))
+
+	_, err = client.PowerState(context.Background(), "127.0.0.1", "admin", "password")
+	g.Expect(err).To(MatchError(ContainSubstring("connecting to Redfish API")))
+}
+
+type powerStateReaderFunc func(ctx context.Context, host, username, password string) (string, error)
+
+func (f powerStateReaderFunc) PowerState(ctx context.Context, host, username, password string) (string, error) {
+	return f(ctx, host, username, password)
+}
+
+func TestBMCConnectivityChecker(t *testing.T) {
+	g := NewWithT(t)
+	f := &fakeRedfish{username: "admin", password: "password", powerState: "Off"}
+	host, port := newFakeRedfishServer(t, f)
+	checker := hardware.NewBMCConnectivityChecker(hardware.NewRedfishClient(5*time.Second, hardware.WithRedfishPort(port)), 2)
+
+	m := NewValidMachine()
+	m.BMCIPAddress = host
+	m.BMCUsername = "admin"
+	m.BMCPassword = "password"
+	g.Expect(checker.Write(m)).To(Succeed())
+	g.Expect(checker.Check(context.Background())).To(Succeed())
+
+	m.Hostname = "wrong-password"
+	m.BMCPassword = "wrong"
+	g.Expect(checker.Write(m)).To(Succeed())
+	g.Expect(checker.Check(context.Background())).To(MatchError(And(
+		ContainSubstring("1 of 2 BMCs are not reachable"),
+		ContainSubstring("hardware wrong-password: BMC "+host+": authentication failed"),
+	)))
+}
+
+func TestBMCConnectivityCheckerReportsAllFailures(t *testing.T) {
+	g := NewWithT(t)
+	const concurrency = 3
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	reader := powerStateReaderFunc(func(_ context.Context, host, _, _ string) (string, error) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		if host == "10.10.10.1" || host == "10.10.10.7" {
+			return "", errors.New("connection refused")
+		}
+		return "On", nil
+	})
+	checker := hardware.NewBMCConnectivityChecker(reader, concurrency)
+
+	for i := 0; i < 10; i++ {
+		m := NewValidMachine()
+		m.Hostname = fmt.Sprintf("node-%d", i)
+		m.BMCIPAddress = fmt.Sprintf("10.10.10.%d", i)
+		g.Expect(checker.Write(m)).To(Succeed())
+	}
+
+	err := checker.Check(context.Background())
+	g.Expect(err).To(MatchError("2 of 10 BMCs are not reachable:\n" +
+		"- hardware node-1: BMC 10.10.10.1: connection refused\n" +
+		"- hardware node-7: BMC 10.10.10.7: connection refused"))
+	g.Expect(maxRunning).To(BeNumerically("<=", concurrency))
+}
+
+func TestBMCConnectivityCheckerSkipsMachinesWithoutCredentials(t *testing.T) {
+	g := NewWithT(t)
+	reader := powerStateReaderFunc(func(_ context.Context, host, _, _ string) (string, error) {
+		return "", fmt.Errorf("unexpected check of BMC %s", host)
+	})
+	checker := hardware.NewBMCConnectivityChecker(reader, 1)
+
+	m := NewValidMachine()
+	m.BMCIPAddress = ""
+	m.BMCUsername = ""
+	m.BMCPassword = ""
+	g.Expect(checker.Write(m)).To(Succeed())
+
+	m = NewValidMachine()
+	m.BMCOptions = &hardware.BMCOptions{RPC: &hardware.RPCOpts{ConsumerURL: "https://consumer"}}
+	g.Expect(checker.Write(m)).To(Succeed())
+
+	g.Expect(checker.Check(context.Background())).To(Succeed())
+}
@@ -0,0 +1,349 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rufiov1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/rufio"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// tinkerbellLabelPrefix is the prefix of the labels CAPT sets on the Hardware it provisions.
+const tinkerbellLabelPrefix = "v1alpha1.tinkerbell.org/"
+
+// ChangeType is the kind of change a sync makes to an object.
+type ChangeType string
+
+const (
+	// ChangeAdd creates an object that doesn't exist in the cluster.
+	ChangeAdd ChangeType = "add"
+	// ChangeUpdate updates an existing object that differs from the desired one.
+	ChangeUpdate ChangeType = "change"
+	// ChangeRemove deletes an object that is not desired anymore.
+	ChangeRemove ChangeType = "remove"
+)
+
+// Change is a single change to a Hardware, BMC Machine or Secret object.
+type Change struct {
+	Type ChangeType
+	Kind string
+	Name string
+	// Reason explains why a change is refused. It's only set for refused changes.
+	Reason string
+
+	object client.Object
+}
+
+// SyncPlan contains the changes needed to make the hardware inventory in a cluster match a Catalogue.
+type SyncPlan struct {
+	// Changes are ordered so Secrets and BMC Machines are created before the Hardware
+	// referencing them, and Hardware is removed before the BMC Machines and Secrets it references.
+	Changes []Change
+	// Refused are changes to Hardware in use by a cluster Machine. They are never applied.
+	Refused []Change
+}
+
+// Empty returns true if the plan doesn't have any change to apply.
+func (p *SyncPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Syncer reconciles the Hardware, BMC Machines and Secrets in a cluster with a desired Catalogue.
+type Syncer struct {
+	client client.Client
+	prune  bool
+}
+
+// SyncerOpt configures a Syncer.
+type SyncerOpt func(*Syncer)
+
+// WithPrune makes the Syncer remove the Hardware, BMC Machines and Secrets that are not in the desired
+// Catalogue. By default, it only adds and updates objects.
+func WithPrune() SyncerOpt {
+	return func(s *Syncer) {
+		s.prune = true
+	}
+}
+
+// NewSyncer returns a new Syncer.
+func NewSyncer(client client.Client, opts ...SyncerOpt) *Syncer {
+	s := &Syncer{client: client}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+type inventory struct {
+	hardware map[string]*tinkv1alpha1.Hardware
+	bmcs     map[string]*rufiov1alpha1.Machine
+	secrets  map[string]*corev1.Secret
+}
+
+// Plan computes the changes needed to make the cluster hardware inventory match desired.
+// Objects not in desired are only removed when pruning is enabled. Hardware in use by a Machine
+// is never removed nor modified. The BMC Machines and Secrets it references are kept as well.
+func (s *Syncer) Plan(ctx context.Context, desired *Catalogue) (*SyncPlan, error) {
+	current, err := s.readInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &SyncPlan{}
+
+	desiredHardware := map[string]bool{}
+	var hardwareChanges []Change
+	for _, hw := range desired.AllHardware() {
+		desiredHardware[hw.Name] = true
+		existing, ok := current.hardware[hw.Name]
+		if !ok {
+			hardwareChanges = append(hardwareChanges, Change{Type: ChangeAdd, Kind: tinkerbellHardwareKind, Name: hw.Name, object: hw})
+			continue
+		}
+
+		updated := existing.DeepCopy()
+		updated.Spec = hw.Spec
+		updated.Labels = mergeHardwareLabels(existing.Labels, hw.Labels)
+		if equality.Semantic.DeepEqual(existing.Spec, updated.Spec) && equality.Semantic.DeepEqual(existing.Labels, updated.Labels) {
+			continue
+		}
+
+		change := Change{Type: ChangeUpdate, Kind: tinkerbellHardwareKind, Name: hw.Name, object: updated}
+		if owner := hardwareOwner(existing); owner != "" {
+			change.Reason = fmt.Sprintf("in use by Machine %s", owner)
+			plan.Refused = append(plan.Refused, change)
+			continue
+		}
+		hardwareChanges = append(hardwareChanges, change)
+	}
+
+	desiredBMCs := map[string]bool{}
+	var bmcChanges []Change
+	for _, bmc := range desired.AllBMCs() {
+		desiredBMCs[bmc.Name] = true
+		existing, ok := current.bmcs[bmc.Name]
+		if !ok {
+			bmcChanges = append(bmcChanges, Change{Type: ChangeAdd, Kind: tinkerbellBMCKind, Name: bmc.Name, object: bmc})
+			continue
+		}
+
+		if equality.Semantic.DeepEqual(existing.Spec, bmc.Spec) {
+			continue
+		}
+		updated := existing.DeepCopy()
+		updated.Spec = bmc.Spec
+		bmcChanges = append(bmcChanges, Change{Type: ChangeUpdate, Kind: tinkerbellBMCKind, Name: bmc.Name, object: updated})
+	}
+
+	desiredSecrets := map[string]bool{}
+	var secretChanges []Change
+	for _, secret := range desired.AllSecrets() {
+		desiredSecrets[secret.Name] = true
+		existing, ok := current.secrets[secret.Name]
+		if !ok {
+			secretChanges = append(secretChanges, Change{Type: ChangeAdd, Kind: secretKind, Name: secret.Name, object: secret})
+			continue
+		}
+
+		if existing.Type == secret.Type && equality.Semantic.DeepEqual(existing.Data, secret.Data) {
+			continue
+		}
+		updated := existing.DeepCopy()
+		updated.Type = secret.Type
+		updated.Data = secret.Data
+		secretChanges = append(secretChanges, Change{Type: ChangeUpdate, Kind: secretKind, Name: secret.Name, object: updated})
+	}
+
+	var removals []Change
+	if s.prune {
+		removals = planRemovals(current, desiredHardware, desiredBMCs, desiredSecrets, plan)
+	}
+
+	plan.Changes = append(plan.Changes, sortedByName(secretChanges)...)
+	plan.Changes = append(plan.Changes, sortedByName(bmcChanges)...)
+	plan.Changes = append(plan.Changes, sortedByName(hardwareChanges)...)
+	plan.Changes = append(plan.Changes, removals...)
+
+	return plan, nil
+}
+
+// planRemovals returns the changes to remove the objects in current that are not desired. Hardware in use by
+// a Machine is refused, and the BMC Machines and Secrets it references are kept.
+func planRemovals(current *inventory, desiredHardware, desiredBMCs, desiredSecrets map[string]bool, plan *SyncPlan) []Change {
+	var removals []Change
+	keepBMCs := map[string]bool{}
+	keepSecrets := map[string]bool{}
+
+	for _, name := range sortedKeys(current.hardware) {
+		if desiredHardware[name] {
+			continue
+		}
+		hw := current.hardware[name]
+		change := Change{Type: ChangeRemove, Kind: tinkerbellHardwareKind, Name: name, object: hw}
+		if owner := hardwareOwner(hw); owner != "" {
+			change.Reason = fmt.Sprintf("in use by Machine %s", owner)
+			plan.Refused = append(plan.Refused, change)
+			if hw.Spec.BMCRef != nil {
+				keepBMCs[hw.Spec.BMCRef.Name] = true
+				if bmc, ok := current.bmcs[hw.Spec.BMCRef.Name]; ok {
+					for _, ref := range bmcSecretRefs(bmc) {
+						keepSecrets[ref] = true
+					}
+				}
+			}
+			continue
+		}
+		removals = append(removals, change)
+	}
+
+	for _, name := range sortedKeys(current.bmcs) {
+		if desiredBMCs[name] || keepBMCs[name] {
+			continue
+		}
+		removals = append(removals, Change{Type: ChangeRemove, Kind: tinkerbellBMCKind, Name: name, object: current.bmcs[name]})
+	}
+
+	for _, name := range sortedKeys(current.secrets) {
+		if desiredSecrets[name] || keepSecrets[name] {
+			continue
+		}
+		removals = append(removals, Change{Type: ChangeRemove, Kind: secretKind, Name: name, object: current.secrets[name]})
+	}
+
+	return removals
+}
+
+// Apply applies all the changes in plan to the cluster. Refused changes are ignored.
+func (s *Syncer) Apply(ctx context.Context, plan *SyncPlan) error {
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Type {
+		case ChangeAdd:
+			err = s.client.Create(ctx, change.object)
+		case ChangeUpdate:
+			err = s.client.Update(ctx, change.object)
+		case ChangeRemove:
+			err = client.IgnoreNotFound(s.client.Delete(ctx, change.object))
+		}
+		if err != nil {
+			return fmt.Errorf("applying %s %s %s: %v", change.Type, change.Kind, change.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) readInventory(ctx context.Context) (*inventory, error) {
+	inv := &inventory{
+		hardware: map[string]*tinkv1alpha1.Hardware{},
+		bmcs:     map[string]*rufiov1alpha1.Machine{},
+		secrets:  map[string]*corev1.Secret{},
+	}
+
+	hardwareList := &tinkv1alpha1.HardwareList{}
+	if err := s.client.List(ctx, hardwareList, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return nil, fmt.Errorf("listing hardware: %v", err)
+	}
+	for i := range hardwareList.Items {
+		inv.hardware[hardwareList.Items[i].Name] = &hardwareList.Items[i]
+	}
+
+	bmcList := &rufiov1alpha1.MachineList{}
+	if err := s.client.List(ctx, bmcList, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return nil, fmt.Errorf("listing rufio machines: %v", err)
+	}
+	for i := range bmcList.Items {
+		bmc := &bmcList.Items[i]
+		inv.bmcs[bmc.Name] = bmc
+
+		// Only the Secrets referenced by BMC Machines are part of the inventory, the
+		// namespace contains other Secrets that must not be touched.
+		for _, name := range bmcSecretRefs(bmc) {
+			if _, ok := inv.secrets[name]; ok {
+				continue
+			}
+			secret := &corev1.Secret{}
+			err := s.client.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: name}, secret)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("reading bmc secret %s: %v", name, err)
+			}
+			inv.secrets[name] = secret
+		}
+	}
+
+	return inv, nil
+}
+
+// bmcSecretRefs returns the names of the Secrets in the eksa-system namespace referenced by bmc.
+func bmcSecretRefs(bmc *rufiov1alpha1.Machine) []string {
+	var names []string
+	addRef := func(ref corev1.SecretReference) {
+		if ref.Name != "" && (ref.Namespace == "" || ref.Namespace == constants.EksaSystemNamespace) {
+			names = append(names, ref.Name)
+		}
+	}
+
+	addRef(bmc.Spec.Connection.AuthSecretRef)
+	if opts := bmc.Spec.Connection.ProviderOptions; opts != nil && opts.RPC != nil && opts.RPC.HMAC != nil {
+		for _, refs := range opts.RPC.HMAC.Secrets {
+			for _, ref := range refs {
+				addRef(ref)
+			}
+		}
+	}
+
+	return names
+}
+
+// hardwareOwner returns the name of the Machine using hw, or an empty string when hw is not in use.
+func hardwareOwner(hw *tinkv1alpha1.Hardware) string {
+	return hw.Labels[OwnerNameLabel]
+}
+
+// mergeHardwareLabels returns the desired labels plus the labels set by CAPT in current,
+// so syncing doesn't release hardware from its owner.
+func mergeHardwareLabels(current, desired map[string]string) map[string]string {
+	merged := map[string]string{}
+	for k, v := range current {
+		if strings.HasPrefix(k, tinkerbellLabelPrefix) {
+			merged[k] = v
+		}
+	}
+	for k, v := range desired {
+		merged[k] = v
+	}
+
+	if len(merged) == 0 {
+		return nil
+	}
+
+	return merged
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedByName(changes []Change) []Change {
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
@@ -0,0 +1,239 @@
+package hardware_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rufiov1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/rufio"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func newSyncMachine(hostname, ip string) hardware.Machine {
+	return hardware.Machine{
+		Hostname:     hostname,
+		IPAddress:    ip,
+		Netmask:      "255.255.255.0",
+		Gateway:      "10.10.10.1",
+		Nameservers:  []string{"1.1.1.1"},
+		MACAddress:   "00:00:00:00:00:01",
+		Disk:         "/dev/sda",
+		Labels:       map[string]string{"type": "cp"},
+		BMCIPAddress: "192.168.0.10",
+		BMCUsername:  "Admin",
+		BMCPassword:  "admin",
+	}
+}
+
+func newSyncCatalogue(t *testing.T, machines ...hardware.Machine) *hardware.Catalogue {
+	t.Helper()
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewMachineCatalogueWriter(catalogue)
+	for _, m := range machines {
+		if err := writer.Write(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return catalogue
+}
+
+func newSyncClient(t *testing.T, catalogue *hardware.Catalogue) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = tinkv1alpha1.AddToScheme(scheme)
+	_ = rufiov1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	var objs []client.Object
+	if catalogue != nil {
+		for _, hw := range catalogue.AllHardware() {
+			objs = append(objs, hw)
+		}
+		for _, bmc := range catalogue.AllBMCs() {
+			objs = append(objs, bmc)
+		}
+		for _, secret := range catalogue.AllSecrets() {
+			objs = append(objs, secret)
+		}
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+type change struct {
+	Type hardware.ChangeType
+	Kind string
+	Name string
+}
+
+func summarize(changes []hardware.Change) []change {
+	s := make([]change, 0, len(changes))
+	for _, c := range changes {
+		s = append(s, change{Type: c.Type, Kind: c.Kind, Name: c.Name})
+	}
+	return s
+}
+
+func TestSyncerPlanAndApplyAdd(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSyncClient(t, nil)
+	syncer := hardware.NewSyncer(c)
+
+	plan, err := syncer.Plan(ctx, newSyncCatalogue(t, newSyncMachine("worker1", "10.10.10.10")))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(summarize(plan.Changes)).To(Equal([]change{
+		{Type: hardware.ChangeAdd, Kind: "Secret", Name: "bmc-worker1-auth"},
+		{Type: hardware.ChangeAdd, Kind: "Machine", Name: "bmc-worker1"},
+		{Type: hardware.ChangeAdd, Kind: "Hardware", Name: "worker1"},
+	}))
+	g.Expect(plan.Refused).To(BeEmpty())
+
+	g.Expect(syncer.Apply(ctx, plan)).To(Succeed())
+	hw := &tinkv1alpha1.Hardware{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "worker1"}, hw)).To(Succeed())
+
+	plan, err = syncer.Plan(ctx, newSyncCatalogue(t, newSyncMachine("worker1", "10.10.10.10")))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(plan.Empty()).To(BeTrue())
+}
+
+func TestSyncerPlanAndApplyChange(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSyncClient(t, newSyncCatalogue(t, newSyncMachine("worker1", "10.10.10.10")))
+	syncer := hardware.NewSyncer(c)
+
+	m := newSyncMachine("worker1", "10.10.10.20")
+	m.BMCPassword = "new-password"
+	plan, err := syncer.Plan(ctx, newSyncCatalogue(t, m))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(summarize(plan.Changes)).To(Equal([]change{
+		{Type: hardware.ChangeUpdate, Kind: "Secret", Name: "bmc-worker1-auth"},
+		{Type: hardware.ChangeUpdate, Kind: "Hardware", Name: "worker1"},
+	}))
+
+	g.Expect(syncer.Apply(ctx, plan)).To(Succeed())
+	hw := &tinkv1alpha1.Hardware{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "worker1"}, hw)).To(Succeed())
+	g.Expect(hw.Spec.Interfaces[0].DHCP.IP.Address).To(Equal("10.10.10.20"))
+	secret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "bmc-worker1-auth"}, secret)).To(Succeed())
+	g.Expect(secret.Data["password"]).To(BeEquivalentTo("new-password"))
+}
+
+func TestSyncerPlanAndApplyRemove(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSyncClient(t, newSyncCatalogue(t, newSyncMachine("worker1", "10.10.10.10"), newSyncMachine("worker2", "10.10.10.11")))
+	syncer := hardware.NewSyncer(c, hardware.WithPrune())
+
+	plan, err := syncer.Plan(ctx, newSyncCatalogue(t, newSyncMachine("worker1", "10.10.10.10")))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(summarize(plan.Changes)).To(Equal([]change{
+		{Type: hardware.ChangeRemove, Kind: "Hardware", Name: "worker2"},
+		{Type: hardware.ChangeRemove, Kind: "Machine", Name: "bmc-worker2"},
+		{Type: hardware.ChangeRemove, Kind: "Secret", Name: "bmc-worker2-auth"},
+	}))
+
+	g.Expect(syncer.Apply(ctx, plan)).To(Succeed())
+	err = c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "worker2"}, &tinkv1alpha1.Hardware{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	err = c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "bmc-worker2-auth"}, &corev1.Secret{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestSyncerPlanWithoutPruneKeepsMissingHardware(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSyncClient(t, newSyncCatalogue(t, newSyncMachine("worker1", "10.10.10.10"), newSyncMachine("worker2", "10.10.10.11")))
+	syncer := hardware.NewSyncer(c)
+
+	plan, err := syncer.Plan(ctx, newSyncCatalogue(t, newSyncMachine("worker1", "10.10.10.20")))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(summarize(plan.Changes)).To(Equal([]change{
+		{Type: hardware.ChangeUpdate, Kind: "Hardware", Name: "worker1"},
+	}))
+	g.Expect(plan.Refused).To(BeEmpty())
+
+	g.Expect(syncer.Apply(ctx, plan)).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "worker2"}, &tinkv1alpha1.Hardware{})).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "bmc-worker2"}, &rufiov1alpha1.Machine{})).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "bmc-worker2-auth"}, &corev1.Secret{})).To(Succeed())
+}
+
+func TestSyncerPlanRefusesHardwareInUse(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	current := newSyncCatalogue(t, newSyncMachine("worker1", "10.10.10.10"), newSyncMachine("worker2", "10.10.10.11"))
+	for _, hw := range current.AllHardware() {
+		hw.Labels[hardware.OwnerNameLabel] = hw.Name + "-machine"
+		// CAPT disables PXE on provisioned hardware.
+		hw.Spec.Metadata.Instance.AllowPxe = false
+	}
+	c := newSyncClient(t, current)
+	syncer := hardware.NewSyncer(c, hardware.WithPrune())
+
+	m := newSyncMachine("worker1", "10.10.10.10")
+	m.BMCPassword = "new-password"
+	plan, err := syncer.Plan(ctx, newSyncCatalogue(t, m))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(summarize(plan.Changes)).To(Equal([]change{
+		{Type: hardware.ChangeUpdate, Kind: "Secret", Name: "bmc-worker1-auth"},
+	}))
+	g.Expect(summarize(plan.Refused)).To(Equal([]change{
+		{Type: hardware.ChangeUpdate, Kind: "Hardware", Name: "worker1"},
+		{Type: hardware.ChangeRemove, Kind: "Hardware", Name: "worker2"},
+	}))
+	g.Expect(plan.Refused[1].Reason).To(Equal("in use by Machine worker2-machine"))
+
+	g.Expect(syncer.Apply(ctx, plan)).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "worker2"}, &tinkv1alpha1.Hardware{})).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "bmc-worker2"}, &rufiov1alpha1.Machine{})).To(Succeed())
+}
+
+func TestSyncerPlanKeepsOwnerLabels(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	current := newSyncCatalogue(t, newSyncMachine("worker1", "10.10.10.10"))
+	current.AllHardware()[0].Labels["v1alpha1.tinkerbell.org/ownerNamespace"] = "eksa-system"
+	c := newSyncClient(t, current)
+	syncer := hardware.NewSyncer(c)
+
+	plan, err := syncer.Plan(ctx, newSyncCatalogue(t, newSyncMachine("worker1", "10.10.10.10")))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(plan.Empty()).To(BeTrue())
+}
+
+func TestSyncerPlanIgnoresUnrelatedSecrets(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSyncClient(t, nil)
+	g.Expect(c.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: constants.EksaSystemNamespace},
+	})).To(Succeed())
+	syncer := hardware.NewSyncer(c)
+
+	plan, err := syncer.Plan(ctx, hardware.NewCatalogue())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(plan.Empty()).To(BeTrue())
+}
+
+func TestBuildCatalogueFromCSV(t *testing.T) {
+	g := NewWithT(t)
+
+	catalogue, err := hardware.BuildCatalogueFromCSV("./testdata/hardware.csv", nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(catalogue.TotalHardware()).To(Equal(1))
+	g.Expect(catalogue.TotalBMCs()).To(Equal(1))
+	g.Expect(catalogue.TotalSecrets()).To(Equal(1))
+}
@@ -592,6 +592,11 @@ func getEtcdMachineSpec(clusterSpec *cluster.Spec) (*v1alpha1.TinkerbellMachineC
 	return etcdMachineSpec, nil
 }
 
+// TemplateBuilderForSpec returns a TemplateBuilder configured with the machine configs and tinkerbell IP of a cluster spec.
+func TemplateBuilderForSpec(clusterSpec *cluster.Spec) (providers.TemplateBuilder, error) {
+	return generateTemplateBuilder(clusterSpec)
+}
+
 func generateTemplateBuilder(clusterSpec *cluster.Spec) (providers.TemplateBuilder, error) {
 	controlPlaneMachineSpec, err := getControlPlaneMachineSpec(clusterSpec)
 	if err != nil {
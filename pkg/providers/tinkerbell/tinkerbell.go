@@ -33,6 +33,9 @@ import (
 const (
 	maxRetries    = 30
 	backOffPeriod = 5 * time.Second
+
+	// bmcConnectivityConcurrency is the number of BMCs checked at the same time when reading the hardware CSV.
+	bmcConnectivityConcurrency = 10
 )
 
 var (
@@ -62,6 +65,9 @@ type Provider struct {
 	tinkerbellIP    string
 	// BMCOptions are Rufio BMC options that are used when creating Rufio machine CRDs.
 	BMCOptions *hardware.BMCOptions
+	// BMCPowerStateReader, when set, is used to validate the BMC credentials of the hardware in the CSV
+	// before creating a cluster.
+	BMCPowerStateReader hardware.BMCPowerStateReader
 
 	// TODO(chrisdoheryt4) Temporarily depend on the netclient until the validator can be injected.
 	// This is already a dependency, just uncached, because we require it during the initializing
@@ -330,7 +330,7 @@ func TestPostBootstrapSetupSuccess(t *testing.T) {
 	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, defaultBMCTimeout, "Contactable", gomock.Any()).MaxTimes(2)
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	if err := provider.readCSVToCatalogue(); err != nil {
+	if err := provider.readCSVToCatalogue(ctx); err != nil {
 		t.Fatalf("failed to read hardware csv: %v", err)
 	}
 
@@ -360,7 +360,7 @@ func TestPostBootstrapSetupWaitForRufioMachinesFail(t *testing.T) {
 	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, defaultBMCTimeout, "Contactable", gomock.Any()).Return(wantError)
 
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
-	if err := provider.readCSVToCatalogue(); err != nil {
+	if err := provider.readCSVToCatalogue(ctx); err != nil {
 		t.Fatalf("failed to read hardware csv: %v", err)
 	}
 
@@ -403,7 +403,7 @@ func TestPostMoveManagementToBootstrapSuccess(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 			provider.hardwareCSVFile = test.hardwareCSVFile
-			if err := provider.readCSVToCatalogue(); err != nil {
+			if err := provider.readCSVToCatalogue(ctx); err != nil {
 				t.Fatalf("failed to read hardware csv: %v", err)
 			}
 
@@ -433,7 +433,7 @@ func TestPostMoveManagementToBootstrapWaitForRufioMachinesFail(t *testing.T) {
 	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
 
 	kubectl.EXPECT().WaitForRufioMachines(ctx, cluster, defaultBMCTimeout, "Contactable", gomock.Any()).Return(wantError)
-	if err := provider.readCSVToCatalogue(); err != nil {
+	if err := provider.readCSVToCatalogue(ctx); err != nil {
 		t.Fatalf("failed to read hardware csv: %v", err)
 	}
 
@@ -441,6 +441,34 @@ func TestPostMoveManagementToBootstrapWaitForRufioMachinesFail(t *testing.T) {
 	assert.Error(t, err, "PostMoveManagementToBootstrap should fail")
 }
 
+type bmcPowerStateReaderFunc func(ctx context.Context, host, username, password string) (string, error)
+
+func (f bmcPowerStateReaderFunc) PowerState(ctx context.Context, host, username, password string) (string, error) {
+	return f(ctx, host, username, password)
+}
+
+func TestReadCSVToCatalogueBMCConnectivityFail(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.BMCPowerStateReader = bmcPowerStateReaderFunc(func(_ context.Context, host, _, _ string) (string, error) {
+		return "", errors.New("authentication failed")
+	})
+
+	err := provider.readCSVToCatalogue(ctx)
+	assert.ErrorContains(t, err, "authentication failed")
+}
+
 func TestTinkerbellTemplate_isScaleUpDownSuccess(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
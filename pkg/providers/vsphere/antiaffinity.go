@@ -0,0 +1,182 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const externalEtcdClusterLabel = "cluster.x-k8s.io/etcd-cluster"
+
+// AntiAffinityGroup is a group of machines that a DRS anti-affinity rule keeps on different ESXi hosts.
+type AntiAffinityGroup struct {
+	// Name identifies the group in logs and error messages.
+	Name string
+	// RuleName is the name of the DRS rule for the group.
+	RuleName      string
+	MachineConfig *anywherev1.VSphereMachineConfig
+	// ResourcePool and Folder are where the group VMs are created.
+	ResourcePool string
+	Folder       string
+	// MaxMachines is the maximum number of machines the group has at once, including
+	// the extra ones created during a rolling upgrade.
+	MaxMachines int
+	// MachineLabels select the CAPI Machines of the group.
+	MachineLabels map[string]string
+}
+
+// Enabled returns true if the machine config of the group asks for an anti-affinity rule.
+func (g AntiAffinityGroup) Enabled() bool {
+	return g.MachineConfig != nil && g.MachineConfig.AntiAffinityEnabled()
+}
+
+// Mandatory returns true if DRS should never run two machines of the group on the same host.
+func (g AntiAffinityGroup) Mandatory() bool {
+	return g.Enabled() && g.MachineConfig.Spec.AntiAffinity.Mandatory
+}
+
+// AntiAffinityRuleName returns the name of the DRS anti-affinity rule for a group of machines of a cluster.
+func AntiAffinityRuleName(clusterName, group string) string {
+	return fmt.Sprintf("%s-%s-anti-affinity", clusterName, group)
+}
+
+// AntiAffinityGroups returns the control plane, etcd and worker node groups of the cluster, whether
+// or not their machine configs enable anti-affinity.
+func AntiAffinityGroups(spec *Spec) []AntiAffinityGroup {
+	cluster := spec.Cluster
+	groups := make([]AntiAffinityGroup, 0, len(cluster.Spec.WorkerNodeGroupConfigurations)+2)
+
+	cp := cluster.Spec.ControlPlaneConfiguration
+	cpSurge := 1
+	if cp.UpgradeRolloutStrategy != nil && cp.UpgradeRolloutStrategy.RollingUpdate != nil {
+		cpSurge = cp.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+	}
+	groups = append(groups, newAntiAffinityGroup(cluster, "control-plane", spec.controlPlaneMachineConfig(), cp.Count+cpSurge, map[string]string{
+		clusterv1beta2.ClusterNameLabel:             cluster.Name,
+		clusterv1beta2.MachineControlPlaneNameLabel: clusterapi.KubeadmControlPlaneName(cluster),
+	}))
+
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		// etcdadm replaces the etcd machines one at a time.
+		groups = append(groups, newAntiAffinityGroup(cluster, "etcd", spec.etcdMachineConfig(), cluster.Spec.ExternalEtcdConfiguration.Count+1, map[string]string{
+			clusterv1beta2.ClusterNameLabel: cluster.Name,
+			externalEtcdClusterLabel:        clusterapi.EtcdClusterName(cluster.Name),
+		}))
+	}
+
+	for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+		count := 0
+		if wng.Count != nil {
+			count = *wng.Count
+		}
+		if wng.AutoScalingConfiguration != nil {
+			count = wng.AutoScalingConfiguration.MaxCount
+		}
+		surge := 1
+		if wng.UpgradeRolloutStrategy != nil && wng.UpgradeRolloutStrategy.RollingUpdate != nil {
+			surge = wng.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+		}
+
+		group := newAntiAffinityGroup(cluster, wng.Name, spec.workerMachineConfig(wng), count+surge, map[string]string{
+			clusterv1beta2.ClusterNameLabel:           cluster.Name,
+			clusterv1beta2.MachineDeploymentNameLabel: clusterapi.MachineDeploymentName(cluster, wng),
+		})
+		if len(wng.FailureDomains) > 0 {
+			for _, fd := range spec.VSphereDatacenter.Spec.FailureDomains {
+				if fd.Name == wng.FailureDomains[0] {
+					group.ResourcePool = fd.ResourcePool
+					group.Folder = fd.Folder
+				}
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+func newAntiAffinityGroup(cluster *anywherev1.Cluster, name string, machineConfig *anywherev1.VSphereMachineConfig, maxMachines int, labels map[string]string) AntiAffinityGroup {
+	group := AntiAffinityGroup{
+		Name:          name,
+		RuleName:      AntiAffinityRuleName(cluster.Name, name),
+		MachineConfig: machineConfig,
+		MaxMachines:   maxMachines,
+		MachineLabels: labels,
+	}
+	if machineConfig != nil {
+		group.ResourcePool = machineConfig.Spec.ResourcePool
+		group.Folder = machineConfig.Spec.Folder
+	}
+
+	return group
+}
+
+// ValidateAntiAffinity checks the compute clusters of the groups with anti-affinity enabled can hold their
+// DRS rules: DRS must be enabled, mandatory rules need enough hosts for all the machines of the group and
+// the vSphere user needs privileges to edit the compute cluster rules.
+func (v *Validator) ValidateAntiAffinity(ctx context.Context, vsphereClusterSpec *Spec) error {
+	var groups []AntiAffinityGroup
+	for _, g := range AntiAffinityGroups(vsphereClusterSpec) {
+		if g.Enabled() {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+
+	vuc := config.NewVsphereUserConfig()
+	vsc, err := v.vSphereClientBuilder.Build(
+		ctx,
+		vsphereClusterSpec.VSphereDatacenter.Spec.Server,
+		vuc.EksaVsphereUsername,
+		vuc.EksaVspherePassword,
+		vsphereClusterSpec.VSphereDatacenter.Spec.Insecure,
+		vsphereClusterSpec.VSphereDatacenter.Spec.Datacenter,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create vSphere client to validate anti-affinity: %v", err)
+	}
+
+	var privObjs []PrivAssociation
+	seen := map[string]interface{}{}
+	for _, g := range groups {
+		drs, err := vsc.GetComputeClusterDRS(ctx, g.ResourcePool)
+		if err != nil {
+			return fmt.Errorf("validating anti-affinity for %s machines: %v", g.Name, err)
+		}
+
+		if !drs.Enabled {
+			return fmt.Errorf("anti-affinity for %s machines requires DRS enabled in compute cluster %s", g.Name, drs.Path)
+		}
+
+		if g.Mandatory() && drs.Hosts < g.MaxMachines {
+			return fmt.Errorf("mandatory anti-affinity for %s machines requires at least %d hosts in compute cluster %s "+
+				"for all the machines including the ones created during upgrades, it has %d", g.Name, g.MaxMachines, drs.Path, drs.Hosts)
+		}
+
+		if _, ok := seen[drs.Path]; !ok {
+			privObjs = append(privObjs, PrivAssociation{
+				objectType:   govmomi.VSphereTypeComputeCluster,
+				privsContent: config.VSphereDRSPrivsFile,
+				path:         drs.Path,
+			})
+			seen[drs.Path] = 1
+		}
+	}
+
+	if _, err := v.validatePrivs(ctx, privObjs, vsc); err != nil {
+		return fmt.Errorf("validating privileges to create anti-affinity rules: %v", err)
+	}
+
+	logger.MarkPass("Anti-affinity rules validated")
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+	"github.com/aws/eks-anywhere/pkg/govmomi/mocks"
+	govcmocks "github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func antiAffinityClusterSpec(opts ...func(*Spec)) *Spec {
+	return clusterSpec(func(s *Spec) {
+		s.Cluster.Name = "my-cluster"
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.VSphereMachineConfigs["test-cp"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{Mandatory: true}
+		s.VSphereMachineConfigs["test-wn"] = &v1alpha1.VSphereMachineConfig{
+			Spec: v1alpha1.VSphereMachineConfigSpec{
+				ResourcePool: "worker-pool",
+				Folder:       "/SDDC-Datacenter/vm/workers",
+			},
+		}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+			{
+				Name:            "md-0",
+				Count:           ptr.Int(2),
+				MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"},
+			},
+		}
+		for _, opt := range opts {
+			opt(s)
+		}
+	})
+}
+
+func TestAntiAffinityGroups(t *testing.T) {
+	g := NewWithT(t)
+	spec := antiAffinityClusterSpec(func(s *Spec) {
+		s.VSphereMachineConfigs["test-etcd"] = &v1alpha1.VSphereMachineConfig{
+			Spec: v1alpha1.VSphereMachineConfigSpec{ResourcePool: "etcd-pool", Folder: "etcd-folder"},
+		}
+		s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+			Count:           3,
+			MachineGroupRef: &v1alpha1.Ref{Name: "test-etcd"},
+		}
+		s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+			RollingUpdate: &v1alpha1.ControlPlaneRollingUpdateParams{MaxSurge: 0},
+		}
+		s.VSphereDatacenter.Spec.FailureDomains = []v1alpha1.FailureDomain{
+			{Name: "fd-1", ResourcePool: "fd-pool", Folder: "fd-folder"},
+		}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = append(s.Cluster.Spec.WorkerNodeGroupConfigurations, v1alpha1.WorkerNodeGroupConfiguration{
+			Name:                     "md-1",
+			AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 5},
+			MachineGroupRef:          &v1alpha1.Ref{Name: "test-wn"},
+			FailureDomains:           []string{"fd-1"},
+			UpgradeRolloutStrategy: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+				RollingUpdate: &v1alpha1.WorkerNodesRollingUpdateParams{MaxSurge: 2},
+			},
+		})
+	})
+
+	groups := AntiAffinityGroups(spec)
+	g.Expect(groups).To(Equal([]AntiAffinityGroup{
+		{
+			Name:          "control-plane",
+			RuleName:      "my-cluster-control-plane-anti-affinity",
+			MachineConfig: spec.VSphereMachineConfigs["test-cp"],
+			ResourcePool:  "pool",
+			Folder:        "folder",
+			MaxMachines:   3,
+			MachineLabels: map[string]string{
+				clusterv1beta2.ClusterNameLabel:             "my-cluster",
+				clusterv1beta2.MachineControlPlaneNameLabel: "my-cluster",
+			},
+		},
+		{
+			Name:          "etcd",
+			RuleName:      "my-cluster-etcd-anti-affinity",
+			MachineConfig: spec.VSphereMachineConfigs["test-etcd"],
+			ResourcePool:  "etcd-pool",
+			Folder:        "etcd-folder",
+			MaxMachines:   4,
+			MachineLabels: map[string]string{
+				clusterv1beta2.ClusterNameLabel: "my-cluster",
+				externalEtcdClusterLabel:        "my-cluster-etcd",
+			},
+		},
+		{
+			Name:          "md-0",
+			RuleName:      "my-cluster-md-0-anti-affinity",
+			MachineConfig: spec.VSphereMachineConfigs["test-wn"],
+			ResourcePool:  "worker-pool",
+			Folder:        "/SDDC-Datacenter/vm/workers",
+			MaxMachines:   3,
+			MachineLabels: map[string]string{
+				clusterv1beta2.ClusterNameLabel:           "my-cluster",
+				clusterv1beta2.MachineDeploymentNameLabel: "my-cluster-md-0",
+			},
+		},
+		{
+			Name:          "md-1",
+			RuleName:      "my-cluster-md-1-anti-affinity",
+			MachineConfig: spec.VSphereMachineConfigs["test-wn"],
+			ResourcePool:  "fd-pool",
+			Folder:        "fd-folder",
+			MaxMachines:   7,
+			MachineLabels: map[string]string{
+				clusterv1beta2.ClusterNameLabel:           "my-cluster",
+				clusterv1beta2.MachineDeploymentNameLabel: "my-cluster-md-1",
+			},
+		},
+	}))
+	g.Expect(groups[0].Enabled()).To(BeTrue())
+	g.Expect(groups[0].Mandatory()).To(BeTrue())
+	g.Expect(groups[2].Enabled()).To(BeFalse())
+	g.Expect(groups[2].Mandatory()).To(BeFalse())
+}
+
+func TestValidatorValidateAntiAffinityDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	v := NewValidator(govcmocks.NewMockProviderGovcClient(ctrl), govcmocks.NewMockVSphereClientBuilder(ctrl))
+	spec := antiAffinityClusterSpec(func(s *Spec) {
+		s.VSphereMachineConfigs["test-cp"].Spec.AntiAffinity = nil
+	})
+
+	g := NewWithT(t)
+	g.Expect(v.ValidateAntiAffinity(context.Background(), spec)).To(Succeed())
+}
+
+func TestValidatorValidateAntiAffinity(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	vscb := govcmocks.NewMockVSphereClientBuilder(ctrl)
+	vsc := mocks.NewMockVSphereClient(ctrl)
+	v := NewValidator(govcmocks.NewMockProviderGovcClient(ctrl), vscb)
+	spec := antiAffinityClusterSpec(func(s *Spec) {
+		s.VSphereMachineConfigs["test-wn"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{}
+	})
+
+	vscb.EXPECT().Build(ctx, "server", gomock.Any(), gomock.Any(), false, "SDDC-Datacenter").Return(vsc, nil)
+	vsc.EXPECT().GetComputeClusterDRS(ctx, "pool").Return(&govmomi.ComputeClusterDRS{Path: "/SDDC-Datacenter/host/cluster", Enabled: true, Hosts: 4}, nil)
+	vsc.EXPECT().GetComputeClusterDRS(ctx, "worker-pool").Return(&govmomi.ComputeClusterDRS{Path: "/SDDC-Datacenter/host/cluster", Enabled: true, Hosts: 2}, nil)
+	vsc.EXPECT().Username().Return("user")
+	vsc.EXPECT().GetPrivsOnEntity(ctx, "/SDDC-Datacenter/host/cluster", govmomi.VSphereTypeComputeCluster, "user").Return([]string{"Host.Inventory.EditCluster"}, nil)
+
+	g := NewWithT(t)
+	g.Expect(v.ValidateAntiAffinity(ctx, spec)).To(Succeed())
+}
+
+func TestValidatorValidateAntiAffinityErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		drs     *govmomi.ComputeClusterDRS
+		drsErr  error
+		privs   []string
+		wantErr string
+	}{
+		{
+			name:    "resource pool not in compute cluster",
+			drsErr:  errors.New("resource pool pool doesn't belong to a compute cluster"),
+			wantErr: "validating anti-affinity for control-plane machines: resource pool pool doesn't belong to a compute cluster",
+		},
+		{
+			name:    "drs disabled",
+			drs:     &govmomi.ComputeClusterDRS{Path: "/SDDC-Datacenter/host/cluster", Enabled: false, Hosts: 4},
+			wantErr: "anti-affinity for control-plane machines requires DRS enabled in compute cluster /SDDC-Datacenter/host/cluster",
+		},
+		{
+			name: "not enough hosts for mandatory rule",
+			drs:  &govmomi.ComputeClusterDRS{Path: "/SDDC-Datacenter/host/cluster", Enabled: true, Hosts: 3},
+			wantErr: "mandatory anti-affinity for control-plane machines requires at least 4 hosts in compute cluster " +
+				"/SDDC-Datacenter/host/cluster for all the machines including the ones created during upgrades, it has 3",
+		},
+		{
+			name:  "missing privileges",
+			drs:   &govmomi.ComputeClusterDRS{Path: "/SDDC-Datacenter/host/cluster", Enabled: true, Hosts: 4},
+			privs: []string{"System.Read"},
+			wantErr: "validating privileges to create anti-affinity rules: user user missing vSphere permissions: " +
+				"ComputeCluster /SDDC-Datacenter/host/cluster: Host.Inventory.EditCluster",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			vscb := govcmocks.NewMockVSphereClientBuilder(ctrl)
+			vsc := mocks.NewMockVSphereClient(ctrl)
+			v := NewValidator(govcmocks.NewMockProviderGovcClient(ctrl), vscb)
+			spec := antiAffinityClusterSpec()
+
+			vscb.EXPECT().Build(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(vsc, nil)
+			vsc.EXPECT().GetComputeClusterDRS(ctx, "pool").Return(tt.drs, tt.drsErr)
+			if tt.privs != nil {
+				vsc.EXPECT().Username().Return("user")
+				vsc.EXPECT().GetPrivsOnEntity(ctx, tt.drs.Path, govmomi.VSphereTypeComputeCluster, "user").Return(tt.privs, nil)
+			}
+
+			g := NewWithT(t)
+			g.Expect(v.ValidateAntiAffinity(ctx, spec)).To(MatchError(tt.wantErr))
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	vspheretags "github.com/aws/eks-anywhere/pkg/providers/vsphere/internal/tags"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/internal/templates"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -106,30 +108,49 @@ func (d *Defaulter) setDefaultTemplateIfMissing(ctx context.Context, spec *Spec,
 func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machineConfig *anywherev1.VSphereMachineConfig, versionsBundle *cluster.VersionsBundle) error {
 	osFamily := machineConfig.Spec.OSFamily
 	eksd := versionsBundle.EksD
-	var ova releasev1.Archive
+	var ova *releasev1.Archive
 	switch osFamily {
 	case anywherev1.Bottlerocket:
-		ova = eksd.Ova.Bottlerocket
-	default:
-		return fmt.Errorf("can not import ova for osFamily: %s, please use %s as osFamily for auto-importing or provide a valid template", osFamily, anywherev1.Bottlerocket)
+		ova = &eksd.Ova.Bottlerocket
+	case anywherev1.Ubuntu:
+		ova = eksd.Ova.Ubuntu
+	case anywherev1.RedHat:
+		ova = eksd.Ova.RedHat
+	}
+	if ova == nil || ova.URI == "" {
+		return fmt.Errorf("can not import ova for osFamily: %s, the bundle for Kubernetes %s doesn't include one, please provide a valid template", osFamily, eksd.KubeVersion)
+	}
+	if ova.SHA256 == "" {
+		return fmt.Errorf("can not import ova %s for osFamily: %s, the bundle doesn't include its sha256 checksum", ova.URI, osFamily)
 	}
 
 	templateName := fmt.Sprintf("%s-%s-%s-%s-%s", osFamily, eksd.KubeVersion, eksd.Name, strings.Join(ova.Arch, "-"), ova.SHA256[:7])
-	machineConfig.Spec.Template = filepath.Join("/", spec.VSphereDatacenter.Spec.Datacenter, defaultTemplatesFolder, templateName)
+	machineConfig.Spec.Template = filepath.Join(DefaultTemplatesFolder(spec.VSphereDatacenter.Spec.Datacenter), templateName)
 
 	tags := requiredTemplateTagsByCategory(machineConfig, versionsBundle)
 
 	// TODO: figure out if it's worth refactoring the factory to be able to reuse across machine configs.
-	templateFactory := templates.NewFactory(d.govc, spec.VSphereDatacenter.Spec.Datacenter, machineConfig.Spec.Datastore, spec.VSphereDatacenter.Spec.Network, machineConfig.Spec.ResourcePool, defaultTemplateLibrary)
+	templateFactory := templates.NewFactory(d.govc, spec.VSphereDatacenter.Spec.Datacenter, machineConfig.Spec.Datastore, spec.VSphereDatacenter.Spec.Network, machineConfig.Spec.ResourcePool, DefaultTemplateLibrary)
 
 	// TODO: remove the factory's dependency on a machineConfig
-	if err := templateFactory.CreateIfMissing(ctx, spec.VSphereDatacenter.Spec.Datacenter, machineConfig, ova.URI, tags); err != nil {
+	if err := templateFactory.CreateIfMissing(ctx, spec.VSphereDatacenter.Spec.Datacenter, machineConfig, ova.URI, ova.SHA256, tags); err != nil {
+		return err
+	}
+
+	// The management clusters using the template adopt it once it's marked as imported, so it's only
+	// garbage collected when none of them uses it anymore.
+	if err := vspheretags.NewFactory(d.govc).TagTemplateOwner(ctx, machineConfig.Spec.Template, govmomi.TemplateOwnerCategory, govmomi.TemplateImportedTag); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// DefaultTemplatesFolder returns the folder where the templates for the OVAs from the bundle are created.
+func DefaultTemplatesFolder(datacenter string) string {
+	return filepath.Join("/", datacenter, defaultTemplatesFolder)
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
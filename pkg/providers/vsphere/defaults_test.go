@@ -0,0 +1,107 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	ubuntuOVA       = "https://anywhere-assets.eks.amazonaws.com/ubuntu-v1.27.1-eks-d-1-27-4-amd64.ova"
+	ubuntuOVASHA256 = "11e82581255f3e0c4f4fcd599563325dbce3f9f671d3e8b016573f8cd4374577"
+	ubuntuTemplate  = "/SDDC-Datacenter/vm/Templates/ubuntu-v1.27.1-ekd-d-1-27-amd64-11e8258"
+)
+
+func defaultTemplateClusterSpec() *Spec {
+	return clusterSpec(func(s *Spec) {
+		s.Cluster.Name = "workload"
+		s.Cluster.Spec.ManagementCluster.Name = "mgmt"
+		s.VSphereMachineConfigs["test-cp"].Spec.Template = ""
+		s.VSphereMachineConfigs["test-cp"].Spec.OSFamily = v1alpha1.Ubuntu
+		eksd := &s.RootVersionsBundle().EksD
+		eksd.KubeVersion = "v1.27.1"
+		eksd.Ova.Ubuntu = &releasev1.Archive{
+			URI:    ubuntuOVA,
+			SHA256: ubuntuOVASHA256,
+			Arch:   []string{"amd64"},
+		}
+	})
+}
+
+func TestDefaulterSetupDefaultTemplateImportsOVA(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	spec := defaultTemplateClusterSpec()
+	mc := spec.VSphereMachineConfigs["test-cp"]
+	imported := govmomi.TemplateImportedTag
+
+	govc.EXPECT().SearchTemplate(ctx, "SDDC-Datacenter", ubuntuTemplate).Return("", nil)
+	govc.EXPECT().LibraryElementExists(ctx, DefaultTemplateLibrary).Return(true, nil)
+	govc.EXPECT().GetLibraryElementContentVersion(ctx, DefaultTemplateLibrary+"/ubuntu-v1.27.1-ekd-d-1-27-amd64-11e8258").Return("-1", nil)
+	govc.EXPECT().ImportTemplate(ctx, DefaultTemplateLibrary, ubuntuOVA, "ubuntu-v1.27.1-ekd-d-1-27-amd64-11e8258", ubuntuOVASHA256)
+	govc.EXPECT().DeployTemplateFromLibrary(ctx, "/SDDC-Datacenter/vm/Templates", "ubuntu-v1.27.1-ekd-d-1-27-amd64-11e8258", DefaultTemplateLibrary, "SDDC-Datacenter", "datastore", "", "pool", false)
+	govc.EXPECT().ListCategories(ctx).Return([]string{"os", "eksdRelease"}, nil).Times(2)
+	govc.EXPECT().ListTags(ctx).Return(nil, nil).Times(2)
+	govc.EXPECT().CreateTag(ctx, gomock.Any(), gomock.Any()).Times(2)
+	govc.EXPECT().AddTag(ctx, ubuntuTemplate, gomock.Any()).Times(2)
+	govc.EXPECT().CreateMultiValueCategoryForVM(ctx, govmomi.TemplateOwnerCategory)
+	govc.EXPECT().CreateTag(ctx, imported, govmomi.TemplateOwnerCategory)
+	govc.EXPECT().AddTag(ctx, ubuntuTemplate, imported)
+
+	g.Expect(NewDefaulter(govc).setupDefaultTemplate(ctx, spec, mc, spec.RootVersionsBundle())).To(Succeed())
+	g.Expect(mc.Spec.Template).To(Equal(ubuntuTemplate))
+}
+
+func TestDefaulterSetupDefaultTemplateMarksExistingTemplateImported(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	spec := defaultTemplateClusterSpec()
+	mc := spec.VSphereMachineConfigs["test-cp"]
+
+	govc.EXPECT().SearchTemplate(ctx, "SDDC-Datacenter", ubuntuTemplate).Return(ubuntuTemplate, nil)
+	govc.EXPECT().ListCategories(ctx).Return([]string{govmomi.TemplateOwnerCategory}, nil)
+	govc.EXPECT().ListTags(ctx).Return([]executables.Tag{{Name: govmomi.TemplateImportedTag}}, nil)
+	govc.EXPECT().AddTag(ctx, ubuntuTemplate, govmomi.TemplateImportedTag)
+
+	g.Expect(NewDefaulter(govc).setupDefaultTemplate(ctx, spec, mc, spec.RootVersionsBundle())).To(Succeed())
+}
+
+func TestDefaulterSetupDefaultTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		ova     *releasev1.Archive
+		wantErr string
+	}{
+		{
+			name:    "no ova in bundle",
+			wantErr: "can not import ova for osFamily: ubuntu, the bundle for Kubernetes v1.27.1 doesn't include one, please provide a valid template",
+		},
+		{
+			name:    "no checksum",
+			ova:     &releasev1.Archive{URI: ubuntuOVA},
+			wantErr: "can not import ova " + ubuntuOVA + " for osFamily: ubuntu, the bundle doesn't include its sha256 checksum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := defaultTemplateClusterSpec()
+			spec.RootVersionsBundle().EksD.Ova.Ubuntu = tt.ova
+			d := NewDefaulter(mocks.NewMockProviderGovcClient(gomock.NewController(t)))
+
+			err := d.setupDefaultTemplate(context.Background(), spec, spec.VSphereMachineConfigs["test-cp"], spec.RootVersionsBundle())
+			g.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
@@ -19,6 +19,7 @@ type GovcClient interface {
 	AddTag(ctx context.Context, path, tag string) error
 	ListCategories(ctx context.Context) ([]string, error)
 	CreateCategoryForVM(ctx context.Context, name string) error
+	CreateMultiValueCategoryForVM(ctx context.Context, name string) error
 }
 
 func NewFactory(client GovcClient) *Factory {
@@ -26,6 +27,16 @@ func NewFactory(client GovcClient) *Factory {
 }
 
 func (f *Factory) TagTemplate(ctx context.Context, templatePath string, tagsByCategory map[string][]string) error {
+	return f.tagTemplate(ctx, templatePath, tagsByCategory, f.client.CreateCategoryForVM)
+}
+
+// TagTemplateOwner tags the template with the owner tag. The owner category is created allowing
+// multiple tags per template, so the same template can be shared by several owners.
+func (f *Factory) TagTemplateOwner(ctx context.Context, templatePath, category, owner string) error {
+	return f.tagTemplate(ctx, templatePath, map[string][]string{category: {owner}}, f.client.CreateMultiValueCategoryForVM)
+}
+
+func (f *Factory) tagTemplate(ctx context.Context, templatePath string, tagsByCategory map[string][]string, createCategory func(ctx context.Context, name string) error) error {
 	logger.V(2).Info("Tagging template", "template", templatePath)
 	categories, err := f.client.ListCategories(ctx)
 	if err != nil {
@@ -47,7 +58,7 @@ func (f *Factory) TagTemplate(ctx context.Context, templatePath string, tagsByCa
 	for category, tags := range tagsByCategory {
 		if !categoriesLookup.IsPresent(category) {
 			logger.V(3).Info("Creating category", "category", category)
-			if err = f.client.CreateCategoryForVM(ctx, category); err != nil {
+			if err = createCategory(ctx, category); err != nil {
 				return fmt.Errorf("failed creating category for tags: %v", err)
 			}
 		}
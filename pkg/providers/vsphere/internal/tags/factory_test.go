@@ -133,3 +133,27 @@ func TestFactoryTagTemplateSuccess(t *testing.T) {
 
 	tt.assertSuccessFromTagTemplate()
 }
+
+func TestFactoryTagTemplateOwnerCreatesMultiValueCategory(t *testing.T) {
+	tt := newTagTest(t)
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return([]string{"kubernetesChannel"}, nil)
+	tt.govc.EXPECT().ListTags(tt.ctx).Return(nil, nil)
+	tt.govc.EXPECT().CreateMultiValueCategoryForVM(tt.ctx, "owner").Return(nil)
+	tt.govc.EXPECT().CreateTag(tt.ctx, "owner:mgmt", "owner").Return(nil)
+	tt.govc.EXPECT().AddTag(tt.ctx, tt.templatePath, "owner:mgmt").Return(nil)
+
+	if err := tt.factory.TagTemplateOwner(tt.ctx, tt.templatePath, "owner", "owner:mgmt"); err != nil {
+		t.Fatalf("factory.TagTemplateOwner() err = %v, want err = nil", err)
+	}
+}
+
+func TestFactoryTagTemplateOwnerExistingTag(t *testing.T) {
+	tt := newTagTest(t)
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return([]string{"owner"}, nil)
+	tt.govc.EXPECT().ListTags(tt.ctx).Return([]executables.Tag{{Name: "owner:mgmt", CategoryId: "owner"}}, nil)
+	tt.govc.EXPECT().AddTag(tt.ctx, tt.templatePath, "owner:mgmt").Return(nil)
+
+	if err := tt.factory.TagTemplateOwner(tt.ctx, tt.templatePath, "owner", "owner:mgmt"); err != nil {
+		t.Fatalf("factory.TagTemplateOwner() err = %v, want err = nil", err)
+	}
+}
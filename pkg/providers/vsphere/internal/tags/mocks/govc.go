@@ -69,6 +69,20 @@ func (mr *MockGovcClientMockRecorder) CreateCategoryForVM(ctx, name any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryForVM", reflect.TypeOf((*MockGovcClient)(nil).CreateCategoryForVM), ctx, name)
 }
 
+// CreateMultiValueCategoryForVM mocks base method.
+func (m *MockGovcClient) CreateMultiValueCategoryForVM(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMultiValueCategoryForVM", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMultiValueCategoryForVM indicates an expected call of CreateMultiValueCategoryForVM.
+func (mr *MockGovcClientMockRecorder) CreateMultiValueCategoryForVM(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultiValueCategoryForVM", reflect.TypeOf((*MockGovcClient)(nil).CreateMultiValueCategoryForVM), ctx, name)
+}
+
 // CreateTag mocks base method.
 func (m *MockGovcClient) CreateTag(ctx context.Context, tag, category string) error {
 	m.ctrl.T.Helper()
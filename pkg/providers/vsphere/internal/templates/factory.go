@@ -31,7 +31,7 @@ type GovcClient interface {
 	CreateLibrary(ctx context.Context, datastore, library string) error
 	DeployTemplateFromLibrary(ctx context.Context, templateDir, templateName, library, datacenter, datastore, network, resourcePool string, resizeBRDisk bool) error
 	SearchTemplate(ctx context.Context, datacenter, template string) (string, error)
-	ImportTemplate(ctx context.Context, library, ovaURL, name string) error
+	ImportTemplate(ctx context.Context, library, ovaURL, name, sha256 string) error
 	LibraryElementExists(ctx context.Context, library string) (bool, error)
 	GetLibraryElementContentVersion(ctx context.Context, element string) (string, error)
 	DeleteLibraryElement(ctx context.Context, element string) error
@@ -40,6 +40,7 @@ type GovcClient interface {
 	AddTag(ctx context.Context, path, tag string) error
 	ListCategories(ctx context.Context) ([]string, error)
 	CreateCategoryForVM(ctx context.Context, name string) error
+	CreateMultiValueCategoryForVM(ctx context.Context, name string) error
 	CreateUser(ctx context.Context, username string, password string) error
 	UserExists(ctx context.Context, username string) (bool, error)
 	CreateGroup(ctx context.Context, name string) error
@@ -62,7 +63,9 @@ func NewFactory(client GovcClient, datacenter, datastore, network, resourcePool,
 	}
 }
 
-func (f *Factory) CreateIfMissing(ctx context.Context, datacenter string, machineConfig *v1alpha1.VSphereMachineConfig, ovaURL string, tagsByCategory map[string][]string) error {
+// CreateIfMissing imports the OVA and deploys it as the machine config template if the template doesn't exist.
+// The OVA import fails if its checksum doesn't match ovaSHA256.
+func (f *Factory) CreateIfMissing(ctx context.Context, datacenter string, machineConfig *v1alpha1.VSphereMachineConfig, ovaURL, ovaSHA256 string, tagsByCategory map[string][]string) error {
 	templateFullPath, err := f.client.SearchTemplate(ctx, datacenter, machineConfig.Spec.Template)
 	if err != nil {
 		return fmt.Errorf("checking for template: %v", err)
@@ -76,7 +79,7 @@ func (f *Factory) CreateIfMissing(ctx context.Context, datacenter string, machin
 	logger.V(2).Info("Template not available. Creating", "template", machineConfig.Spec.Template)
 
 	osFamily := machineConfig.Spec.OSFamily
-	if err = f.createTemplate(ctx, machineConfig.Spec.Template, ovaURL, string(osFamily)); err != nil {
+	if err = f.createTemplate(ctx, machineConfig.Spec.Template, ovaURL, ovaSHA256, string(osFamily)); err != nil {
 		return err
 	}
 
@@ -86,7 +89,7 @@ func (f *Factory) CreateIfMissing(ctx context.Context, datacenter string, machin
 	return nil
 }
 
-func (f *Factory) createTemplate(ctx context.Context, templatePath, ovaURL, osFamily string) error {
+func (f *Factory) createTemplate(ctx context.Context, templatePath, ovaURL, ovaSHA256, osFamily string) error {
 	if err := f.createLibraryIfMissing(ctx); err != nil {
 		return err
 	}
@@ -95,7 +98,7 @@ func (f *Factory) createTemplate(ctx context.Context, templatePath, ovaURL, osFa
 	templateName := filepath.Base(templatePath)
 	templateDir := filepath.Dir(templatePath)
 
-	if err := f.importOVAIfMissing(ctx, templateName, ovaURL); err != nil {
+	if err := f.importOVAIfMissing(ctx, templateName, ovaURL, ovaSHA256); err != nil {
 		return err
 	}
 
@@ -126,7 +129,7 @@ func (f *Factory) createLibraryIfMissing(ctx context.Context) error {
 	return nil
 }
 
-func (f *Factory) importOVAIfMissing(ctx context.Context, templateName, ovaURL string) error {
+func (f *Factory) importOVAIfMissing(ctx context.Context, templateName, ovaURL, ovaSHA256 string) error {
 	contentVersion, err := f.client.GetLibraryElementContentVersion(ctx, filepath.Join(f.templateLibrary, templateName))
 	if err != nil {
 		return fmt.Errorf("failed to validate template in library for new template: %v", err)
@@ -142,7 +145,7 @@ func (f *Factory) importOVAIfMissing(ctx context.Context, templateName, ovaURL s
 
 	if contentVersion == libraryContentDoesNotExist {
 		logger.V(2).Info("Importing template from ova url", "ova", ovaURL)
-		if err = f.client.ImportTemplate(ctx, f.templateLibrary, ovaURL, templateName); err != nil {
+		if err = f.client.ImportTemplate(ctx, f.templateLibrary, ovaURL, templateName, ovaSHA256); err != nil {
 			return fmt.Errorf("failed importing template into library: %v", err)
 		}
 	}
@@ -111,6 +111,20 @@ func (mr *MockGovcClientMockRecorder) CreateLibrary(ctx, datastore, library any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLibrary", reflect.TypeOf((*MockGovcClient)(nil).CreateLibrary), ctx, datastore, library)
 }
 
+// CreateMultiValueCategoryForVM mocks base method.
+func (m *MockGovcClient) CreateMultiValueCategoryForVM(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMultiValueCategoryForVM", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMultiValueCategoryForVM indicates an expected call of CreateMultiValueCategoryForVM.
+func (mr *MockGovcClientMockRecorder) CreateMultiValueCategoryForVM(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultiValueCategoryForVM", reflect.TypeOf((*MockGovcClient)(nil).CreateMultiValueCategoryForVM), ctx, name)
+}
+
 // CreateRole mocks base method.
 func (m *MockGovcClient) CreateRole(ctx context.Context, name string, privileges []string) error {
 	m.ctrl.T.Helper()
@@ -212,17 +226,17 @@ func (mr *MockGovcClientMockRecorder) GroupExists(ctx, name any) *gomock.Call {
 }
 
 // ImportTemplate mocks base method.
-func (m *MockGovcClient) ImportTemplate(ctx context.Context, library, ovaURL, name string) error {
+func (m *MockGovcClient) ImportTemplate(ctx context.Context, library, ovaURL, name, sha256 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ImportTemplate", ctx, library, ovaURL, name)
+	ret := m.ctrl.Call(m, "ImportTemplate", ctx, library, ovaURL, name, sha256)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ImportTemplate indicates an expected call of ImportTemplate.
-func (mr *MockGovcClientMockRecorder) ImportTemplate(ctx, library, ovaURL, name any) *gomock.Call {
+func (mr *MockGovcClientMockRecorder) ImportTemplate(ctx, library, ovaURL, name, sha256 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplate", reflect.TypeOf((*MockGovcClient)(nil).ImportTemplate), ctx, library, ovaURL, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplate", reflect.TypeOf((*MockGovcClient)(nil).ImportTemplate), ctx, library, ovaURL, name, sha256)
 }
 
 // LibraryElementExists mocks base method.
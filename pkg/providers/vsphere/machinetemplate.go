@@ -19,6 +19,11 @@ func getMachineTemplate(ctx context.Context, client kubernetes.Client, name, nam
 	return m, nil
 }
 
+// machineTemplateEqual returns a boolean indicating whether the provided VSphereMachineTemplates are equal.
+// DeepDerivative ignores the fields unset in new, so the data disks and PCI devices are compared explicitly.
+// Otherwise removing all of them from a machine config wouldn't roll out new machines.
 func machineTemplateEqual(new, old *vspherev1.VSphereMachineTemplate) bool {
-	return equality.Semantic.DeepDerivative(new.Spec, old.Spec)
+	return equality.Semantic.DeepDerivative(new.Spec, old.Spec) &&
+		equality.Semantic.DeepEqual(new.Spec.Template.Spec.DataDisks, old.Spec.Template.Spec.DataDisks) &&
+		equality.Semantic.DeepEqual(new.Spec.Template.Spec.PciDevices, old.Spec.Template.Spec.PciDevices)
 }
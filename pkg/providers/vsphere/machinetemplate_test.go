@@ -0,0 +1,85 @@
+package vsphere
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
+)
+
+func TestMachineTemplateEqual(t *testing.T) {
+	withDevices := func() *vspherev1.VSphereMachineTemplate {
+		return &vspherev1.VSphereMachineTemplate{
+			Spec: vspherev1.VSphereMachineTemplateSpec{
+				Template: vspherev1.VSphereMachineTemplateResource{
+					Spec: vspherev1.VSphereMachineSpec{
+						VirtualMachineCloneSpec: vspherev1.VirtualMachineCloneSpec{
+							Template:   "ubuntu",
+							DataDisks:  []vspherev1.VSphereDisk{{Name: "containerd", SizeGiB: 50}},
+							PciDevices: []vspherev1.PCIDeviceSpec{{DeviceID: ptr.To[int32](8712), VendorID: ptr.To[int32](4318)}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*vspherev1.VSphereMachineTemplate)
+		want   bool
+	}{
+		{
+			name:   "same spec",
+			modify: func(*vspherev1.VSphereMachineTemplate) {},
+			want:   true,
+		},
+		{
+			name: "data disks removed",
+			modify: func(m *vspherev1.VSphereMachineTemplate) {
+				m.Spec.Template.Spec.DataDisks = nil
+			},
+			want: false,
+		},
+		{
+			name: "data disks emptied",
+			modify: func(m *vspherev1.VSphereMachineTemplate) {
+				m.Spec.Template.Spec.DataDisks = []vspherev1.VSphereDisk{}
+			},
+			want: false,
+		},
+		{
+			name: "pci devices removed",
+			modify: func(m *vspherev1.VSphereMachineTemplate) {
+				m.Spec.Template.Spec.PciDevices = nil
+			},
+			want: false,
+		},
+		{
+			name: "data disk resized",
+			modify: func(m *vspherev1.VSphereMachineTemplate) {
+				m.Spec.Template.Spec.DataDisks[0].SizeGiB = 100
+			},
+			want: false,
+		},
+		{
+			name: "field defaulted in the existing template",
+			modify: func(m *vspherev1.VSphereMachineTemplate) {
+				m.Spec.Template.Spec.Snapshot = ""
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			old := withDevices()
+			old.Spec.Template.Spec.Snapshot = "current"
+			new := withDevices()
+			tt.modify(new)
+			g.Expect(machineTemplateEqual(new, old)).To(Equal(tt.want))
+		})
+	}
+}
@@ -132,6 +132,20 @@ func (mr *MockProviderGovcClientMockRecorder) CreateLibrary(ctx, datastore, libr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLibrary", reflect.TypeOf((*MockProviderGovcClient)(nil).CreateLibrary), ctx, datastore, library)
 }
 
+// CreateMultiValueCategoryForVM mocks base method.
+func (m *MockProviderGovcClient) CreateMultiValueCategoryForVM(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMultiValueCategoryForVM", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMultiValueCategoryForVM indicates an expected call of CreateMultiValueCategoryForVM.
+func (mr *MockProviderGovcClientMockRecorder) CreateMultiValueCategoryForVM(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultiValueCategoryForVM", reflect.TypeOf((*MockProviderGovcClient)(nil).CreateMultiValueCategoryForVM), ctx, name)
+}
+
 // CreateRole mocks base method.
 func (m *MockProviderGovcClient) CreateRole(ctx context.Context, name string, privileges []string) error {
 	m.ctrl.T.Helper()
@@ -372,6 +386,21 @@ func (mr *MockProviderGovcClientMockRecorder) GetVMDiskSizeInGB(ctx, vm, datacen
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVMDiskSizeInGB", reflect.TypeOf((*MockProviderGovcClient)(nil).GetVMDiskSizeInGB), ctx, vm, datacenter)
 }
 
+// GetVMHardwareVersion mocks base method.
+func (m *MockProviderGovcClient) GetVMHardwareVersion(ctx context.Context, vm, datacenter string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVMHardwareVersion", ctx, vm, datacenter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVMHardwareVersion indicates an expected call of GetVMHardwareVersion.
+func (mr *MockProviderGovcClientMockRecorder) GetVMHardwareVersion(ctx, vm, datacenter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVMHardwareVersion", reflect.TypeOf((*MockProviderGovcClient)(nil).GetVMHardwareVersion), ctx, vm, datacenter)
+}
+
 // GetWorkloadAvailableSpace mocks base method.
 func (m *MockProviderGovcClient) GetWorkloadAvailableSpace(ctx context.Context, datastore string) (float64, error) {
 	m.ctrl.T.Helper()
@@ -403,17 +432,17 @@ func (mr *MockProviderGovcClientMockRecorder) GroupExists(ctx, name any) *gomock
 }
 
 // ImportTemplate mocks base method.
-func (m *MockProviderGovcClient) ImportTemplate(ctx context.Context, library, ovaURL, name string) error {
+func (m *MockProviderGovcClient) ImportTemplate(ctx context.Context, library, ovaURL, name, sha256 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ImportTemplate", ctx, library, ovaURL, name)
+	ret := m.ctrl.Call(m, "ImportTemplate", ctx, library, ovaURL, name, sha256)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ImportTemplate indicates an expected call of ImportTemplate.
-func (mr *MockProviderGovcClientMockRecorder) ImportTemplate(ctx, library, ovaURL, name any) *gomock.Call {
+func (mr *MockProviderGovcClientMockRecorder) ImportTemplate(ctx, library, ovaURL, name, sha256 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplate", reflect.TypeOf((*MockProviderGovcClient)(nil).ImportTemplate), ctx, library, ovaURL, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplate", reflect.TypeOf((*MockProviderGovcClient)(nil).ImportTemplate), ctx, library, ovaURL, name, sha256)
 }
 
 // IsCertSelfSigned mocks base method.
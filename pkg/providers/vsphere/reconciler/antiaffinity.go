@@ -0,0 +1,190 @@
+package reconciler
+
+import (
+	"context"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+)
+
+// AntiAffinityRequeueInterval is how often the anti-affinity rules are reconciled while machines are being
+// created or deleted, since the controller doesn't watch the CAPI Machines.
+const AntiAffinityRequeueInterval = 30 * time.Second
+
+var _ clusters.ProviderClusterDeleteReconciler = &Reconciler{}
+
+// ReconcileAntiAffinityRules keeps the vSphere DRS anti-affinity rules of the control plane, etcd and worker node
+// groups in sync with their machines, so the rules follow the machines replaced during upgrades. The rules that
+// aren't needed anymore, like the ones of removed worker node groups or of groups that don't enable anti-affinity
+// anymore, are deleted.
+func (r *Reconciler) ReconcileAntiAffinityRules(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	managedRules := spec.Cluster.VSphereAntiAffinityRules()
+	groups := vsphere.AntiAffinityGroups(vsphere.NewSpec(spec))
+
+	enabled := false
+	for _, g := range groups {
+		enabled = enabled || g.Enabled()
+	}
+	if !enabled && len(managedRules) == 0 {
+		return controller.Result{}, nil
+	}
+
+	log = log.WithValues("phase", "reconcileAntiAffinityRules")
+	vsc, err := r.buildAntiAffinityClient(ctx, spec.VSphereDatacenter)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	result := controller.Result{}
+	rules := make(map[string]string, len(groups))
+	for _, g := range groups {
+		if !g.Enabled() {
+			continue
+		}
+
+		rules[g.RuleName] = g.ResourcePool
+		vms, settled, err := r.antiAffinityGroupVMs(ctx, g)
+		if err != nil {
+			return controller.Result{}, err
+		}
+		if !settled {
+			log.Info("Machines are being created or deleted, anti-affinity rule will be updated again", "rule", g.RuleName)
+			result = controller.ResultWithRequeue(AntiAffinityRequeueInterval)
+		}
+
+		// A rule needs at least two VMs to keep apart.
+		if len(vms) < 2 {
+			if err := vsc.DeleteVMAntiAffinityRule(ctx, g.ResourcePool, g.RuleName); err != nil {
+				return controller.Result{}, errors.Wrapf(err, "deleting anti-affinity rule %s", g.RuleName)
+			}
+			continue
+		}
+
+		if err := vsc.ReconcileVMAntiAffinityRule(ctx, g.ResourcePool, govmomi.VMAntiAffinityRule{
+			Name:      g.RuleName,
+			VMs:       vms,
+			Mandatory: g.Mandatory(),
+		}); err != nil {
+			return controller.Result{}, errors.Wrapf(err, "reconciling anti-affinity rule %s", g.RuleName)
+		}
+	}
+
+	// Rules that are not in the desired set anymore, or that moved to a different resource pool, are deleted
+	// from the compute cluster that holds them.
+	for _, name := range sortedRuleNames(managedRules) {
+		if pool, ok := rules[name]; ok && pool == managedRules[name] {
+			continue
+		}
+		log.Info("Deleting anti-affinity rule", "rule", name)
+		if err := vsc.DeleteVMAntiAffinityRule(ctx, managedRules[name], name); err != nil {
+			return controller.Result{}, errors.Wrapf(err, "deleting anti-affinity rule %s", name)
+		}
+	}
+
+	spec.Cluster.SetVSphereAntiAffinityRules(rules)
+
+	return result, nil
+}
+
+// ReconcileDelete deletes the vSphere DRS anti-affinity rules maintained for the cluster.
+func (r *Reconciler) ReconcileDelete(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	rules := cluster.VSphereAntiAffinityRules()
+	if len(rules) == 0 {
+		return nil
+	}
+
+	log = log.WithValues("phase", "reconcileDeleteAntiAffinityRules")
+	datacenter := &anywherev1.VSphereDatacenterConfig{}
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.DatacenterRef.Name}
+	if err := r.client.Get(ctx, key, datacenter); err != nil {
+		return errors.Wrap(err, "getting VSphereDatacenterConfig for anti-affinity rules")
+	}
+
+	if err := SetupEnvVars(ctx, datacenter, r.client); err != nil {
+		return err
+	}
+
+	vsc, err := r.buildAntiAffinityClient(ctx, datacenter)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedRuleNames(rules) {
+		log.Info("Deleting anti-affinity rule", "rule", name)
+		if err := vsc.DeleteVMAntiAffinityRule(ctx, rules[name], name); err != nil {
+			return errors.Wrapf(err, "deleting anti-affinity rule %s", name)
+		}
+	}
+
+	cluster.SetVSphereAntiAffinityRules(nil)
+
+	return nil
+}
+
+func (r *Reconciler) buildAntiAffinityClient(ctx context.Context, datacenter *anywherev1.VSphereDatacenterConfig) (govmomi.VSphereClient, error) {
+	vuc := config.NewVsphereUserConfig()
+	vsc, err := r.vSphereClientBuilder.Build(
+		ctx,
+		datacenter.Spec.Server,
+		vuc.EksaVsphereUsername,
+		vuc.EksaVspherePassword,
+		datacenter.Spec.Insecure,
+		datacenter.Spec.Datacenter,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "building vSphere client for anti-affinity rules")
+	}
+	return vsc, nil
+}
+
+func sortedRuleNames(rules map[string]string) []string {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// antiAffinityGroupVMs returns the inventory paths of the VMs of the group machines. It also returns false if any
+// machine of the group is still being provisioned or deleted, which means the VMs in the group will change soon.
+func (r *Reconciler) antiAffinityGroupVMs(ctx context.Context, g vsphere.AntiAffinityGroup) ([]string, bool, error) {
+	machines := &clusterv1beta2.MachineList{}
+	if err := r.client.List(ctx, machines, client.InNamespace(constants.EksaSystemNamespace), client.MatchingLabels(g.MachineLabels)); err != nil {
+		return nil, false, errors.Wrapf(err, "listing %s machines", g.Name)
+	}
+
+	settled := true
+	vms := make([]string, 0, len(machines.Items))
+	for _, m := range machines.Items {
+		if !m.DeletionTimestamp.IsZero() {
+			settled = false
+			continue
+		}
+
+		provisioned := m.Status.Initialization.InfrastructureProvisioned
+		if provisioned == nil || !*provisioned || m.Spec.InfrastructureRef.Name == "" {
+			settled = false
+			continue
+		}
+
+		// CAPV names the VMs after their VSphereMachine.
+		vms = append(vms, path.Join(g.Folder, m.Spec.InfrastructureRef.Name))
+	}
+
+	return vms, settled, nil
+}
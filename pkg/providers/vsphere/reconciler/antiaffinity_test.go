@@ -0,0 +1,304 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	clusterspec "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+	govmomimocks "github.com/aws/eks-anywhere/pkg/govmomi/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/reconciler"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	cpRule       = "my-cluster-control-plane-anti-affinity"
+	resourcePool = "/datacenter/host/cluster/Resources"
+)
+
+type antiAffinityTest struct {
+	*WithT
+	ctx  context.Context
+	spec *clusterspec.Spec
+	vscb *mocks.MockVSphereClientBuilder
+	vsc  *govmomimocks.MockVSphereClient
+}
+
+func newAntiAffinityTest(t *testing.T) *antiAffinityTest {
+	ctrl := gomock.NewController(t)
+	spec := test.NewClusterSpec(func(s *clusterspec.Spec) {
+		s.Cluster.Name = "my-cluster"
+		s.Cluster.Spec.ControlPlaneConfiguration = anywherev1.ControlPlaneConfiguration{
+			Count:           3,
+			MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "cp"},
+		}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+			{
+				Name:            "md-0",
+				Count:           ptr.Int(2),
+				MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "wn"},
+			},
+		}
+		s.VSphereDatacenter = &anywherev1.VSphereDatacenterConfig{
+			Spec: anywherev1.VSphereDatacenterConfigSpec{
+				Server:     "vcenter",
+				Datacenter: "datacenter",
+			},
+		}
+		s.VSphereMachineConfigs = map[string]*anywherev1.VSphereMachineConfig{
+			"cp": {
+				Spec: anywherev1.VSphereMachineConfigSpec{
+					ResourcePool: "/datacenter/host/cluster/Resources",
+					Folder:       "/datacenter/vm/cp",
+					AntiAffinity: &anywherev1.VSphereAntiAffinity{Mandatory: true},
+				},
+			},
+			"wn": {
+				Spec: anywherev1.VSphereMachineConfigSpec{
+					ResourcePool: "/datacenter/host/cluster/Resources",
+					Folder:       "/datacenter/vm/workers",
+				},
+			},
+		}
+	})
+
+	return &antiAffinityTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		spec:  spec,
+		vscb:  mocks.NewMockVSphereClientBuilder(ctrl),
+		vsc:   govmomimocks.NewMockVSphereClient(ctrl),
+	}
+}
+
+func (tt *antiAffinityTest) reconciler(objs ...client.Object) *reconciler.Reconciler {
+	c := fake.NewClientBuilder().WithObjects(objs...).Build()
+	return reconciler.New(c, nil, nil, nil, nil, nil, tt.vscb)
+}
+
+func (tt *antiAffinityTest) expectClient() {
+	tt.vscb.EXPECT().Build(tt.ctx, "vcenter", gomock.Any(), gomock.Any(), false, "datacenter").Return(tt.vsc, nil)
+}
+
+func controlPlaneMachine(name string, provisioned bool) *clusterv1beta2.Machine {
+	return &clusterv1beta2.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterv1beta2.ClusterNameLabel:             "my-cluster",
+				clusterv1beta2.MachineControlPlaneNameLabel: "my-cluster",
+			},
+		},
+		Spec: clusterv1beta2.MachineSpec{
+			ClusterName: "my-cluster",
+			InfrastructureRef: clusterv1beta2.ContractVersionedObjectReference{
+				Kind: "VSphereMachine",
+				Name: name,
+			},
+		},
+		Status: clusterv1beta2.MachineStatus{
+			Initialization: clusterv1beta2.MachineInitializationStatus{
+				InfrastructureProvisioned: ptr.Bool(provisioned),
+			},
+		},
+	}
+}
+
+func TestReconcilerReconcileAntiAffinityRulesDisabled(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	tt.spec.VSphereMachineConfigs["cp"].Spec.AntiAffinity = nil
+
+	result, err := tt.reconciler().ReconcileAntiAffinityRules(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileAntiAffinityRules(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	tt.expectClient()
+	tt.vsc.EXPECT().ReconcileVMAntiAffinityRule(tt.ctx, "/datacenter/host/cluster/Resources", govmomi.VMAntiAffinityRule{
+		Name:      cpRule,
+		VMs:       []string{"/datacenter/vm/cp/my-cluster-cp-1", "/datacenter/vm/cp/my-cluster-cp-2", "/datacenter/vm/cp/my-cluster-cp-3"},
+		Mandatory: true,
+	})
+
+	result, err := tt.reconciler(
+		controlPlaneMachine("my-cluster-cp-1", true),
+		controlPlaneMachine("my-cluster-cp-2", true),
+		controlPlaneMachine("my-cluster-cp-3", true),
+	).ReconcileAntiAffinityRules(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.spec.Cluster.VSphereAntiAffinityRules()).To(Equal(map[string]string{cpRule: resourcePool}))
+}
+
+func TestReconcilerReconcileAntiAffinityRulesMachinesRollingOut(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	tt.expectClient()
+	tt.vsc.EXPECT().ReconcileVMAntiAffinityRule(tt.ctx, "/datacenter/host/cluster/Resources", govmomi.VMAntiAffinityRule{
+		Name:      cpRule,
+		VMs:       []string{"/datacenter/vm/cp/my-cluster-cp-2", "/datacenter/vm/cp/my-cluster-cp-3"},
+		Mandatory: true,
+	})
+
+	deleting := controlPlaneMachine("my-cluster-cp-1", true)
+	deleting.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+	deleting.Finalizers = []string{"machine.cluster.x-k8s.io"}
+
+	result, err := tt.reconciler(
+		deleting,
+		controlPlaneMachine("my-cluster-cp-2", true),
+		controlPlaneMachine("my-cluster-cp-3", true),
+		controlPlaneMachine("my-cluster-cp-4", false),
+	).ReconcileAntiAffinityRules(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.ToCtrlResult().RequeueAfter).To(Equal(reconciler.AntiAffinityRequeueInterval))
+}
+
+func TestReconcilerReconcileAntiAffinityRulesSingleMachine(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	tt.expectClient()
+	tt.vsc.EXPECT().DeleteVMAntiAffinityRule(tt.ctx, "/datacenter/host/cluster/Resources", cpRule)
+
+	result, err := tt.reconciler(
+		controlPlaneMachine("my-cluster-cp-1", true),
+	).ReconcileAntiAffinityRules(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.spec.Cluster.VSphereAntiAffinityRules()).To(Equal(map[string]string{cpRule: resourcePool}))
+}
+
+func TestReconcilerReconcileAntiAffinityRulesDeleteDisabled(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	tt.spec.VSphereMachineConfigs["cp"].Spec.AntiAffinity = nil
+	tt.spec.Cluster.SetVSphereAntiAffinityRules(map[string]string{cpRule: resourcePool})
+	tt.expectClient()
+	tt.vsc.EXPECT().DeleteVMAntiAffinityRule(tt.ctx, "/datacenter/host/cluster/Resources", cpRule)
+
+	_, err := tt.reconciler(
+		controlPlaneMachine("my-cluster-cp-1", true),
+		controlPlaneMachine("my-cluster-cp-2", true),
+	).ReconcileAntiAffinityRules(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.spec.Cluster.VSphereAntiAffinityRules()).To(BeEmpty())
+}
+
+func TestReconcilerReconcileAntiAffinityRulesDeleteRemovedGroup(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	tt.spec.Cluster.SetVSphereAntiAffinityRules(map[string]string{
+		cpRule:                          resourcePool,
+		"my-cluster-md-1-anti-affinity": "/datacenter/host/cluster-2/Resources",
+	})
+	tt.expectClient()
+	tt.vsc.EXPECT().ReconcileVMAntiAffinityRule(tt.ctx, resourcePool, gomock.Any())
+	tt.vsc.EXPECT().DeleteVMAntiAffinityRule(tt.ctx, "/datacenter/host/cluster-2/Resources", "my-cluster-md-1-anti-affinity")
+
+	_, err := tt.reconciler(
+		controlPlaneMachine("my-cluster-cp-1", true),
+		controlPlaneMachine("my-cluster-cp-2", true),
+	).ReconcileAntiAffinityRules(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.spec.Cluster.VSphereAntiAffinityRules()).To(Equal(map[string]string{cpRule: resourcePool}))
+}
+
+func TestReconcilerReconcileAntiAffinityRulesDeleteMovedRule(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	tt.spec.Cluster.SetVSphereAntiAffinityRules(map[string]string{cpRule: "/datacenter/host/old-cluster/Resources"})
+	tt.expectClient()
+	tt.vsc.EXPECT().ReconcileVMAntiAffinityRule(tt.ctx, resourcePool, gomock.Any())
+	tt.vsc.EXPECT().DeleteVMAntiAffinityRule(tt.ctx, "/datacenter/host/old-cluster/Resources", cpRule)
+
+	_, err := tt.reconciler(
+		controlPlaneMachine("my-cluster-cp-1", true),
+		controlPlaneMachine("my-cluster-cp-2", true),
+	).ReconcileAntiAffinityRules(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.spec.Cluster.VSphereAntiAffinityRules()).To(Equal(map[string]string{cpRule: resourcePool}))
+}
+
+func TestReconcilerReconcileAntiAffinityRulesError(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	tt.expectClient()
+	tt.vsc.EXPECT().ReconcileVMAntiAffinityRule(tt.ctx, gomock.Any(), gomock.Any()).Return(errors.New("rule conflict"))
+
+	_, err := tt.reconciler(
+		controlPlaneMachine("my-cluster-cp-1", true),
+		controlPlaneMachine("my-cluster-cp-2", true),
+	).ReconcileAntiAffinityRules(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).To(MatchError("reconciling anti-affinity rule my-cluster-control-plane-anti-affinity: rule conflict"))
+	tt.Expect(tt.spec.Cluster.VSphereAntiAffinityRules()).To(BeEmpty())
+}
+
+func TestReconcilerReconcileAntiAffinityRulesClientError(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	tt.vscb.EXPECT().Build(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("bad credentials"))
+
+	_, err := tt.reconciler().ReconcileAntiAffinityRules(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).To(MatchError("building vSphere client for anti-affinity rules: bad credentials"))
+}
+
+func TestReconcilerReconcileDeleteAntiAffinityRules(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	cluster := tt.spec.Cluster
+	cluster.Namespace = "default"
+	cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: "datacenter"}
+	cluster.SetVSphereAntiAffinityRules(map[string]string{
+		cpRule:                          resourcePool,
+		"my-cluster-md-0-anti-affinity": "/datacenter/host/cluster-2/Resources",
+	})
+	datacenter := tt.spec.VSphereDatacenter.DeepCopy()
+	datacenter.Name = "datacenter"
+	datacenter.Namespace = "default"
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vsphere.CredentialsObjectName,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("user"),
+			"password": []byte("pass"),
+		},
+	}
+
+	tt.vscb.EXPECT().Build(tt.ctx, "vcenter", "user", "pass", false, "datacenter").Return(tt.vsc, nil)
+	gomock.InOrder(
+		tt.vsc.EXPECT().DeleteVMAntiAffinityRule(tt.ctx, resourcePool, cpRule),
+		tt.vsc.EXPECT().DeleteVMAntiAffinityRule(tt.ctx, "/datacenter/host/cluster-2/Resources", "my-cluster-md-0-anti-affinity"),
+	)
+
+	err := tt.reconciler(datacenter, secret).ReconcileDelete(tt.ctx, test.NewNullLogger(), cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(cluster.VSphereAntiAffinityRules()).To(BeEmpty())
+}
+
+func TestReconcilerReconcileDeleteNoAntiAffinityRules(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+
+	tt.Expect(tt.reconciler().ReconcileDelete(tt.ctx, test.NewNullLogger(), tt.spec.Cluster)).To(Succeed())
+}
+
+func TestReconcilerReconcileDeleteAntiAffinityRulesDatacenterNotFound(t *testing.T) {
+	tt := newAntiAffinityTest(t)
+	tt.spec.Cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: "datacenter"}
+	tt.spec.Cluster.SetVSphereAntiAffinityRules(map[string]string{cpRule: resourcePool})
+
+	err := tt.reconciler().ReconcileDelete(tt.ctx, test.NewNullLogger(), tt.spec.Cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting VSphereDatacenterConfig for anti-affinity rules")))
+	tt.Expect(tt.spec.Cluster.VSphereAntiAffinityRules()).To(HaveKey(cpRule))
+}
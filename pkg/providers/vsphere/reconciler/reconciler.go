@@ -47,11 +47,12 @@ type Reconciler struct {
 	cniReconciler        CNIReconciler
 	remoteClientRegistry RemoteClientRegistry
 	ipValidator          IPValidator
+	vSphereClientBuilder vsphere.VSphereClientBuilder
 	*serverside.ObjectApplier
 }
 
 // New defines a new VSphere reconciler.
-func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.Defaulter, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
+func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.Defaulter, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator, vSphereClientBuilder vsphere.VSphereClientBuilder) *Reconciler {
 	return &Reconciler{
 		client:               client,
 		validator:            validator,
@@ -59,6 +60,7 @@ func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.
 		cniReconciler:        cniReconciler,
 		remoteClientRegistry: remoteClientRegistry,
 		ipValidator:          ipValidator,
+		vSphereClientBuilder: vSphereClientBuilder,
 		ObjectApplier:        serverside.NewObjectApplier(client),
 	}
 }
@@ -128,6 +130,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
 		r.ReconcileWorkers,
+		r.ReconcileAntiAffinityRules,
+		r.ReconcileTemplates,
 	).Run(ctx, log, clusterSpec)
 }
 
@@ -403,6 +403,7 @@ type reconcilerTest struct {
 	machineConfigControlPlane *anywherev1.VSphereMachineConfig
 	machineConfigWorker       *anywherev1.VSphereMachineConfig
 	ipValidator               *vspherereconcilermocks.MockIPValidator
+	vSphereClientBuilder      *mocks.MockVSphereClientBuilder
 	kcp                       *controlplanev1beta2.KubeadmControlPlane
 	vsphereDeploymentZone     *vspherev1.VSphereDeploymentZone
 }
@@ -530,6 +531,7 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 		validator:            validator,
 		defaulter:            defaulter,
 		ipValidator:          ipValidator,
+		vSphereClientBuilder: mocks.NewMockVSphereClientBuilder(ctrl),
 		remoteClientRegistry: remoteClientRegistry,
 		client:               c,
 		env:                  env,
@@ -577,7 +579,7 @@ func (tt *reconcilerTest) withFakeClient() {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.validator, tt.defaulter, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator)
+	return reconciler.New(tt.client, tt.validator, tt.defaulter, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator, tt.vSphereClientBuilder)
 }
 
 func (tt *reconcilerTest) createAllObjs() {
@@ -0,0 +1,129 @@
+package reconciler
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/collection"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+)
+
+// TemplateGCGracePeriod is how long an imported template is kept after it's created even if no machine uses it,
+// so the templates imported by the CLI are not deleted before the cluster that needs them is created or upgraded.
+const TemplateGCGracePeriod = 24 * time.Hour
+
+// ReconcileTemplates garbage collects the templates imported from the bundle OVAs that are not used anymore by
+// any VSphereMachineConfig or CAPV machine of the management cluster and its workload clusters. The management
+// cluster first adopts the imported templates its clusters use by tagging them with its owner tag, which includes
+// the cluster UID. A template shared with other management clusters is only released by removing this cluster's
+// owner tag, it's deleted once no management cluster owns it.
+func (r *Reconciler) ReconcileTemplates(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	// The management cluster owns the templates of all the clusters it manages.
+	if !spec.Cluster.IsSelfManaged() {
+		return controller.Result{}, nil
+	}
+
+	log = log.WithValues("phase", "reconcileTemplates")
+	vuc := config.NewVsphereUserConfig()
+	vsc, err := r.vSphereClientBuilder.Build(
+		ctx,
+		spec.VSphereDatacenter.Spec.Server,
+		vuc.EksaVsphereUsername,
+		vuc.EksaVspherePassword,
+		spec.VSphereDatacenter.Spec.Insecure,
+		spec.VSphereDatacenter.Spec.Datacenter,
+	)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "building vSphere client for templates")
+	}
+
+	templates, err := vsc.ListImportedTemplates(ctx, vsphere.DefaultTemplatesFolder(spec.VSphereDatacenter.Spec.Datacenter))
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "listing imported templates")
+	}
+
+	if len(templates) == 0 {
+		return controller.Result{}, nil
+	}
+
+	used, err := r.usedTemplates(ctx)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	owner := govmomi.TemplateOwnerTag(spec.Cluster.Name, string(spec.Cluster.UID))
+	for _, t := range templates {
+		owned := collection.NewSetFrom(t.Owners...).Contains(owner)
+		if used.Contains(path.Base(t.Path)) {
+			if !owned {
+				log.Info("Adopting imported template", "template", t.Path)
+				if err := vsc.AdoptImportedTemplate(ctx, t.Path, owner); err != nil {
+					return controller.Result{}, errors.Wrapf(err, "adopting template %s", t.Path)
+				}
+			}
+			continue
+		}
+
+		if !owned || time.Since(t.Created) <= TemplateGCGracePeriod {
+			continue
+		}
+
+		if len(t.Owners) > 1 {
+			log.Info("Releasing unused template shared with other management clusters", "template", t.Path)
+			if err := vsc.ReleaseImportedTemplate(ctx, t.Path, owner); err != nil {
+				return controller.Result{}, errors.Wrapf(err, "releasing template %s", t.Path)
+			}
+			continue
+		}
+
+		log.Info("Deleting unused template", "template", t.Path)
+		if err := vsc.DeleteImportedTemplate(ctx, t.Path, vsphere.DefaultTemplateLibrary); err != nil {
+			return controller.Result{}, errors.Wrapf(err, "deleting template %s", t.Path)
+		}
+	}
+
+	return controller.Result{}, nil
+}
+
+// usedTemplates returns the names of the templates referenced by the VSphereMachineConfigs, the CAPV machine
+// templates and the CAPV machines in all namespaces. Old machine templates are included since their machines
+// might still be rolling out.
+func (r *Reconciler) usedTemplates(ctx context.Context) (collection.Set[string], error) {
+	used := collection.NewSet[string]()
+
+	machineConfigs := &anywherev1.VSphereMachineConfigList{}
+	if err := r.client.List(ctx, machineConfigs); err != nil {
+		return nil, errors.Wrap(err, "listing VSphereMachineConfigs")
+	}
+	for _, m := range machineConfigs.Items {
+		used.Add(path.Base(m.Spec.Template))
+	}
+
+	machineTemplates := &vspherev1.VSphereMachineTemplateList{}
+	if err := r.client.List(ctx, machineTemplates); err != nil {
+		return nil, errors.Wrap(err, "listing VSphereMachineTemplates")
+	}
+	for _, m := range machineTemplates.Items {
+		used.Add(path.Base(m.Spec.Template.Spec.Template))
+	}
+
+	machines := &vspherev1.VSphereMachineList{}
+	if err := r.client.List(ctx, machines); err != nil {
+		return nil, errors.Wrap(err, "listing VSphereMachines")
+	}
+	for _, m := range machines.Items {
+		used.Add(path.Base(m.Spec.Template))
+	}
+
+	return used, nil
+}
@@ -0,0 +1,199 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	clusterspec "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+	govmomimocks "github.com/aws/eks-anywhere/pkg/govmomi/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/reconciler"
+)
+
+const templatesFolder = "/datacenter/vm/Templates"
+
+type templatesTest struct {
+	*WithT
+	ctx   context.Context
+	spec  *clusterspec.Spec
+	vscb  *mocks.MockVSphereClientBuilder
+	vsc   *govmomimocks.MockVSphereClient
+	old   time.Time
+	owner string
+}
+
+func newTemplatesTest(t *testing.T) *templatesTest {
+	ctrl := gomock.NewController(t)
+	spec := test.NewClusterSpec(func(s *clusterspec.Spec) {
+		s.Cluster.Name = "mgmt"
+		s.Cluster.UID = "4c4a5a2e-1b5e-4a9e-8e0b-3f3d7c1f6a2b"
+		s.Cluster.Spec.ManagementCluster.Name = "mgmt"
+		s.VSphereDatacenter = &anywherev1.VSphereDatacenterConfig{
+			Spec: anywherev1.VSphereDatacenterConfigSpec{
+				Server:     "vcenter",
+				Datacenter: "datacenter",
+			},
+		}
+	})
+
+	return &templatesTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		spec:  spec,
+		vscb:  mocks.NewMockVSphereClientBuilder(ctrl),
+		vsc:   govmomimocks.NewMockVSphereClient(ctrl),
+		old:   time.Now().Add(-2 * reconciler.TemplateGCGracePeriod),
+		owner: govmomi.TemplateOwnerTag("mgmt", "4c4a5a2e-1b5e-4a9e-8e0b-3f3d7c1f6a2b"),
+	}
+}
+
+func (tt *templatesTest) reconciler(objs ...client.Object) *reconciler.Reconciler {
+	c := fake.NewClientBuilder().WithObjects(objs...).Build()
+	return reconciler.New(c, nil, nil, nil, nil, nil, tt.vscb)
+}
+
+func (tt *templatesTest) expectClient() {
+	tt.vscb.EXPECT().Build(tt.ctx, "vcenter", gomock.Any(), gomock.Any(), false, "datacenter").Return(tt.vsc, nil)
+}
+
+func machineConfigWithTemplate(name, template string) *anywherev1.VSphereMachineConfig {
+	return &anywherev1.VSphereMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "workloads"},
+		Spec:       anywherev1.VSphereMachineConfigSpec{Template: template},
+	}
+}
+
+func TestReconcilerReconcileTemplates(t *testing.T) {
+	tt := newTemplatesTest(t)
+	otherOwner := govmomi.TemplateOwnerTag("other-mgmt", "0f3a7a47-32d4-4d8b-9a6f-6c1c0e9e2f57")
+	// Same name, different management cluster.
+	sameNameOwner := govmomi.TemplateOwnerTag("mgmt", "b7d9e2c1-5a3f-4e6b-8c2d-1e0f9a8b7c6d")
+	tt.expectClient()
+	tt.vsc.EXPECT().ListImportedTemplates(tt.ctx, templatesFolder).Return([]govmomi.ImportedTemplate{
+		{Path: templatesFolder + "/unused", Owners: []string{tt.owner}, Created: tt.old},
+		{Path: templatesFolder + "/shared", Owners: []string{tt.owner, otherOwner}, Created: tt.old},
+		{Path: templatesFolder + "/machine-config", Owners: []string{tt.owner}, Created: tt.old},
+		{Path: templatesFolder + "/machine-template", Owners: []string{tt.owner}, Created: tt.old},
+		{Path: templatesFolder + "/machine", Owners: []string{tt.owner}, Created: tt.old},
+		{Path: templatesFolder + "/recent", Owners: []string{tt.owner}, Created: time.Now()},
+		{Path: templatesFolder + "/not-owned", Owners: []string{otherOwner}, Created: tt.old},
+		{Path: templatesFolder + "/same-name", Owners: []string{sameNameOwner}, Created: tt.old},
+		{Path: templatesFolder + "/imported-unused", Created: tt.old},
+	}, nil)
+	tt.vsc.EXPECT().DeleteImportedTemplate(tt.ctx, templatesFolder+"/unused", vsphere.DefaultTemplateLibrary)
+	tt.vsc.EXPECT().ReleaseImportedTemplate(tt.ctx, templatesFolder+"/shared", tt.owner)
+
+	machineTemplate := &vspherev1.VSphereMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-cp-1", Namespace: "eksa-system"},
+	}
+	machineTemplate.Spec.Template.Spec.Template = templatesFolder + "/machine-template"
+	machine := &vspherev1.VSphereMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-cp-1-abcde", Namespace: "eksa-system"},
+	}
+	machine.Spec.Template = templatesFolder + "/machine"
+
+	result, err := tt.reconciler(
+		machineConfigWithTemplate("workload-cp", templatesFolder+"/machine-config"),
+		machineTemplate,
+		machine,
+	).ReconcileTemplates(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileTemplatesAdoptsUsedTemplates(t *testing.T) {
+	tt := newTemplatesTest(t)
+	otherOwner := govmomi.TemplateOwnerTag("other-mgmt", "0f3a7a47-32d4-4d8b-9a6f-6c1c0e9e2f57")
+	tt.expectClient()
+	tt.vsc.EXPECT().ListImportedTemplates(tt.ctx, templatesFolder).Return([]govmomi.ImportedTemplate{
+		{Path: templatesFolder + "/imported", Created: time.Now()},
+		{Path: templatesFolder + "/shared", Owners: []string{otherOwner}, Created: tt.old},
+		{Path: templatesFolder + "/owned", Owners: []string{tt.owner}, Created: tt.old},
+	}, nil)
+	tt.vsc.EXPECT().AdoptImportedTemplate(tt.ctx, templatesFolder+"/imported", tt.owner)
+	tt.vsc.EXPECT().AdoptImportedTemplate(tt.ctx, templatesFolder+"/shared", tt.owner)
+
+	result, err := tt.reconciler(
+		machineConfigWithTemplate("workload-cp", templatesFolder+"/imported"),
+		machineConfigWithTemplate("workload-md-0", templatesFolder+"/shared"),
+		machineConfigWithTemplate("mgmt-cp", templatesFolder+"/owned"),
+	).ReconcileTemplates(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileTemplatesAdoptError(t *testing.T) {
+	tt := newTemplatesTest(t)
+	tt.expectClient()
+	tt.vsc.EXPECT().ListImportedTemplates(tt.ctx, templatesFolder).Return([]govmomi.ImportedTemplate{
+		{Path: templatesFolder + "/imported", Created: time.Now()},
+	}, nil)
+	tt.vsc.EXPECT().AdoptImportedTemplate(tt.ctx, templatesFolder+"/imported", tt.owner).Return(errors.New("tag category not found"))
+
+	_, err := tt.reconciler(
+		machineConfigWithTemplate("workload-cp", templatesFolder+"/imported"),
+	).ReconcileTemplates(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).To(MatchError("adopting template /datacenter/vm/Templates/imported: tag category not found"))
+}
+
+func TestReconcilerReconcileTemplatesWorkloadCluster(t *testing.T) {
+	tt := newTemplatesTest(t)
+	tt.spec.Cluster.Spec.ManagementCluster.Name = "other-mgmt"
+
+	result, err := tt.reconciler().ReconcileTemplates(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileTemplatesNothingToCollect(t *testing.T) {
+	tt := newTemplatesTest(t)
+	tt.expectClient()
+	tt.vsc.EXPECT().ListImportedTemplates(tt.ctx, templatesFolder).Return(nil, nil)
+
+	result, err := tt.reconciler().ReconcileTemplates(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileTemplatesClientError(t *testing.T) {
+	tt := newTemplatesTest(t)
+	tt.vscb.EXPECT().Build(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("bad credentials"))
+
+	_, err := tt.reconciler().ReconcileTemplates(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).To(MatchError("building vSphere client for templates: bad credentials"))
+}
+
+func TestReconcilerReconcileTemplatesListError(t *testing.T) {
+	tt := newTemplatesTest(t)
+	tt.expectClient()
+	tt.vsc.EXPECT().ListImportedTemplates(tt.ctx, templatesFolder).Return(nil, errors.New("folder not found"))
+
+	_, err := tt.reconciler().ReconcileTemplates(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).To(MatchError("listing imported templates: folder not found"))
+}
+
+func TestReconcilerReconcileTemplatesDeleteError(t *testing.T) {
+	tt := newTemplatesTest(t)
+	tt.expectClient()
+	tt.vsc.EXPECT().ListImportedTemplates(tt.ctx, templatesFolder).Return([]govmomi.ImportedTemplate{
+		{Path: templatesFolder + "/unused", Owners: []string{tt.owner}, Created: tt.old},
+	}, nil)
+	tt.vsc.EXPECT().DeleteImportedTemplate(tt.ctx, templatesFolder+"/unused", vsphere.DefaultTemplateLibrary).Return(errors.New("template in use"))
+
+	_, err := tt.reconciler().ReconcileTemplates(tt.ctx, test.NewNullLogger(), tt.spec)
+	tt.Expect(err).To(MatchError("deleting template /datacenter/vm/Templates/unused: template in use"))
+}
@@ -40,6 +40,20 @@ func (m *MockGovcClient) EXPECT() *MockGovcClientMockRecorder {
 	return m.recorder
 }
 
+// AddRolePrivileges mocks base method.
+func (m *MockGovcClient) AddRolePrivileges(ctx context.Context, name string, privileges []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRolePrivileges", ctx, name, privileges)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRolePrivileges indicates an expected call of AddRolePrivileges.
+func (mr *MockGovcClientMockRecorder) AddRolePrivileges(ctx, name, privileges any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRolePrivileges", reflect.TypeOf((*MockGovcClient)(nil).AddRolePrivileges), ctx, name, privileges)
+}
+
 // AddUserToGroup mocks base method.
 func (m *MockGovcClient) AddUserToGroup(ctx context.Context, name, username string) error {
 	m.ctrl.T.Helper()
@@ -96,6 +110,21 @@ func (mr *MockGovcClientMockRecorder) CreateUser(ctx, username, password any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockGovcClient)(nil).CreateUser), ctx, username, password)
 }
 
+// GetRolePrivileges mocks base method.
+func (m *MockGovcClient) GetRolePrivileges(ctx context.Context, name string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePrivileges", ctx, name)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePrivileges indicates an expected call of GetRolePrivileges.
+func (mr *MockGovcClientMockRecorder) GetRolePrivileges(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePrivileges", reflect.TypeOf((*MockGovcClient)(nil).GetRolePrivileges), ctx, name)
+}
+
 // GroupExists mocks base method.
 func (m *MockGovcClient) GroupExists(ctx context.Context, name string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/aws/eks-anywhere/pkg/collection"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
@@ -24,6 +25,8 @@ type GovcClient interface {
 	AddUserToGroup(ctx context.Context, name string, username string) error
 	RoleExists(ctx context.Context, name string) (bool, error)
 	CreateRole(ctx context.Context, name string, privileges []string) error
+	GetRolePrivileges(ctx context.Context, name string) ([]string, error)
+	AddRolePrivileges(ctx context.Context, name string, privileges []string) error
 	SetGroupRoleOnObject(ctx context.Context, principal string, role string, object string, domain string) error
 }
 
@@ -69,6 +72,57 @@ func Run(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcClient) err
 	return nil
 }
 
+// RoleDrift is the difference between the privileges of a vSphere role and the ones EKS Anywhere needs.
+type RoleDrift struct {
+	// Role is the name of the role.
+	Role string
+	// Exists is false if the role doesn't exist, in which case all its privileges are missing.
+	Exists bool
+	// MissingPrivileges are the privileges EKS Anywhere needs that the role doesn't have.
+	MissingPrivileges []string
+}
+
+// Check compares the privileges of the roles in the config with the ones EKS Anywhere needs and
+// returns the roles that don't exist or miss privileges. It doesn't change anything in vSphere.
+func Check(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcClient) ([]RoleDrift, error) {
+	roles, err := getRoles(vsuc)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []RoleDrift
+	for _, r := range roles {
+		drift, err := checkRole(ctx, govc, r)
+		if err != nil {
+			return nil, err
+		}
+		if len(drift.MissingPrivileges) > 0 {
+			drifts = append(drifts, drift)
+		}
+	}
+
+	return drifts, nil
+}
+
+// Reconcile makes sure the group, roles and permissions in the config exist and that the roles have
+// all the privileges EKS Anywhere needs. Missing roles are created and missing privileges are added
+// to the existing ones; privileges are never removed. The user must already exist.
+func Reconcile(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcClient) error {
+	if err := createGroup(ctx, vsuc, govc); err != nil {
+		return err
+	}
+
+	if err := addUserToGroup(ctx, vsuc, govc); err != nil {
+		return err
+	}
+
+	if err := reconcileRoles(ctx, vsuc, govc); err != nil {
+		return err
+	}
+
+	return associateRolesToObjects(ctx, vsuc, govc)
+}
+
 func createGroup(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcClient) error {
 	exists, err := govc.GroupExists(ctx, vsuc.Spec.GroupName)
 	if err != nil {
@@ -113,6 +167,65 @@ func createRoles(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcCli
 	return nil
 }
 
+func reconcileRoles(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcClient) error {
+	roles, err := getRoles(vsuc)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range roles {
+		drift, err := checkRole(ctx, govc, r)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !drift.Exists:
+			if err := govc.CreateRole(ctx, r.name, r.privs); err != nil {
+				return err
+			}
+			logger.V(0).Info(fmt.Sprintf("Created %s role", r.name))
+		case len(drift.MissingPrivileges) > 0:
+			if err := govc.AddRolePrivileges(ctx, r.name, drift.MissingPrivileges); err != nil {
+				return err
+			}
+			logger.V(0).Info(fmt.Sprintf("Added missing privileges to %s role", r.name), "privileges", drift.MissingPrivileges)
+		default:
+			logger.V(0).Info(fmt.Sprintf("Skipping %s role because it already has all the required privileges", r.name))
+		}
+	}
+
+	return nil
+}
+
+func checkRole(ctx context.Context, govc GovcClient, r vsphereRole) (RoleDrift, error) {
+	drift := RoleDrift{Role: r.name}
+
+	exists, err := govc.RoleExists(ctx, r.name)
+	if err != nil {
+		return drift, err
+	}
+	if !exists {
+		drift.MissingPrivileges = r.privs
+		return drift, nil
+	}
+	drift.Exists = true
+
+	privs, err := govc.GetRolePrivileges(ctx, r.name)
+	if err != nil {
+		return drift, err
+	}
+
+	has := collection.NewSetFrom(privs...)
+	for _, p := range r.privs {
+		if !has.Contains(p) {
+			drift.MissingPrivileges = append(drift.MissingPrivileges, p)
+		}
+	}
+
+	return drift, nil
+}
+
 func associateRolesToObjects(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcClient) error {
 	err := setGroupRoleOnObjects(ctx, vsuc, govc, vsuc.Spec.GlobalRole, []string{vSphereRootPath})
 	if err != nil {
@@ -2,6 +2,7 @@ package setupuser_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/setupuser"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/setupuser/mocks"
 )
@@ -373,6 +375,146 @@ func TestSetupUserRun(t *testing.T) {
 	}
 }
 
+func requiredPrivs(t *testing.T, privsContent string) []string {
+	var privs []string
+	if err := json.Unmarshal([]byte(privsContent), &privs); err != nil {
+		t.Fatalf("failed to parse privileges: %v", err)
+	}
+	return privs
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	c, err := setupuser.GenerateConfig(ctx, "./testdata/configs/valid.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	gc := mocks.NewMockGovcClient(gomock.NewController(t))
+	globalPrivs := requiredPrivs(t, config.VSphereGlobalPrivsFile)
+	userPrivs := requiredPrivs(t, config.VSphereUserPrivsFile)
+	adminPrivs := requiredPrivs(t, config.VSphereAdminPrivsFile)
+
+	gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+	gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(append([]string{"System.Anonymous"}, globalPrivs...), nil)
+	gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(true, nil)
+	gc.EXPECT().GetRolePrivileges(ctx, c.Spec.UserRole).Return(userPrivs[2:], nil)
+	gc.EXPECT().RoleExists(ctx, c.Spec.AdminRole).Return(false, nil)
+
+	drifts, err := setupuser.Check(ctx, c, gc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(drifts).To(Equal([]setupuser.RoleDrift{
+		{Role: c.Spec.UserRole, Exists: true, MissingPrivileges: userPrivs[:2]},
+		{Role: c.Spec.AdminRole, Exists: false, MissingPrivileges: adminPrivs},
+	}))
+}
+
+func TestCheckNoDrift(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	c, err := setupuser.GenerateConfig(ctx, "./testdata/configs/valid.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	gc := mocks.NewMockGovcClient(gomock.NewController(t))
+
+	for role, privs := range map[string]string{
+		c.Spec.GlobalRole: config.VSphereGlobalPrivsFile,
+		c.Spec.UserRole:   config.VSphereUserPrivsFile,
+		c.Spec.AdminRole:  config.VSphereAdminPrivsFile,
+	} {
+		gc.EXPECT().RoleExists(ctx, role).Return(true, nil)
+		gc.EXPECT().GetRolePrivileges(ctx, role).Return(requiredPrivs(t, privs), nil)
+	}
+
+	drifts, err := setupuser.Check(ctx, c, gc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(drifts).To(BeEmpty())
+}
+
+func TestCheckErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		prepare func(context.Context, *setupuser.VSphereSetupUserConfig, *mocks.MockGovcClient)
+	}{
+		{
+			name:    "role exists error",
+			wantErr: "failed to connect to govc",
+			prepare: func(ctx context.Context, c *setupuser.VSphereSetupUserConfig, gc *mocks.MockGovcClient) {
+				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(false, fmt.Errorf("failed to connect to govc"))
+			},
+		},
+		{
+			name:    "get role privileges error",
+			wantErr: "getting privileges of role MyExistingGlobalRole: govc error",
+			prepare: func(ctx context.Context, c *setupuser.VSphereSetupUserConfig, gc *mocks.MockGovcClient) {
+				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(nil, fmt.Errorf("getting privileges of role MyExistingGlobalRole: govc error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			g := NewWithT(t)
+			c, err := setupuser.GenerateConfig(ctx, "./testdata/configs/valid.yaml")
+			g.Expect(err).NotTo(HaveOccurred())
+			gc := mocks.NewMockGovcClient(gomock.NewController(t))
+			tt.prepare(ctx, c, gc)
+
+			_, err = setupuser.Check(ctx, c, gc)
+			g.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	c, err := setupuser.GenerateConfig(ctx, "./testdata/configs/valid.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	gc := mocks.NewMockGovcClient(gomock.NewController(t))
+	globalPrivs := requiredPrivs(t, config.VSphereGlobalPrivsFile)
+	userPrivs := requiredPrivs(t, config.VSphereUserPrivsFile)
+	adminPrivs := requiredPrivs(t, config.VSphereAdminPrivsFile)
+
+	gc.EXPECT().GroupExists(ctx, c.Spec.GroupName).Return(true, nil)
+	gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username)
+
+	gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+	gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(globalPrivs, nil)
+
+	gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(true, nil)
+	gc.EXPECT().GetRolePrivileges(ctx, c.Spec.UserRole).Return(userPrivs[1:], nil)
+	gc.EXPECT().AddRolePrivileges(ctx, c.Spec.UserRole, userPrivs[:1])
+
+	gc.EXPECT().RoleExists(ctx, c.Spec.AdminRole).Return(false, nil)
+	gc.EXPECT().CreateRole(ctx, c.Spec.AdminRole, adminPrivs)
+
+	gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.GlobalRole, "/", c.Spec.VSphereDomain)
+	gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.AdminRole, c.Spec.Objects.Folders[0], c.Spec.VSphereDomain)
+	gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.AdminRole, c.Spec.Objects.Templates[0], c.Spec.VSphereDomain)
+	gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.UserRole, c.Spec.Objects.Networks[0], c.Spec.VSphereDomain)
+	gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.UserRole, c.Spec.Objects.Datastores[0], c.Spec.VSphereDomain)
+	gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.UserRole, c.Spec.Objects.ResourcePools[0], c.Spec.VSphereDomain)
+
+	g.Expect(setupuser.Reconcile(ctx, c, gc)).To(Succeed())
+}
+
+func TestReconcileAddRolePrivilegesError(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	c, err := setupuser.GenerateConfig(ctx, "./testdata/configs/valid.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	gc := mocks.NewMockGovcClient(gomock.NewController(t))
+
+	gc.EXPECT().GroupExists(ctx, c.Spec.GroupName).Return(true, nil)
+	gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username)
+	gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+	gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(nil, nil)
+	gc.EXPECT().AddRolePrivileges(ctx, c.Spec.GlobalRole, requiredPrivs(t, config.VSphereGlobalPrivsFile)).Return(fmt.Errorf("govc error"))
+
+	g.Expect(setupuser.Reconcile(ctx, c, gc)).To(MatchError("govc error"))
+}
+
 func TestSetupGOVCEnv(t *testing.T) {
 	ctx := context.Background()
 
@@ -173,7 +173,7 @@ func buildTemplateMapCP(
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.APIServerExtraArgs)).
-		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster.Spec.EtcdEncryption)).
+		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster.ControlPlaneEtcdEncryption())).
 		Append(sharedExtraArgs)
 	clusterapi.SetPodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig, apiServerExtraArgs)
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
@@ -214,6 +214,7 @@ func buildTemplateMapCP(
 		"controlPlaneVMsMemoryMiB":             controlPlaneMachineSpec.MemoryMiB,
 		"controlPlaneVMsNumCPUs":               controlPlaneMachineSpec.NumCPUs,
 		"controlPlaneDiskGiB":                  controlPlaneMachineSpec.DiskGiB,
+		"controlPlaneDataDisks":                controlPlaneMachineSpec.DataDisks,
 		"controlPlaneTagIDs":                   controlPlaneMachineSpec.TagIDs,
 		"etcdTagIDs":                           etcdMachineSpec.TagIDs,
 		"controlPlaneSshUsername":              firstControlPlaneMachinesUser.Name,
@@ -320,6 +321,7 @@ func buildTemplateMapCP(
 		values["etcdVsphereDatastore"] = etcdMachineSpec.Datastore
 		values["etcdVsphereFolder"] = etcdMachineSpec.Folder
 		values["etcdDiskGiB"] = etcdMachineSpec.DiskGiB
+		values["etcdDataDisks"] = etcdMachineSpec.DataDisks
 		values["etcdVMsMemoryMiB"] = etcdMachineSpec.MemoryMiB
 		values["etcdVMsNumCPUs"] = etcdMachineSpec.NumCPUs
 		values["etcdVsphereResourcePool"] = etcdMachineSpec.ResourcePool
@@ -394,12 +396,18 @@ func buildTemplateMapCP(
 		}
 	}
 
-	if clusterSpec.Cluster.Spec.EtcdEncryption != nil && len(*clusterSpec.Cluster.Spec.EtcdEncryption) != 0 {
-		conf, err := common.GenerateKMSEncryptionConfiguration(clusterSpec.Cluster.Spec.EtcdEncryption)
+	if etcdEncryption := clusterSpec.Cluster.ControlPlaneEtcdEncryption(); etcdEncryption != nil && len(*etcdEncryption) != 0 {
+		conf, err := common.GenerateKMSEncryptionConfiguration(etcdEncryption)
 		if err != nil {
 			return nil, err
 		}
 		values["encryptionProviderConfig"] = conf
+
+		kmsPluginManifests, err := common.GenerateKMSPluginManifests(etcdEncryption)
+		if err != nil {
+			return nil, err
+		}
+		values["kmsPluginManifests"] = kmsPluginManifests
 	}
 
 	if bottlerocketKubernetesSettings != nil || controlPlaneMachineSpec.HostOSConfiguration != nil {
@@ -486,6 +494,8 @@ func buildTemplateMapMD(
 		"workloadVMsMemoryMiB":           workerNodeGroupMachineSpec.MemoryMiB,
 		"workloadVMsNumCPUs":             workerNodeGroupMachineSpec.NumCPUs,
 		"workloadDiskGiB":                workerNodeGroupMachineSpec.DiskGiB,
+		"workerDataDisks":                workerNodeGroupMachineSpec.DataDisks,
+		"workerPCIDevices":               workerNodeGroupMachineSpec.PCIDevices,
 		"workerTagIDs":                   workerNodeGroupMachineSpec.TagIDs,
 		"workerSshUsername":              firstUser.Name,
 		"vsphereWorkerSshAuthorizedKey":  sshKey,
@@ -14,6 +14,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 const (
@@ -196,6 +197,65 @@ func TestVsphereTemplateBuilderGenerateFailureDomainYaml(t *testing.T) {
 	test.AssertContentToFile(t, string(data), "testdata/expected_results_failuredomain.yaml")
 }
 
+func TestVsphereTemplateBuilderGenerateCAPISpecDataDisks(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	dataDisks := []v1alpha1.VSphereDataDisk{
+		{
+			Name:       "containerd",
+			SizeGiB:    50,
+			Device:     "/dev/sdb",
+			MountPath:  "/var/lib/containerd",
+			Filesystem: "ext4",
+			Label:      "containerd",
+		},
+		{
+			Name:             "etcd",
+			SizeGiB:          20,
+			ProvisioningMode: v1alpha1.VSphereEagerlyZeroedProvisioningMode,
+			Device:           "/dev/sdc",
+			MountPath:        "/var/lib/etcd",
+			Filesystem:       "xfs",
+			Label:            "etcd",
+		},
+	}
+	controlPlaneMachineConfigName := spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	spec.VSphereMachineConfigs[controlPlaneMachineConfigName].Spec.DataDisks = dataDisks
+	etcdMachineConfigName := spec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name
+	spec.VSphereMachineConfigs[etcdMachineConfigName].Spec.DataDisks = dataDisks[1:]
+	firstMachineConfigName := spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name
+	spec.VSphereMachineConfigs[firstMachineConfigName].Spec.DataDisks = dataDisks[:1]
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	cpData, err := builder.GenerateCAPISpecControlPlane(spec, func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(cpData), "testdata/expected_kcp_data_disks.yaml")
+	wData, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(wData), "testdata/expected_kct_data_disks.yaml")
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersPCIDevices(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	firstMachineConfigName := spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name
+	spec.VSphereMachineConfigs[firstMachineConfigName].Spec.PCIDevices = []v1alpha1.VSpherePCIDevice{
+		{
+			DeviceID:    ptr.Int32(8717),
+			VendorID:    ptr.Int32(4318),
+			CustomLabel: "gpu-0",
+		},
+		{
+			VGPUProfile: "grid_a100-8c",
+		},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	data, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(data), "testdata/expected_results_main_md_pci_devices.yaml")
+}
+
 func TestVsphereTemplateBuilderGenerateCAPISpecVCenterTags(t *testing.T) {
 	g := NewWithT(t)
 	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"strings"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/collection"
@@ -19,6 +20,9 @@ import (
 
 const (
 	vsphereRootPath = "/"
+	// minPCIDevicesHardwareVersion is the VM hardware version of vSphere 7.0, the first one supporting
+	// Dynamic DirectPath I/O passthrough devices.
+	minPCIDevicesHardwareVersion = 17
 )
 
 type PrivAssociation struct {
@@ -230,6 +234,10 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		return err
 	}
 
+	if err := v.validatePCIDevices(ctx, vsphereClusterSpec); err != nil {
+		return err
+	}
+
 	logger.MarkPass("Control plane and Workload templates validated")
 
 	for _, mc := range vsphereClusterSpec.VSphereMachineConfigs {
@@ -240,6 +248,10 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		}
 	}
 
+	if err := v.ValidateAntiAffinity(ctx, vsphereClusterSpec); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -290,6 +302,41 @@ func (v *Validator) validateTemplates(ctx context.Context, spec *Spec) error {
 	return nil
 }
 
+// validatePCIDevices checks the PCI devices are only attached to worker machines and their templates use a
+// hardware version that supports them.
+func (v *Validator) validatePCIDevices(ctx context.Context, spec *Spec) error {
+	if cp := spec.controlPlaneMachineConfig(); cp.PCIPassthroughEnabled() {
+		return fmt.Errorf("pciDevices are not supported for control plane machine config %s", cp.Name)
+	}
+	if etcd := spec.etcdMachineConfig(); etcd != nil && etcd.PCIPassthroughEnabled() {
+		return fmt.Errorf("pciDevices are not supported for etcd machine config %s", etcd.Name)
+	}
+
+	datacenter := spec.VSphereDatacenter.Spec.Datacenter
+	validated := map[string]struct{}{}
+	for _, w := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineConfig := spec.workerMachineConfig(w)
+		if !machineConfig.PCIPassthroughEnabled() {
+			continue
+		}
+		if _, ok := validated[machineConfig.Name]; ok {
+			continue
+		}
+
+		version, err := v.govc.GetVMHardwareVersion(ctx, machineConfig.Spec.Template, datacenter)
+		if err != nil {
+			return fmt.Errorf("validating template hardware version for pciDevices: %v", err)
+		}
+		if version < minPCIDevicesHardwareVersion {
+			return fmt.Errorf("pciDevices in VSphereMachineConfig %s require a template with hardware version %d or later, template %s has hardware version %d",
+				machineConfig.Name, minPCIDevicesHardwareVersion, machineConfig.Spec.Template, version)
+		}
+		validated[machineConfig.Name] = struct{}{}
+	}
+
+	return nil
+}
+
 func (v *Validator) getTemplatePath(ctx context.Context, datacenter, templatePath string) (string, error) {
 	templateFullPath, err := v.govc.SearchTemplate(ctx, datacenter, templatePath)
 	if err != nil {
@@ -660,7 +707,12 @@ func (v *Validator) validatePrivs(ctx context.Context, privObjs []PrivAssociatio
 		errMsg := fmt.Sprintf("user %s missing vSphere permissions", username)
 		logger.V(3).Info(errMsg, "Missing Permissions (JSON)", missingPrivs)
 
-		return passed, fmt.Errorf("user %s missing vSphere permissions", username)
+		missing := make([]string, 0, len(missingPrivs))
+		for _, mp := range missingPrivs {
+			missing = append(missing, fmt.Sprintf("%s %s: %s", mp.ObjectType, mp.Path, strings.Join(mp.Permissions, ", ")))
+		}
+
+		return passed, fmt.Errorf("%s: %s", errMsg, strings.Join(missing, "; "))
 	}
 
 	passed = true
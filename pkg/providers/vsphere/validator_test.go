@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -221,6 +222,28 @@ func TestValidatorValidateVsphereUserPrivsError(t *testing.T) {
 	g.Expect(err).To(MatchError(ContainSubstring("error")))
 }
 
+func TestValidatorValidateVsphereUserPrivsMissingPrivileges(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	vscb := govcmocks.NewMockVSphereClientBuilder(ctrl)
+	vsc := mocks.NewMockVSphereClient(ctrl)
+	v := NewValidator(govcmocks.NewMockProviderGovcClient(ctrl), vscb)
+	spec := clusterSpec()
+	g := NewWithT(t)
+
+	var adminPrivs, globalPrivs []string
+	g.Expect(json.Unmarshal([]byte(config.VSphereAdminPrivsFile), &adminPrivs)).To(Succeed())
+	g.Expect(json.Unmarshal([]byte(config.VSphereGlobalPrivsFile), &globalPrivs)).To(Succeed())
+
+	vscb.EXPECT().Build(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), spec.VSphereDatacenter.Spec.Datacenter).Return(vsc, nil)
+	vsc.EXPECT().Username().Return("foobar")
+	vsc.EXPECT().GetPrivsOnEntity(ctx, "/", govmomi.VSphereTypeFolder, "foobar").Return(globalPrivs[1:], nil)
+	vsc.EXPECT().GetPrivsOnEntity(ctx, gomock.Any(), gomock.Any(), "foobar").Return(adminPrivs, nil).AnyTimes()
+
+	err := v.validateVsphereUserPrivs(ctx, spec)
+	g.Expect(err).To(MatchError("user foobar missing vSphere permissions: Folder /: " + globalPrivs[0]))
+}
+
 func TestValidatorValidateVsphereCPUserPrivsError(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
@@ -892,6 +915,99 @@ func TestValidateBRHardDiskSize(t *testing.T) {
 	}
 }
 
+func pciDevicesClusterSpec(opts ...func(*Spec)) *Spec {
+	return clusterSpec(func(s *Spec) {
+		s.VSphereMachineConfigs["test-wn"] = &v1alpha1.VSphereMachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-wn"},
+			Spec: v1alpha1.VSphereMachineConfigSpec{
+				Template:   "ubuntu-gpu",
+				PCIDevices: []v1alpha1.VSpherePCIDevice{{VGPUProfile: "grid_a100-8c"}},
+			},
+		}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+			{Name: "md-0", MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}},
+			{Name: "md-1", MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}},
+		}
+		for _, opt := range opts {
+			opt(s)
+		}
+	})
+}
+
+func TestValidatorValidatePCIDevices(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	v := NewValidator(govc, nil)
+
+	govc.EXPECT().GetVMHardwareVersion(ctx, "ubuntu-gpu", "SDDC-Datacenter").Return(19, nil)
+
+	g := NewWithT(t)
+	g.Expect(v.validatePCIDevices(ctx, pciDevicesClusterSpec())).To(Succeed())
+}
+
+func TestValidatorValidatePCIDevicesErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            *Spec
+		hardwareVersion int
+		govcErr         error
+		wantErr         string
+	}{
+		{
+			name: "control plane",
+			spec: pciDevicesClusterSpec(func(s *Spec) {
+				s.VSphereMachineConfigs["test-cp"].Name = "test-cp"
+				s.VSphereMachineConfigs["test-cp"].Spec.PCIDevices = []v1alpha1.VSpherePCIDevice{{VGPUProfile: "grid_a100-8c"}}
+			}),
+			wantErr: "pciDevices are not supported for control plane machine config test-cp",
+		},
+		{
+			name: "etcd",
+			spec: pciDevicesClusterSpec(func(s *Spec) {
+				s.VSphereMachineConfigs["test-etcd"] = &v1alpha1.VSphereMachineConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-etcd"},
+					Spec: v1alpha1.VSphereMachineConfigSpec{
+						PCIDevices: []v1alpha1.VSpherePCIDevice{{VGPUProfile: "grid_a100-8c"}},
+					},
+				}
+				s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+					Count:           3,
+					MachineGroupRef: &v1alpha1.Ref{Name: "test-etcd"},
+				}
+			}),
+			wantErr: "pciDevices are not supported for etcd machine config test-etcd",
+		},
+		{
+			name:    "govc error",
+			spec:    pciDevicesClusterSpec(),
+			govcErr: errors.New("vm not found"),
+			wantErr: "validating template hardware version for pciDevices: vm not found",
+		},
+		{
+			name:            "old hardware version",
+			spec:            pciDevicesClusterSpec(),
+			hardwareVersion: 15,
+			wantErr:         "pciDevices in VSphereMachineConfig test-wn require a template with hardware version 17 or later, template ubuntu-gpu has hardware version 15",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			govc := govcmocks.NewMockProviderGovcClient(ctrl)
+			v := NewValidator(govc, nil)
+			if tt.hardwareVersion != 0 || tt.govcErr != nil {
+				govc.EXPECT().GetVMHardwareVersion(ctx, "ubuntu-gpu", "SDDC-Datacenter").Return(tt.hardwareVersion, tt.govcErr)
+			}
+
+			g := NewWithT(t)
+			g.Expect(v.validatePCIDevices(ctx, tt.spec)).To(MatchError(tt.wantErr))
+		})
+	}
+}
+
 func TestValidator_validateTemplates(t *testing.T) {
 	type template struct {
 		name string
@@ -41,7 +41,6 @@ const (
 	govcDatacenterKey        = "GOVC_DATACENTER"
 	govcInsecure             = "GOVC_INSECURE"
 	expClusterResourceSetKey = "EXP_CLUSTER_RESOURCE_SET"
-	defaultTemplateLibrary   = "eks-a-templates"
 	defaultTemplatesFolder   = "vm/Templates"
 	maxRetries               = 30
 	backOffPeriod            = 5 * time.Second
@@ -50,6 +49,9 @@ const (
 	MemoryAvailable          = "Memory_Available"
 )
 
+// DefaultTemplateLibrary is the content library the OVAs from the bundle are imported to.
+const DefaultTemplateLibrary = "eks-a-templates"
+
 const (
 	// Documentation URLs.
 	vSpherePermissionDoc = "https://anywhere.eks.amazonaws.com/docs/getting-started/vsphere/vsphere-preparation/"
@@ -110,14 +112,16 @@ type ProviderGovcClient interface {
 	GetComputeClusterPath(ctx context.Context, datacenter string, computeCluster string, envMap map[string]string) (string, error)
 	CreateLibrary(ctx context.Context, datastore, library string) error
 	DeployTemplateFromLibrary(ctx context.Context, templateDir, templateName, library, datacenter, datastore, network, resourcePool string, resizeDisk2 bool) error
-	ImportTemplate(ctx context.Context, library, ovaURL, name string) error
+	ImportTemplate(ctx context.Context, library, ovaURL, name, sha256 string) error
 	GetVMDiskSizeInGB(ctx context.Context, vm, datacenter string) (int, error)
+	GetVMHardwareVersion(ctx context.Context, vm, datacenter string) (int, error)
 	GetTags(ctx context.Context, path string) (tags []string, err error)
 	ListTags(ctx context.Context) ([]executables.Tag, error)
 	CreateTag(ctx context.Context, tag, category string) error
 	AddTag(ctx context.Context, path, tag string) error
 	ListCategories(ctx context.Context) ([]string, error)
 	CreateCategoryForVM(ctx context.Context, name string) error
+	CreateMultiValueCategoryForVM(ctx context.Context, name string) error
 	CreateUser(ctx context.Context, username, password string) error
 	UserExists(ctx context.Context, username string) (bool, error)
 	CreateGroup(ctx context.Context, name string) error
@@ -356,7 +360,7 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 
 	if !p.skippedValidations[validations.VSphereUserPriv] {
 		if err := p.validator.validateVsphereUserPrivs(ctx, vSphereClusterSpec); err != nil {
-			return fmt.Errorf("validating vsphere user privileges: %w, please refer to %s for required permissions or use -v 3 for full missing permissions", err, vSpherePermissionDoc)
+			return fmt.Errorf("validating vsphere user privileges: %w, please refer to %s for required permissions or run 'eksctl anywhere exp vsphere setup user --reconcile' to add the missing ones to the EKS Anywhere roles", err, vSpherePermissionDoc)
 		}
 	}
 
@@ -409,7 +413,7 @@ func (p *vsphereProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cl
 
 	if !p.skippedValidations[validations.VSphereUserPriv] {
 		if err := p.validator.validateVsphereUserPrivs(ctx, vSphereClusterSpec); err != nil {
-			return fmt.Errorf("validating vsphere user privileges: %w, please refer to %s for required permissions or use -v 3 for full missing permissions", err, vSpherePermissionDoc)
+			return fmt.Errorf("validating vsphere user privileges: %w, please refer to %s for required permissions or run 'eksctl anywhere exp vsphere setup user --reconcile' to add the missing ones to the EKS Anywhere roles", err, vSpherePermissionDoc)
 		}
 	}
 
@@ -474,7 +478,7 @@ func (p *vsphereProvider) getPrevMachineConfigDatastoreUsage(ctx context.Context
 			return 0, err
 		}
 		if em != nil {
-			return float64(em.Spec.DiskGiB * count), nil
+			return float64((em.Spec.DiskGiB + em.DataDisksGiB()) * count), nil
 		}
 	}
 	return 0, nil
@@ -485,7 +489,7 @@ func (p *vsphereProvider) getMachineConfigDatastoreRequirements(ctx context.Cont
 	if err != nil {
 		return 0, 0, fmt.Errorf("getting datastore details: %v", err)
 	}
-	needGiB := machineConfig.Spec.DiskGiB * count
+	needGiB := (machineConfig.Spec.DiskGiB + machineConfig.DataDisksGiB()) * count
 	return availableSpace, needGiB, nil
 }
 
@@ -165,7 +165,7 @@ func (pc *DummyProviderGovcClient) ResizeDisk(ctx context.Context, template, dis
 	return nil
 }
 
-func (pc *DummyProviderGovcClient) ImportTemplate(ctx context.Context, library, ovaURL, name string) error {
+func (pc *DummyProviderGovcClient) ImportTemplate(ctx context.Context, library, ovaURL, name, sha256 string) error {
 	return nil
 }
 
@@ -173,6 +173,10 @@ func (pc *DummyProviderGovcClient) GetVMDiskSizeInGB(ctx context.Context, vm, da
 	return 25, nil
 }
 
+func (pc *DummyProviderGovcClient) GetVMHardwareVersion(ctx context.Context, vm, datacenter string) (int, error) {
+	return 17, nil
+}
+
 func (pc *DummyProviderGovcClient) GetHardDiskSize(ctx context.Context, vm, datacenter string) (map[string]float64, error) {
 	return map[string]float64{"Hard disk 1": 23068672}, nil
 }
@@ -205,6 +209,10 @@ func (pc *DummyProviderGovcClient) CreateCategoryForVM(ctx context.Context, name
 	return nil
 }
 
+func (pc *DummyProviderGovcClient) CreateMultiValueCategoryForVM(ctx context.Context, name string) error {
+	return nil
+}
+
 func (pc *DummyProviderGovcClient) AddUserToGroup(ctx context.Context, name, username string) error {
 	return nil
 }
@@ -679,7 +687,7 @@ func TestSetupAndValidateCreateClusterMissingPrivError(t *testing.T) {
 	provider.validator.vSphereClientBuilder = vscb
 
 	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
-	expectedErrorMsg := fmt.Sprintf("validating vsphere user privileges: error, please refer to %s for required permissions or use -v 3 for full missing permissions", vSpherePermissionDoc)
+	expectedErrorMsg := fmt.Sprintf("validating vsphere user privileges: error, please refer to %s for required permissions or run 'eksctl anywhere exp vsphere setup user --reconcile' to add the missing ones to the EKS Anywhere roles", vSpherePermissionDoc)
 	thenErrorExpected(t, expectedErrorMsg, err)
 }
 
@@ -702,7 +710,7 @@ func TestSetupAndValidateUpgradeClusterMissingPrivError(t *testing.T) {
 
 	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, clusterSpec)
 
-	expectedErrorMsg := fmt.Sprintf("validating vsphere user privileges: error, please refer to %s for required permissions or use -v 3 for full missing permissions", vSpherePermissionDoc)
+	expectedErrorMsg := fmt.Sprintf("validating vsphere user privileges: error, please refer to %s for required permissions or run 'eksctl anywhere exp vsphere setup user --reconcile' to add the missing ones to the EKS Anywhere roles", vSpherePermissionDoc)
 	thenErrorExpected(t, expectedErrorMsg, err)
 }
 
@@ -2009,6 +2017,7 @@ func TestSetupAndValidateCreateClusterDefaultTemplateWorker(t *testing.T) {
 	clusterSpec.VersionsBundles["1.19"].EksD.Ova.Bottlerocket.URI = "https://amazonaws.com/artifacts/0.0.1/eks-distro/ova/1-19/1-19-4/bottlerocket-eks-a-0.0.1.build.38-amd64.ova"
 	clusterSpec.VersionsBundles["1.19"].EksD.Ova.Bottlerocket.SHA256 = "63a8dce1683379cb8df7d15e9c5adf9462a2b9803a544dd79b16f19a4657967f"
 	clusterSpec.VersionsBundles["1.19"].EksD.Ova.Bottlerocket.Arch = []string{"amd64"}
+	clusterSpec.VersionsBundles["1.19"].EksD.Ova.Ubuntu = nil
 	clusterSpec.VersionsBundles["1.19"].EksD.Name = eksd119Release
 	clusterSpec.VersionsBundles["1.19"].EksD.KubeVersion = "v1.19.8"
 	clusterSpec.VersionsBundles["1.19"].KubeVersion = "1.19"
@@ -2020,7 +2029,7 @@ func TestSetupAndValidateCreateClusterDefaultTemplateWorker(t *testing.T) {
 	clusterSpec.VSphereMachineConfigs[workerNodeMachineConfigName].Spec.Template = ""
 	etcdMachineConfigName := clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name
 	clusterSpec.VSphereMachineConfigs[etcdMachineConfigName].Spec.Template = ""
-	wantError := fmt.Errorf("failed setting default values for vsphere machine configs: can not import ova for osFamily: ubuntu, please use bottlerocket as osFamily for auto-importing or provide a valid template")
+	wantError := fmt.Errorf("failed setting default values for vsphere machine configs: can not import ova for osFamily: ubuntu, the bundle for Kubernetes v1.19.8 doesn't include one, please provide a valid template")
 
 	setupContext(t)
 
@@ -2035,6 +2044,7 @@ func TestSetupAndValidateCreateClusterDefaultTemplateCP(t *testing.T) {
 	clusterSpec.VersionsBundles["1.19"].EksD.Ova.Bottlerocket.URI = "https://amazonaws.com/artifacts/0.0.1/eks-distro/ova/1-19/1-19-4/bottlerocket-eks-a-0.0.1.build.38-amd64.ova"
 	clusterSpec.VersionsBundles["1.19"].EksD.Ova.Bottlerocket.SHA256 = "63a8dce1683379cb8df7d15e9c5adf9462a2b9803a544dd79b16f19a4657967f"
 	clusterSpec.VersionsBundles["1.19"].EksD.Ova.Bottlerocket.Arch = []string{"amd64"}
+	clusterSpec.VersionsBundles["1.19"].EksD.Ova.Ubuntu = nil
 	clusterSpec.VersionsBundles["1.19"].EksD.Name = eksd119Release
 	clusterSpec.VersionsBundles["1.19"].EksD.KubeVersion = "v1.19.8"
 	clusterSpec.VersionsBundles["1.19"].KubeVersion = "1.19"
@@ -2044,7 +2054,7 @@ func TestSetupAndValidateCreateClusterDefaultTemplateCP(t *testing.T) {
 	clusterSpec.VSphereMachineConfigs[controlPlaneMachineConfigName].Spec.Template = ""
 	etcdMachineConfigName := clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name
 	clusterSpec.VSphereMachineConfigs[etcdMachineConfigName].Spec.Template = ""
-	wantError := fmt.Errorf("failed setting default values for vsphere machine configs: can not import ova for osFamily: ubuntu, please use bottlerocket as osFamily for auto-importing or provide a valid template")
+	wantError := fmt.Errorf("failed setting default values for vsphere machine configs: can not import ova for osFamily: ubuntu, the bundle for Kubernetes v1.19.8 doesn't include one, please provide a valid template")
 
 	setupContext(t)
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	orasregistry "oras.land/oras-go/v2/registry"
+
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
@@ -19,6 +21,10 @@ func Copy(ctx context.Context, srcClient StorageClient, dstClient StorageClient,
 		return fmt.Errorf("repository destination: %v", err)
 	}
 
+	return copyToStorage(ctx, srcClient, srcStorage, dstClient, dstStorage, image)
+}
+
+func copyToStorage(ctx context.Context, srcClient StorageClient, srcStorage orasregistry.Repository, dstClient StorageClient, dstStorage orasregistry.Repository, image Artifact) error {
 	desc, err := srcClient.CopyGraph(ctx, srcStorage, image.VersionedImage(), dstStorage, dstClient.Destination(image))
 	if err != nil {
 		return fmt.Errorf("registry copy: %v", err)
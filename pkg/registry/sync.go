@@ -0,0 +1,340 @@
+package registry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"oras.land/oras-go/v2/errdef"
+	orasregistry "oras.land/oras-go/v2/registry"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// SyncStatus is the result of syncing an artifact.
+type SyncStatus string
+
+const (
+	// SyncCopied means the artifact was copied to the destination.
+	SyncCopied SyncStatus = "copied"
+	// SyncSkipped means the artifact was already in the destination, or copied by a previous sync.
+	SyncSkipped SyncStatus = "skipped"
+	// SyncFailed means the artifact couldn't be copied.
+	SyncFailed SyncStatus = "failed"
+)
+
+const defaultSyncConcurrency = 4
+
+// SyncResult is the outcome of syncing an artifact.
+type SyncResult struct {
+	Source      string     `json:"source"`
+	Destination string     `json:"destination"`
+	Digest      string     `json:"digest,omitempty"`
+	Status      SyncStatus `json:"status"`
+	// Verified is true when the destination resolves to the digest of the source artifact.
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SyncReport lists the result of every artifact in a sync.
+type SyncReport struct {
+	Results []SyncResult `json:"results"`
+}
+
+// Count returns the number of artifacts with the status.
+func (r *SyncReport) Count(status SyncStatus) int {
+	count := 0
+	for _, res := range r.Results {
+		if res.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Unverified returns the artifacts that couldn't be verified in the destination, including the failed ones.
+func (r *SyncReport) Unverified() []SyncResult {
+	var unverified []SyncResult
+	for _, res := range r.Results {
+		if !res.Verified {
+			unverified = append(unverified, res)
+		}
+	}
+	return unverified
+}
+
+// SourceClientFunc returns the storage client for a source registry host.
+type SourceClientFunc func(host string) (StorageClient, error)
+
+// Syncer copies a list of artifacts from their source registries to a destination registry.
+type Syncer struct {
+	sourceClient SourceClientFunc
+	destination  StorageClient
+	concurrency  int
+	stateFile    string
+	cosign       bool
+}
+
+// SyncerOpt configures a Syncer.
+type SyncerOpt func(*Syncer)
+
+// WithSyncConcurrency sets the number of artifacts copied in parallel.
+func WithSyncConcurrency(concurrency int) SyncerOpt {
+	return func(s *Syncer) {
+		s.concurrency = concurrency
+	}
+}
+
+// WithSyncStateFile sets the file where the copied artifacts are recorded. A sync with the same state file
+// doesn't copy again the artifacts recorded by a previous sync to the same destination, so an interrupted
+// sync can be resumed.
+func WithSyncStateFile(path string) SyncerOpt {
+	return func(s *Syncer) {
+		s.stateFile = path
+	}
+}
+
+// WithSyncCosignArtifacts makes the sync copy the cosign signatures and attestations of the artifacts,
+// when the source has them, so the artifacts can be verified in the destination.
+func WithSyncCosignArtifacts() SyncerOpt {
+	return func(s *Syncer) {
+		s.cosign = true
+	}
+}
+
+// NewSyncer returns a new Syncer.
+func NewSyncer(sourceClient SourceClientFunc, destination StorageClient, opts ...SyncerOpt) *Syncer {
+	s := &Syncer{
+		sourceClient: sourceClient,
+		destination:  destination,
+		concurrency:  defaultSyncConcurrency,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// syncState records the artifacts already copied, one per line. Each artifact is recorded with both its source
+// and its destination, so the same state file can't make a sync to a different registry skip artifacts.
+type syncState struct {
+	sync.Mutex
+	file   *os.File
+	synced map[string]struct{}
+}
+
+func openSyncState(path string) (*syncState, error) {
+	state := &syncState{synced: map[string]struct{}{}}
+	if path == "" {
+		return state, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating sync state folder: %v", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening sync state file: %v", err)
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			state.synced[line] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("reading sync state file: %v", err)
+	}
+	state.file = f
+
+	return state, nil
+}
+
+func syncStateKey(result SyncResult) string {
+	return result.Source + " " + result.Destination
+}
+
+func (s *syncState) done(result SyncResult) bool {
+	s.Lock()
+	defer s.Unlock()
+	_, ok := s.synced[syncStateKey(result)]
+	return ok
+}
+
+func (s *syncState) record(result SyncResult) error {
+	s.Lock()
+	defer s.Unlock()
+	key := syncStateKey(result)
+	s.synced[key] = struct{}{}
+	if s.file == nil {
+		return nil
+	}
+	_, err := fmt.Fprintln(s.file, key)
+	return err
+}
+
+func (s *syncState) close() {
+	if s.file != nil {
+		s.file.Close()
+	}
+}
+
+// Sync copies the artifacts to the destination, in parallel. Artifacts already in the destination are skipped
+// and, for the ones copied, only the blobs missing in the destination are pushed. After copying, every artifact
+// is resolved in the destination to verify it matches the source digest. A failed artifact doesn't stop the sync,
+// the error is recorded in its result.
+func (s *Syncer) Sync(ctx context.Context, artifacts []Artifact) (*SyncReport, error) {
+	sources := map[string]StorageClient{}
+	for _, a := range artifacts {
+		if _, ok := sources[a.Registry]; ok {
+			continue
+		}
+		client, err := s.sourceClient(a.Registry)
+		if err != nil {
+			return nil, fmt.Errorf("creating client for source registry %s: %v", a.Registry, err)
+		}
+		sources[a.Registry] = client
+	}
+
+	state, err := openSyncState(s.stateFile)
+	if err != nil {
+		return nil, err
+	}
+	defer state.close()
+
+	report := &SyncReport{Results: make([]SyncResult, len(artifacts))}
+	jobs := make(chan int)
+	wg := &sync.WaitGroup{}
+	workers := max(min(s.concurrency, len(artifacts)), 1)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				report.Results[i] = s.syncArtifact(ctx, sources[artifacts[i].Registry], state, artifacts[i])
+			}
+		}()
+	}
+
+	for i := range artifacts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return report, nil
+}
+
+func (s *Syncer) syncArtifact(ctx context.Context, src StorageClient, state *syncState, artifact Artifact) SyncResult {
+	result := SyncResult{
+		Source:      artifact.VersionedImage(),
+		Destination: s.destination.Destination(artifact),
+		Digest:      artifact.Digest,
+	}
+	fail := func(err error) SyncResult {
+		result.Status = SyncFailed
+		result.Error = err.Error()
+		logger.V(0).Info("Failed copying artifact", "artifact", result.Source, "error", err)
+		return result
+	}
+
+	srcStorage, err := src.GetStorage(ctx, artifact)
+	if err != nil {
+		return fail(fmt.Errorf("repository source: %v", err))
+	}
+
+	dstStorage, err := s.destination.GetStorage(ctx, artifact)
+	if err != nil {
+		return fail(fmt.Errorf("repository destination: %v", err))
+	}
+
+	if result.Digest == "" {
+		desc, err := srcStorage.Resolve(ctx, artifact.VersionedImage())
+		if err != nil {
+			return fail(fmt.Errorf("resolving source: %v", err))
+		}
+		result.Digest = desc.Digest.String()
+	}
+
+	// Check the tag when there is one, since the manifest can be in the destination without being tagged.
+	checkRef := result.Destination
+	if artifact.Tag != "" {
+		tagged := artifact
+		tagged.Digest = ""
+		checkRef = s.destination.Destination(tagged)
+	}
+
+	switch {
+	case state.done(result):
+		result.Status = SyncSkipped
+	case inDestination(ctx, dstStorage, checkRef, result.Digest):
+		result.Status = SyncSkipped
+	default:
+		logger.V(3).Info("Copying artifact", "from", result.Source, "to", result.Destination)
+		if err := copyToStorage(ctx, src, srcStorage, s.destination, dstStorage, artifact); err != nil {
+			return fail(err)
+		}
+		result.Status = SyncCopied
+	}
+
+	// Signatures are copied even if the artifact was skipped, since a previous sync might not have copied them.
+	if s.cosign {
+		if err := s.copyCosignArtifacts(ctx, src, srcStorage, dstStorage, artifact, result.Digest); err != nil {
+			return fail(err)
+		}
+	}
+
+	result.Verified = inDestination(ctx, dstStorage, checkRef, result.Digest)
+	if !result.Verified {
+		result.Error = fmt.Sprintf("%s doesn't resolve to %s in the destination", checkRef, result.Digest)
+		return result
+	}
+
+	if err := state.record(result); err != nil {
+		logger.V(3).Info("Failed recording synced artifact", "artifact", result.Source, "error", err)
+	}
+
+	return result
+}
+
+func (s *Syncer) copyCosignArtifacts(ctx context.Context, src StorageClient, srcStorage, dstStorage orasregistry.Repository, artifact Artifact, digest string) error {
+	artifact.Digest = digest
+	for _, a := range CosignArtifacts(artifact) {
+		if _, err := srcStorage.Resolve(ctx, a.Tag); err != nil {
+			if errors.Is(err, errdef.ErrNotFound) {
+				continue
+			}
+			return fmt.Errorf("resolving source %s: %v", a.VersionedImage(), err)
+		}
+		if err := copyToStorage(ctx, src, srcStorage, s.destination, dstStorage, a); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func inDestination(ctx context.Context, dstStorage orasregistry.Repository, ref, digest string) bool {
+	desc, err := dstStorage.Resolve(ctx, ref)
+	return err == nil && desc.Digest.String() == digest
+}
+
+// WriteSyncReport writes the sync report as json.
+func WriteSyncReport(report *SyncReport, path string) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling sync report: %v", err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("writing sync report: %v", err)
+	}
+	return nil
+}
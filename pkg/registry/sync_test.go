@@ -0,0 +1,198 @@
+package registry_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registry/mocks"
+)
+
+const dstRef = "harbor.local/eksa/l0g8r8j6/kube-vip/kube-vip:v0.5.5-eks-a-v0.0.0-dev-build.4452"
+
+var srcDesc = ocispec.Descriptor{Digest: digest.Digest(srcArtifact.Digest)}
+
+func newSyncMocks(t *testing.T) (*mocks.MockStorageClient, *mocks.MockStorageClient, *mocks.MockRepository, *mocks.MockRepository) {
+	srcClient := mocks.NewMockStorageClient(gomock.NewController(t))
+	dstClient := mocks.NewMockStorageClient(gomock.NewController(t))
+	srcRepo := mocks.NewMockRepository(gomock.NewController(t))
+	dstRepo := mocks.NewMockRepository(gomock.NewController(t))
+
+	srcClient.EXPECT().GetStorage(ctx, srcArtifact).Return(srcRepo, nil)
+	dstClient.EXPECT().GetStorage(ctx, srcArtifact).Return(dstRepo, nil)
+	dstClient.EXPECT().Destination(gomock.Any()).Return(dstRef).AnyTimes()
+
+	return srcClient, dstClient, srcRepo, dstRepo
+}
+
+func sourceClient(client registry.StorageClient) registry.SourceClientFunc {
+	return func(string) (registry.StorageClient, error) {
+		return client, nil
+	}
+}
+
+func TestSyncerSyncCopy(t *testing.T) {
+	srcClient, dstClient, srcRepo, dstRepo := newSyncMocks(t)
+	stateFile := filepath.Join(t.TempDir(), "state")
+
+	dstRepo.EXPECT().Resolve(ctx, dstRef).Return(ocispec.Descriptor{}, fmt.Errorf("not found")).Times(1)
+	srcClient.EXPECT().CopyGraph(ctx, srcRepo, expectedSrcRef, dstRepo, dstRef).Return(srcDesc, nil)
+	dstClient.EXPECT().Tag(ctx, dstRepo, srcDesc, srcArtifact.Tag).Return(nil)
+	dstRepo.EXPECT().Resolve(ctx, dstRef).Return(srcDesc, nil)
+
+	syncer := registry.NewSyncer(sourceClient(srcClient), dstClient, registry.WithSyncStateFile(stateFile))
+	report, err := syncer.Sync(ctx, []registry.Artifact{srcArtifact})
+	assert.NoError(t, err)
+	assert.Equal(t, []registry.SyncResult{{
+		Source:      expectedSrcRef,
+		Destination: dstRef,
+		Digest:      srcArtifact.Digest,
+		Status:      registry.SyncCopied,
+		Verified:    true,
+	}}, report.Results)
+	assert.Empty(t, report.Unverified())
+
+	state, err := os.ReadFile(stateFile)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSrcRef+" "+dstRef+"\n", string(state))
+}
+
+func TestSyncerSyncAlreadyInDestination(t *testing.T) {
+	srcClient, dstClient, _, dstRepo := newSyncMocks(t)
+
+	dstRepo.EXPECT().Resolve(ctx, dstRef).Return(srcDesc, nil).Times(2)
+
+	report, err := registry.NewSyncer(sourceClient(srcClient), dstClient).Sync(ctx, []registry.Artifact{srcArtifact})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Count(registry.SyncSkipped))
+	assert.True(t, report.Results[0].Verified)
+}
+
+func TestSyncerSyncResume(t *testing.T) {
+	srcClient, dstClient, _, dstRepo := newSyncMocks(t)
+	stateFile := filepath.Join(t.TempDir(), "state")
+	assert.NoError(t, os.WriteFile(stateFile, []byte(expectedSrcRef+" "+dstRef+"\n"), 0o644))
+
+	dstRepo.EXPECT().Resolve(ctx, dstRef).Return(srcDesc, nil).Times(1)
+
+	syncer := registry.NewSyncer(sourceClient(srcClient), dstClient, registry.WithSyncStateFile(stateFile))
+	report, err := syncer.Sync(ctx, []registry.Artifact{srcArtifact})
+	assert.NoError(t, err)
+	assert.Equal(t, registry.SyncSkipped, report.Results[0].Status)
+	assert.True(t, report.Results[0].Verified)
+}
+
+func TestSyncerSyncResumeDifferentDestination(t *testing.T) {
+	srcClient, dstClient, srcRepo, dstRepo := newSyncMocks(t)
+	stateFile := filepath.Join(t.TempDir(), "state")
+	otherDst := "other.local/eksa/kube-vip/kube-vip:v0.5.5-eks-a-v0.0.0-dev-build.4452"
+	assert.NoError(t, os.WriteFile(stateFile, []byte(expectedSrcRef+" "+otherDst+"\n"), 0o644))
+
+	dstRepo.EXPECT().Resolve(ctx, dstRef).Return(ocispec.Descriptor{}, fmt.Errorf("not found")).Times(1)
+	srcClient.EXPECT().CopyGraph(ctx, srcRepo, expectedSrcRef, dstRepo, dstRef).Return(srcDesc, nil)
+	dstClient.EXPECT().Tag(ctx, dstRepo, srcDesc, srcArtifact.Tag).Return(nil)
+	dstRepo.EXPECT().Resolve(ctx, dstRef).Return(srcDesc, nil)
+
+	syncer := registry.NewSyncer(sourceClient(srcClient), dstClient, registry.WithSyncStateFile(stateFile))
+	report, err := syncer.Sync(ctx, []registry.Artifact{srcArtifact})
+	assert.NoError(t, err)
+	assert.Equal(t, registry.SyncCopied, report.Results[0].Status)
+
+	state, err := os.ReadFile(stateFile)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSrcRef+" "+otherDst+"\n"+expectedSrcRef+" "+dstRef+"\n", string(state))
+}
+
+func TestSyncerSyncResolveSourceDigest(t *testing.T) {
+	artifact := srcArtifact
+	artifact.Digest = ""
+	srcClient := mocks.NewMockStorageClient(gomock.NewController(t))
+	dstClient := mocks.NewMockStorageClient(gomock.NewController(t))
+	srcRepo := mocks.NewMockRepository(gomock.NewController(t))
+	dstRepo := mocks.NewMockRepository(gomock.NewController(t))
+
+	srcClient.EXPECT().GetStorage(ctx, artifact).Return(srcRepo, nil)
+	dstClient.EXPECT().GetStorage(ctx, artifact).Return(dstRepo, nil)
+	dstClient.EXPECT().Destination(artifact).Return(dstRef).Times(2)
+	srcRepo.EXPECT().Resolve(ctx, artifact.VersionedImage()).Return(srcDesc, nil)
+	dstRepo.EXPECT().Resolve(ctx, dstRef).Return(srcDesc, nil).Times(2)
+
+	report, err := registry.NewSyncer(sourceClient(srcClient), dstClient).Sync(ctx, []registry.Artifact{artifact})
+	assert.NoError(t, err)
+	assert.Equal(t, srcArtifact.Digest, report.Results[0].Digest)
+	assert.True(t, report.Results[0].Verified)
+}
+
+func TestSyncerSyncCopyError(t *testing.T) {
+	srcClient, dstClient, srcRepo, dstRepo := newSyncMocks(t)
+	stateFile := filepath.Join(t.TempDir(), "state")
+
+	dstRepo.EXPECT().Resolve(ctx, dstRef).Return(ocispec.Descriptor{}, fmt.Errorf("not found"))
+	srcClient.EXPECT().CopyGraph(ctx, srcRepo, expectedSrcRef, dstRepo, dstRef).Return(ocispec.Descriptor{}, fmt.Errorf("oops"))
+
+	syncer := registry.NewSyncer(sourceClient(srcClient), dstClient, registry.WithSyncStateFile(stateFile))
+	report, err := syncer.Sync(ctx, []registry.Artifact{srcArtifact})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Count(registry.SyncFailed))
+	assert.Equal(t, "registry copy: oops", report.Results[0].Error)
+	assert.Len(t, report.Unverified(), 1)
+
+	state, err := os.ReadFile(stateFile)
+	assert.NoError(t, err)
+	assert.Empty(t, state)
+}
+
+func TestSyncerSyncSourceClientError(t *testing.T) {
+	dstClient := mocks.NewMockStorageClient(gomock.NewController(t))
+	failing := func(string) (registry.StorageClient, error) {
+		return nil, fmt.Errorf("oops")
+	}
+
+	_, err := registry.NewSyncer(failing, dstClient).Sync(ctx, []registry.Artifact{srcArtifact})
+	assert.EqualError(t, err, "creating client for source registry public.ecr.aws: oops")
+}
+
+func TestWriteSyncReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	report := &registry.SyncReport{Results: []registry.SyncResult{{
+		Source:      expectedSrcRef,
+		Destination: dstRef,
+		Status:      registry.SyncCopied,
+		Verified:    true,
+	}}}
+
+	assert.NoError(t, registry.WriteSyncReport(report, path))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	got := &registry.SyncReport{}
+	assert.NoError(t, json.Unmarshal(content, got))
+	assert.Equal(t, report, got)
+}
+
+func TestSyncerSyncCosignArtifacts(t *testing.T) {
+	srcClient, dstClient, srcRepo, dstRepo := newSyncMocks(t)
+	sigArtifact := registry.CosignArtifacts(srcArtifact)[0]
+	sigDesc := ocispec.Descriptor{Digest: digest.FromString("signature")}
+
+	dstRepo.EXPECT().Resolve(ctx, dstRef).Return(srcDesc, nil).Times(2)
+	srcRepo.EXPECT().Resolve(ctx, sigTag).Return(sigDesc, nil)
+	srcRepo.EXPECT().Resolve(ctx, attTag).Return(ocispec.Descriptor{}, fmt.Errorf("%s: %w", attTag, errdef.ErrNotFound))
+	srcClient.EXPECT().CopyGraph(ctx, srcRepo, sigArtifact.VersionedImage(), dstRepo, dstRef).Return(sigDesc, nil)
+	dstClient.EXPECT().Tag(ctx, dstRepo, sigDesc, sigTag).Return(nil)
+
+	syncer := registry.NewSyncer(sourceClient(srcClient), dstClient, registry.WithSyncCosignArtifacts())
+	report, err := syncer.Sync(ctx, []registry.Artifact{srcArtifact})
+	assert.NoError(t, err)
+	assert.Equal(t, registry.SyncSkipped, report.Results[0].Status)
+	assert.True(t, report.Results[0].Verified)
+}
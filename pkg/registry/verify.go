@@ -0,0 +1,275 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	ssldsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+	"oras.land/oras-go/v2/errdef"
+	orasregistry "oras.land/oras-go/v2/registry"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	cosignSignatureSuffix     = ".sig"
+	cosignAttestationSuffix   = ".att"
+	dssePayloadType           = "application/vnd.in-toto+json"
+	maxSignatureManifestSize  = 4 * 1024 * 1024
+)
+
+// ErrImageVerification is returned when an image signature or attestation doesn't match the image.
+var ErrImageVerification = errors.New("image verification failed")
+
+// ImageVerifier verifies the cosign signatures and attestations of images against a public key.
+// The signatures are checked with the sigstore libraries cosign is built on.
+type ImageVerifier struct {
+	verifier           signature.Verifier
+	requiredPredicates []string
+}
+
+// ImageVerifierOpt configures an ImageVerifier.
+type ImageVerifierOpt func(*ImageVerifier)
+
+// WithRequiredAttestations makes the verification fail if an image doesn't have
+// a signed attestation for each of the predicate types, like https://spdx.dev/Document for SPDX SBOMs.
+func WithRequiredAttestations(predicateTypes ...string) ImageVerifierOpt {
+	return func(v *ImageVerifier) {
+		v.requiredPredicates = append(v.requiredPredicates, predicateTypes...)
+	}
+}
+
+// NewImageVerifier returns an ImageVerifier for a PEM or base64 DER encoded public key.
+func NewImageVerifier(publicKey []byte, opts ...ImageVerifierOpt) (*ImageVerifier, error) {
+	key, err := parseVerificationKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := signature.LoadVerifier(key, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("loading public key: %v", err)
+	}
+	v := &ImageVerifier{verifier: verifier}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v, nil
+}
+
+func parseVerificationKey(publicKey []byte) (crypto.PublicKey, error) {
+	if block, _ := pem.Decode(publicKey); block != nil {
+		key, err := cryptoutils.UnmarshalPEMToPublicKey(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key: %v", err)
+		}
+		return key, nil
+	}
+
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(publicKey)))
+	if err != nil {
+		return nil, fmt.Errorf("public key is neither PEM nor base64 encoded: %v", err)
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %v", err)
+	}
+
+	return key, nil
+}
+
+// Verify checks that the artifact in the storage resolves to the artifact digest, when set, and that it has
+// a cosign signature made with the verifier key. The attestations attached to the image are verified too.
+func (v *ImageVerifier) Verify(ctx context.Context, client StorageClient, artifact Artifact) error {
+	storage, err := client.GetStorage(ctx, artifact)
+	if err != nil {
+		return fmt.Errorf("repository for %s: %v", artifact.VersionedImage(), err)
+	}
+
+	// Resolve the tag when there is one, since that's what the cluster pulls.
+	reference := artifact.Tag
+	if reference == "" {
+		reference = artifact.Digest
+	}
+	desc, err := storage.Resolve(ctx, reference)
+	if err != nil {
+		return fmt.Errorf("resolving %s: %v", client.Destination(artifact), err)
+	}
+	if artifact.Digest != "" && desc.Digest.String() != artifact.Digest {
+		return fmt.Errorf("%w: %s resolves to %s, expected %s", ErrImageVerification, client.Destination(artifact), desc.Digest, artifact.Digest)
+	}
+	digest := desc.Digest.String()
+
+	signatures, err := v.fetchCosignLayers(ctx, client, storage, cosignTag(digest, cosignSignatureSuffix))
+	if err != nil {
+		return fmt.Errorf("fetching signatures of %s: %v", client.Destination(artifact), err)
+	}
+	if len(signatures) == 0 {
+		return fmt.Errorf("%w: %s doesn't have a cosign signature", ErrImageVerification, client.Destination(artifact))
+	}
+	if !v.anySignatureValid(signatures, digest) {
+		return fmt.Errorf("%w: %s doesn't have a valid signature for %s", ErrImageVerification, client.Destination(artifact), digest)
+	}
+
+	attestations, err := v.fetchCosignLayers(ctx, client, storage, cosignTag(digest, cosignAttestationSuffix))
+	if err != nil {
+		return fmt.Errorf("fetching attestations of %s: %v", client.Destination(artifact), err)
+	}
+	predicates := map[string]struct{}{}
+	for _, a := range attestations {
+		predicateType, err := v.verifyAttestation(a.payload, digest)
+		if err != nil {
+			return fmt.Errorf("%w: attestation of %s: %v", ErrImageVerification, client.Destination(artifact), err)
+		}
+		predicates[predicateType] = struct{}{}
+	}
+	for _, p := range v.requiredPredicates {
+		if _, ok := predicates[p]; !ok {
+			return fmt.Errorf("%w: %s doesn't have a signed %s attestation", ErrImageVerification, client.Destination(artifact), p)
+		}
+	}
+
+	logger.V(6).Info("Verified image", "image", client.Destination(artifact), "digest", digest, "attestations", len(attestations))
+	return nil
+}
+
+// VerifyAll verifies all the artifacts and returns an error listing the ones that failed.
+func (v *ImageVerifier) VerifyAll(ctx context.Context, client StorageClient, artifacts []Artifact) error {
+	var errs []error
+	for _, a := range artifacts {
+		if err := v.Verify(ctx, client, a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CosignArtifacts returns the cosign signature and attestation artifacts of an artifact with a digest.
+func CosignArtifacts(artifact Artifact) []Artifact {
+	if artifact.Digest == "" {
+		return nil
+	}
+	return []Artifact{
+		NewArtifact(artifact.Registry, artifact.Repository, cosignTag(artifact.Digest, cosignSignatureSuffix), ""),
+		NewArtifact(artifact.Registry, artifact.Repository, cosignTag(artifact.Digest, cosignAttestationSuffix), ""),
+	}
+}
+
+// cosignTag returns the tag cosign uses to store signatures and attestations of a digest.
+func cosignTag(digest, suffix string) string {
+	return strings.Replace(digest, ":", "-", 1) + suffix
+}
+
+type cosignLayer struct {
+	payload   []byte
+	signature string
+}
+
+// fetchCosignLayers returns the layers of a cosign signature or attestation manifest. It returns
+// no layers if the manifest doesn't exist.
+func (v *ImageVerifier) fetchCosignLayers(ctx context.Context, client StorageClient, storage orasregistry.Repository, tag string) ([]cosignLayer, error) {
+	_, rc, err := storage.FetchReference(ctx, tag)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxSignatureManifestSize))
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ocispec.Manifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("parsing manifest %s: %v", tag, err)
+	}
+
+	layers := make([]cosignLayer, 0, len(manifest.Layers))
+	for _, l := range manifest.Layers {
+		payload, err := client.FetchBlob(ctx, storage, l)
+		if err != nil {
+			return nil, fmt.Errorf("fetching layer %s: %v", l.Digest, err)
+		}
+		layers = append(layers, cosignLayer{payload: payload, signature: l.Annotations[cosignSignatureAnnotation]})
+	}
+
+	return layers, nil
+}
+
+func (v *ImageVerifier) anySignatureValid(signatures []cosignLayer, digest string) bool {
+	for _, s := range signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.signature)
+		if err != nil {
+			continue
+		}
+		if err := v.verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(s.payload)); err != nil {
+			continue
+		}
+		signed := &payload.SimpleContainerImage{}
+		if err := json.Unmarshal(s.payload, signed); err != nil {
+			continue
+		}
+		if signed.Critical.Type == payload.CosignSignatureType && signed.Critical.Image.DockerManifestDigest == digest {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inTotoStatement is the attestation statement, with the image as subject.
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+	Subject       []struct {
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+func (v *ImageVerifier) verifyAttestation(envelopeContent []byte, digest string) (string, error) {
+	if err := dsse.WrapVerifier(v.verifier).VerifySignature(bytes.NewReader(envelopeContent), nil); err != nil {
+		return "", fmt.Errorf("no valid signature: %v", err)
+	}
+
+	envelope := &ssldsse.Envelope{}
+	if err := json.Unmarshal(envelopeContent, envelope); err != nil {
+		return "", fmt.Errorf("parsing envelope: %v", err)
+	}
+	if envelope.PayloadType != dssePayloadType {
+		return "", fmt.Errorf("unexpected payload type %s", envelope.PayloadType)
+	}
+	content, err := envelope.DecodeB64Payload()
+	if err != nil {
+		return "", fmt.Errorf("decoding payload: %v", err)
+	}
+
+	statement := &inTotoStatement{}
+	if err := json.Unmarshal(content, statement); err != nil {
+		return "", fmt.Errorf("parsing statement: %v", err)
+	}
+	algorithm, hex, _ := strings.Cut(digest, ":")
+	for _, s := range statement.Subject {
+		if s.Digest[algorithm] == hex {
+			return statement.PredicateType, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s attestation subject doesn't match %s", statement.PredicateType, digest)
+}
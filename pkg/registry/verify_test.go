@@ -0,0 +1,302 @@
+package registry_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registry/mocks"
+)
+
+const (
+	sigTag        = "sha256-6efe21500abbfbb6b3e37b80dd5dea0b11a0d1b145e84298fee5d7784a77e967.sig"
+	attTag        = "sha256-6efe21500abbfbb6b3e37b80dd5dea0b11a0d1b145e84298fee5d7784a77e967.att"
+	spdxPredicate = "https://spdx.dev/Document"
+)
+
+type verifyTest struct {
+	t         *testing.T
+	key       *ecdsa.PrivateKey
+	publicKey []byte
+	client    *mocks.MockStorageClient
+	repo      *mocks.MockRepository
+}
+
+func newKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func newVerifyTest(t *testing.T) *verifyTest {
+	key, publicKey := newKey(t)
+	tt := &verifyTest{
+		t:         t,
+		key:       key,
+		publicKey: publicKey,
+		client:    mocks.NewMockStorageClient(gomock.NewController(t)),
+		repo:      mocks.NewMockRepository(gomock.NewController(t)),
+	}
+	tt.client.EXPECT().GetStorage(ctx, srcArtifact).Return(tt.repo, nil)
+	tt.client.EXPECT().Destination(srcArtifact).Return(dstRef).AnyTimes()
+
+	return tt
+}
+
+func (tt *verifyTest) sign(payload []byte) string {
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, tt.key, hash[:])
+	assert.NoError(tt.t, err)
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func (tt *verifyTest) expectManifest(tag string, layers map[string][]byte, annotations map[string]map[string]string) {
+	manifest := ocispec.Manifest{}
+	for name, content := range layers {
+		layer := ocispec.Descriptor{Digest: digest.FromBytes(content), Size: int64(len(content)), Annotations: annotations[name]}
+		manifest.Layers = append(manifest.Layers, layer)
+		tt.client.EXPECT().FetchBlob(ctx, tt.repo, layer).Return(content, nil)
+	}
+	content, err := json.Marshal(manifest)
+	assert.NoError(tt.t, err)
+	tt.repo.EXPECT().FetchReference(ctx, tag).Return(ocispec.Descriptor{}, io.NopCloser(bytes.NewReader(content)), nil)
+}
+
+func (tt *verifyTest) expectNotFound(tag string) {
+	tt.repo.EXPECT().FetchReference(ctx, tag).Return(ocispec.Descriptor{}, nil, fmt.Errorf("%s: %w", tag, errdef.ErrNotFound))
+}
+
+func (tt *verifyTest) expectSignature(imageDigest string) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"public.ecr.aws/l0g8r8j6/kube-vip/kube-vip"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, imageDigest))
+	tt.expectManifest(sigTag,
+		map[string][]byte{"sig": payload},
+		map[string]map[string]string{"sig": {"dev.cosignproject.cosign/signature": tt.sign(payload)}},
+	)
+}
+
+func (tt *verifyTest) attestation(predicateType, subjectDigest string) []byte {
+	statement := []byte(fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"%s","subject":[{"name":"public.ecr.aws/l0g8r8j6/kube-vip/kube-vip","digest":{"sha256":"%s"}}],"predicate":{}}`, predicateType, subjectDigest))
+	payloadType := "application/vnd.in-toto+json"
+	pae := fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(statement), statement)
+	envelope, err := json.Marshal(map[string]interface{}{
+		"payloadType": payloadType,
+		"payload":     base64.StdEncoding.EncodeToString(statement),
+		"signatures":  []map[string]string{{"sig": tt.sign([]byte(pae))}},
+	})
+	assert.NoError(tt.t, err)
+	return envelope
+}
+
+func TestImageVerifierVerify(t *testing.T) {
+	tt := newVerifyTest(t)
+	tt.repo.EXPECT().Resolve(ctx, srcArtifact.Tag).Return(srcDesc, nil)
+	tt.expectSignature(srcArtifact.Digest)
+	tt.expectManifest(attTag, map[string][]byte{
+		"sbom": tt.attestation(spdxPredicate, srcDesc.Digest.Encoded()),
+	}, nil)
+
+	verifier, err := registry.NewImageVerifier(tt.publicKey, registry.WithRequiredAttestations(spdxPredicate))
+	assert.NoError(t, err)
+	assert.NoError(t, verifier.Verify(ctx, tt.client, srcArtifact))
+}
+
+func TestImageVerifierVerifyTagMoved(t *testing.T) {
+	tt := newVerifyTest(t)
+	tt.repo.EXPECT().Resolve(ctx, srcArtifact.Tag).Return(ocispec.Descriptor{Digest: digest.FromString("tampered")}, nil)
+
+	verifier, err := registry.NewImageVerifier(tt.publicKey)
+	assert.NoError(t, err)
+	err = verifier.Verify(ctx, tt.client, srcArtifact)
+	assert.True(t, errors.Is(err, registry.ErrImageVerification))
+	assert.ErrorContains(t, err, "expected "+srcArtifact.Digest)
+}
+
+func TestImageVerifierVerifyMissingSignature(t *testing.T) {
+	tt := newVerifyTest(t)
+	tt.repo.EXPECT().Resolve(ctx, srcArtifact.Tag).Return(srcDesc, nil)
+	tt.expectNotFound(sigTag)
+
+	verifier, err := registry.NewImageVerifier(tt.publicKey)
+	assert.NoError(t, err)
+	err = verifier.Verify(ctx, tt.client, srcArtifact)
+	assert.True(t, errors.Is(err, registry.ErrImageVerification))
+	assert.ErrorContains(t, err, "doesn't have a cosign signature")
+}
+
+func TestImageVerifierVerifyWrongKey(t *testing.T) {
+	tt := newVerifyTest(t)
+	tt.repo.EXPECT().Resolve(ctx, srcArtifact.Tag).Return(srcDesc, nil)
+	tt.expectSignature(srcArtifact.Digest)
+
+	_, otherKey := newKey(t)
+	verifier, err := registry.NewImageVerifier(otherKey)
+	assert.NoError(t, err)
+	err = verifier.Verify(ctx, tt.client, srcArtifact)
+	assert.True(t, errors.Is(err, registry.ErrImageVerification))
+	assert.ErrorContains(t, err, "doesn't have a valid signature")
+}
+
+func TestImageVerifierVerifySignatureForOtherDigest(t *testing.T) {
+	tt := newVerifyTest(t)
+	tt.repo.EXPECT().Resolve(ctx, srcArtifact.Tag).Return(srcDesc, nil)
+	tt.expectSignature(digest.FromString("other").String())
+
+	verifier, err := registry.NewImageVerifier(tt.publicKey)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, verifier.Verify(ctx, tt.client, srcArtifact), "doesn't have a valid signature")
+}
+
+func TestImageVerifierVerifyAttestationSubjectMismatch(t *testing.T) {
+	tt := newVerifyTest(t)
+	tt.repo.EXPECT().Resolve(ctx, srcArtifact.Tag).Return(srcDesc, nil)
+	tt.expectSignature(srcArtifact.Digest)
+	tt.expectManifest(attTag, map[string][]byte{
+		"sbom": tt.attestation(spdxPredicate, digest.FromString("other").Encoded()),
+	}, nil)
+
+	verifier, err := registry.NewImageVerifier(tt.publicKey)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, verifier.Verify(ctx, tt.client, srcArtifact), "attestation subject doesn't match")
+}
+
+func TestImageVerifierVerifyMissingRequiredAttestation(t *testing.T) {
+	tt := newVerifyTest(t)
+	tt.repo.EXPECT().Resolve(ctx, srcArtifact.Tag).Return(srcDesc, nil)
+	tt.expectSignature(srcArtifact.Digest)
+	tt.expectNotFound(attTag)
+
+	verifier, err := registry.NewImageVerifier(tt.publicKey, registry.WithRequiredAttestations(spdxPredicate))
+	assert.NoError(t, err)
+	assert.ErrorContains(t, verifier.Verify(ctx, tt.client, srcArtifact), "doesn't have a signed https://spdx.dev/Document attestation")
+}
+
+func TestNewImageVerifierBase64Key(t *testing.T) {
+	_, publicKey := newKey(t)
+	block, _ := pem.Decode(publicKey)
+	_, err := registry.NewImageVerifier([]byte(base64.StdEncoding.EncodeToString(block.Bytes)))
+	assert.NoError(t, err)
+}
+
+func TestNewImageVerifierInvalidKey(t *testing.T) {
+	_, err := registry.NewImageVerifier([]byte("not a key"))
+	assert.ErrorContains(t, err, "public key is neither PEM nor base64 encoded")
+}
+
+func TestCosignArtifacts(t *testing.T) {
+	assert.Equal(t, []registry.Artifact{
+		registry.NewArtifact(srcArtifact.Registry, srcArtifact.Repository, sigTag, ""),
+		registry.NewArtifact(srcArtifact.Registry, srcArtifact.Repository, attTag, ""),
+	}, registry.CosignArtifacts(srcArtifact))
+	assert.Empty(t, registry.CosignArtifacts(registry.Artifact{Registry: "public.ecr.aws", Repository: "a", Tag: "v1"}))
+}
+
+// The cosign testdata was produced with cosign v2.4.0 for an image pushed to a local registry:
+//
+//	cosign generate-key-pair
+//	cosign sign --key cosign.key --tlog-upload=false <image>@<digest>
+//	cosign attest --key cosign.key --type spdxjson --predicate sbom.json --tlog-upload=false <image>@<digest>
+//
+// The signature and attestation manifests and their layers were then pulled from the registry.
+const (
+	cosignImageDigest = "sha256:9dd7d2c837f7075dac42c1374275ce4a6298b54ba6963474f642a19aa3fdc5c4"
+	cosignTagPrefix   = "sha256-9dd7d2c837f7075dac42c1374275ce4a6298b54ba6963474f642a19aa3fdc5c4"
+)
+
+var cosignArtifact = registry.NewArtifact("127.0.0.1:5123", "l0g8r8j6/kube-vip/kube-vip", "v0.5.5", cosignImageDigest)
+
+func readTestFile(t *testing.T, name string) []byte {
+	content, err := os.ReadFile(filepath.Join("testdata", "cosign", name))
+	assert.NoError(t, err)
+	return content
+}
+
+// expectCosignManifest expects the testdata manifest to be fetched from tag, and its layers with the content returned by blob.
+func expectCosignManifest(t *testing.T, client *mocks.MockStorageClient, repo *mocks.MockRepository, tag, file string, blob func(content []byte) []byte) {
+	content := readTestFile(t, file)
+	manifest := &ocispec.Manifest{}
+	assert.NoError(t, json.Unmarshal(content, manifest))
+	for _, l := range manifest.Layers {
+		client.EXPECT().FetchBlob(ctx, repo, l).Return(blob(readTestFile(t, filepath.Join("blobs", l.Digest.Encoded()))), nil)
+	}
+	repo.EXPECT().FetchReference(ctx, tag).Return(ocispec.Descriptor{}, io.NopCloser(bytes.NewReader(content)), nil)
+}
+
+func newCosignVerifyMocks(t *testing.T) (*mocks.MockStorageClient, *mocks.MockRepository) {
+	client := mocks.NewMockStorageClient(gomock.NewController(t))
+	repo := mocks.NewMockRepository(gomock.NewController(t))
+	client.EXPECT().GetStorage(ctx, cosignArtifact).Return(repo, nil)
+	client.EXPECT().Destination(cosignArtifact).Return(cosignArtifact.VersionedImage()).AnyTimes()
+	repo.EXPECT().Resolve(ctx, cosignArtifact.Tag).Return(ocispec.Descriptor{Digest: digest.Digest(cosignImageDigest)}, nil)
+
+	return client, repo
+}
+
+func unmodified(content []byte) []byte {
+	return content
+}
+
+func TestImageVerifierVerifyCosignSignatures(t *testing.T) {
+	client, repo := newCosignVerifyMocks(t)
+	expectCosignManifest(t, client, repo, cosignTagPrefix+".sig", "signature-manifest.json", unmodified)
+	expectCosignManifest(t, client, repo, cosignTagPrefix+".att", "attestation-manifest.json", unmodified)
+
+	verifier, err := registry.NewImageVerifier(readTestFile(t, "cosign.pub"), registry.WithRequiredAttestations(spdxPredicate))
+	assert.NoError(t, err)
+	assert.NoError(t, verifier.Verify(ctx, client, cosignArtifact))
+}
+
+func TestImageVerifierVerifyCosignSignatureTampered(t *testing.T) {
+	client, repo := newCosignVerifyMocks(t)
+	expectCosignManifest(t, client, repo, cosignTagPrefix+".sig", "signature-manifest.json", func(content []byte) []byte {
+		return bytes.Replace(content, []byte("127.0.0.1:5123"), []byte("127.0.0.1:5124"), 1)
+	})
+
+	verifier, err := registry.NewImageVerifier(readTestFile(t, "cosign.pub"))
+	assert.NoError(t, err)
+	err = verifier.Verify(ctx, client, cosignArtifact)
+	assert.True(t, errors.Is(err, registry.ErrImageVerification))
+	assert.ErrorContains(t, err, "doesn't have a valid signature")
+}
+
+func TestImageVerifierVerifyCosignAttestationTampered(t *testing.T) {
+	client, repo := newCosignVerifyMocks(t)
+	expectCosignManifest(t, client, repo, cosignTagPrefix+".sig", "signature-manifest.json", unmodified)
+	expectCosignManifest(t, client, repo, cosignTagPrefix+".att", "attestation-manifest.json", func(content []byte) []byte {
+		envelope := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(content, &envelope))
+		statement, err := base64.StdEncoding.DecodeString(envelope["payload"].(string))
+		assert.NoError(t, err)
+		envelope["payload"] = base64.StdEncoding.EncodeToString(bytes.Replace(statement, []byte("kube-vip"), []byte("kube-vop"), 1))
+		tampered, err := json.Marshal(envelope)
+		assert.NoError(t, err)
+		return tampered
+	})
+
+	verifier, err := registry.NewImageVerifier(readTestFile(t, "cosign.pub"))
+	assert.NoError(t, err)
+	err = verifier.Verify(ctx, client, cosignArtifact)
+	assert.True(t, errors.Is(err, registry.ErrImageVerification))
+	assert.ErrorContains(t, err, "no valid signature")
+}
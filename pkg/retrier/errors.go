@@ -0,0 +1,80 @@
+package retrier
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrorClassifier decides if an error belongs to a certain class.
+type ErrorClassifier func(error) bool
+
+// Most of the errors we retry come from executables (kubectl, govc, cmk...) and only carry their output,
+// so besides checking the typed errors, classifiers match the messages these tools print.
+var (
+	conflictRegex = regexp.MustCompile(`(?i)Error from server \(Conflict\)|the object has been modified; please apply your changes to the latest version`)
+	timeoutRegex  = regexp.MustCompile(`(?i)i/o timeout|TLS handshake timeout|Client\.Timeout exceeded|context deadline exceeded|connection timed out|Error from server \((Server)?Timeout\)`)
+	// We don't match vSphere SOAP faults (ServerFaultCode) since most of them are caused by the request, not the server.
+	serverErrorRegex = regexp.MustCompile(`(?i)\b5\d\d (Internal Server Error|Not Implemented|Bad Gateway|Service Unavailable|Gateway Timeout)\b|status code:? 5\d\d\b|\(HTTP 5\d\d\b|Error from server \((InternalError|ServiceUnavailable|TooManyRequests)\)`)
+	connectionRegex  = regexp.MustCompile(`(?i)connection reset by peer|connection refused|broken pipe|unexpected EOF|The connection to the server .* was refused`)
+)
+
+// IsConflict returns true for update conflicts from the kubernetes API server.
+// These are solved by retrying the operation with the latest version of the object.
+func IsConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	return apierrors.IsConflict(err) || conflictRegex.MatchString(err.Error())
+}
+
+// IsTimeout returns true for errors caused by a request or a connection timing out.
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, os.ErrDeadlineExceeded) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServerTimeout(err) ||
+		timeoutRegex.MatchString(err.Error())
+}
+
+// IsServerError returns true for 5xx responses, from the kubernetes API server or any other HTTP server like
+// vCenter or CloudStack, as well as for throttled requests.
+func IsServerError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code >= 500 {
+		return true
+	}
+
+	return apierrors.IsTooManyRequests(err) || serverErrorRegex.MatchString(err.Error())
+}
+
+// IsConnectionError returns true for errors caused by a connection being refused or dropped.
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return connectionRegex.MatchString(err.Error())
+}
+
+// IsTransient returns true for errors that are expected to go away by retrying the same operation:
+// conflicts, timeouts, server errors and connection errors.
+func IsTransient(err error) bool {
+	return IsConflict(err) || IsTimeout(err) || IsServerError(err) || IsConnectionError(err)
+}
@@ -0,0 +1,121 @@
+package retrier_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+func TestErrorClassifiers(t *testing.T) {
+	gr := schema.GroupResource{Group: "cluster.x-k8s.io", Resource: "clusters"}
+	tests := []struct {
+		name       string
+		err        error
+		classifier retrier.ErrorClassifier
+		want       bool
+	}{
+		{
+			name:       "api conflict",
+			err:        apierrors.NewConflict(gr, "test", errors.New("modified")),
+			classifier: retrier.IsConflict,
+			want:       true,
+		},
+		{
+			name:       "kubectl conflict",
+			err:        errors.New("Error from server (Conflict): error when applying patch: Operation cannot be fulfilled on clusters.cluster.x-k8s.io \"test\": the object has been modified; please apply your changes to the latest version and try again"),
+			classifier: retrier.IsConflict,
+			want:       true,
+		},
+		{
+			name:       "not found is not a conflict",
+			err:        apierrors.NewNotFound(gr, "test"),
+			classifier: retrier.IsConflict,
+			want:       false,
+		},
+		{
+			name:       "context deadline",
+			err:        fmt.Errorf("getting cluster: %w", context.DeadlineExceeded),
+			classifier: retrier.IsTimeout,
+			want:       true,
+		},
+		{
+			name:       "govc tls timeout",
+			err:        errors.New("govc: Post \"https://vcenter.example.com/sdk\": net/http: TLS handshake timeout"),
+			classifier: retrier.IsTimeout,
+			want:       true,
+		},
+		{
+			name:       "api server timeout",
+			err:        apierrors.NewServerTimeout(gr, "get", 1),
+			classifier: retrier.IsTimeout,
+			want:       true,
+		},
+		{
+			name:       "api internal error",
+			err:        apierrors.NewInternalError(errors.New("etcdserver: leader changed")),
+			classifier: retrier.IsServerError,
+			want:       true,
+		},
+		{
+			name:       "govc 503",
+			err:        errors.New("govc: 503 Service Unavailable"),
+			classifier: retrier.IsServerError,
+			want:       true,
+		},
+		{
+			name:       "cmk 530",
+			err:        errors.New("Error: (HTTP 530, error code 9999) Internal error executing command"),
+			classifier: retrier.IsServerError,
+			want:       true,
+		},
+		{
+			name:       "vsphere soap fault",
+			err:        errors.New("govc: ServerFaultCode: A specified parameter was not correct: spec.name"),
+			classifier: retrier.IsServerError,
+			want:       false,
+		},
+		{
+			name:       "cmk 431",
+			err:        errors.New("Error: (HTTP 431, error code 4350) Unable to find zone"),
+			classifier: retrier.IsServerError,
+			want:       false,
+		},
+		{
+			name:       "connection refused",
+			err:        errors.New("The connection to the server 10.0.0.1:6443 was refused - did you specify the right host or port?"),
+			classifier: retrier.IsConnectionError,
+			want:       true,
+		},
+		{
+			name:       "transient",
+			err:        errors.New("read tcp 10.0.0.2:52342->10.0.0.1:443: read: connection reset by peer"),
+			classifier: retrier.IsTransient,
+			want:       true,
+		},
+		{
+			name:       "not transient",
+			err:        errors.New("admission webhook \"validation.cluster.anywhere.amazonaws.com\" denied the request"),
+			classifier: retrier.IsTransient,
+			want:       false,
+		},
+		{
+			name:       "nil",
+			err:        nil,
+			classifier: retrier.IsTransient,
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.classifier(tt.err)).To(Equal(tt.want))
+		})
+	}
+}
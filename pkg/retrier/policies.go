@@ -0,0 +1,77 @@
+package retrier
+
+import (
+	"math"
+	"time"
+)
+
+// defaultJitter is the jitter factor used by the retriers built with NewWithTransientErrorsPolicy.
+const defaultJitter = 0.1
+
+// MaxRetriesPolicy retries up to maxRetries times with a wait time between retries of backOffPeriod.
+func MaxRetriesPolicy(maxRetries int, backOffPeriod time.Duration) RetryPolicy {
+	return maxRetriesPolicy(maxRetries, backOffPeriod)
+}
+
+// ExponentialBackoffPolicy retries up to maxRetries times, doubling the wait time after every retry,
+// starting at initialWait and never exceeding maxWait.
+func ExponentialBackoffPolicy(maxRetries int, initialWait, maxWait time.Duration) RetryPolicy {
+	return func(totalRetries int, _ error) (retry bool, wait time.Duration) {
+		if totalRetries >= maxRetries {
+			return false, 0
+		}
+
+		// Retrier first calls the policy with totalRetries 1. We want it zero-based for exponentiation.
+		wait = time.Duration(float64(initialWait) * math.Pow(2, float64(totalRetries-1)))
+		// A negative value means the multiplication overflowed.
+		if wait > maxWait || wait < 0 {
+			wait = maxWait
+		}
+
+		return true, wait
+	}
+}
+
+// RetryIf only retries errors accepted by the classifier, following policy.
+// Any other error is considered fatal and aborts the execution.
+func RetryIf(classifier ErrorClassifier, policy RetryPolicy) RetryPolicy {
+	return ClassifyErrors(classifier, policy, func(int, error) (bool, time.Duration) {
+		return false, 0
+	})
+}
+
+// ClassifyErrors follows the matched policy for errors accepted by classifier and
+// the unmatched policy for all the rest.
+func ClassifyErrors(classifier ErrorClassifier, matched, unmatched RetryPolicy) RetryPolicy {
+	return func(totalRetries int, err error) (retry bool, wait time.Duration) {
+		if classifier(err) {
+			return matched(totalRetries, err)
+		}
+		return unmatched(totalRetries, err)
+	}
+}
+
+// TransientErrorsPolicy retries transient errors (see IsTransient) up to maxTransientRetries times with an exponential
+// backoff starting at backOffPeriod and capped at maxBackOff. Any other error is retried up to maxRetries times,
+// waiting backOffPeriod in between. This allows to ride out temporary outages of a server without waiting
+// longer for errors that won't go away.
+func TransientErrorsPolicy(maxRetries, maxTransientRetries int, backOffPeriod, maxBackOff time.Duration) RetryPolicy {
+	return ClassifyErrors(
+		IsTransient,
+		ExponentialBackoffPolicy(maxTransientRetries, backOffPeriod, maxBackOff),
+		maxRetriesPolicy(maxRetries, backOffPeriod),
+	)
+}
+
+// NewWithTransientErrorsPolicy creates a new retrier with no global timeout and a TransientErrorsPolicy,
+// adding jitter to the waits between retries. opts are applied after the defaults, so they can override them.
+func NewWithTransientErrorsPolicy(maxRetries, maxTransientRetries int, backOffPeriod, maxBackOff time.Duration, opts ...RetrierOpt) *Retrier {
+	return New(
+		time.Duration(math.MaxInt64),
+		append([]RetrierOpt{
+			WithRetryPolicy(TransientErrorsPolicy(maxRetries, maxTransientRetries, backOffPeriod, maxBackOff)),
+			WithJitter(defaultJitter),
+			WithMaxBackoff(maxBackOff),
+		}, opts...)...,
+	)
+}
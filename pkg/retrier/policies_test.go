@@ -0,0 +1,102 @@
+package retrier_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+func TestExponentialBackoffPolicy(t *testing.T) {
+	g := NewWithT(t)
+	p := retrier.ExponentialBackoffPolicy(5, time.Second, 5*time.Second)
+	err := errors.New("")
+
+	wantWaits := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, want := range wantWaits {
+		retry, wait := p(i+1, err)
+		g.Expect(retry).To(BeTrue())
+		g.Expect(wait).To(Equal(want))
+	}
+
+	retry, _ := p(5, err)
+	g.Expect(retry).To(BeFalse())
+}
+
+func TestExponentialBackoffPolicyOverflow(t *testing.T) {
+	g := NewWithT(t)
+	p := retrier.ExponentialBackoffPolicy(1000, time.Second, time.Minute)
+
+	retry, wait := p(500, errors.New(""))
+	g.Expect(retry).To(BeTrue())
+	g.Expect(wait).To(Equal(time.Minute))
+}
+
+func TestRetryIf(t *testing.T) {
+	g := NewWithT(t)
+	p := retrier.RetryIf(retrier.IsConflict, retrier.BackOffPolicy(time.Second))
+
+	retry, wait := p(1, errors.New("Error from server (Conflict): the object has been modified; please apply your changes to the latest version and try again"))
+	g.Expect(retry).To(BeTrue())
+	g.Expect(wait).To(Equal(time.Second))
+
+	retry, _ = p(1, errors.New("admission webhook denied the request"))
+	g.Expect(retry).To(BeFalse())
+}
+
+func TestTransientErrorsPolicy(t *testing.T) {
+	g := NewWithT(t)
+	p := retrier.TransientErrorsPolicy(2, 10, time.Second, 10*time.Second)
+	transient := errors.New("Post \"https://vcenter/sdk\": net/http: TLS handshake timeout")
+	fatal := errors.New("govc: template not found")
+
+	retry, wait := p(4, transient)
+	g.Expect(retry).To(BeTrue())
+	g.Expect(wait).To(Equal(8 * time.Second))
+
+	retry, _ = p(10, transient)
+	g.Expect(retry).To(BeFalse())
+
+	retry, wait = p(1, fatal)
+	g.Expect(retry).To(BeTrue())
+	g.Expect(wait).To(Equal(time.Second))
+
+	retry, _ = p(2, fatal)
+	g.Expect(retry).To(BeFalse())
+}
+
+func TestNewWithTransientErrorsPolicy(t *testing.T) {
+	g := NewWithT(t)
+	r := retrier.NewWithTransientErrorsPolicy(2, 4, 0, 0)
+
+	gotRetries := 0
+	err := r.Retry(func() error {
+		gotRetries += 1
+		return errors.New("Error from server (InternalError): Internal error occurred: failed calling webhook")
+	})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(gotRetries).To(Equal(4))
+
+	gotRetries = 0
+	err = r.Retry(func() error {
+		gotRetries += 1
+		return errors.New("invalid spec")
+	})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(gotRetries).To(Equal(2))
+}
+
+func TestNewWithTransientErrorsPolicyOptions(t *testing.T) {
+	g := NewWithT(t)
+	var metrics []retrier.Metrics
+	r := retrier.NewWithTransientErrorsPolicy(2, 4, 0, 0, retrier.WithMetricsRecorder(func(m retrier.Metrics) {
+		metrics = append(metrics, m)
+	}))
+
+	g.Expect(r.Retry(func() error { return errors.New("failed") })).NotTo(Succeed())
+	g.Expect(metrics).To(HaveLen(1))
+	g.Expect(metrics[0].Attempts).To(Equal(2))
+}
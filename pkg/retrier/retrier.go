@@ -1,16 +1,23 @@
 package retrier
 
 import (
+	"context"
+	"fmt"
 	"math"
+	"math/rand/v2"
 	"time"
 
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 type Retrier struct {
-	retryPolicy   RetryPolicy
-	timeout       time.Duration
-	backoffFactor *float32
+	retryPolicy     RetryPolicy
+	timeout         time.Duration
+	backoffFactor   *float32
+	jitter          float64
+	maxBackoff      time.Duration
+	metricsRecorder MetricsRecorder
 }
 
 type (
@@ -18,8 +25,22 @@ type (
 	// should be performed and the wait duration indicates the wait time before the next retry.
 	RetryPolicy func(totalRetries int, err error) (retry bool, wait time.Duration)
 	RetrierOpt  func(*Retrier)
+	// MetricsRecorder receives the Metrics of every call to Retry or RetryWithContext.
+	MetricsRecorder func(Metrics)
 )
 
+// Metrics describes a single call to Retry or RetryWithContext.
+type Metrics struct {
+	// Attempts is the number of times the function was executed.
+	Attempts int
+	// Waited is the total time spent waiting in between attempts.
+	Waited time.Duration
+	// Duration is the total time spent in the call, including the attempts and the waits.
+	Duration time.Duration
+	// Err is the error returned by the call, nil if it succeeded.
+	Err error
+}
+
 // New creates a new retrier with a global timeout (max time allowed for the whole execution)
 // The default retry policy is to always retry with no wait time in between retries.
 func New(timeout time.Duration, opts ...RetrierOpt) *Retrier {
@@ -35,9 +56,10 @@ func New(timeout time.Duration, opts ...RetrierOpt) *Retrier {
 }
 
 // NewWithMaxRetries creates a new retrier with no global timeout and a max retries policy.
-func NewWithMaxRetries(maxRetries int, backOffPeriod time.Duration) *Retrier {
+// opts are applied after the policy, so they can override it.
+func NewWithMaxRetries(maxRetries int, backOffPeriod time.Duration, opts ...RetrierOpt) *Retrier {
 	// this value is roughly 292 years, so in practice there is no timeout
-	return New(time.Duration(math.MaxInt64), WithMaxRetries(maxRetries, backOffPeriod))
+	return New(time.Duration(math.MaxInt64), append([]RetrierOpt{WithMaxRetries(maxRetries, backOffPeriod)}, opts...)...)
 }
 
 // NewWithNoTimeout creates a new retrier with no global timeout and infinite retries.
@@ -65,9 +87,49 @@ func WithRetryPolicy(policy RetryPolicy) RetrierOpt {
 	}
 }
 
+// WithJitter randomizes the wait between retries by up to the given fraction of it, in both directions.
+// For example, a factor of 0.2 turns a wait of 10s into a random wait between 8s and 12s. This avoids
+// multiple clients retrying against the same server in lockstep.
+func WithJitter(factor float64) RetrierOpt {
+	return func(r *Retrier) {
+		r.jitter = factor
+	}
+}
+
+// WithMaxBackoff caps the wait between retries, after applying the backoff factor and jitter.
+func WithMaxBackoff(max time.Duration) RetrierOpt {
+	return func(r *Retrier) {
+		r.maxBackoff = max
+	}
+}
+
+// WithMetricsRecorder sets a function that receives the Metrics of every retried call.
+func WithMetricsRecorder(recorder MetricsRecorder) RetrierOpt {
+	return func(r *Retrier) {
+		r.metricsRecorder = recorder
+	}
+}
+
+// LogMetrics returns a MetricsRecorder that logs the calls that needed more than one attempt, identified by name,
+// so the retries hidden by a successful call still show up in the verbose logs.
+func LogMetrics(name string) MetricsRecorder {
+	return func(m Metrics) {
+		if m.Attempts <= 1 {
+			return
+		}
+		logger.V(3).Info("Retried call", "name", name, "attempts", m.Attempts, "waited", m.Waited, "duration", m.Duration, "succeeded", m.Err == nil)
+	}
+}
+
 // Retry runs the fn function until it either successful completes (not error),
 // the set timeout reached or the retry policy aborts the execution.
 func (r *Retrier) Retry(fn func() error) error {
+	return r.RetryWithContext(context.Background(), fn)
+}
+
+// RetryWithContext behaves like Retry but it also stops retrying, without waiting for the next attempt,
+// once ctx is done. In that case the returned error wraps the context error.
+func (r *Retrier) RetryWithContext(ctx context.Context, fn func() error) (err error) {
 	// While it seems aberrant to call a method with a nil receiver, several unit tests actually do.  With a previous
 	// version of this module (which didn't attempt to dereference the receiver until after the wrapped function failed)
 	// these passed.  Changes below, to log the receiver struct's key params changed that breaking the unit tests.
@@ -78,9 +140,18 @@ func (r *Retrier) Retry(fn func() error) error {
 
 	start := time.Now()
 	retries := 0
-	var err error
+	var waited time.Duration
+	if r.metricsRecorder != nil {
+		defer func() {
+			r.metricsRecorder(Metrics{Attempts: retries, Waited: waited, Duration: time.Since(start), Err: err})
+		}()
+	}
+
 	logger.V(5).Info("Retrier:", "timeout", r.timeout, "backoffFactor", r.backoffFactor)
 	for retry := true; retry; retry = time.Since(start) < r.timeout {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return contextError(ctxErr, err)
+		}
 		err = fn()
 		retries += 1
 		if err == nil {
@@ -94,9 +165,7 @@ func (r *Retrier) Retry(fn func() error) error {
 			logger.V(5).Info("Execution aborted by retry policy")
 			return err
 		}
-		if r.backoffFactor != nil {
-			wait = time.Duration(float32(wait) * (*r.backoffFactor * float32(retries)))
-		}
+		wait = r.adjustWait(wait, retries)
 
 		// If there's not enough time left for the policy-proposed wait, there's no value in waiting that duration
 		// before quitting at the bottom of the loop.  Just do it now.
@@ -107,7 +176,11 @@ func (r *Retrier) Retry(fn func() error) error {
 		}
 
 		logger.V(5).Info("Sleeping before next retry", "time", wait)
-		time.Sleep(wait)
+		events.Retried(retries, err, wait)
+		if ctxErr := sleep(ctx, wait); ctxErr != nil {
+			return contextError(ctxErr, err)
+		}
+		waited += wait
 	}
 
 	logger.V(5).Info("Timeout reached. Returning error", "retries", retries, "duration", time.Since(start), "error", err)
@@ -115,6 +188,39 @@ func (r *Retrier) Retry(fn func() error) error {
 	return err
 }
 
+// adjustWait applies the backoff factor, jitter and max backoff to a wait proposed by the retry policy.
+func (r *Retrier) adjustWait(wait time.Duration, retries int) time.Duration {
+	if r.backoffFactor != nil {
+		wait = time.Duration(float32(wait) * (*r.backoffFactor * float32(retries)))
+	}
+	if r.jitter > 0 {
+		wait += time.Duration((rand.Float64()*2 - 1) * r.jitter * float64(wait))
+	}
+	if r.maxBackoff > 0 && wait > r.maxBackoff {
+		wait = r.maxBackoff
+	}
+
+	return wait
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func contextError(ctxErr, lastErr error) error {
+	if lastErr == nil {
+		return ctxErr
+	}
+	return fmt.Errorf("%w, last error: %v", ctxErr, lastErr)
+}
+
 // Retry runs fn with a MaxRetriesPolicy.
 func Retry(maxRetries int, backOffPeriod time.Duration, fn func() error) error {
 	r := NewWithMaxRetries(maxRetries, backOffPeriod)
@@ -1,6 +1,7 @@
 package retrier_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -53,6 +54,18 @@ func TestNewWithMaxRetriesSuccessAfterRetries(t *testing.T) {
 	}
 }
 
+func TestNewWithMaxRetriesOptions(t *testing.T) {
+	g := NewWithT(t)
+	var metrics []retrier.Metrics
+	r := retrier.NewWithMaxRetries(3, 0, retrier.WithMetricsRecorder(func(m retrier.Metrics) {
+		metrics = append(metrics, m)
+	}))
+
+	g.Expect(r.Retry(func() error { return errors.New("failed") })).NotTo(Succeed())
+	g.Expect(metrics).To(HaveLen(1))
+	g.Expect(metrics[0].Attempts).To(Equal(3))
+}
+
 func TestNewWithNoTimeout(t *testing.T) {
 	r := retrier.NewWithNoTimeout()
 	fn := func() error {
@@ -209,3 +222,81 @@ func TestBackOffPolicy(t *testing.T) {
 	g.Expect(retry).To(BeTrue())
 	g.Expect(gotBackOff).To(Equal(backOff))
 }
+
+func TestRetryWithContextCanceledWhileWaiting(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	r := retrier.New(time.Hour, retrier.WithRetryPolicy(retrier.BackOffPolicy(time.Minute)))
+
+	gotRetries := 0
+	fn := func() error {
+		gotRetries += 1
+		cancel()
+		return errors.New("failed")
+	}
+
+	err := r.RetryWithContext(ctx, fn)
+	g.Expect(err).To(MatchError(context.Canceled))
+	g.Expect(err).To(MatchError(ContainSubstring("last error: failed")))
+	g.Expect(gotRetries).To(Equal(1))
+}
+
+func TestRetryWithContextAlreadyCanceled(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := retrier.NewWithMaxRetries(5, 0)
+
+	err := r.RetryWithContext(ctx, func() error {
+		t.Fatal("fn shouldn't be called with a canceled context")
+		return nil
+	})
+	g.Expect(err).To(MatchError(context.Canceled))
+}
+
+func TestRetryWithMaxBackoff(t *testing.T) {
+	g := NewWithT(t)
+	r := retrier.New(
+		100*time.Millisecond,
+		retrier.WithRetryPolicy(retrier.BackOffPolicy(time.Hour)),
+		retrier.WithMaxBackoff(time.Millisecond),
+	)
+
+	gotRetries := 0
+	err := r.Retry(func() error {
+		gotRetries += 1
+		if gotRetries == 3 {
+			return nil
+		}
+		return errors.New("failed")
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(gotRetries).To(Equal(3))
+}
+
+func TestRetryMetricsRecorder(t *testing.T) {
+	g := NewWithT(t)
+	var metrics []retrier.Metrics
+	r := retrier.New(
+		time.Minute,
+		retrier.WithRetryPolicy(retrier.MaxRetriesPolicy(3, time.Millisecond)),
+		retrier.WithJitter(0.5),
+		retrier.WithMetricsRecorder(func(m retrier.Metrics) {
+			metrics = append(metrics, m)
+		}),
+	)
+	failure := errors.New("failed")
+
+	g.Expect(r.Retry(func() error { return nil })).To(Succeed())
+	g.Expect(r.Retry(func() error { return failure })).To(MatchError(failure))
+
+	g.Expect(metrics).To(HaveLen(2))
+	g.Expect(metrics[0].Attempts).To(Equal(1))
+	g.Expect(metrics[0].Waited).To(BeZero())
+	g.Expect(metrics[0].Err).To(BeNil())
+	g.Expect(metrics[1].Attempts).To(Equal(3))
+	g.Expect(metrics[1].Waited).To(BeNumerically(">=", time.Millisecond), "two waits of 1ms with a 50% jitter")
+	g.Expect(metrics[1].Waited).To(BeNumerically("<=", 3*time.Millisecond), "two waits of 1ms with a 50% jitter")
+	g.Expect(metrics[1].Duration).To(BeNumerically(">=", metrics[1].Waited))
+	g.Expect(metrics[1].Err).To(MatchError(failure))
+}
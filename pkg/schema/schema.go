@@ -0,0 +1,249 @@
+// Package schema generates JSON Schemas for the objects of a cluster config from the EKS Anywhere CRDs.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/config/crd"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// ClusterConfigKinds are the kinds of the objects that can be part of a cluster config.
+var ClusterConfigKinds = []string{
+	anywherev1.ClusterKind,
+	anywherev1.AWSIamConfigKind,
+	anywherev1.CloudStackDatacenterKind,
+	anywherev1.CloudStackMachineConfigKind,
+	anywherev1.DockerDatacenterKind,
+	anywherev1.FluxConfigKind,
+	anywherev1.GitOpsConfigKind,
+	anywherev1.NutanixDatacenterKind,
+	anywherev1.NutanixMachineConfigKind,
+	anywherev1.OIDCConfigKind,
+	anywherev1.SnowDatacenterKind,
+	anywherev1.SnowIPPoolKind,
+	anywherev1.SnowMachineConfigKind,
+	anywherev1.TinkerbellDatacenterKind,
+	anywherev1.TinkerbellMachineConfigKind,
+	anywherev1.TinkerbellTemplateConfigKind,
+	anywherev1.VSphereDatacenterKind,
+	anywherev1.VSphereMachineConfigKind,
+}
+
+// Options configures the generated schemas.
+type Options struct {
+	// Strict disallows the fields not defined in the API, to catch typos. The CLI ignores them.
+	Strict bool
+}
+
+// Generate returns a JSON Schema for the kinds. With a single kind, the schema validates objects of that kind.
+// With several kinds, it validates objects of any of them, picking the schema based on the object kind,
+// so it can be used with the multi-document yaml files of cluster configs.
+func Generate(kinds []string, opts Options) ([]byte, error) {
+	if len(kinds) == 0 {
+		return nil, fmt.Errorf("at least one kind is required")
+	}
+
+	crds, err := readCRDs()
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make(map[string]map[string]any, len(kinds))
+	for _, kind := range kinds {
+		crd, ok := crds[kind]
+		if !ok {
+			return nil, fmt.Errorf("kind %s is not supported, supported kinds: %s", kind, strings.Join(ClusterConfigKinds, ", "))
+		}
+		s, err := kindSchema(crd, opts)
+		if err != nil {
+			return nil, fmt.Errorf("generating schema for %s: %v", kind, err)
+		}
+		schemas[kind] = s
+	}
+
+	var schema map[string]any
+	if len(kinds) == 1 {
+		schema = schemas[kinds[0]]
+		schema["title"] = kinds[0]
+	} else {
+		schema = multiKindSchema(kinds, schemas)
+	}
+	schema["$schema"] = jsonSchemaDraft
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func readCRDs() (map[string]*apiextensionsv1.CustomResourceDefinition, error) {
+	crds := map[string]*apiextensionsv1.CustomResourceDefinition{}
+	err := fs.WalkDir(crd.Bases, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := crd.Bases.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		c := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(content, c); err != nil {
+			return fmt.Errorf("parsing CRD %s: %v", path, err)
+		}
+		if c.Spec.Group == anywherev1.GroupVersion.Group {
+			crds[c.Spec.Names.Kind] = c
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading CRDs: %v", err)
+	}
+
+	for kind := range crds {
+		if !isClusterConfigKind(kind) {
+			delete(crds, kind)
+		}
+	}
+
+	return crds, nil
+}
+
+func isClusterConfigKind(kind string) bool {
+	for _, k := range ClusterConfigKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func kindSchema(crd *apiextensionsv1.CustomResourceDefinition, opts Options) (map[string]any, error) {
+	var crdSchema *apiextensionsv1.JSONSchemaProps
+	for _, v := range crd.Spec.Versions {
+		if v.Name == anywherev1.GroupVersion.Version && v.Schema != nil {
+			crdSchema = v.Schema.OpenAPIV3Schema
+		}
+	}
+	if crdSchema == nil {
+		return nil, fmt.Errorf("version %s not found in CRD %s", anywherev1.GroupVersion.Version, crd.Name)
+	}
+
+	raw, err := json.Marshal(crdSchema)
+	if err != nil {
+		return nil, err
+	}
+	schema := map[string]any{}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, err
+	}
+
+	props, _ := schema["properties"].(map[string]any)
+	if props == nil {
+		return nil, fmt.Errorf("CRD %s schema has no properties", crd.Name)
+	}
+	// The status is set by the controllers, it's never part of a cluster config.
+	delete(props, "status")
+	props["apiVersion"] = map[string]any{
+		"type": "string",
+		"enum": []string{anywherev1.GroupVersion.String()},
+	}
+	props["kind"] = map[string]any{
+		"type": "string",
+		"enum": []string{crd.Spec.Names.Kind},
+	}
+	props["metadata"] = metadataSchema()
+	schema["required"] = []string{"apiVersion", "kind", "metadata"}
+
+	return toJSONSchema(schema, opts).(map[string]any), nil
+}
+
+func metadataSchema() map[string]any {
+	stringMap := map[string]any{
+		"type":                 "object",
+		"additionalProperties": map[string]any{"type": "string"},
+	}
+	return map[string]any{
+		"type":     "object",
+		"required": []string{"name"},
+		"properties": map[string]any{
+			"name":        map[string]any{"type": "string"},
+			"namespace":   map[string]any{"type": "string"},
+			"annotations": stringMap,
+			"labels":      stringMap,
+		},
+	}
+}
+
+// toJSONSchema removes the Kubernetes extensions from an OpenAPI v3 schema, which JSON Schema
+// validators don't understand, and disallows the unknown fields if strict.
+func toJSONSchema(s any, opts Options) any {
+	switch v := s.(type) {
+	case map[string]any:
+		preserveUnknownFields, _ := v["x-kubernetes-preserve-unknown-fields"].(bool)
+		for k, e := range v {
+			if strings.HasPrefix(k, "x-kubernetes-") {
+				delete(v, k)
+				continue
+			}
+			if k == "properties" {
+				// properties is a map of field names, which must not be taken as schema keywords.
+				if props, ok := e.(map[string]any); ok {
+					for name, p := range props {
+						props[name] = toJSONSchema(p, opts)
+					}
+					continue
+				}
+			}
+			v[k] = toJSONSchema(e, opts)
+		}
+		if _, ok := v["properties"]; ok && opts.Strict && !preserveUnknownFields {
+			if _, ok := v["additionalProperties"]; !ok {
+				v["additionalProperties"] = false
+			}
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = toJSONSchema(e, opts)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func multiKindSchema(kinds []string, schemas map[string]map[string]any) map[string]any {
+	sorted := append([]string{}, kinds...)
+	sort.Strings(sorted)
+
+	definitions := map[string]any{}
+	conditions := make([]any, 0, len(sorted))
+	for _, kind := range sorted {
+		definitions[kind] = schemas[kind]
+		conditions = append(conditions, map[string]any{
+			"if": map[string]any{
+				"required":   []string{"kind"},
+				"properties": map[string]any{"kind": map[string]any{"const": kind}},
+			},
+			"then": map[string]any{"$ref": "#/definitions/" + kind},
+		})
+	}
+
+	return map[string]any{
+		"title":    "EKS Anywhere cluster config",
+		"type":     "object",
+		"required": []string{"apiVersion", "kind"},
+		"properties": map[string]any{
+			"kind": map[string]any{"type": "string", "enum": sorted},
+		},
+		"allOf":       conditions,
+		"definitions": definitions,
+	}
+}
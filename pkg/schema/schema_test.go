@@ -0,0 +1,80 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+func generate(t *testing.T, kinds []string, opts schema.Options) map[string]any {
+	t.Helper()
+	g := NewWithT(t)
+	out, err := schema.Generate(kinds, opts)
+	g.Expect(err).NotTo(HaveOccurred())
+	s := map[string]any{}
+	g.Expect(json.Unmarshal(out, &s)).To(Succeed())
+	return s
+}
+
+func TestGenerateSingleKind(t *testing.T) {
+	g := NewWithT(t)
+
+	s := generate(t, []string{"VSphereMachineConfig"}, schema.Options{})
+
+	g.Expect(s).To(HaveKeyWithValue("$schema", "http://json-schema.org/draft-07/schema#"))
+	g.Expect(s).To(HaveKeyWithValue("title", "VSphereMachineConfig"))
+	g.Expect(s).To(HaveKeyWithValue("required", ConsistOf("apiVersion", "kind", "metadata")))
+	props := s["properties"].(map[string]any)
+	g.Expect(props).NotTo(HaveKey("status"))
+	g.Expect(props).To(HaveKey("spec"))
+	g.Expect(props["kind"]).To(HaveKeyWithValue("enum", ConsistOf("VSphereMachineConfig")))
+	g.Expect(props["apiVersion"]).To(HaveKeyWithValue("enum", ConsistOf("anywhere.eks.amazonaws.com/v1alpha1")))
+	g.Expect(s).NotTo(HaveKey("additionalProperties"))
+}
+
+func TestGenerateAllKinds(t *testing.T) {
+	g := NewWithT(t)
+
+	s := generate(t, schema.ClusterConfigKinds, schema.Options{})
+
+	definitions := s["definitions"].(map[string]any)
+	g.Expect(definitions).To(HaveLen(len(schema.ClusterConfigKinds)))
+	for _, kind := range schema.ClusterConfigKinds {
+		g.Expect(definitions).To(HaveKey(kind))
+	}
+	g.Expect(s["allOf"]).To(ContainElement(map[string]any{
+		"if": map[string]any{
+			"required":   []any{"kind"},
+			"properties": map[string]any{"kind": map[string]any{"const": "Cluster"}},
+		},
+		"then": map[string]any{"$ref": "#/definitions/Cluster"},
+	}))
+}
+
+func TestGenerateStrict(t *testing.T) {
+	g := NewWithT(t)
+
+	s := generate(t, []string{"Cluster"}, schema.Options{Strict: true})
+
+	g.Expect(s).To(HaveKeyWithValue("additionalProperties", false))
+	spec := s["properties"].(map[string]any)["spec"].(map[string]any)
+	g.Expect(spec).To(HaveKeyWithValue("additionalProperties", false))
+}
+
+func TestGenerateRemovesKubernetesExtensions(t *testing.T) {
+	g := NewWithT(t)
+
+	out, err := schema.Generate(schema.ClusterConfigKinds, schema.Options{Strict: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(out)).NotTo(ContainSubstring("x-kubernetes-"))
+}
+
+func TestGenerateUnsupportedKind(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := schema.Generate([]string{"Bundles"}, schema.Options{})
+	g.Expect(err).To(MatchError(ContainSubstring("kind Bundles is not supported")))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -48,16 +49,25 @@ func Untar(source io.Reader, router Router) error {
 			continue
 		}
 
-		file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
-		if err != nil {
+		// Some tarballs, like the support bundles, don't include entries for the directories.
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 			return err
 		}
-		defer file.Close()
 
-		_, err = io.Copy(file, tarReader)
-		if err != nil {
+		if err = extractFile(path, tarReader, info.Mode()); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+func extractFile(path string, source io.Reader, mode os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, source)
+	return err
+}
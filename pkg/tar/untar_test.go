@@ -74,3 +74,29 @@ func createArbitraryTarball(t *testing.T, w io.Writer) {
 
 	tb.Close()
 }
+
+func TestUntarFile_NoDirectoryEntries(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	tarPath := filepath.Join(dir, "test.tar")
+	fh, err := os.Create(tarPath)
+	g.Expect(err).To(Succeed())
+
+	tb := stdtar.NewWriter(fh)
+	data := []byte("Hello, world!")
+	g.Expect(tb.WriteHeader(&stdtar.Header{
+		Name:     "bundle/logs/kube-system/etcd.log",
+		Mode:     0o644,
+		Typeflag: stdtar.TypeReg,
+		Size:     int64(len(data)),
+	})).To(Succeed())
+	_, err = tb.Write(data)
+	g.Expect(err).To(Succeed())
+	g.Expect(tb.Close()).To(Succeed())
+	g.Expect(fh.Close()).To(Succeed())
+
+	untarFolder := filepath.Join(dir, "untar")
+	g.Expect(tar.UntarFile(tarPath, untarFolder)).To(Succeed())
+	g.Expect(filepath.Join(untarFolder, "bundle", "logs", "kube-system", "etcd.log")).To(BeARegularFile())
+}
@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -21,6 +22,8 @@ import (
 type Task interface {
 	Run(ctx context.Context, commandContext *CommandContext) Task
 	Name() string
+	// Checkpoint returns the state needed to restore the task in a later run.
+	// A nil value means the task can't be restored and will be run again.
 	Checkpoint() *CompletedTask
 	Restore(ctx context.Context, commandContext *CommandContext, completedTask *CompletedTask) (Task, error)
 }
@@ -120,6 +123,7 @@ type taskRunner struct {
 	task           Task
 	writer         filewriter.FileWriter
 	withCheckpoint bool
+	resume         bool
 }
 
 type TaskRunnerOpt func(*taskRunner)
@@ -131,8 +135,23 @@ func WithCheckpointFile() TaskRunnerOpt {
 	}
 }
 
+// WithResume makes the task runner restore the tasks completed in a previous failed run from its checkpoint file,
+// failing if there is none. It implies WithCheckpointFile.
+func WithResume() TaskRunnerOpt {
+	return func(t *taskRunner) {
+		WithCheckpointFile()(t)
+		t.resume = true
+	}
+}
+
+// CheckpointFileName returns the name of the file the task runner saves the checkpoint for the cluster to,
+// in the temporary folder of the writer.
+func CheckpointFileName(clusterName string) string {
+	return fmt.Sprintf("%s-checkpoint.yaml", clusterName)
+}
+
 func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContext) error {
-	checkpointFileName := fmt.Sprintf("%s-checkpoint.yaml", commandContext.ClusterSpec.Cluster.Name)
+	checkpointFileName := CheckpointFileName(commandContext.ClusterSpec.Cluster.Name)
 	var checkpointInfo CheckpointInfo
 	var err error
 
@@ -151,22 +170,33 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 	}
 
 	for task != nil {
-		if completedTask, ok := checkpointInfo.CompletedTasks[task.Name()]; ok {
-			logger.V(4).Info("Restoring task", "task_name", task.Name())
+		taskName := task.Name()
+		if completedTask, ok := checkpointInfo.CompletedTasks[taskName]; ok {
+			logger.V(4).Info("Restoring task", "task_name", taskName)
 			nextTask, err := task.Restore(ctx, commandContext, completedTask)
 			if err != nil {
+				events.TaskFailed(taskName, err)
 				return fmt.Errorf("restoring checkpoint info: %v", err)
 			}
+			events.TaskRestoredFromCheckpoint(taskName)
 			task = nextTask
 			continue
 		}
-		logger.V(4).Info("Task start", "task_name", task.Name())
-		commandContext.Profiler.SetStartTask(task.Name())
+		logger.V(4).Info("Task start", "task_name", taskName)
+		events.TaskStarted(taskName)
+		commandContext.Profiler.SetStartTask(taskName)
+		hadError := commandContext.OriginalError != nil
 		nextTask := task.Run(ctx, commandContext)
-		commandContext.Profiler.MarkDoneTask(task.Name())
-		commandContext.Profiler.logProfileSummary(task.Name())
+		commandContext.Profiler.MarkDoneTask(taskName)
+		commandContext.Profiler.logProfileSummary(taskName)
+		if !hadError && commandContext.OriginalError != nil {
+			events.TaskFailed(taskName, commandContext.OriginalError)
+		}
+		events.TaskEnded(taskName, commandContext.Profiler.Metrics()[taskName])
 		if commandContext.OriginalError == nil {
-			checkpointInfo.taskCompleted(task.Name(), task.Checkpoint())
+			if completedTask := task.Checkpoint(); completedTask != nil {
+				checkpointInfo.taskCompleted(taskName, completedTask)
+			}
 		}
 		task = nextTask
 	}
@@ -174,8 +204,13 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 		if err := tr.saveCheckpoint(checkpointInfo, checkpointFileName); err != nil {
 			return err
 		}
+		return commandContext.OriginalError
+	}
+	// There is nothing left to resume once all the tasks complete.
+	if tr.withCheckpoint {
+		tr.deleteCheckpoint(commandContext, checkpointFileName)
 	}
-	return commandContext.OriginalError
+	return nil
 }
 
 func taskRunnerFinalBlock(startTime time.Time) {
@@ -217,11 +252,21 @@ func (tr *taskRunner) setupCheckpointInfo(commandContext *CommandContext, checkp
 				return checkpointInfo, err
 			}
 			checkpointInfo.CompletedTasks = checkpointFile.CompletedTasks
+		} else if tr.resume {
+			return checkpointInfo, fmt.Errorf("no checkpoint of a previous failed run found at %s, there is nothing to resume", checkpointFilePath)
 		}
 	}
 	return checkpointInfo, nil
 }
 
+func (tr *taskRunner) deleteCheckpoint(commandContext *CommandContext, checkpointFileName string) {
+	checkpointFilePath := filepath.Join(commandContext.Writer.TempDir(), checkpointFileName)
+	logger.V(4).Info("Deleting checkpoint", "file", checkpointFilePath)
+	if err := os.Remove(checkpointFilePath); err != nil && !os.IsNotExist(err) {
+		logger.V(3).Info("Failed deleting checkpoint", "file", checkpointFilePath, "error", err)
+	}
+}
+
 type TaskCheckpoint interface{}
 
 type CheckpointInfo struct {
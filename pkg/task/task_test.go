@@ -1,16 +1,22 @@
 package task_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"go.uber.org/mock/gomock"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/task"
 	mocktasks "github.com/aws/eks-anywhere/pkg/task/mocks"
@@ -21,14 +27,14 @@ func TestTaskRunnerRunTask(t *testing.T) {
 	tr := newTaskRunnerTest(t)
 
 	tr.taskA.EXPECT().Run(tr.ctx, tr.cmdContext).Return(tr.taskB).Times(1)
-	tr.taskA.EXPECT().Name().Return("taskA").Times(7)
-	tr.taskA.EXPECT().Checkpoint()
+	tr.taskA.EXPECT().Name().Return("taskA").Times(2)
+	tr.taskA.EXPECT().Checkpoint().Return(&task.CompletedTask{})
 	tr.taskB.EXPECT().Run(tr.ctx, tr.cmdContext).Return(tr.taskC).Times(1)
-	tr.taskB.EXPECT().Name().Return("taskB").Times(7)
-	tr.taskB.EXPECT().Checkpoint()
+	tr.taskB.EXPECT().Name().Return("taskB").Times(2)
+	tr.taskB.EXPECT().Checkpoint().Return(&task.CompletedTask{})
 	tr.taskC.EXPECT().Run(tr.ctx, tr.cmdContext).Return(nil).Times(1)
-	tr.taskC.EXPECT().Name().Return("taskC").Times(7)
-	tr.taskC.EXPECT().Checkpoint()
+	tr.taskC.EXPECT().Name().Return("taskC").Times(2)
+	tr.taskC.EXPECT().Checkpoint().Return(&task.CompletedTask{})
 
 	type fields struct {
 		tasks []task.Task
@@ -59,18 +65,54 @@ func TestTaskRunnerRunTask(t *testing.T) {
 	}
 }
 
+func TestTaskRunnerRunTaskEmitsEvents(t *testing.T) {
+	tr := newTaskRunnerTest(t)
+	out := &bytes.Buffer{}
+	events.Set(events.NewJSONLEmitter(out))
+	t.Cleanup(func() { events.Set(nil) })
+
+	tr.taskA.EXPECT().Run(tr.ctx, tr.cmdContext).Return(tr.taskB)
+	tr.taskA.EXPECT().Name().Return("taskA").AnyTimes()
+	tr.taskA.EXPECT().Checkpoint()
+	tr.taskB.EXPECT().Run(tr.ctx, tr.cmdContext).DoAndReturn(func(_ context.Context, commandContext *task.CommandContext) task.Task {
+		commandContext.SetError(fmt.Errorf("error"))
+		return nil
+	})
+	tr.taskB.EXPECT().Name().Return("taskB").AnyTimes()
+	tr.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", tr.cmdContext.ClusterSpec.Cluster.Name), gomock.Any())
+
+	runner := task.NewTaskRunner(tr.taskA, tr.writer)
+	if err := runner.RunTask(tr.ctx, tr.cmdContext); err == nil {
+		t.Fatalf("Task.RunTask want err, got nil")
+	}
+
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		e := events.Event{}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, fmt.Sprintf("%s/%s", e.Type, e.Task))
+	}
+	want := []string{"taskStart/taskA", "taskEnd/taskA", "taskStart/taskB", "error/taskB", "taskEnd/taskB"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
 func TestTaskRunnerRunTaskWithCheckpointSecondRunSuccess(t *testing.T) {
 	tt := newTaskRunnerTest(t)
 
 	tt.taskA.EXPECT().Restore(tt.ctx, tt.cmdContext, gomock.Any()).Return(tt.taskB, nil)
-	tt.taskA.EXPECT().Name().Return("taskA").Times(2)
+	tt.taskA.EXPECT().Name().Return("taskA").Times(1)
 	tt.taskB.EXPECT().Run(tt.ctx, tt.cmdContext).Return(tt.taskC).Times(1)
-	tt.taskB.EXPECT().Name().Return("taskB").Times(6)
-	tt.taskB.EXPECT().Checkpoint()
+	tt.taskB.EXPECT().Name().Return("taskB").Times(1)
+	tt.taskB.EXPECT().Checkpoint().Return(&task.CompletedTask{})
 	tt.taskC.EXPECT().Run(tt.ctx, tt.cmdContext).Return(nil).Times(1)
-	tt.taskC.EXPECT().Name().Return("taskC").Times(6)
-	tt.taskC.EXPECT().Checkpoint()
-	tt.writer.EXPECT().TempDir().Return("testdata")
+	tt.taskC.EXPECT().Name().Return("taskC").Times(1)
+	tt.taskC.EXPECT().Checkpoint().Return(&task.CompletedTask{})
+	dir := copyCheckpoint(t)
+	tt.writer.EXPECT().TempDir().Return(dir).Times(2)
 
 	tasks := []task.Task{tt.taskA, tt.taskB, tt.taskC}
 
@@ -80,17 +122,48 @@ func TestTaskRunnerRunTaskWithCheckpointSecondRunSuccess(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	if _, err := os.Stat(filepath.Join(dir, task.CheckpointFileName(tt.cmdContext.ClusterSpec.Cluster.Name))); !os.IsNotExist(err) {
+		t.Fatalf("checkpoint file should be deleted after all tasks complete, got stat error %v", err)
+	}
+
 	if err := os.Unsetenv(features.CheckpointEnabledEnvVar); err != nil {
 		t.Fatal(err)
 	}
 }
 
+func TestTaskRunnerRunTaskWithResume(t *testing.T) {
+	tt := newTaskRunnerTest(t)
+
+	tt.taskA.EXPECT().Restore(tt.ctx, tt.cmdContext, gomock.Any()).Return(tt.taskB, nil)
+	tt.taskA.EXPECT().Name().Return("taskA").Times(1)
+	tt.taskB.EXPECT().Run(tt.ctx, tt.cmdContext).Return(nil).Times(1)
+	tt.taskB.EXPECT().Name().Return("taskB").Times(1)
+	tt.taskB.EXPECT().Checkpoint().Return(&task.CompletedTask{})
+	tt.writer.EXPECT().TempDir().Return(copyCheckpoint(t)).Times(2)
+
+	runner := task.NewTaskRunner(tt.taskA, tt.cmdContext.Writer, task.WithResume())
+	if err := runner.RunTask(tt.ctx, tt.cmdContext); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTaskRunnerRunTaskWithResumeNoCheckpoint(t *testing.T) {
+	tt := newTaskRunnerTest(t)
+	tt.writer.EXPECT().TempDir().Return(t.TempDir())
+
+	runner := task.NewTaskRunner(tt.taskA, tt.cmdContext.Writer, task.WithResume())
+	err := runner.RunTask(tt.ctx, tt.cmdContext)
+	if err == nil || !strings.Contains(err.Error(), "there is nothing to resume") {
+		t.Fatalf("Task.RunTask want nothing to resume err, got %v", err)
+	}
+}
+
 func TestTaskRunnerRunTaskWithCheckpointFirstRunFailed(t *testing.T) {
 	tt := newTaskRunnerTest(t)
 	tt.cmdContext.OriginalError = fmt.Errorf("error")
 
 	tt.taskA.EXPECT().Run(tt.ctx, tt.cmdContext).Return(nil)
-	tt.taskA.EXPECT().Name().Return("taskA").Times(5)
+	tt.taskA.EXPECT().Name().Return("taskA").Times(1)
 	tt.writer.EXPECT().TempDir()
 	tt.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", tt.cmdContext.ClusterSpec.Cluster.Name), gomock.Any())
 
@@ -110,7 +183,7 @@ func TestTaskRunnerRunTaskWithCheckpointSecondRunRestoreFailure(t *testing.T) {
 	tt := newTaskRunnerTest(t)
 
 	tt.taskA.EXPECT().Restore(tt.ctx, tt.cmdContext, gomock.Any()).Return(nil, fmt.Errorf("error"))
-	tt.taskA.EXPECT().Name().Return("taskA").Times(2)
+	tt.taskA.EXPECT().Name().Return("taskA").Times(1)
 	tt.writer.EXPECT().TempDir().Return("testdata")
 
 	tasks := []task.Task{tt.taskA, tt.taskB, tt.taskC}
@@ -131,7 +204,7 @@ func TestTaskRunnerRunTaskWithCheckpointSaveFailed(t *testing.T) {
 	tt.cmdContext.OriginalError = fmt.Errorf("error")
 
 	tt.taskA.EXPECT().Run(tt.ctx, tt.cmdContext).Return(nil)
-	tt.taskA.EXPECT().Name().Return("taskA").Times(5)
+	tt.taskA.EXPECT().Name().Return("taskA").Times(1)
 	tt.writer.EXPECT().TempDir()
 	tt.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", tt.cmdContext.ClusterSpec.Cluster.Name), gomock.Any()).Return("", fmt.Errorf("error"))
 
@@ -148,6 +221,42 @@ func TestTaskRunnerRunTaskWithCheckpointSaveFailed(t *testing.T) {
 	}
 }
 
+func TestTaskRunnerRunTaskSkipsNilCheckpoints(t *testing.T) {
+	tt := newTaskRunnerTest(t)
+
+	tt.taskA.EXPECT().Run(tt.ctx, tt.cmdContext).Return(tt.taskB)
+	tt.taskA.EXPECT().Name().Return("taskA").AnyTimes()
+	tt.taskA.EXPECT().Checkpoint().Return(&task.CompletedTask{})
+	tt.taskB.EXPECT().Run(tt.ctx, tt.cmdContext).Return(tt.taskC)
+	tt.taskB.EXPECT().Name().Return("taskB").AnyTimes()
+	tt.taskB.EXPECT().Checkpoint().Return(nil)
+	tt.taskC.EXPECT().Run(tt.ctx, tt.cmdContext).DoAndReturn(func(_ context.Context, commandContext *task.CommandContext) task.Task {
+		commandContext.SetError(fmt.Errorf("error"))
+		return nil
+	})
+	tt.taskC.EXPECT().Name().Return("taskC").AnyTimes()
+
+	var saved string
+	tt.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", tt.cmdContext.ClusterSpec.Cluster.Name), gomock.Any()).DoAndReturn(
+		func(_ string, content []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+			saved = string(content)
+			return "", nil
+		},
+	)
+
+	runner := task.NewTaskRunner(tt.taskA, tt.cmdContext.Writer)
+	if err := runner.RunTask(tt.ctx, tt.cmdContext); err == nil {
+		t.Fatalf("Task.RunTask want err, got nil")
+	}
+
+	if !strings.Contains(saved, "taskA") {
+		t.Errorf("checkpoint = %s, want it to contain taskA", saved)
+	}
+	if strings.Contains(saved, "taskB") {
+		t.Errorf("checkpoint = %s, want it to not contain taskB", saved)
+	}
+}
+
 func TestTaskRunnerRunTaskWithCheckpointReadFailure(t *testing.T) {
 	tt := newTaskRunnerTest(t)
 	tt.cmdContext.ClusterSpec.Cluster.Name = "invalid"
@@ -214,3 +323,17 @@ func newTaskRunnerTest(t *testing.T) *taskRunnerTest {
 		writer:     writer,
 	}
 }
+
+// copyCheckpoint copies the test-cluster checkpoint from testdata to a temporary dir and returns the dir.
+func copyCheckpoint(t *testing.T) string {
+	t.Helper()
+	content, err := os.ReadFile("testdata/test-cluster-checkpoint.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test-cluster-checkpoint.yaml"), content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
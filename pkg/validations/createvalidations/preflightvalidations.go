@@ -35,6 +35,13 @@ func (v *CreateValidations) PreflightValidations(ctx context.Context) []validati
 				Err:         validations.ValidateCertForRegistryMirror(v.Opts.Spec, v.Opts.TLSValidator),
 			}
 		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate image signatures in registry mirror",
+				Remediation: fmt.Sprintf("copy the images to the registry mirror again with their signatures using 'eksctl anywhere copy images --verify-key', or unset %s to skip the verification", config.EksaImageVerificationKeyEnv),
+				Err:         validations.ValidateImageSignaturesForRegistryMirror(ctx, v.Opts.Spec, v.Opts.CliConfig),
+			}
+		},
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
 				Name:        "validate authentication for git provider",
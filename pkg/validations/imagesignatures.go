@@ -0,0 +1,77 @@
+package validations
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// ValidateImageSignaturesForRegistryMirror verifies the cosign signatures of the cluster images in the registry mirror
+// with the public key in EKSA_IMAGE_VERIFICATION_KEY. It fails if an image in the mirror doesn't match the digest in
+// the bundle or isn't signed with the key. It's a no-op if the cluster doesn't use a registry mirror or the key isn't set.
+func ValidateImageSignaturesForRegistryMirror(ctx context.Context, clusterSpec *cluster.Spec, cliConfig *config.CliConfig) error {
+	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration == nil || cliConfig == nil || cliConfig.ImageVerificationKeyFile == "" {
+		return nil
+	}
+
+	key, err := os.ReadFile(cliConfig.ImageVerificationKeyFile)
+	if err != nil {
+		return fmt.Errorf("reading image verification key: %v", err)
+	}
+	verifier, err := registry.NewImageVerifier(key)
+	if err != nil {
+		return fmt.Errorf("image verification key %s: %v", cliConfig.ImageVerificationKeyFile, err)
+	}
+
+	mirror := registrymirror.FromCluster(clusterSpec.Cluster)
+	var certificates *x509.CertPool
+	if mirror.CACertContent != "" {
+		certificates = x509.NewCertPool()
+		certificates.AppendCertsFromPEM([]byte(mirror.CACertContent))
+	}
+	credentialStore := registry.NewCredentialStore()
+	if err := credentialStore.Init(); err != nil {
+		return fmt.Errorf("reading registry credentials: %v", err)
+	}
+	client := registry.NewOCIRegistry(registry.NewStorageContext(mirror.BaseRegistry, credentialStore, certificates, mirror.InsecureSkipVerify))
+	if err := client.Init(); err != nil {
+		return err
+	}
+
+	return verifier.VerifyAll(ctx, client, mirroredArtifacts(clusterSpec, mirror))
+}
+
+// mirroredArtifacts returns the location in the registry mirror of the images of all the cluster versions bundles.
+func mirroredArtifacts(clusterSpec *cluster.Spec, mirror *registrymirror.RegistryMirror) []registry.Artifact {
+	images := map[string]releasev1alpha1.Image{}
+	for _, vb := range clusterSpec.VersionsBundles {
+		for _, image := range vb.Images() {
+			images[image.URI] = image
+		}
+	}
+
+	uris := make([]string, 0, len(images))
+	for uri := range images {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	artifacts := make([]registry.Artifact, 0, len(uris))
+	for _, uri := range uris {
+		mirrored := releasev1alpha1.Image{URI: mirror.ReplaceRegistry(uri)}
+		if mirrored.URI == uri {
+			continue
+		}
+		artifacts = append(artifacts, registry.NewArtifact(mirror.BaseRegistry, mirrored.Repository(), mirrored.Version(), images[uri].ImageDigest))
+	}
+
+	return artifacts
+}
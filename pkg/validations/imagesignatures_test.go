@@ -0,0 +1,73 @@
+package validations_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func TestValidateImageSignaturesForRegistryMirror(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "cosign.pub")
+	if err := os.WriteFile(keyFile, []byte("not a key"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mirror := &v1alpha1.RegistryMirrorConfiguration{Endpoint: "harbor.local", Port: "443"}
+
+	tests := []struct {
+		name      string
+		mirror    *v1alpha1.RegistryMirrorConfiguration
+		cliConfig *config.CliConfig
+		wantErr   string
+	}{
+		{
+			name:      "no registry mirror",
+			cliConfig: &config.CliConfig{ImageVerificationKeyFile: keyFile},
+		},
+		{
+			name:   "no cli config",
+			mirror: mirror,
+		},
+		{
+			name:      "no verification key",
+			mirror:    mirror,
+			cliConfig: &config.CliConfig{},
+		},
+		{
+			name:      "missing key file",
+			mirror:    mirror,
+			cliConfig: &config.CliConfig{ImageVerificationKeyFile: filepath.Join(t.TempDir(), "missing.pub")},
+			wantErr:   "reading image verification key",
+		},
+		{
+			name:      "invalid key",
+			mirror:    mirror,
+			cliConfig: &config.CliConfig{ImageVerificationKeyFile: keyFile},
+			wantErr:   "public key is neither PEM nor base64 encoded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.RegistryMirrorConfiguration = tt.mirror
+			})
+
+			err := validations.ValidateImageSignaturesForRegistryMirror(context.Background(), spec, tt.cliConfig)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package offlinevalidations
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestAlreadyReported(t *testing.T) {
+	gk := schema.GroupKind{Group: "anywhere.eks.amazonaws.com", Kind: "VSphereMachineConfig"}
+	users := []anywherev1.UserConfiguration{{Name: "capv: admin"}}
+	machineConfig := &anywherev1.VSphereMachineConfig{
+		Spec: anywherev1.VSphereMachineConfigSpec{
+			Template: "ubuntu",
+			Users:    users,
+		},
+	}
+	reported := utilerrors.NewAggregate([]error{
+		errors.New("control plane node count cannot be an even number"),
+		errors.New("users[0].name capv: admin is invalid"),
+	})
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "no error",
+			err:  nil,
+			want: false,
+		},
+		{
+			name: "all field errors reported",
+			err: apierrors.NewInvalid(gk, "md", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "users"), users, "users[0].name capv: admin is invalid"),
+				field.Required(field.NewPath("spec", "count"), "control plane node count cannot be an even number"),
+			}),
+			want: true,
+		},
+		{
+			name: "one field error not reported",
+			err: apierrors.NewInvalid(gk, "md", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "users"), users, "users[0].name capv: admin is invalid"),
+				field.Invalid(field.NewPath("spec", "template"), "ubuntu", "template not found"),
+			}),
+			want: false,
+		},
+		{
+			name: "detail ending with a reported error",
+			err: apierrors.NewInvalid(gk, "md", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "users"), users, "validating users: users[0].name capv: admin is invalid"),
+			}),
+			want: false,
+		},
+		{
+			name: "reported error for a different value",
+			err: apierrors.NewInvalid(gk, "md", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "users"), []anywherev1.UserConfiguration{{Name: "root"}}, "users[0].name capv: admin is invalid"),
+			}),
+			want: false,
+		},
+		{
+			name: "field error of an element",
+			err: apierrors.NewInvalid(gk, "md", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "users").Index(0).Child("name"), "capv: admin", "users[0].name capv: admin is invalid"),
+			}),
+			want: true,
+		},
+		{
+			name: "not a field error",
+			err:  errors.New("users[0].name capv: admin is invalid"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(alreadyReported(machineConfig, tt.err, reported)).To(Equal(tt.want))
+		})
+	}
+}
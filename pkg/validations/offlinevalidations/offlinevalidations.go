@@ -0,0 +1,218 @@
+// Package offlinevalidations implements the validations of a cluster config that don't need access to
+// the infrastructure provider or to an existing cluster.
+package offlinevalidations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// cliGenerated is set in the fields the CLI fills in with API calls when creating a cluster, like the
+// vSphere templates and the SSH keys, before running the webhook validations.
+const cliGenerated = "generated-by-eksctl-anywhere"
+
+// SetDefaults sets the defaults of the cluster config, first the ones the CLI sets and then the ones set
+// by the webhooks of each object.
+func SetDefaults(ctx context.Context, config *cluster.Config) error {
+	if err := cluster.SetConfigDefaults(config); err != nil {
+		return err
+	}
+
+	for _, obj := range config.ClusterAndChildren() {
+		defaulter, ok := obj.(webhook.CustomDefaulter)
+		if !ok {
+			continue
+		}
+		if err := defaulter.Default(ctx, obj); err != nil {
+			return fmt.Errorf("setting defaults for %s %s: %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// Validations returns the validations of the cluster config: the cluster config validations run by the CLI
+// and the create validations of the webhook of each object. config should have the defaults set with SetDefaults.
+// The webhook errors already reported by the cluster config validations are omitted.
+func Validations(ctx context.Context, config *cluster.Config) []validations.Validation {
+	configErr := cluster.ValidateConfig(config)
+	vs := []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate cluster config",
+				Remediation: "fix the cluster config following the EKS Anywhere cluster specification",
+				Err:         configErr,
+			}
+		},
+	}
+
+	objs := config.ChildObjects()
+	// Child objects come from maps, sort them to always report the validations in the same order.
+	sort.Slice(objs, func(i, j int) bool {
+		ki, kj := objs[i].GetObjectKind().GroupVersionKind().Kind, objs[j].GetObjectKind().GroupVersionKind().Kind
+		if ki != kj {
+			return ki < kj
+		}
+		return objs[i].GetName() < objs[j].GetName()
+	})
+
+	for _, obj := range append([]kubernetes.Object{config.Cluster}, objs...) {
+		validator, ok := obj.(webhook.CustomValidator)
+		if !ok {
+			continue
+		}
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		name := obj.GetName()
+		createObj := withCLIGeneratedFields(obj)
+		vs = append(vs, func() *validations.ValidationResult {
+			_, err := validator.ValidateCreate(ctx, createObj)
+			if alreadyReported(createObj, err, configErr) {
+				err = nil
+			}
+			return &validations.ValidationResult{
+				Name:        fmt.Sprintf("validate %s %s", kind, name),
+				Remediation: fmt.Sprintf("fix the %s spec", kind),
+				Err:         err,
+			}
+		})
+	}
+
+	return vs
+}
+
+// alreadyReported returns true if all the field errors of err, returned by the webhook validating obj, are errors
+// of reported. The webhooks wrap the same validations as the cluster config ones in a field error of the invalid
+// field, so each cause is compared with the one of that field error built for every reported error.
+func alreadyReported(obj runtime.Object, err, reported error) bool {
+	if err == nil || reported == nil {
+		return false
+	}
+
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil || len(statusErr.ErrStatus.Details.Causes) == 0 {
+		return false
+	}
+	details := statusErr.ErrStatus.Details
+
+	reportedErrs := []error{reported}
+	var agg utilerrors.Aggregate
+	if errors.As(reported, &agg) {
+		reportedErrs = agg.Errors()
+	}
+
+	content, jsonErr := json.Marshal(obj)
+	if jsonErr != nil {
+		return false
+	}
+
+	gk := schema.GroupKind{Group: details.Group, Kind: details.Kind}
+	for _, cause := range details.Causes {
+		fieldErr := &field.Error{Type: field.ErrorType(cause.Type), Field: cause.Field, BadValue: fieldValue(content, cause.Field)}
+		if !slices.ContainsFunc(reportedErrs, func(r error) bool {
+			fieldErr.Detail = r.Error()
+			return apierrors.NewInvalid(gk, details.Name, field.ErrorList{fieldErr}).ErrStatus.Details.Causes[0] == cause
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldPathElement matches the field names and the subscripts of a field path like spec.users[0].
+var fieldPathElement = regexp.MustCompile(`[^.\[\]]+|\[[^\]]*\]`)
+
+// fieldValue returns the value at path of the JSON object content the way the webhook passes it to a field error:
+// the simple values decoded and the rest as JSON. It returns nil if the path doesn't exist.
+func fieldValue(content []byte, path string) interface{} {
+	raw := json.RawMessage(content)
+	for _, elem := range fieldPathElement.FindAllString(path, -1) {
+		if subscript, ok := strings.CutPrefix(elem, "["); ok {
+			subscript = strings.TrimSuffix(subscript, "]")
+			if i, err := strconv.Atoi(subscript); err == nil {
+				var items []json.RawMessage
+				if err := json.Unmarshal(raw, &items); err != nil || i < 0 || i >= len(items) {
+					return nil
+				}
+				raw = items[i]
+				continue
+			}
+			elem = subscript
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil
+		}
+		value, ok := fields[elem]
+		if !ok {
+			return nil
+		}
+		raw = value
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil
+	}
+	switch v.(type) {
+	case string, bool, float64, nil:
+		return v
+	default:
+		return raw
+	}
+}
+
+// withCLIGeneratedFields returns a copy of obj with the fields that the CLI generates when creating a cluster.
+// The webhooks require them because they can't generate them, but they can be omitted in the cluster config.
+func withCLIGeneratedFields(obj kubernetes.Object) runtime.Object {
+	c := obj.DeepCopyObject()
+	switch o := c.(type) {
+	case *anywherev1.Cluster:
+		o.AddManagedByCLIAnnotation()
+	case *anywherev1.VSphereMachineConfig:
+		if o.Spec.Template == "" {
+			o.Spec.Template = cliGenerated
+		}
+		setGeneratedSSHKey(o.Spec.Users)
+	case *anywherev1.CloudStackMachineConfig:
+		setGeneratedSSHKey(o.Spec.Users)
+	case *anywherev1.TinkerbellMachineConfig:
+		setGeneratedSSHKey(o.Spec.Users)
+	case *anywherev1.SnowMachineConfig:
+		if o.Spec.SshKeyName == "" {
+			o.Spec.SshKeyName = cliGenerated
+		}
+	}
+	return c
+}
+
+func setGeneratedSSHKey(users []anywherev1.UserConfiguration) {
+	if len(users) == 0 {
+		return
+	}
+	if len(users[0].SshAuthorizedKeys) == 0 {
+		users[0].SshAuthorizedKeys = []string{""}
+	}
+	if users[0].SshAuthorizedKeys[0] == "" {
+		users[0].SshAuthorizedKeys[0] = cliGenerated
+	}
+}
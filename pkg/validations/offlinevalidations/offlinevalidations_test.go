@@ -0,0 +1,82 @@
+package offlinevalidations_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/offlinevalidations"
+)
+
+func runValidations(t *testing.T, configFile string) []*validations.ValidationResult {
+	t.Helper()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	config, err := cluster.ParseConfigFromFile(configFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(offlinevalidations.SetDefaults(ctx, config)).To(Succeed())
+
+	var results []*validations.ValidationResult
+	for _, v := range offlinevalidations.Validations(ctx, config) {
+		results = append(results, v())
+	}
+	return results
+}
+
+func TestValidationsSuccess(t *testing.T) {
+	g := NewWithT(t)
+
+	results := runValidations(t, "testdata/cluster_vsphere.yaml")
+
+	names := make([]string, 0, len(results))
+	for _, r := range results {
+		g.Expect(r.Err).NotTo(HaveOccurred(), r.Name)
+		names = append(names, r.Name)
+	}
+	g.Expect(names).To(Equal([]string{
+		"validate cluster config",
+		"validate Cluster test",
+		"validate VSphereDatacenterConfig test",
+		"validate VSphereMachineConfig test-cp",
+		"validate VSphereMachineConfig test-md",
+	}))
+}
+
+func TestValidationsReportsAllErrors(t *testing.T) {
+	g := NewWithT(t)
+
+	results := runValidations(t, "testdata/cluster_vsphere_invalid.yaml")
+
+	errs := map[string]string{}
+	for _, r := range results {
+		if r.Err != nil {
+			errs[r.Name] = r.Err.Error()
+		}
+	}
+	g.Expect(errs).To(HaveLen(2))
+	g.Expect(errs["validate cluster config"]).To(And(
+		ContainSubstring("control plane node count cannot be an even number when using stacked etcd topology"),
+		ContainSubstring("VSphereMachineConfig test-cp osFamily: windows is not supported"),
+	))
+	g.Expect(errs["validate VSphereMachineConfig test-md"]).To(ContainSubstring("users[0].name capv is invalid. Please use 'ec2-user' for Bottlerocket"))
+}
+
+func TestValidationsCLIGeneratedFields(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	config, err := cluster.ParseConfigFromFile("testdata/cluster_vsphere.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(offlinevalidations.SetDefaults(ctx, config)).To(Succeed())
+
+	for _, v := range offlinevalidations.Validations(ctx, config) {
+		g.Expect(v().Err).NotTo(HaveOccurred())
+	}
+	g.Expect(config.Cluster.IsManagedByCLI()).To(BeFalse())
+	g.Expect(config.VSphereMachineConfigs["test-cp"].Spec.Template).To(BeEmpty())
+	g.Expect(config.VSphereMachineConfigs["test-cp"].Spec.Users[0].SshAuthorizedKeys).To(ConsistOf(""))
+}
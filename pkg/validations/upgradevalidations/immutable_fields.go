@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
@@ -165,6 +166,28 @@ func ValidateGitOpsImmutableFields(ctx context.Context, k validations.KubectlCli
 			}
 		}
 
+		if prevGitOps.Spec.Gitlab != nil {
+			if clusterSpec.FluxConfig.Spec.Gitlab == nil {
+				return errors.New("fluxConfig spec.gitlab is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Repository != clusterSpec.FluxConfig.Spec.Gitlab.Repository {
+				return errors.New("fluxConfig spec.gitlab.repository is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Owner != clusterSpec.FluxConfig.Spec.Gitlab.Owner {
+				return errors.New("fluxConfig spec.gitlab.owner is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Personal != clusterSpec.FluxConfig.Spec.Gitlab.Personal {
+				return errors.New("fluxConfig spec.gitlab.personal is immutable")
+			}
+
+			if gitlab.Hostname(prevGitOps.Spec.Gitlab) != gitlab.Hostname(clusterSpec.FluxConfig.Spec.Gitlab) {
+				return errors.New("fluxConfig spec.gitlab.hostname is immutable")
+			}
+		}
+
 		if prevGitOps.Spec.Branch != clusterSpec.FluxConfig.Spec.Branch {
 			return errors.New("fluxConfig spec.branch is immutable")
 		}
@@ -3,6 +3,7 @@ package validations
 import (
 	"unicode"
 
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
@@ -14,6 +15,7 @@ type ValidationResult struct {
 }
 
 func (v *ValidationResult) Report() {
+	events.ValidationReported(v.Name, v.Err, v.Remediation)
 	if v.Err != nil {
 		logger.MarkFail("Validation failed", "validation", v.Name, "error", v.Err.Error(), "remediation", v.Remediation)
 		return
@@ -0,0 +1,30 @@
+package workflows
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/task"
+)
+
+// TaskRunnerOpts returns the task runner options for a workflow. When resume is true or the checkpoint
+// feature is enabled, the tasks completed in a previous failed run are restored from the checkpoint file
+// instead of being run again. When resume is true, the workflow fails if there is no checkpoint file.
+func TaskRunnerOpts(resume bool) []task.TaskRunnerOpt {
+	if resume {
+		return []task.TaskRunnerOpt{task.WithResume()}
+	}
+	if features.IsActive(features.CheckpointEnabled()) {
+		return []task.TaskRunnerOpt{task.WithCheckpointFile()}
+	}
+
+	return nil
+}
+
+// HasCheckpoint returns true if dir has the checkpoint file saved by a previous failed run of a workflow
+// for the cluster.
+func HasCheckpoint(dir, clusterName string) bool {
+	_, err := os.Stat(filepath.Join(dir, task.CheckpointFileName(clusterName)))
+	return err == nil
+}
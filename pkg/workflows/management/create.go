@@ -7,6 +7,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
@@ -24,6 +25,7 @@ type Create struct {
 	eksaInstaller  interfaces.EksaInstaller
 	clusterMover   interfaces.ClusterMover
 	iamAuth        interfaces.AwsIamAuth
+	resume         bool
 }
 
 // NewCreate builds a new create construct.
@@ -55,6 +57,13 @@ func NewCreate(bootstrapper interfaces.Bootstrapper,
 	return createWorkflow
 }
 
+// WithResume configures the workflow to restore the tasks completed in a previous failed run
+// from the checkpoint file instead of running them again.
+func (c *Create) WithResume(resume bool) *Create {
+	c.resume = resume
+	return c
+}
+
 // Run runs all the create management cluster tasks.
 func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator) error {
 	commandContext := &task.CommandContext{
@@ -74,5 +83,5 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 		IamAuth:        c.iamAuth,
 	}
 
-	return task.NewTaskRunner(&setupAndValidateCreate{}, c.writer).RunTask(ctx, commandContext)
+	return task.NewTaskRunner(&setupAndValidateCreate{}, c.writer, workflows.TaskRunnerOpts(c.resume)...).RunTask(ctx, commandContext)
 }
@@ -22,5 +22,7 @@ func (s *installCuratedPackagesTask) Restore(ctx context.Context, commandContext
 }
 
 func (s *installCuratedPackagesTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
@@ -25,9 +25,11 @@ func (s *installGitOpsManagerTask) Name() string {
 }
 
 func (s *installGitOpsManagerTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &writeCreateClusterConfig{}, nil
 }
 
 func (s *installGitOpsManagerTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
@@ -29,11 +29,13 @@ func (s *installEksaComponentsOnBootstrapTask) Name() string {
 }
 
 func (s *installEksaComponentsOnBootstrapTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &createWorkloadClusterTask{}, nil
 }
 
 func (s *installEksaComponentsOnBootstrapTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 type installEksaComponentsOnWorkloadTask struct{}
@@ -97,11 +99,15 @@ func (s *installEksaComponentsOnWorkloadTask) Name() string {
 }
 
 func (s *installEksaComponentsOnWorkloadTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	commandContext.ClusterSpec.Cluster.AddManagedByCLIAnnotation()
+	commandContext.ClusterSpec.Cluster.SetManagementComponentsVersion(commandContext.ClusterSpec.EKSARelease.Spec.Version)
+	return &installGitOpsManagerTask{}, nil
 }
 
 func (s *installEksaComponentsOnWorkloadTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 func installEKSAComponents(ctx context.Context, commandContext *task.CommandContext, targetCluster *types.Cluster) error {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"go.uber.org/mock/gomock"
@@ -315,6 +317,40 @@ func TestCreateRunSuccess(t *testing.T) {
 	}
 }
 
+func TestCreateRunResumeFromCheckpoint(t *testing.T) {
+	test := newCreateTest(t)
+	test.workflow.WithResume(true)
+	// The checkpoint is deleted once the workflow completes, so it's copied out of testdata.
+	dir := t.TempDir()
+	checkpoint, err := os.ReadFile("testdata/test-cluster-checkpoint.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "test-cluster-checkpoint.yaml"), checkpoint, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	test.writer.EXPECT().TempDir().Return(dir).Times(2)
+	// The validations run again on resume.
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectInstallResourcesOnManagementTask(nil)
+	test.expectPauseReconcile(nil)
+	test.expectMoveManagement(nil)
+	test.expectInstallEksaComponentsWorkload(nil, nil, nil, nil, nil)
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap(nil)
+	test.expectCuratedPackagesInstallation()
+	test.expectCreateNamespace()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+
+	err = test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
 func TestCreateBootstrapOptsFailure(t *testing.T) {
 	c := newCreateTest(t)
 	c.expectSetup()
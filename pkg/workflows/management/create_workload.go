@@ -6,11 +6,14 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/workflows"
 )
 
 // createWorkloadClusterTask implementation.
-type createWorkloadClusterTask struct{}
+type createWorkloadClusterTask struct {
+	WorkloadCluster *types.Cluster
+}
 
 func (s *createWorkloadClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Creating new management cluster")
@@ -37,6 +40,7 @@ func (s *createWorkloadClusterTask) Run(ctx context.Context, commandContext *tas
 		return &workflows.CollectMgmtClusterDiagnosticsTask{}
 	}
 	commandContext.WorkloadCluster = workloadCluster
+	s.WorkloadCluster = workloadCluster
 
 	logger.Info("Creating EKS-A namespace")
 	err = commandContext.ClusterManager.CreateEKSANamespace(ctx, commandContext.WorkloadCluster)
@@ -74,9 +78,18 @@ func (s *createWorkloadClusterTask) Name() string {
 }
 
 func (s *createWorkloadClusterTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	s.WorkloadCluster = &types.Cluster{}
+	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, s.WorkloadCluster); err != nil {
+		return nil, err
+	}
+	commandContext.ClusterSpec.Cluster.AddManagedByCLIAnnotation()
+	commandContext.ClusterSpec.Cluster.SetManagementComponentsVersion(commandContext.ClusterSpec.EKSARelease.Spec.Version)
+	commandContext.WorkloadCluster = s.WorkloadCluster
+	return &installProviderSpecificResources{}, nil
 }
 
 func (s *createWorkloadClusterTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: s.WorkloadCluster,
+	}
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
@@ -68,5 +69,5 @@ func (c *Delete) Run(ctx context.Context, workload *types.Cluster, clusterSpec *
 		ClusterMover:    c.clusterMover,
 	}
 
-	return task.NewTaskRunner(&setupAndValidateDelete{}, c.writer).RunTask(ctx, commandContext)
+	return task.NewTaskRunner(&setupAndValidateDelete{}, c.writer, workflows.TaskRunnerOpts(false)...).RunTask(ctx, commandContext)
 }
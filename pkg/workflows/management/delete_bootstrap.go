@@ -34,5 +34,7 @@ func (s *deleteBootstrapClusterForDeleteTask) Restore(ctx context.Context, comma
 }
 
 func (s *deleteBootstrapClusterForDeleteTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
@@ -5,9 +5,12 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
 )
 
-type createBootStrapClusterForDeleteTask struct{}
+type createBootStrapClusterForDeleteTask struct {
+	BootstrapCluster *types.Cluster
+}
 
 func (s *createBootStrapClusterForDeleteTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Creating new bootstrap cluster")
@@ -24,6 +27,7 @@ func (s *createBootStrapClusterForDeleteTask) Run(ctx context.Context, commandCo
 		return nil
 	}
 	commandContext.BootstrapCluster = bootstrapCluster
+	s.BootstrapCluster = bootstrapCluster
 
 	return &installCAPIComponentsForDeleteTask{}
 }
@@ -33,9 +37,16 @@ func (s *createBootStrapClusterForDeleteTask) Name() string {
 }
 
 func (s *createBootStrapClusterForDeleteTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	s.BootstrapCluster = &types.Cluster{}
+	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, s.BootstrapCluster); err != nil {
+		return nil, err
+	}
+	commandContext.BootstrapCluster = s.BootstrapCluster
+	return &installCAPIComponentsForDeleteTask{}, nil
 }
 
 func (s *createBootStrapClusterForDeleteTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: s.BootstrapCluster,
+	}
 }
@@ -54,9 +54,11 @@ func (s *installEksaComponentsOnBootstrapForDeleteTask) Name() string {
 }
 
 func (s *installEksaComponentsOnBootstrapForDeleteTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &deleteManagementCluster{}, nil
 }
 
 func (s *installEksaComponentsOnBootstrapForDeleteTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
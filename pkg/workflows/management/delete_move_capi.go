@@ -33,9 +33,11 @@ func (s *moveClusterManagementForDeleteTask) Name() string {
 }
 
 func (s *moveClusterManagementForDeleteTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &installEksaComponentsOnBootstrapForDeleteTask{}, nil
 }
 
 func (s *moveClusterManagementForDeleteTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
@@ -42,5 +42,5 @@ func (s *postClusterUpgrade) Checkpoint() *task.CompletedTask {
 }
 
 func (s *postClusterUpgrade) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &upgradeCuratedPackagesTask{}, nil
 }
@@ -4,11 +4,11 @@ import (
 	"context"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
-	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
@@ -26,6 +26,7 @@ type Upgrade struct {
 	clusterUpgrader   interfaces.ClusterUpgrader
 	packageManager    interfaces.PackageManager
 	iamAuth           interfaces.AwsIamAuth
+	resume            bool
 }
 
 // NewUpgrade builds a new upgrade construct.
@@ -59,6 +60,13 @@ func NewUpgrade(clientFactory interfaces.ClientFactory, provider providers.Provi
 	return upgradeWorkflow
 }
 
+// WithResume configures the workflow to restore the tasks completed in a previous failed run
+// from the checkpoint file instead of running them again.
+func (c *Upgrade) WithResume(resume bool) *Upgrade {
+	c.resume = resume
+	return c
+}
+
 // Run Upgrade implements upgrade functionality for management cluster's upgrade operation.
 func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster, validator interfaces.Validator) error {
 	commandContext := &task.CommandContext{
@@ -78,9 +86,5 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, management
 		PackageManager:    c.packageManager,
 		IamAuth:           c.iamAuth,
 	}
-	if features.IsActive(features.CheckpointEnabled()) {
-		return task.NewTaskRunner(&setupAndValidateUpgrade{}, c.writer, task.WithCheckpointFile()).RunTask(ctx, commandContext)
-	}
-
-	return task.NewTaskRunner(&setupAndValidateUpgrade{}, c.writer).RunTask(ctx, commandContext)
+	return task.NewTaskRunner(&setupAndValidateUpgrade{}, c.writer, workflows.TaskRunnerOpts(c.resume)...).RunTask(ctx, commandContext)
 }
@@ -27,5 +27,7 @@ func (s *upgradeCuratedPackagesTask) Restore(_ context.Context, _ *task.CommandC
 }
 
 func (s *upgradeCuratedPackagesTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
@@ -175,8 +175,18 @@ func (s *setupAndValidateMC) Name() string {
 	return "validate"
 }
 
-func (s *setupAndValidateMC) Restore(_ context.Context, _ *task.CommandContext, _ *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+func (s *setupAndValidateMC) Restore(ctx context.Context, commandContext *task.CommandContext, _ *task.CompletedTask) (task.Task, error) {
+	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec.Cluster.Name)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	commandContext.CurrentClusterSpec = currentSpec
+	if err := commandContext.Provider.SetupAndValidateUpgradeManagementComponents(ctx, commandContext.ClusterSpec); err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	return &upgradeCoreComponentsMC{}, nil
 }
 
 func (s *setupAndValidateMC) Checkpoint() *task.CompletedTask {
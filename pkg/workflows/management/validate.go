@@ -16,13 +16,7 @@ type setupAndValidateCreate struct{}
 
 func (s *setupAndValidateCreate) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Performing setup and validations")
-	runner := validations.NewRunner()
-	runner.Register(s.providerValidation(ctx, commandContext)...)
-	runner.Register(commandContext.GitOpsManager.Validations(ctx, commandContext.ClusterSpec)...)
-	runner.Register(commandContext.Validations.PreflightValidations(ctx)...)
-
-	err := runner.Run()
-	if err != nil {
+	if err := s.validate(ctx, commandContext); err != nil {
 		commandContext.SetError(err)
 		return nil
 	}
@@ -30,6 +24,17 @@ func (s *setupAndValidateCreate) Run(ctx context.Context, commandContext *task.C
 	return &createBootStrapClusterTask{}
 }
 
+// validate runs the provider, GitOps and preflight validations. They run again when the workflow is resumed,
+// since the cluster config and the environment may have changed since the failed run.
+func (s *setupAndValidateCreate) validate(ctx context.Context, commandContext *task.CommandContext) error {
+	runner := validations.NewRunner()
+	runner.Register(s.providerValidation(ctx, commandContext)...)
+	runner.Register(commandContext.GitOpsManager.Validations(ctx, commandContext.ClusterSpec)...)
+	runner.Register(commandContext.Validations.PreflightValidations(ctx)...)
+
+	return runner.Run()
+}
+
 func (s *setupAndValidateCreate) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
 	return []validations.Validation{
 		func() *validations.ValidationResult {
@@ -46,11 +51,18 @@ func (s *setupAndValidateCreate) Name() string {
 }
 
 func (s *setupAndValidateCreate) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	logger.Info("Performing setup and validations")
+	if err := s.validate(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	return &createBootStrapClusterTask{}, nil
 }
 
 func (s *setupAndValidateCreate) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 type setupAndValidateUpgrade struct{}
@@ -64,12 +76,7 @@ func (s *setupAndValidateUpgrade) Run(ctx context.Context, commandContext *task.
 		return nil
 	}
 	commandContext.CurrentClusterSpec = currentSpec
-	runner := validations.NewRunner()
-	runner.Register(s.providerValidation(ctx, commandContext)...)
-	runner.Register(commandContext.Validations.PreflightValidations(ctx)...)
-
-	err = runner.Run()
-	if err != nil {
+	if err := s.validate(ctx, commandContext); err != nil {
 		commandContext.SetError(err)
 		return &workflows.CollectMgmtClusterDiagnosticsTask{}
 	}
@@ -77,6 +84,16 @@ func (s *setupAndValidateUpgrade) Run(ctx context.Context, commandContext *task.
 	return &updateSecrets{}
 }
 
+// validate runs the provider and preflight validations. They run again when the workflow is resumed,
+// since the cluster config and the environment may have changed since the failed run.
+func (s *setupAndValidateUpgrade) validate(ctx context.Context, commandContext *task.CommandContext) error {
+	runner := validations.NewRunner()
+	runner.Register(s.providerValidation(ctx, commandContext)...)
+	runner.Register(commandContext.Validations.PreflightValidations(ctx)...)
+
+	return runner.Run()
+}
+
 func (s *setupAndValidateUpgrade) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
 	return []validations.Validation{
 		func() *validations.ValidationResult {
@@ -93,17 +110,17 @@ func (s *setupAndValidateUpgrade) Name() string {
 }
 
 func (s *setupAndValidateUpgrade) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	if err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec); err != nil {
-		commandContext.SetError(err)
-		return nil, err
-	}
-	logger.Info(fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()))
+	logger.Info("Performing setup and validations")
 	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec.Cluster.Name)
 	if err != nil {
 		commandContext.SetError(err)
 		return nil, err
 	}
 	commandContext.CurrentClusterSpec = currentSpec
+	if err := s.validate(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
 	return &updateSecrets{}, nil
 }
 
@@ -131,9 +148,15 @@ func (s *setupAndValidateDelete) Name() string {
 }
 
 func (s *setupAndValidateDelete) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	if err := commandContext.Provider.SetupAndValidateDeleteCluster(ctx, commandContext.WorkloadCluster, commandContext.ClusterSpec); err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	return &createBootStrapClusterForDeleteTask{}, nil
 }
 
 func (s *setupAndValidateDelete) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
@@ -90,11 +90,13 @@ func (s *writeCreateClusterConfig) Name() string {
 }
 
 func (s *writeCreateClusterConfig) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &deleteBootstrapClusterTask{}, nil
 }
 
 func (s *writeCreateClusterConfig) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 func writeClusterConfigToDisk(clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig, writer filewriter.FileWriter) error {
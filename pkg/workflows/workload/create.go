@@ -7,6 +7,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
@@ -21,6 +22,7 @@ type Create struct {
 	clusterCreator   interfaces.ClusterCreator
 	packageInstaller interfaces.PackageManager
 	iamAuth          interfaces.AwsIamAuth
+	resume           bool
 }
 
 // NewCreate builds a new create construct.
@@ -48,6 +50,13 @@ func NewCreate(provider providers.Provider,
 	return createWorkflow
 }
 
+// WithResume configures the workflow to restore the tasks completed in a previous failed run
+// from the checkpoint file instead of running them again.
+func (c *Create) WithResume(resume bool) *Create {
+	c.resume = resume
+	return c
+}
+
 // Run executes the tasks to create a workload cluster.
 func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator) error {
 	commandContext := &task.CommandContext{
@@ -63,5 +72,5 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 		IamAuth:           c.iamAuth,
 	}
 
-	return task.NewTaskRunner(&setAndValidateCreateWorkloadTask{}, c.writer).RunTask(ctx, commandContext)
+	return task.NewTaskRunner(&setAndValidateCreateWorkloadTask{}, c.writer, workflows.TaskRunnerOpts(c.resume)...).RunTask(ctx, commandContext)
 }
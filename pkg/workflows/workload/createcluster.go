@@ -6,10 +6,13 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/workflows"
 )
 
-type createCluster struct{}
+type createCluster struct {
+	WorkloadCluster *types.Cluster
+}
 
 // Run createCluster performs actions needed to create the management cluster.
 func (c *createCluster) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -34,6 +37,7 @@ func (c *createCluster) Run(ctx context.Context, commandContext *task.CommandCon
 		return &workflows.CollectMgmtClusterDiagnosticsTask{}
 	}
 	commandContext.WorkloadCluster = workloadCluster
+	c.WorkloadCluster = workloadCluster
 
 	datacenterConfig := commandContext.Provider.DatacenterConfig(commandContext.ClusterSpec)
 	machineConfigs := commandContext.Provider.MachineConfigs(commandContext.ClusterSpec)
@@ -53,10 +57,15 @@ func (c *createCluster) Name() string {
 
 func (c *createCluster) Checkpoint() *task.CompletedTask {
 	return &task.CompletedTask{
-		Checkpoint: nil,
+		Checkpoint: c.WorkloadCluster,
 	}
 }
 
 func (c *createCluster) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	c.WorkloadCluster = &types.Cluster{}
+	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, c.WorkloadCluster); err != nil {
+		return nil, err
+	}
+	commandContext.WorkloadCluster = c.WorkloadCluster
 	return &installGitOpsManagerTask{}, nil
 }
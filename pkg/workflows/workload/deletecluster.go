@@ -26,9 +26,11 @@ func (s *deleteWorkloadCluster) Name() string {
 }
 
 func (s *deleteWorkloadCluster) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &postDeleteWorkload{}, nil
 }
 
 func (s *deleteWorkloadCluster) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
@@ -40,5 +40,7 @@ func (s *postDeleteWorkload) Restore(ctx context.Context, commandContext *task.C
 }
 
 func (s *postDeleteWorkload) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
@@ -22,6 +23,7 @@ type Upgrade struct {
 	clusterUpgrader  interfaces.ClusterUpgrader
 	packageInstaller interfaces.PackageManager
 	iamAuth          interfaces.AwsIamAuth
+	resume           bool
 }
 
 // NewUpgrade builds a new upgrade construct.
@@ -49,6 +51,13 @@ func NewUpgrade(clientFactory interfaces.ClientFactory,
 	return upgradeWorkflow
 }
 
+// WithResume configures the workflow to restore the tasks completed in a previous failed run
+// from the checkpoint file instead of running them again.
+func (c *Upgrade) WithResume(resume bool) *Upgrade {
+	c.resume = resume
+	return c
+}
+
 // Run Upgrade implements upgrade functionality for workload cluster's upgrade operation.
 func (c *Upgrade) Run(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, validator interfaces.Validator) error {
 	commandContext := &task.CommandContext{
@@ -65,5 +74,5 @@ func (c *Upgrade) Run(ctx context.Context, cluster *types.Cluster, clusterSpec *
 		IamAuth:           c.iamAuth,
 	}
 
-	return task.NewTaskRunner(&setAndValidateUpgradeWorkloadTask{}, c.writer).RunTask(ctx, commandContext)
+	return task.NewTaskRunner(&setAndValidateUpgradeWorkloadTask{}, c.writer, workflows.TaskRunnerOpts(c.resume)...).RunTask(ctx, commandContext)
 }
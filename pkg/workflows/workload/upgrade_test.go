@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
 	"github.com/aws/eks-anywhere/pkg/workflows/workload"
 )
@@ -193,6 +195,63 @@ func TestUpgradeRunSuccess(t *testing.T) {
 	}
 }
 
+func TestUpgradeRunResumeFromCheckpoint(t *testing.T) {
+	features.ClearCache()
+	os.Setenv(features.UseControllerForCli, "true")
+	test := newUpgradeTest(t)
+	test.workload.WithResume(true)
+	// The checkpoint is deleted once the workflow completes, so it's copied out of testdata.
+	dir := t.TempDir()
+	checkpoint, err := os.ReadFile("testdata/workload-checkpoint.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "workload-checkpoint.yaml"), checkpoint, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	test.writer.EXPECT().TempDir().Return(dir).Times(2)
+	// The validations run again on resume.
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	test.expectUpgradeWorkloadCluster(nil)
+	test.expectBuildClientFromKubeconfig(nil)
+	test.expectWriteWorkloadClusterConfig(nil)
+
+	err = test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestUpgradeRunResumeValidationsFail(t *testing.T) {
+	features.ClearCache()
+	os.Setenv(features.UseControllerForCli, "true")
+	test := newUpgradeTest(t)
+	test.workload.WithResume(true)
+	dir := t.TempDir()
+	checkpoint, err := os.ReadFile("testdata/workload-checkpoint.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "workload-checkpoint.yaml"), checkpoint, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	test.writer.EXPECT().TempDir().Return(dir)
+	test.expectSetup()
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return([]validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{Name: "kubernetes version skew", Err: errors.New("unsupported skew")}
+		},
+	})
+
+	err = test.run()
+	if err == nil {
+		t.Fatal("Upgrade.Run() err = nil, want err not nil")
+	}
+}
+
 func TestUpgradeRunUpgradeFail(t *testing.T) {
 	features.ClearCache()
 	os.Setenv(features.UseControllerForCli, "true")
@@ -16,17 +16,22 @@ type (
 
 // Run setAndValidateCreateWorkloadTask performs actions needed to validate creating the workload cluster.
 func (s *setAndValidateCreateWorkloadTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if err := s.validate(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	return &createCluster{}
+}
+
+// validate runs the provider, GitOps and preflight validations. They run again when the workflow is resumed,
+// since the cluster config and the environment may have changed since the failed run.
+func (s *setAndValidateCreateWorkloadTask) validate(ctx context.Context, commandContext *task.CommandContext) error {
 	runner := validations.NewRunner()
 	runner.Register(s.providerValidation(ctx, commandContext)...)
 	runner.Register(commandContext.GitOpsManager.Validations(ctx, commandContext.ClusterSpec)...)
 	runner.Register(commandContext.Validations.PreflightValidations(ctx)...)
 
-	err := runner.Run()
-	if err != nil {
-		commandContext.SetError(err)
-		return nil
-	}
-	return &createCluster{}
+	return runner.Run()
 }
 
 func (s *setAndValidateCreateWorkloadTask) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
@@ -45,11 +50,17 @@ func (s *setAndValidateCreateWorkloadTask) Name() string {
 }
 
 func (s *setAndValidateCreateWorkloadTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	if err := s.validate(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	return &createCluster{}, nil
 }
 
 func (s *setAndValidateCreateWorkloadTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 // Run setAndValidateWorkloadTask performs actions needed to validate the workload cluster.
@@ -60,17 +71,22 @@ func (s *setAndValidateUpgradeWorkloadTask) Run(ctx context.Context, commandCont
 		return nil
 	}
 	commandContext.CurrentClusterSpec = currentSpec
+	if err := s.validate(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	return &preClusterUpgrade{}
+}
+
+// validate runs the provider, GitOps and preflight validations. They run again when the workflow is resumed,
+// since the cluster config and the environment may have changed since the failed run.
+func (s *setAndValidateUpgradeWorkloadTask) validate(ctx context.Context, commandContext *task.CommandContext) error {
 	runner := validations.NewRunner()
 	runner.Register(s.providerValidation(ctx, commandContext)...)
 	runner.Register(commandContext.GitOpsManager.Validations(ctx, commandContext.ClusterSpec)...)
 	runner.Register(commandContext.Validations.PreflightValidations(ctx)...)
 
-	err = runner.Run()
-	if err != nil {
-		commandContext.SetError(err)
-		return nil
-	}
-	return &preClusterUpgrade{}
+	return runner.Run()
 }
 
 func (s *setAndValidateUpgradeWorkloadTask) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
@@ -89,11 +105,23 @@ func (s *setAndValidateUpgradeWorkloadTask) Name() string {
 }
 
 func (s *setAndValidateUpgradeWorkloadTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, commandContext.ClusterSpec.ManagementCluster, commandContext.ClusterSpec.Cluster.Name)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	commandContext.CurrentClusterSpec = currentSpec
+	if err := s.validate(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	return &preClusterUpgrade{}, nil
 }
 
 func (s *setAndValidateUpgradeWorkloadTask) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 type setupAndValidateDelete struct{}
@@ -113,9 +141,15 @@ func (s *setupAndValidateDelete) Name() string {
 }
 
 func (s *setupAndValidateDelete) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	if err := commandContext.Provider.SetupAndValidateDeleteCluster(ctx, commandContext.WorkloadCluster, commandContext.ClusterSpec); err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	return &deleteWorkloadCluster{}, nil
 }
 
 func (s *setupAndValidateDelete) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
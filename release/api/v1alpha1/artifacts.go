@@ -88,10 +88,18 @@ func (vb *VersionsBundle) Manifests() map[string][]*string {
 }
 
 // Ovas returns a list of OVA archives in a VersionsBundle.
+// The Ubuntu and RedHat OVAs are only included when the bundle has them.
 func (vb *VersionsBundle) Ovas() []Archive {
-	return []Archive{
+	ovas := []Archive{
 		vb.EksD.Ova.Bottlerocket,
 	}
+	for _, ova := range []*Archive{vb.EksD.Ova.Ubuntu, vb.EksD.Ova.RedHat} {
+		if ova != nil && ova.URI != "" {
+			ovas = append(ovas, *ova)
+		}
+	}
+
+	return ovas
 }
 
 // CloudStackImages returns images needed for the CloudStack provider in a VersionsBundle.
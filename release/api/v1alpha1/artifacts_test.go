@@ -253,3 +253,22 @@ func TestManifestsIncludesCloudStackWhenRealURI(t *testing.T) {
 	_, hasCloudStack := manifests["cluster-api-provider-cloudstack"]
 	g.Expect(hasCloudStack).To(BeTrue(), "cloudstack should be present in manifests when real URIs are provided")
 }
+
+func TestOvasSkipsMissingOSImages(t *testing.T) {
+	g := NewWithT(t)
+
+	bottlerocket := v1alpha1.Archive{URI: "https://anywhere-assets.eks.amazonaws.com/bottlerocket.ova"}
+	ubuntu := v1alpha1.Archive{URI: "https://anywhere-assets.eks.amazonaws.com/ubuntu.ova"}
+
+	vb := &v1alpha1.VersionsBundle{
+		EksD: v1alpha1.EksDRelease{
+			Ova: v1alpha1.OSImageBundle{
+				Bottlerocket: bottlerocket,
+				Ubuntu:       &ubuntu,
+				RedHat:       &v1alpha1.Archive{},
+			},
+		},
+	}
+
+	g.Expect(vb.Ovas()).To(Equal([]v1alpha1.Archive{bottlerocket, ubuntu}))
+}
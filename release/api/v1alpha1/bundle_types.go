@@ -146,8 +146,11 @@ type UpgraderBundle struct {
 }
 
 // OSImageBundle defines a set of OS images (e.g., Bottlerocket) for this bundle.
+// The Ubuntu and RedHat images are only set by builds that publish them.
 type OSImageBundle struct {
-	Bottlerocket Archive `json:"bottlerocket,omitempty"`
+	Bottlerocket Archive  `json:"bottlerocket,omitempty"`
+	RedHat       *Archive `json:"redhat,omitempty"`
+	Ubuntu       *Archive `json:"ubuntu,omitempty"`
 }
 
 // BottlerocketHostContainersBundle defines the Bottlerocket host containers used by the bundle.
@@ -743,6 +743,16 @@ func (in *NutanixBundle) DeepCopy() *NutanixBundle {
 func (in *OSImageBundle) DeepCopyInto(out *OSImageBundle) {
 	*out = *in
 	in.Bottlerocket.DeepCopyInto(&out.Bottlerocket)
+	if in.RedHat != nil {
+		in, out := &in.RedHat, &out.RedHat
+		*out = new(Archive)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ubuntu != nil {
+		in, out := &in.Ubuntu, &out.Ubuntu
+		*out = new(Archive)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSImageBundle.
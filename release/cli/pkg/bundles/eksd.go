@@ -157,6 +157,8 @@ func GetEksDReleaseBundle(r *releasetypes.ReleaseConfig, eksDReleaseChannel, kub
 		ImageBuilder:   bundleArchiveArtifacts["image-builder"],
 		Ova: anywherev1alpha1.OSImageBundle{
 			Bottlerocket: bundleArchiveArtifacts["bottlerocket-ova"],
+			Ubuntu:       optionalArchive(bundleArchiveArtifacts, "ubuntu-ova"),
+			RedHat:       optionalArchive(bundleArchiveArtifacts, "redhat-ova"),
 		},
 		Raw: anywherev1alpha1.OSImageBundle{
 			Bottlerocket: bundleArchiveArtifacts["bottlerocket-raw"],
@@ -166,3 +168,13 @@ func GetEksDReleaseBundle(r *releasetypes.ReleaseConfig, eksDReleaseChannel, kub
 
 	return bundle, nil
 }
+
+// optionalArchive returns the archive with the given name, or nil if the release doesn't include it.
+func optionalArchive(archives map[string]anywherev1alpha1.Archive, name string) *anywherev1alpha1.Archive {
+	archive, ok := archives[name]
+	if !ok {
+		return nil
+	}
+
+	return &archive
+}